@@ -1,8 +1,7 @@
 package api
 
 import (
-	"database/sql"
-	"errors"
+	"fmt"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
@@ -51,32 +50,28 @@ type getAccountRequest struct {
 	ID int64 `uri:"id" binding:"required,min=1"`
 }
 
-func (server *Server) getAccount(ctx *gin.Context) {
+const accountKey = "account"
+
+// accountOwner loads the account addressed by the request for
+// requireOwnership and keeps it on the context for the handler.
+func (server *Server) accountOwner(ctx *gin.Context) (string, error) {
 	var req getAccountRequest
 
 	if err := ctx.ShouldBindUri(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, errorResponse(err))
-		return
+		return "", fmt.Errorf("%w: %v", errInvalidResourceRequest, err)
 	}
 
 	account, err := server.store.GetAccount(ctx, req.ID)
 	if err != nil {
-		if err == sql.ErrNoRows {
-			ctx.JSON(http.StatusNotFound, errorResponse(err))
-			return
-		}
-
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
-		return
+		return "", err
 	}
 
-	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
-	if account.Owner != authPayload.Username {
-		err := errors.New("account does not belong to the authenticated user")
-		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
-		return
-	}
+	ctx.Set(accountKey, account)
+	return account.Owner, nil
+}
 
+func (server *Server) getAccount(ctx *gin.Context) {
+	account := ctx.MustGet(accountKey).(db.Account)
 	ctx.JSON(http.StatusOK, account)
 }
 
@@ -93,10 +88,13 @@ func (server *Server) listAccount(ctx *gin.Context) {
 		return
 	}
 
-	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	owner, ok := server.ownerScope(ctx, accountPolicy)
+	if !ok {
+		return
+	}
 
 	accounts, err := server.store.ListAccounts(ctx, db.ListAccountsParams{
-		Owner:  authPayload.Username,
+		Owner:  owner,
 		Limit:  req.PageSize,
 		Offset: (req.PageID - 1) * req.PageSize,
 	})
@@ -21,6 +21,7 @@ import (
 
 func TestCreateAccount(t *testing.T) {
 	user, _ := randomUser(t)
+	other, _ := randomUser(t)
 	account := randomAccount(user.Username)
 
 	testCases := []struct {
@@ -98,16 +99,49 @@ func TestCreateAccount(t *testing.T) {
 			name:      "UnauthorizedUser",
 			accountId: account.ID,
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
-				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, "unauthorized_user", time.Minute)
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, other.Username, time.Minute)
 			},
 			buildStubs: func(store *mockdb.MockStore) {
 				store.EXPECT().
 					GetAccount(gomock.Any(), gomock.Eq(account.ID)).
 					Times(1).
 					Return(account, nil)
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(other.Username)).
+					Times(1).
+					Return(other, nil)
+				store.EXPECT().
+					GetPermissionsForUser(gomock.Any(), gomock.Eq(other.ID)).
+					Times(1).
+					Return([]string{"VIEW_SCREEN_USER"}, nil)
 			},
 			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
-				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:      "AllAccountsPermission",
+			accountId: account.ID,
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, other.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetAccount(gomock.Any(), gomock.Eq(account.ID)).
+					Times(1).
+					Return(account, nil)
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(other.Username)).
+					Times(1).
+					Return(other, nil)
+				store.EXPECT().
+					GetPermissionsForUser(gomock.Any(), gomock.Eq(other.ID)).
+					Times(1).
+					Return([]string{"MANAGE_ALL_ACCOUNTS"}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchAccount(t, recorder.Body, account)
 			},
 		},
 		{
@@ -152,6 +186,91 @@ func TestCreateAccount(t *testing.T) {
 	}
 }
 
+func TestListAccountAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	other, _ := randomUser(t)
+	own := randomAccount(user.Username)
+	foreign := randomAccount(other.Username)
+
+	testCases := []struct {
+		name          string
+		permissions   []string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:        "OwnAccounts",
+			permissions: []string{"VIEW_SCREEN_USER"},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.ListAccountsParams{
+					Owner:  sql.NullString{String: user.Username, Valid: true},
+					Limit:  5,
+					Offset: 0,
+				}
+				store.EXPECT().
+					ListAccounts(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return([]db.Account{own}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:        "AllAccountsPermission",
+			permissions: []string{"MANAGE_ALL_ACCOUNTS"},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.ListAccountsParams{
+					Limit:  5,
+					Offset: 0,
+				}
+				store.EXPECT().
+					ListAccounts(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return([]db.Account{own, foreign}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var accounts []db.Account
+				err := json.Unmarshal(recorder.Body.Bytes(), &accounts)
+				require.NoError(t, err)
+				require.Len(t, accounts, 2)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().
+				GetUser(gomock.Any(), gomock.Eq(user.Username)).
+				Times(1).
+				Return(user, nil)
+			store.EXPECT().
+				GetPermissionsForUser(gomock.Any(), gomock.Eq(user.ID)).
+				Times(1).
+				Return(tc.permissions, nil)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			request, err := http.NewRequest(http.MethodGet, "/accounts?page_id=1&page_size=5", nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
 func randomAccount(owner string) db.Account {
 	return db.Account{
 		ID:       utils.RandomInt(1, 1000),
@@ -0,0 +1,151 @@
+package api
+
+import (
+	"database/sql"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/lib/pq"
+	db "github.com/toannguyen3105/nht-bsihuyen.com-api/db/sqlc"
+	"github.com/toannguyen3105/nht-bsihuyen.com-api/utils"
+)
+
+type createIngredientRequest struct {
+	Name string `json:"name" binding:"required,max=100"`
+}
+
+func (server *Server) createIngredient(ctx *gin.Context) {
+	var req createIngredientRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	ingredient, err := server.store.CreateActiveIngredient(ctx, req.Name)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code.Name() == "unique_violation" {
+			ctx.JSON(http.StatusForbidden, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, successResponse("Ingredient created successfully", ingredient))
+}
+
+func (server *Server) listIngredients(ctx *gin.Context) {
+	ingredients, err := server.store.ListActiveIngredients(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, successResponse("Ingredients retrieved successfully", ingredients))
+}
+
+type deleteIngredientRequest struct {
+	ID int32 `uri:"id" binding:"required,min=1"`
+}
+
+// deleteIngredient refuses ingredients that a medicine still contains or that
+// interactions are recorded for.
+func (server *Server) deleteIngredient(ctx *gin.Context) {
+	var req deleteIngredientRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	deleted, err := server.store.DeleteActiveIngredient(ctx, req.ID)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code.Name() == "foreign_key_violation" {
+			ctx.JSON(http.StatusForbidden, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if deleted == 0 {
+		ctx.JSON(http.StatusNotFound, errorResponse(sql.ErrNoRows))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, successResponse("Ingredient deleted successfully", nil))
+}
+
+type medicineIngredientResponse struct {
+	IngredientID int32         `json:"ingredient_id"`
+	Name         string        `json:"name"`
+	Strength     utils.Decimal `json:"strength"`
+	StrengthUnit string        `json:"strength_unit"`
+}
+
+func newMedicineIngredientsResponse(ingredients []db.ListMedicineIngredientsRow) []medicineIngredientResponse {
+	rsp := make([]medicineIngredientResponse, len(ingredients))
+	for i, ingredient := range ingredients {
+		rsp[i] = medicineIngredientResponse{
+			IngredientID: ingredient.IngredientID,
+			Name:         ingredient.Name,
+			Strength:     ingredient.Strength,
+			StrengthUnit: ingredient.StrengthUnit,
+		}
+	}
+	return rsp
+}
+
+type medicineIngredientRequest struct {
+	IngredientID int32         `json:"ingredient_id" binding:"required,min=1"`
+	Strength     utils.Decimal `json:"strength" binding:"required,gt=0"`
+	StrengthUnit string        `json:"strength_unit" binding:"required,max=20"`
+}
+
+type setMedicineIngredientsRequest struct {
+	Ingredients []medicineIngredientRequest `json:"ingredients" binding:"required,unique=IngredientID,dive"`
+}
+
+// setMedicineIngredients replaces the active ingredients of a medicine, each
+// with its strength per unit of the medicine, such as 500 mg of paracetamol
+// per tablet.
+func (server *Server) setMedicineIngredients(ctx *gin.Context) {
+	var reqURI getMedicineRequest
+	if err := ctx.ShouldBindUri(&reqURI); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req setMedicineIngredientsRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	arg := db.SetMedicineIngredientsTxParams{
+		MedicineID:  reqURI.ID,
+		Ingredients: make([]db.MedicineIngredientParams, len(req.Ingredients)),
+	}
+	for i, ingredient := range req.Ingredients {
+		arg.Ingredients[i] = db.MedicineIngredientParams{
+			IngredientID: ingredient.IngredientID,
+			Strength:     ingredient.Strength,
+			StrengthUnit: ingredient.StrengthUnit,
+		}
+	}
+
+	ingredients, err := server.store.SetMedicineIngredientsTx(ctx, arg)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code.Name() == "foreign_key_violation" {
+			ctx.JSON(http.StatusForbidden, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, successResponse("Medicine ingredients saved successfully", newMedicineIngredientsResponse(ingredients)))
+}
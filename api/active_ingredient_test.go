@@ -0,0 +1,143 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+	mockdb "github.com/toannguyen3105/nht-bsihuyen.com-api/db/mock"
+	db "github.com/toannguyen3105/nht-bsihuyen.com-api/db/sqlc"
+	"github.com/toannguyen3105/nht-bsihuyen.com-api/utils"
+)
+
+func TestSetMedicineIngredientsAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	medicine := randomMedicine()
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{"ingredients": []gin.H{
+				{"ingredient_id": 1, "strength": "500", "strength_unit": "mg"},
+				{"ingredient_id": 2, "strength": 65, "strength_unit": "mg"},
+			}},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.SetMedicineIngredientsTxParams{
+					MedicineID: medicine.ID,
+					Ingredients: []db.MedicineIngredientParams{
+						{IngredientID: 1, Strength: utils.MustParseDecimal("500"), StrengthUnit: "mg"},
+						{IngredientID: 2, Strength: utils.MustParseDecimal("65"), StrengthUnit: "mg"},
+					},
+				}
+				store.EXPECT().
+					SetMedicineIngredientsTx(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return([]db.ListMedicineIngredientsRow{
+						{MedicineID: medicine.ID, IngredientID: 2, Name: "Caffeine", Strength: utils.MustParseDecimal("65"), StrengthUnit: "mg"},
+						{MedicineID: medicine.ID, IngredientID: 1, Name: "Paracetamol", Strength: utils.MustParseDecimal("500"), StrengthUnit: "mg"},
+					}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var response struct {
+					Data []medicineIngredientResponse `json:"data"`
+				}
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+				require.Len(t, response.Data, 2)
+				require.Equal(t, "Caffeine", response.Data[0].Name)
+			},
+		},
+		{
+			name: "DuplicateIngredient",
+			body: gin.H{"ingredients": []gin.H{
+				{"ingredient_id": 1, "strength": "500", "strength_unit": "mg"},
+				{"ingredient_id": 1, "strength": "250", "strength_unit": "mg"},
+			}},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					SetMedicineIngredientsTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "ZeroStrength",
+			body: gin.H{"ingredients": []gin.H{
+				{"ingredient_id": 1, "strength": "0", "strength_unit": "mg"},
+			}},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					SetMedicineIngredientsTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "UnknownIngredient",
+			body: gin.H{"ingredients": []gin.H{
+				{"ingredient_id": 99, "strength": "500", "strength_unit": "mg"},
+			}},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					SetMedicineIngredientsTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, &pq.Error{Code: "23503"})
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().
+				GetUser(gomock.Any(), gomock.Eq(user.Username)).
+				Times(1).
+				Return(user, nil)
+			store.EXPECT().
+				GetPermissionsForUser(gomock.Any(), gomock.Eq(user.ID)).
+				Times(1).
+				Return([]string{"VIEW_SCREEN_MEDICINE"}, nil)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/medicines/%d/ingredients", medicine.ID)
+			request, err := http.NewRequest(http.MethodPut, url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
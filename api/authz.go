@@ -0,0 +1,439 @@
+package api
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	db "github.com/toannguyen3105/nht-bsihuyen.com-api/db/sqlc"
+	"gopkg.in/yaml.v3"
+)
+
+type explainRequest struct {
+	UserID     int32  `form:"user_id" binding:"required,min=1"`
+	Permission string `form:"permission" binding:"required,max=255"`
+}
+
+type explainRoleResponse struct {
+	RoleID           int32      `json:"role_id"`
+	RoleName         string     `json:"role_name"`
+	Active           bool       `json:"active"`
+	ValidFrom        *time.Time `json:"valid_from"`
+	ValidUntil       *time.Time `json:"valid_until"`
+	GrantsPermission bool       `json:"grants_permission"`
+	DeniesPermission bool       `json:"denies_permission"`
+	Permissions      []string   `json:"permissions"`
+	Denies           []string   `json:"denies"`
+}
+
+type explainOverrideResponse struct {
+	Permission string `json:"permission"`
+	Effect     string `json:"effect"`
+	Reason     string `json:"reason"`
+}
+
+type explainBreakGlassResponse struct {
+	ID            int64     `json:"id"`
+	Permission    string    `json:"permission"`
+	Justification string    `json:"justification"`
+	ExpiresAt     time.Time `json:"expires_at"`
+}
+
+type explainResponse struct {
+	User             userResponse                `json:"user"`
+	Permission       string                      `json:"permission"`
+	PermissionExists bool                        `json:"permission_exists"`
+	Granted          bool                        `json:"granted"`
+	Reason           string                      `json:"reason"`
+	Roles            []explainRoleResponse       `json:"roles"`
+	Overrides        []explainOverrideResponse   `json:"overrides"`
+	BreakGlass       []explainBreakGlassResponse `json:"break_glass"`
+}
+
+// explainPermission groups the role grants of a user by role and works out
+// which of them, if any, grants the permission.
+func explainPermission(grants []db.ListUserRoleGrantsRow, permission string) (roles []explainRoleResponse, grantedBy []string) {
+	roles = []explainRoleResponse{}
+	index := make(map[int32]int)
+
+	for _, grant := range grants {
+		i, ok := index[grant.RoleID]
+		if !ok {
+			role := explainRoleResponse{
+				RoleID:      grant.RoleID,
+				RoleName:    grant.RoleName,
+				Active:      grant.Active,
+				Permissions: []string{},
+				Denies:      []string{},
+			}
+			if grant.ValidFrom.Valid {
+				role.ValidFrom = &grant.ValidFrom.Time
+			}
+			if grant.ValidUntil.Valid {
+				role.ValidUntil = &grant.ValidUntil.Time
+			}
+
+			i = len(roles)
+			index[grant.RoleID] = i
+			roles = append(roles, role)
+		}
+
+		if !grant.PermissionName.Valid {
+			continue
+		}
+
+		roles[i].Permissions = append(roles[i].Permissions, grant.PermissionName.String)
+		if grant.PermissionName.String == permission {
+			roles[i].GrantsPermission = true
+			if roles[i].Active {
+				grantedBy = append(grantedBy, "role "+roles[i].RoleName)
+			}
+		}
+	}
+
+	return
+}
+
+// explainDenies attaches role denies and user overrides to the explanation.
+// It returns what grants the permission through a user override and what
+// denies it, since denies win over every grant.
+func explainDenies(
+	roles []explainRoleResponse,
+	denies []db.ListUserRoleDeniesRow,
+	overrides []db.ListUserPermissionOverridesRow,
+	permission string,
+) (overrideResponses []explainOverrideResponse, allowedBy []string, deniedBy []string) {
+	index := make(map[int32]int, len(roles))
+	for i, role := range roles {
+		index[role.RoleID] = i
+	}
+
+	for _, deny := range denies {
+		i, ok := index[deny.RoleID]
+		if !ok {
+			continue
+		}
+
+		roles[i].Denies = append(roles[i].Denies, deny.PermissionName)
+		if deny.PermissionName == permission {
+			roles[i].DeniesPermission = true
+			if roles[i].Active {
+				deniedBy = append(deniedBy, "role "+roles[i].RoleName)
+			}
+		}
+	}
+
+	overrideResponses = make([]explainOverrideResponse, 0, len(overrides))
+	for _, override := range overrides {
+		overrideResponses = append(overrideResponses, explainOverrideResponse{
+			Permission: override.PermissionName,
+			Effect:     override.Effect,
+			Reason:     override.Reason,
+		})
+
+		if override.PermissionName != permission {
+			continue
+		}
+		switch override.Effect {
+		case permissionEffectAllow:
+			allowedBy = append(allowedBy, "user override")
+		case permissionEffectDeny:
+			deniedBy = append(deniedBy, "user override")
+		}
+	}
+
+	return
+}
+
+// explainBreakGlass lists the unexpired break-glass grants of a user and
+// returns the ones that grant the permission.
+func explainBreakGlass(grants []db.ListActiveBreakGlassGrantsForUserRow, permission string) (grantResponses []explainBreakGlassResponse, grantedBy []string) {
+	grantResponses = make([]explainBreakGlassResponse, 0, len(grants))
+	for _, grant := range grants {
+		grantResponses = append(grantResponses, explainBreakGlassResponse{
+			ID:            grant.ID,
+			Permission:    grant.PermissionName,
+			Justification: grant.Justification,
+			ExpiresAt:     grant.ExpiresAt,
+		})
+		if grant.PermissionName == permission {
+			grantedBy = append(grantedBy, fmt.Sprintf("break-glass grant #%d", grant.ID))
+		}
+	}
+
+	return
+}
+
+func explainReason(exists bool, permission string, roles []explainRoleResponse, grantedBy []string, deniedBy []string) string {
+	switch {
+	case !exists:
+		return fmt.Sprintf("permission %s does not exist", permission)
+	case len(deniedBy) > 0:
+		return fmt.Sprintf("denied by %s", deniedBy[0])
+	case len(grantedBy) > 0:
+		return fmt.Sprintf("granted by %s", grantedBy[0])
+	case len(roles) == 0:
+		return "user has no roles"
+	}
+
+	for _, role := range roles {
+		if role.GrantsPermission {
+			return fmt.Sprintf("role %s grants %s but the assignment is not active", role.RoleName, permission)
+		}
+	}
+
+	return fmt.Sprintf("none of the user's roles grants %s", permission)
+}
+
+func (server *Server) explainAuthorization(ctx *gin.Context) {
+	var req explainRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	user, err := server.store.GetUserByID(ctx, req.UserID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	exists := true
+	_, err = server.store.GetPermissionByName(ctx, req.Permission)
+	if err != nil {
+		if err != sql.ErrNoRows {
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+		exists = false
+	}
+
+	grants, err := server.store.ListUserRoleGrants(ctx, user.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	denies, err := server.store.ListUserRoleDenies(ctx, user.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	overrides, err := server.store.ListUserPermissionOverrides(ctx, user.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	breakGlassGrants, err := server.store.ListActiveBreakGlassGrantsForUser(ctx, user.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	roles, grantedBy := explainPermission(grants, req.Permission)
+	overrideResponses, allowedBy, deniedBy := explainDenies(roles, denies, overrides, req.Permission)
+	breakGlassResponses, breakGlassBy := explainBreakGlass(breakGlassGrants, req.Permission)
+	grantedBy = append(grantedBy, allowedBy...)
+	grantedBy = append(grantedBy, breakGlassBy...)
+
+	rsp := explainResponse{
+		User:             newUserResponse(user),
+		Permission:       req.Permission,
+		PermissionExists: exists,
+		Granted:          exists && len(grantedBy) > 0 && len(deniedBy) == 0,
+		Reason:           explainReason(exists, req.Permission, roles, grantedBy, deniedBy),
+		Roles:            roles,
+		Overrides:        overrideResponses,
+		BreakGlass:       breakGlassResponses,
+	}
+
+	ctx.JSON(http.StatusOK, successResponse("Authorization explained successfully", rsp))
+}
+
+type matrixRoleResponse struct {
+	ID       int32  `json:"id"`
+	Name     string `json:"name"`
+	IsSystem bool   `json:"is_system"`
+	Granted  []bool `json:"granted"`
+}
+
+// matrixResponse lists one row per role. Granted is aligned with Permissions,
+// so Roles[i].Granted[j] tells whether role i grants permission j.
+type matrixResponse struct {
+	Permissions []permissionResponse `json:"permissions"`
+	Roles       []matrixRoleResponse `json:"roles"`
+}
+
+func newMatrixResponse(roles []db.Role, permissions []db.Permission, grants []db.RolePermission) matrixResponse {
+	rsp := matrixResponse{
+		Permissions: make([]permissionResponse, 0, len(permissions)),
+		Roles:       make([]matrixRoleResponse, 0, len(roles)),
+	}
+
+	column := make(map[int32]int, len(permissions))
+	for i, permission := range permissions {
+		column[permission.ID] = i
+		rsp.Permissions = append(rsp.Permissions, newPermissionResponse(permission))
+	}
+
+	row := make(map[int32]int, len(roles))
+	for i, role := range roles {
+		row[role.ID] = i
+		rsp.Roles = append(rsp.Roles, matrixRoleResponse{
+			ID:       role.ID,
+			Name:     role.Name,
+			IsSystem: role.IsSystem,
+			Granted:  make([]bool, len(permissions)),
+		})
+	}
+
+	for _, grant := range grants {
+		rsp.Roles[row[grant.RoleID]].Granted[column[grant.PermissionID]] = true
+	}
+
+	return rsp
+}
+
+func (server *Server) getAuthorizationMatrix(ctx *gin.Context) {
+	roles, err := server.store.ListAllRoles(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	permissions, err := server.store.ListAllPermissions(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	grants, err := server.store.ListAllRolePermissions(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, successResponse("Authorization matrix retrieved successfully", newMatrixResponse(roles, permissions, grants)))
+}
+
+const (
+	policyFormatJSON = "json"
+	policyFormatYAML = "yaml"
+)
+
+type exportPolicyRequest struct {
+	Format string `form:"format" binding:"omitempty,oneof=json yaml"`
+}
+
+func (server *Server) exportPolicy(ctx *gin.Context) {
+	var req exportPolicyRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	doc, err := server.store.ExportPolicy(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if req.Format == policyFormatYAML {
+		data, err := yaml.Marshal(doc)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+
+		ctx.Header("Content-Disposition", `attachment; filename="policy.yaml"`)
+		ctx.Data(http.StatusOK, "application/yaml", data)
+		return
+	}
+
+	ctx.Header("Content-Disposition", `attachment; filename="policy.json"`)
+	ctx.IndentedJSON(http.StatusOK, doc)
+}
+
+// policyImportPermissions are all needed to import a policy, since an import
+// changes what the role, permission, role permission and user role screens
+// each manage.
+var policyImportPermissions = []string{
+	"VIEW_SCREEN_ROLE",
+	"VIEW_SCREEN_PERMISSION",
+	"VIEW_SCREEN_ROLE_PERMISSION",
+	"VIEW_SCREEN_USER_ROLE",
+}
+
+type importPolicyRequest struct {
+	Format string `form:"format" binding:"omitempty,oneof=json yaml"`
+	DryRun bool   `form:"dry_run"`
+	// PruneUsers also removes the role assignments and overrides of users
+	// the document does not list.
+	PruneUsers bool `form:"prune_users"`
+}
+
+// decodePolicy parses a policy document, rejecting unknown fields so that a
+// typo does not silently drop part of the setup.
+func decodePolicy(format string, body io.Reader) (db.PolicyDocument, error) {
+	var doc db.PolicyDocument
+
+	if format == policyFormatYAML {
+		decoder := yaml.NewDecoder(body)
+		decoder.KnownFields(true)
+		err := decoder.Decode(&doc)
+		return doc, err
+	}
+
+	decoder := json.NewDecoder(body)
+	decoder.DisallowUnknownFields()
+	err := decoder.Decode(&doc)
+	return doc, err
+}
+
+func (server *Server) importPolicy(ctx *gin.Context) {
+	var req importPolicyRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	doc, err := decodePolicy(req.Format, ctx.Request.Body)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	result, err := server.store.ImportPolicyTx(ctx, db.ImportPolicyTxParams{
+		Document:   doc,
+		DryRun:     req.DryRun,
+		PruneUsers: req.PruneUsers,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, db.ErrInvalidPolicy):
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		case errors.Is(err, db.ErrProtectedRole), errors.Is(err, db.ErrProtectedPermission), errors.Is(err, db.ErrLastAdmin):
+			ctx.JSON(http.StatusConflict, errorResponse(err))
+		default:
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		}
+		return
+	}
+
+	message := "Policy imported successfully"
+	if result.DryRun {
+		message = "Policy import previewed successfully"
+	}
+	ctx.JSON(http.StatusOK, successResponse(message, result))
+}
@@ -0,0 +1,881 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	mockdb "github.com/toannguyen3105/nht-bsihuyen.com-api/db/mock"
+	db "github.com/toannguyen3105/nht-bsihuyen.com-api/db/sqlc"
+	"github.com/toannguyen3105/nht-bsihuyen.com-api/token"
+	"github.com/toannguyen3105/nht-bsihuyen.com-api/utils"
+)
+
+func TestExplainAuthorizationAPI(t *testing.T) {
+	admin, _ := randomUser(t)
+	user, _ := randomUser(t)
+	admin.ID = 1
+	user.ID = 2
+
+	permission := randomPermission()
+	expired := sql.NullTime{Time: time.Now().Add(-time.Hour), Valid: true}
+
+	testCases := []struct {
+		name          string
+		userID        int32
+		permission    string
+		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:       "Granted",
+			userID:     user.ID,
+			permission: permission.Name,
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, admin.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(admin.Username)).
+					Times(1).
+					Return(admin, nil)
+				store.EXPECT().
+					GetPermissionsForUser(gomock.Any(), gomock.Eq(admin.ID)).
+					Times(1).
+					Return([]string{"VIEW_SCREEN_AUTHORIZATION"}, nil)
+				store.EXPECT().
+					GetUserByID(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					GetPermissionByName(gomock.Any(), gomock.Eq(permission.Name)).
+					Times(1).
+					Return(permission, nil)
+				store.EXPECT().
+					ListUserRoleGrants(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return([]db.ListUserRoleGrantsRow{
+						{
+							RoleID:         1,
+							RoleName:       "doctor",
+							Active:         true,
+							PermissionID:   sql.NullInt32{Int32: permission.ID, Valid: true},
+							PermissionName: sql.NullString{String: permission.Name, Valid: true},
+						},
+					}, nil)
+				store.EXPECT().
+					ListUserRoleDenies(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return([]db.ListUserRoleDeniesRow{}, nil)
+				store.EXPECT().
+					ListUserPermissionOverrides(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return([]db.ListUserPermissionOverridesRow{}, nil)
+				store.EXPECT().
+					ListActiveBreakGlassGrantsForUser(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return([]db.ListActiveBreakGlassGrantsForUserRow{}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				rsp := requireBodyExplain(t, recorder.Body)
+				require.True(t, rsp.Granted)
+				require.Equal(t, "granted by role doctor", rsp.Reason)
+				require.Len(t, rsp.Roles, 1)
+				require.True(t, rsp.Roles[0].GrantsPermission)
+			},
+		},
+		{
+			name:       "InactiveAssignment",
+			userID:     user.ID,
+			permission: permission.Name,
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, admin.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(admin.Username)).
+					Times(1).
+					Return(admin, nil)
+				store.EXPECT().
+					GetPermissionsForUser(gomock.Any(), gomock.Eq(admin.ID)).
+					Times(1).
+					Return([]string{"VIEW_SCREEN_AUTHORIZATION"}, nil)
+				store.EXPECT().
+					GetUserByID(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					GetPermissionByName(gomock.Any(), gomock.Eq(permission.Name)).
+					Times(1).
+					Return(permission, nil)
+				store.EXPECT().
+					ListUserRoleGrants(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return([]db.ListUserRoleGrantsRow{
+						{
+							RoleID:         1,
+							RoleName:       "intern",
+							ValidUntil:     expired,
+							Active:         false,
+							PermissionID:   sql.NullInt32{Int32: permission.ID, Valid: true},
+							PermissionName: sql.NullString{String: permission.Name, Valid: true},
+						},
+						{
+							RoleID:   2,
+							RoleName: "nurse",
+							Active:   true,
+						},
+					}, nil)
+				store.EXPECT().
+					ListUserRoleDenies(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return([]db.ListUserRoleDeniesRow{}, nil)
+				store.EXPECT().
+					ListUserPermissionOverrides(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return([]db.ListUserPermissionOverridesRow{}, nil)
+				store.EXPECT().
+					ListActiveBreakGlassGrantsForUser(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return([]db.ListActiveBreakGlassGrantsForUserRow{}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				rsp := requireBodyExplain(t, recorder.Body)
+				require.False(t, rsp.Granted)
+				require.Len(t, rsp.Roles, 2)
+				require.False(t, rsp.Roles[0].Active)
+				require.NotNil(t, rsp.Roles[0].ValidUntil)
+				require.Empty(t, rsp.Roles[1].Permissions)
+			},
+		},
+		{
+			name:       "DeniedByRole",
+			userID:     user.ID,
+			permission: permission.Name,
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, admin.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(admin.Username)).
+					Times(1).
+					Return(admin, nil)
+				store.EXPECT().
+					GetPermissionsForUser(gomock.Any(), gomock.Eq(admin.ID)).
+					Times(1).
+					Return([]string{"VIEW_SCREEN_AUTHORIZATION"}, nil)
+				store.EXPECT().
+					GetUserByID(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					GetPermissionByName(gomock.Any(), gomock.Eq(permission.Name)).
+					Times(1).
+					Return(permission, nil)
+				store.EXPECT().
+					ListUserRoleGrants(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return([]db.ListUserRoleGrantsRow{
+						{
+							RoleID:         1,
+							RoleName:       "doctor",
+							Active:         true,
+							PermissionID:   sql.NullInt32{Int32: permission.ID, Valid: true},
+							PermissionName: sql.NullString{String: permission.Name, Valid: true},
+						},
+						{
+							RoleID:   2,
+							RoleName: "locum",
+							Active:   true,
+						},
+					}, nil)
+				store.EXPECT().
+					ListUserRoleDenies(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return([]db.ListUserRoleDeniesRow{
+						{RoleID: 2, RoleName: "locum", PermissionName: permission.Name},
+					}, nil)
+				store.EXPECT().
+					ListUserPermissionOverrides(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return([]db.ListUserPermissionOverridesRow{}, nil)
+				store.EXPECT().
+					ListActiveBreakGlassGrantsForUser(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return([]db.ListActiveBreakGlassGrantsForUserRow{}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				rsp := requireBodyExplain(t, recorder.Body)
+				require.False(t, rsp.Granted)
+				require.Equal(t, "denied by role locum", rsp.Reason)
+				require.True(t, rsp.Roles[0].GrantsPermission)
+				require.True(t, rsp.Roles[1].DeniesPermission)
+				require.Equal(t, []string{permission.Name}, rsp.Roles[1].Denies)
+			},
+		},
+		{
+			name:       "UserOverrides",
+			userID:     user.ID,
+			permission: permission.Name,
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, admin.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(admin.Username)).
+					Times(1).
+					Return(admin, nil)
+				store.EXPECT().
+					GetPermissionsForUser(gomock.Any(), gomock.Eq(admin.ID)).
+					Times(1).
+					Return([]string{"VIEW_SCREEN_AUTHORIZATION"}, nil)
+				store.EXPECT().
+					GetUserByID(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					GetPermissionByName(gomock.Any(), gomock.Eq(permission.Name)).
+					Times(1).
+					Return(permission, nil)
+				store.EXPECT().
+					ListUserRoleGrants(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return([]db.ListUserRoleGrantsRow{}, nil)
+				store.EXPECT().
+					ListUserRoleDenies(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return([]db.ListUserRoleDeniesRow{}, nil)
+				store.EXPECT().
+					ListUserPermissionOverrides(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return([]db.ListUserPermissionOverridesRow{
+						{PermissionName: permission.Name, Effect: permissionEffectAllow, Reason: "covering ward"},
+					}, nil)
+				store.EXPECT().
+					ListActiveBreakGlassGrantsForUser(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return([]db.ListActiveBreakGlassGrantsForUserRow{}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				rsp := requireBodyExplain(t, recorder.Body)
+				require.True(t, rsp.Granted)
+				require.Equal(t, "granted by user override", rsp.Reason)
+				require.Len(t, rsp.Overrides, 1)
+			},
+		},
+		{
+			name:       "BreakGlassGrant",
+			userID:     user.ID,
+			permission: permission.Name,
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, admin.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(admin.Username)).
+					Times(1).
+					Return(admin, nil)
+				store.EXPECT().
+					GetPermissionsForUser(gomock.Any(), gomock.Eq(admin.ID)).
+					Times(1).
+					Return([]string{"VIEW_SCREEN_AUTHORIZATION"}, nil)
+				store.EXPECT().
+					GetUserByID(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					GetPermissionByName(gomock.Any(), gomock.Eq(permission.Name)).
+					Times(1).
+					Return(permission, nil)
+				store.EXPECT().
+					ListUserRoleGrants(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return([]db.ListUserRoleGrantsRow{{RoleID: 2, RoleName: "nurse", Active: true}}, nil)
+				store.EXPECT().
+					ListUserRoleDenies(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return([]db.ListUserRoleDeniesRow{}, nil)
+				store.EXPECT().
+					ListUserPermissionOverrides(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return([]db.ListUserPermissionOverridesRow{}, nil)
+				store.EXPECT().
+					ListActiveBreakGlassGrantsForUser(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return([]db.ListActiveBreakGlassGrantsForUserRow{
+						{
+							ID:             5,
+							PermissionName: permission.Name,
+							Justification:  "Patient in cardiac arrest, attending doctor unavailable",
+							ExpiresAt:      time.Now().Add(time.Hour),
+						},
+					}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				rsp := requireBodyExplain(t, recorder.Body)
+				require.True(t, rsp.Granted)
+				require.Equal(t, "granted by break-glass grant #5", rsp.Reason)
+				require.Len(t, rsp.BreakGlass, 1)
+				require.Equal(t, int64(5), rsp.BreakGlass[0].ID)
+			},
+		},
+		{
+			name:       "UnknownPermission",
+			userID:     user.ID,
+			permission: "UNKNOWN",
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, admin.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(admin.Username)).
+					Times(1).
+					Return(admin, nil)
+				store.EXPECT().
+					GetPermissionsForUser(gomock.Any(), gomock.Eq(admin.ID)).
+					Times(1).
+					Return([]string{"VIEW_SCREEN_AUTHORIZATION"}, nil)
+				store.EXPECT().
+					GetUserByID(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					GetPermissionByName(gomock.Any(), gomock.Eq("UNKNOWN")).
+					Times(1).
+					Return(db.Permission{}, sql.ErrNoRows)
+				store.EXPECT().
+					ListUserRoleGrants(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return([]db.ListUserRoleGrantsRow{}, nil)
+				store.EXPECT().
+					ListUserRoleDenies(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return([]db.ListUserRoleDeniesRow{}, nil)
+				store.EXPECT().
+					ListUserPermissionOverrides(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return([]db.ListUserPermissionOverridesRow{}, nil)
+				store.EXPECT().
+					ListActiveBreakGlassGrantsForUser(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return([]db.ListActiveBreakGlassGrantsForUserRow{}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				rsp := requireBodyExplain(t, recorder.Body)
+				require.False(t, rsp.PermissionExists)
+				require.False(t, rsp.Granted)
+			},
+		},
+		{
+			name:       "UserNotFound",
+			userID:     user.ID,
+			permission: permission.Name,
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, admin.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(admin.Username)).
+					Times(1).
+					Return(admin, nil)
+				store.EXPECT().
+					GetPermissionsForUser(gomock.Any(), gomock.Eq(admin.ID)).
+					Times(1).
+					Return([]string{"VIEW_SCREEN_AUTHORIZATION"}, nil)
+				store.EXPECT().
+					GetUserByID(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return(db.User{}, sql.ErrNoRows)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:       "Forbidden",
+			userID:     user.ID,
+			permission: permission.Name,
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, admin.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(admin.Username)).
+					Times(1).
+					Return(admin, nil)
+				store.EXPECT().
+					GetPermissionsForUser(gomock.Any(), gomock.Eq(admin.ID)).
+					Times(1).
+					Return([]string{"VIEW_SCREEN_USER"}, nil)
+				store.EXPECT().
+					GetUserByID(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			query := url.Values{}
+			query.Add("user_id", fmt.Sprintf("%d", tc.userID))
+			query.Add("permission", tc.permission)
+
+			request, err := http.NewRequest(http.MethodGet, "/authz/explain?"+query.Encode(), nil)
+			require.NoError(t, err)
+
+			tc.setupAuth(t, request, server.tokenMaker)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func requireBodyExplain(t *testing.T, body *bytes.Buffer) explainResponse {
+	data, err := io.ReadAll(body)
+	require.NoError(t, err)
+
+	var response struct {
+		Data explainResponse `json:"data"`
+	}
+	err = json.Unmarshal(data, &response)
+	require.NoError(t, err)
+	return response.Data
+}
+
+func TestAuthorizationMatrixAPI(t *testing.T) {
+	admin, _ := randomUser(t)
+	admin.ID = 1
+
+	role1 := randomRole()
+	role2 := randomRole()
+	role2.ID = role1.ID + 1
+	permission1 := randomPermission()
+	permission2 := randomPermission()
+	permission2.ID = permission1.ID + 1
+
+	testCases := []struct {
+		name          string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(admin.Username)).
+					Times(1).
+					Return(admin, nil)
+				store.EXPECT().
+					GetPermissionsForUser(gomock.Any(), gomock.Eq(admin.ID)).
+					Times(1).
+					Return([]string{"VIEW_SCREEN_AUTHORIZATION"}, nil)
+				store.EXPECT().
+					ListAllRoles(gomock.Any()).
+					Times(1).
+					Return([]db.Role{role1, role2}, nil)
+				store.EXPECT().
+					ListAllPermissions(gomock.Any()).
+					Times(1).
+					Return([]db.Permission{permission1, permission2}, nil)
+				store.EXPECT().
+					ListAllRolePermissions(gomock.Any()).
+					Times(1).
+					Return([]db.RolePermission{
+						{RoleID: role1.ID, PermissionID: permission2.ID},
+						{RoleID: role2.ID, PermissionID: permission1.ID},
+					}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var response struct {
+					Data matrixResponse `json:"data"`
+				}
+				err := json.Unmarshal(recorder.Body.Bytes(), &response)
+				require.NoError(t, err)
+
+				matrix := response.Data
+				require.Len(t, matrix.Permissions, 2)
+				require.Len(t, matrix.Roles, 2)
+				require.Equal(t, []bool{false, true}, matrix.Roles[0].Granted)
+				require.Equal(t, []bool{true, false}, matrix.Roles[1].Granted)
+			},
+		},
+		{
+			name: "InternalError",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(admin.Username)).
+					Times(1).
+					Return(admin, nil)
+				store.EXPECT().
+					GetPermissionsForUser(gomock.Any(), gomock.Eq(admin.ID)).
+					Times(1).
+					Return([]string{"VIEW_SCREEN_AUTHORIZATION"}, nil)
+				store.EXPECT().
+					ListAllRoles(gomock.Any()).
+					Times(1).
+					Return([]db.Role{}, sql.ErrConnDone)
+				store.EXPECT().
+					ListAllPermissions(gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			request, err := http.NewRequest(http.MethodGet, "/authz/matrix", nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, admin.Username, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestExportPolicyAPI(t *testing.T) {
+	admin, _ := randomUser(t)
+	admin.ID = 1
+
+	doc := randomPolicyDocument()
+
+	testCases := []struct {
+		name          string
+		format        string
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:   "JSON",
+			format: "json",
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got db.PolicyDocument
+				err := json.Unmarshal(recorder.Body.Bytes(), &got)
+				require.NoError(t, err)
+				require.Equal(t, doc, got)
+			},
+		},
+		{
+			name:   "YAML",
+			format: "yaml",
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Equal(t, "application/yaml", recorder.Header().Get("Content-Type"))
+
+				got, err := decodePolicy(policyFormatYAML, recorder.Body)
+				require.NoError(t, err)
+				require.Equal(t, doc, got)
+			},
+		},
+		{
+			name:   "InvalidFormat",
+			format: "xml",
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().
+				GetUser(gomock.Any(), gomock.Eq(admin.Username)).
+				Times(1).
+				Return(admin, nil)
+			store.EXPECT().
+				GetPermissionsForUser(gomock.Any(), gomock.Eq(admin.ID)).
+				Times(1).
+				Return([]string{"VIEW_SCREEN_AUTHORIZATION"}, nil)
+			store.EXPECT().
+				ExportPolicy(gomock.Any()).
+				AnyTimes().
+				Return(doc, nil)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			request, err := http.NewRequest(http.MethodGet, "/authz/policy?format="+tc.format, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, admin.Username, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestImportPolicyAPI(t *testing.T) {
+	admin, _ := randomUser(t)
+	admin.ID = 1
+
+	doc := randomPolicyDocument()
+	yamlBody := "permissions:\n  - name: " + doc.Permissions[0].Name + "\n    description: " + doc.Permissions[0].Description +
+		"\nroles:\n  - name: " + doc.Roles[0].Name + "\n    description: " + doc.Roles[0].Description +
+		"\n    permissions:\n      - " + doc.Permissions[0].Name + "\n"
+	jsonBody, err := json.Marshal(doc)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name          string
+		query         string
+		body          string
+		permissions   []string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "DryRun",
+			query: "format=json&dry_run=true",
+			body:  string(jsonBody),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ImportPolicyTx(gomock.Any(), gomock.Eq(db.ImportPolicyTxParams{Document: doc, DryRun: true})).
+					Times(1).
+					Return(db.ImportPolicyTxResult{DryRun: true, Diff: db.PolicyDiff{CreatedRoles: []string{doc.Roles[0].Name}}}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var response struct {
+					Data db.ImportPolicyTxResult `json:"data"`
+				}
+				err := json.Unmarshal(recorder.Body.Bytes(), &response)
+				require.NoError(t, err)
+				require.True(t, response.Data.DryRun)
+				require.Equal(t, []string{doc.Roles[0].Name}, response.Data.Diff.CreatedRoles)
+			},
+		},
+		{
+			name:  "ApplyYAML",
+			query: "format=yaml",
+			body:  yamlBody,
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.ImportPolicyTxParams{
+					Document: db.PolicyDocument{
+						Permissions: doc.Permissions[:1],
+						Roles: []db.PolicyRole{{
+							Name:        doc.Roles[0].Name,
+							Description: doc.Roles[0].Description,
+							Permissions: []string{doc.Permissions[0].Name},
+						}},
+					},
+				}
+				store.EXPECT().
+					ImportPolicyTx(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(db.ImportPolicyTxResult{}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:  "PruneUsers",
+			query: "format=json&prune_users=true",
+			body:  string(jsonBody),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ImportPolicyTx(gomock.Any(), gomock.Eq(db.ImportPolicyTxParams{Document: doc, PruneUsers: true})).
+					Times(1).
+					Return(db.ImportPolicyTxResult{}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:        "MissingUserRolePermission",
+			query:       "format=json",
+			body:        string(jsonBody),
+			permissions: []string{"VIEW_SCREEN_ROLE", "VIEW_SCREEN_PERMISSION", "VIEW_SCREEN_ROLE_PERMISSION"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ImportPolicyTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:  "UnknownField",
+			query: "format=json",
+			body:  `{"permissions": [], "rolez": []}`,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ImportPolicyTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:  "InvalidPolicy",
+			query: "format=json",
+			body:  string(jsonBody),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ImportPolicyTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.ImportPolicyTxResult{}, fmt.Errorf("%w: duplicate role", db.ErrInvalidPolicy))
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:  "ProtectedRole",
+			query: "format=json",
+			body:  string(jsonBody),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ImportPolicyTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.ImportPolicyTxResult{}, fmt.Errorf("%w: admin", db.ErrProtectedRole))
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name:  "LastAdmin",
+			query: "format=json",
+			body:  string(jsonBody),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ImportPolicyTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.ImportPolicyTxResult{}, db.ErrLastAdmin)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			permissions := tc.permissions
+			if permissions == nil {
+				permissions = policyImportPermissions
+			}
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().
+				GetUser(gomock.Any(), gomock.Eq(admin.Username)).
+				Times(1).
+				Return(admin, nil)
+			store.EXPECT().
+				GetPermissionsForUser(gomock.Any(), gomock.Eq(admin.ID)).
+				Times(1).
+				Return(permissions, nil)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			request, err := http.NewRequest(http.MethodPost, "/authz/policy?"+tc.query, bytes.NewBufferString(tc.body))
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, admin.Username, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func randomPolicyDocument() db.PolicyDocument {
+	permission1 := randomPermission()
+	permission2 := randomPermission()
+	permission3 := randomPermission()
+	role := randomRole()
+	validUntil := time.Date(2027, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	return db.PolicyDocument{
+		Permissions: []db.PolicyPermission{
+			{Name: permission1.Name, Description: permission1.Description.String},
+			{Name: permission2.Name, Description: permission2.Description.String, BreakGlass: true},
+			{Name: permission3.Name, Description: permission3.Description.String, IsSystem: true},
+		},
+		Roles: []db.PolicyRole{
+			{
+				Name:        role.Name,
+				Description: role.Description.String,
+				Permissions: []string{permission1.Name, permission2.Name},
+				Denies:      []string{permission3.Name},
+			},
+		},
+		Users: []db.PolicyUser{
+			{
+				Username:  utils.RandomOwner(),
+				Roles:     []db.PolicyUserRole{{Role: role.Name, ValidUntil: &validUntil}},
+				Overrides: []db.PolicyOverride{{Permission: permission3.Name, Effect: "allow", Reason: "on call"}},
+			},
+		},
+	}
+}
@@ -0,0 +1,266 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	db "github.com/toannguyen3105/nht-bsihuyen.com-api/db/sqlc"
+	"github.com/toannguyen3105/nht-bsihuyen.com-api/notify"
+	"github.com/toannguyen3105/nht-bsihuyen.com-api/token"
+)
+
+const defaultBreakGlassDuration = time.Hour
+
+var (
+	errBreakGlassSystemPermission = errors.New("system permissions cannot be obtained through break-glass access")
+	errBreakGlassNotAllowed       = errors.New("permission cannot be obtained through break-glass access")
+	errBreakGlassReviewed         = errors.New("break-glass grant has already been reviewed")
+	errBreakGlassSelfReview       = errors.New("break-glass grants cannot be reviewed by their holder")
+)
+
+type breakGlassRequest struct {
+	Permission    string `json:"permission" binding:"required,max=255"`
+	Justification string `json:"justification" binding:"required,min=20,max=2000"`
+}
+
+type breakGlassResponse struct {
+	ID            int64      `json:"id"`
+	UserID        int32      `json:"user_id"`
+	PermissionID  int32      `json:"permission_id"`
+	Justification string     `json:"justification"`
+	ExpiresAt     time.Time  `json:"expires_at"`
+	ReviewedBy    string     `json:"reviewed_by"`
+	ReviewedAt    *time.Time `json:"reviewed_at"`
+	ReviewNote    string     `json:"review_note"`
+	CreatedAt     time.Time  `json:"created_at"`
+}
+
+func newBreakGlassResponse(grant db.BreakGlassGrant) breakGlassResponse {
+	rsp := breakGlassResponse{
+		ID:            grant.ID,
+		UserID:        grant.UserID,
+		PermissionID:  grant.PermissionID,
+		Justification: grant.Justification,
+		ExpiresAt:     grant.ExpiresAt,
+		ReviewedBy:    grant.ReviewedBy.String,
+		ReviewNote:    grant.ReviewNote.String,
+		CreatedAt:     grant.CreatedAt,
+	}
+	if grant.ReviewedAt.Valid {
+		rsp.ReviewedAt = &grant.ReviewedAt.Time
+	}
+	return rsp
+}
+
+// breakGlass grants the authenticated user a permission flagged break_glass
+// for a short time in an emergency. The grant takes effect immediately and
+// the administrators are notified; it is reviewed afterwards.
+func (server *Server) breakGlass(ctx *gin.Context) {
+	var req breakGlassRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	user, err := server.store.GetUser(ctx, authPayload.Username)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	permission, err := server.store.GetPermissionByName(ctx, req.Permission)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if permission.IsSystem {
+		ctx.JSON(http.StatusForbidden, errorResponse(errBreakGlassSystemPermission))
+		return
+	}
+
+	if !permission.BreakGlass {
+		ctx.JSON(http.StatusForbidden, errorResponse(errBreakGlassNotAllowed))
+		return
+	}
+
+	duration := server.config.BreakGlassDuration
+	if duration <= 0 {
+		duration = defaultBreakGlassDuration
+	}
+
+	grant, err := server.store.CreateBreakGlassGrant(ctx, db.CreateBreakGlassGrantParams{
+		UserID:        user.ID,
+		PermissionID:  permission.ID,
+		Justification: req.Justification,
+		ExpiresAt:     time.Now().Add(duration),
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	// Access must not be held up by a failing notifier; the grant is in the
+	// review queue either way.
+	err = server.notifyAdmins(ctx, notify.Notification{
+		Subject: fmt.Sprintf("Break-glass access: %s obtained %s", user.Username, permission.Name),
+		Body: fmt.Sprintf(
+			"%s (%s) obtained %s until %s. Justification: %s",
+			user.FullName,
+			user.Username,
+			permission.Name,
+			grant.ExpiresAt.Format(time.RFC3339),
+			grant.Justification,
+		),
+	})
+	if err != nil {
+		slog.Error("cannot notify administrators about break-glass access", "grant_id", grant.ID, "error", err)
+	}
+
+	ctx.JSON(http.StatusOK, successResponse("Break-glass access granted", newBreakGlassResponse(grant)))
+}
+
+func (server *Server) notifyAdmins(ctx *gin.Context, notification notify.Notification) error {
+	admins, err := server.store.ListSystemAdmins(ctx)
+	if err != nil {
+		return err
+	}
+
+	notification.Recipients = make([]string, len(admins))
+	for i, admin := range admins {
+		notification.Recipients[i] = admin.Email
+	}
+
+	return server.notifier.Notify(ctx, notification)
+}
+
+type listBreakGlassGrantsRequest struct {
+	PageID   int32 `form:"page_id" binding:"required,min=1"`
+	PageSize int32 `form:"page_size" binding:"required,min=10,max=100"`
+}
+
+type breakGlassGrantsResponse struct {
+	Meta struct {
+		Page       int32 `json:"page"`
+		TotalPages int32 `json:"total_pages"`
+		TotalCount int64 `json:"total_count"`
+	} `json:"meta"`
+	Data []db.ListPendingBreakGlassGrantsRow `json:"data"`
+}
+
+func (server *Server) listPendingBreakGlassGrants(ctx *gin.Context) {
+	var req listBreakGlassGrantsRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	arg := db.ListPendingBreakGlassGrantsParams{
+		Limit:  req.PageSize,
+		Offset: (req.PageID - 1) * req.PageSize,
+	}
+
+	grants, err := server.store.ListPendingBreakGlassGrants(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	totalCount, err := server.store.CountPendingBreakGlassGrants(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	totalPages := int32(totalCount) / req.PageSize
+	if int32(totalCount)%req.PageSize != 0 {
+		totalPages++
+	}
+
+	rsp := breakGlassGrantsResponse{
+		Meta: struct {
+			Page       int32 `json:"page"`
+			TotalPages int32 `json:"total_pages"`
+			TotalCount int64 `json:"total_count"`
+		}{
+			Page:       req.PageID,
+			TotalPages: totalPages,
+			TotalCount: totalCount,
+		},
+		Data: grants,
+	}
+
+	ctx.JSON(http.StatusOK, successResponse("Break-glass grants retrieved successfully", rsp))
+}
+
+type getBreakGlassGrantRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+type reviewBreakGlassRequest struct {
+	Note   string `json:"note" binding:"max=2000"`
+	Revoke bool   `json:"revoke"`
+}
+
+func (server *Server) reviewBreakGlassGrant(ctx *gin.Context) {
+	var reqURI getBreakGlassGrantRequest
+	if err := ctx.ShouldBindUri(&reqURI); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req reviewBreakGlassRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	grant, err := server.store.GetBreakGlassGrant(ctx, reqURI.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if grant.ReviewedAt.Valid {
+		ctx.JSON(http.StatusConflict, errorResponse(errBreakGlassReviewed))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	if grant.Username == authPayload.Username {
+		ctx.JSON(http.StatusForbidden, errorResponse(errBreakGlassSelfReview))
+		return
+	}
+
+	reviewed, err := server.store.ReviewBreakGlassGrant(ctx, db.ReviewBreakGlassGrantParams{
+		ID:         reqURI.ID,
+		ReviewedBy: sql.NullString{String: authPayload.Username, Valid: true},
+		ReviewNote: sql.NullString{String: req.Note, Valid: req.Note != ""},
+		Revoke:     req.Revoke,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, successResponse("Break-glass grant reviewed successfully", newBreakGlassResponse(reviewed)))
+}
@@ -0,0 +1,437 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	mockdb "github.com/toannguyen3105/nht-bsihuyen.com-api/db/mock"
+	db "github.com/toannguyen3105/nht-bsihuyen.com-api/db/sqlc"
+	"github.com/toannguyen3105/nht-bsihuyen.com-api/notify"
+)
+
+type recordingNotifier struct {
+	notifications []notify.Notification
+	err           error
+}
+
+func (notifier *recordingNotifier) Notify(ctx context.Context, notification notify.Notification) error {
+	notifier.notifications = append(notifier.notifications, notification)
+	return notifier.err
+}
+
+func TestBreakGlassAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	admin, _ := randomUser(t)
+	user.ID = 7
+	permission := randomPermission()
+	permission.Name = "VIEW_SCREEN_DASHBOARD"
+	permission.BreakGlass = true
+	justification := "Patient in cardiac arrest, attending doctor unavailable"
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		notifyErr     error
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder, notifier *recordingNotifier)
+	}{
+		{
+			name: "OK",
+			body: gin.H{
+				"permission":    permission.Name,
+				"justification": justification,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					GetPermissionByName(gomock.Any(), gomock.Eq(permission.Name)).
+					Times(1).
+					Return(permission, nil)
+				store.EXPECT().
+					CreateBreakGlassGrant(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, arg db.CreateBreakGlassGrantParams) (db.BreakGlassGrant, error) {
+						require.Equal(t, user.ID, arg.UserID)
+						require.Equal(t, permission.ID, arg.PermissionID)
+						require.Equal(t, justification, arg.Justification)
+						require.WithinDuration(t, time.Now().Add(defaultBreakGlassDuration), arg.ExpiresAt, time.Minute)
+						return db.BreakGlassGrant{
+							ID:            1,
+							UserID:        arg.UserID,
+							PermissionID:  arg.PermissionID,
+							Justification: arg.Justification,
+							ExpiresAt:     arg.ExpiresAt,
+						}, nil
+					})
+				store.EXPECT().
+					ListSystemAdmins(gomock.Any()).
+					Times(1).
+					Return([]db.User{admin}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder, notifier *recordingNotifier) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Len(t, notifier.notifications, 1)
+				require.Equal(t, []string{admin.Email}, notifier.notifications[0].Recipients)
+				require.Contains(t, notifier.notifications[0].Body, justification)
+			},
+		},
+		{
+			name: "NotifierFailure",
+			body: gin.H{
+				"permission":    permission.Name,
+				"justification": justification,
+			},
+			notifyErr: errors.New("mail server down"),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					GetPermissionByName(gomock.Any(), gomock.Eq(permission.Name)).
+					Times(1).
+					Return(permission, nil)
+				store.EXPECT().
+					CreateBreakGlassGrant(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.BreakGlassGrant{ID: 1}, nil)
+				store.EXPECT().
+					ListSystemAdmins(gomock.Any()).
+					Times(1).
+					Return([]db.User{admin}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder, notifier *recordingNotifier) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "ShortJustification",
+			body: gin.H{
+				"permission":    permission.Name,
+				"justification": "urgent",
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateBreakGlassGrant(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder, notifier *recordingNotifier) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+				require.Empty(t, notifier.notifications)
+			},
+		},
+		{
+			name: "PermissionNotFound",
+			body: gin.H{
+				"permission":    "VIEW_SCREEN_USER",
+				"justification": justification,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					GetPermissionByName(gomock.Any(), gomock.Eq("VIEW_SCREEN_USER")).
+					Times(1).
+					Return(db.Permission{}, sql.ErrNoRows)
+				store.EXPECT().
+					CreateBreakGlassGrant(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder, notifier *recordingNotifier) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name: "NotAllowed",
+			body: gin.H{
+				"permission":    "VIEW_SCREEN_MEDICINE",
+				"justification": justification,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					GetPermissionByName(gomock.Any(), gomock.Eq("VIEW_SCREEN_MEDICINE")).
+					Times(1).
+					Return(db.Permission{ID: 2, Name: "VIEW_SCREEN_MEDICINE"}, nil)
+				store.EXPECT().
+					CreateBreakGlassGrant(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder, notifier *recordingNotifier) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name: "ReviewPermission",
+			body: gin.H{
+				"permission":    "REVIEW_BREAK_GLASS",
+				"justification": justification,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					GetPermissionByName(gomock.Any(), gomock.Eq("REVIEW_BREAK_GLASS")).
+					Times(1).
+					Return(db.Permission{ID: 9, Name: "REVIEW_BREAK_GLASS", IsSystem: true}, nil)
+				store.EXPECT().
+					CreateBreakGlassGrant(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder, notifier *recordingNotifier) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name: "SystemPermission",
+			body: gin.H{
+				"permission":    "VIEW_SCREEN_USER",
+				"justification": justification,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					GetPermissionByName(gomock.Any(), gomock.Eq("VIEW_SCREEN_USER")).
+					Times(1).
+					Return(db.Permission{ID: 4, Name: "VIEW_SCREEN_USER", IsSystem: true}, nil)
+				store.EXPECT().
+					CreateBreakGlassGrant(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder, notifier *recordingNotifier) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			notifier := &recordingNotifier{err: tc.notifyErr}
+			server := newTestServer(t, store)
+			server.notifier = notifier
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			request, err := http.NewRequest(http.MethodPost, "/break-glass", bytes.NewReader(data))
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder, notifier)
+		})
+	}
+}
+
+func TestReviewBreakGlassGrantAPI(t *testing.T) {
+	admin, _ := randomUser(t)
+	admin.ID = 1
+	grant := db.GetBreakGlassGrantRow{
+		ID:            3,
+		UserID:        7,
+		Username:      "nurse",
+		PermissionID:  5,
+		Justification: "Patient in cardiac arrest, attending doctor unavailable",
+		ExpiresAt:     time.Now().Add(time.Hour),
+	}
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "Revoke",
+			body: gin.H{
+				"note":   "not an emergency",
+				"revoke": true,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetBreakGlassGrant(gomock.Any(), gomock.Eq(grant.ID)).
+					Times(1).
+					Return(grant, nil)
+				arg := db.ReviewBreakGlassGrantParams{
+					ID:         grant.ID,
+					ReviewedBy: sql.NullString{String: admin.Username, Valid: true},
+					ReviewNote: sql.NullString{String: "not an emergency", Valid: true},
+					Revoke:     true,
+				}
+				reviewed := db.BreakGlassGrant{
+					ID:            grant.ID,
+					UserID:        grant.UserID,
+					PermissionID:  grant.PermissionID,
+					Justification: grant.Justification,
+					ExpiresAt:     time.Now(),
+					ReviewedBy:    arg.ReviewedBy,
+					ReviewedAt:    sql.NullTime{Time: time.Now(), Valid: true},
+					ReviewNote:    arg.ReviewNote,
+				}
+				store.EXPECT().
+					ReviewBreakGlassGrant(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(reviewed, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "AlreadyReviewed",
+			body: gin.H{},
+			buildStubs: func(store *mockdb.MockStore) {
+				reviewed := grant
+				reviewed.ReviewedAt = sql.NullTime{Time: time.Now(), Valid: true}
+				store.EXPECT().
+					GetBreakGlassGrant(gomock.Any(), gomock.Eq(grant.ID)).
+					Times(1).
+					Return(reviewed, nil)
+				store.EXPECT().
+					ReviewBreakGlassGrant(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name: "SelfReview",
+			body: gin.H{},
+			buildStubs: func(store *mockdb.MockStore) {
+				own := grant
+				own.UserID = admin.ID
+				own.Username = admin.Username
+				store.EXPECT().
+					GetBreakGlassGrant(gomock.Any(), gomock.Eq(grant.ID)).
+					Times(1).
+					Return(own, nil)
+				store.EXPECT().
+					ReviewBreakGlassGrant(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name: "NotFound",
+			body: gin.H{},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetBreakGlassGrant(gomock.Any(), gomock.Eq(grant.ID)).
+					Times(1).
+					Return(db.GetBreakGlassGrantRow{}, sql.ErrNoRows)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().
+				GetUser(gomock.Any(), gomock.Eq(admin.Username)).
+				Times(1).
+				Return(admin, nil)
+			store.EXPECT().
+				GetPermissionsForUser(gomock.Any(), gomock.Eq(admin.ID)).
+				Times(1).
+				Return([]string{"REVIEW_BREAK_GLASS"}, nil)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/break-glass/%d/review", grant.ID)
+			request, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, admin.Username, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestListPendingBreakGlassGrantsAPI(t *testing.T) {
+	admin, _ := randomUser(t)
+	admin.ID = 1
+	grants := []db.ListPendingBreakGlassGrantsRow{
+		{ID: 3, UserID: 7, Username: "nurse", PermissionID: 5, PermissionName: "VIEW_SCREEN_MEDICINE"},
+	}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().
+		GetUser(gomock.Any(), gomock.Eq(admin.Username)).
+		Times(1).
+		Return(admin, nil)
+	store.EXPECT().
+		GetPermissionsForUser(gomock.Any(), gomock.Eq(admin.ID)).
+		Times(1).
+		Return([]string{"REVIEW_BREAK_GLASS"}, nil)
+	store.EXPECT().
+		ListPendingBreakGlassGrants(gomock.Any(), gomock.Eq(db.ListPendingBreakGlassGrantsParams{Limit: 10, Offset: 0})).
+		Times(1).
+		Return(grants, nil)
+	store.EXPECT().
+		CountPendingBreakGlassGrants(gomock.Any()).
+		Times(1).
+		Return(int64(len(grants)), nil)
+
+	server := newTestServer(t, store)
+	recorder := httptest.NewRecorder()
+
+	request, err := http.NewRequest(http.MethodGet, "/break-glass/pending?page_id=1&page_size=10", nil)
+	require.NoError(t, err)
+
+	addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, admin.Username, time.Minute)
+	server.router.ServeHTTP(recorder, request)
+	require.Equal(t, http.StatusOK, recorder.Code)
+}
@@ -0,0 +1,142 @@
+package api
+
+import (
+	"database/sql"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/lib/pq"
+	db "github.com/toannguyen3105/nht-bsihuyen.com-api/db/sqlc"
+)
+
+type createCategoryRequest struct {
+	Name        string `json:"name" binding:"required,max=100"`
+	Description string `json:"description" binding:"max=255"`
+}
+
+type categoryResponse struct {
+	ID          int32     `json:"id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+func newCategoryResponse(category db.Category) categoryResponse {
+	return categoryResponse{
+		ID:          category.ID,
+		Name:        category.Name,
+		Description: category.Description.String,
+		CreatedAt:   category.CreatedAt,
+	}
+}
+
+func newCategoriesResponse(categories []db.Category) []categoryResponse {
+	rsp := make([]categoryResponse, len(categories))
+	for i, category := range categories {
+		rsp[i] = newCategoryResponse(category)
+	}
+	return rsp
+}
+
+func (server *Server) createCategory(ctx *gin.Context) {
+	var req createCategoryRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	category, err := server.store.CreateCategory(ctx, db.CreateCategoryParams{
+		Name:        req.Name,
+		Description: sql.NullString{String: req.Description, Valid: req.Description != ""},
+	})
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code.Name() == "unique_violation" {
+			ctx.JSON(http.StatusForbidden, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, successResponse("Category created successfully", newCategoryResponse(category)))
+}
+
+func (server *Server) listCategories(ctx *gin.Context) {
+	categories, err := server.store.ListCategories(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, successResponse("Categories retrieved successfully", newCategoriesResponse(categories)))
+}
+
+type deleteCategoryRequest struct {
+	ID int32 `uri:"id" binding:"required,min=1"`
+}
+
+// deleteCategory refuses categories that medicines are still in.
+func (server *Server) deleteCategory(ctx *gin.Context) {
+	var req deleteCategoryRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	deleted, err := server.store.DeleteCategory(ctx, req.ID)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code.Name() == "foreign_key_violation" {
+			ctx.JSON(http.StatusForbidden, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if deleted == 0 {
+		ctx.JSON(http.StatusNotFound, errorResponse(sql.ErrNoRows))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, successResponse("Category deleted successfully", nil))
+}
+
+type setMedicineCategoriesRequest struct {
+	CategoryIDs []int32 `json:"category_ids" binding:"required,unique,dive,min=1"`
+}
+
+// setMedicineCategories replaces the categories of a medicine; an empty list
+// removes them all.
+func (server *Server) setMedicineCategories(ctx *gin.Context) {
+	var reqURI getMedicineRequest
+	if err := ctx.ShouldBindUri(&reqURI); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req setMedicineCategoriesRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	categories, err := server.store.SetMedicineCategoriesTx(ctx, db.SetMedicineCategoriesTxParams{
+		MedicineID:  reqURI.ID,
+		CategoryIDs: req.CategoryIDs,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code.Name() == "foreign_key_violation" {
+			ctx.JSON(http.StatusForbidden, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, successResponse("Medicine categories saved successfully", newCategoriesResponse(categories)))
+}
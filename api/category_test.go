@@ -0,0 +1,221 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+	mockdb "github.com/toannguyen3105/nht-bsihuyen.com-api/db/mock"
+	db "github.com/toannguyen3105/nht-bsihuyen.com-api/db/sqlc"
+)
+
+func TestSetMedicineCategoriesAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	medicine := randomMedicine()
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{"category_ids": []int32{2, 1}},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.SetMedicineCategoriesTxParams{
+					MedicineID:  medicine.ID,
+					CategoryIDs: []int32{2, 1},
+				}
+				store.EXPECT().
+					SetMedicineCategoriesTx(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return([]db.Category{{ID: 1, Name: "Analgesics"}, {ID: 2, Name: "Antipyretics"}}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var response struct {
+					Data []categoryResponse `json:"data"`
+				}
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+				require.Len(t, response.Data, 2)
+			},
+		},
+		{
+			name: "Clear",
+			body: gin.H{"category_ids": []int32{}},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.SetMedicineCategoriesTxParams{
+					MedicineID:  medicine.ID,
+					CategoryIDs: []int32{},
+				}
+				store.EXPECT().
+					SetMedicineCategoriesTx(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return([]db.Category{}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "UnknownCategory",
+			body: gin.H{"category_ids": []int32{99}},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					SetMedicineCategoriesTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, &pq.Error{Code: "23503"})
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name: "DuplicateCategory",
+			body: gin.H{"category_ids": []int32{1, 1}},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					SetMedicineCategoriesTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "MedicineNotFound",
+			body: gin.H{"category_ids": []int32{1}},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					SetMedicineCategoriesTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, sql.ErrNoRows)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().
+				GetUser(gomock.Any(), gomock.Eq(user.Username)).
+				Times(1).
+				Return(user, nil)
+			store.EXPECT().
+				GetPermissionsForUser(gomock.Any(), gomock.Eq(user.ID)).
+				Times(1).
+				Return([]string{"VIEW_SCREEN_MEDICINE"}, nil)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/medicines/%d/categories", medicine.ID)
+			request, err := http.NewRequest(http.MethodPut, url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestDeleteCategoryAPI(t *testing.T) {
+	user, _ := randomUser(t)
+
+	testCases := []struct {
+		name          string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					DeleteCategory(gomock.Any(), gomock.Eq(int32(3))).
+					Times(1).
+					Return(int64(1), nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "InUse",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					DeleteCategory(gomock.Any(), gomock.Eq(int32(3))).
+					Times(1).
+					Return(int64(0), &pq.Error{Code: "23503"})
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name: "NotFound",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					DeleteCategory(gomock.Any(), gomock.Eq(int32(3))).
+					Times(1).
+					Return(int64(0), nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().
+				GetUser(gomock.Any(), gomock.Eq(user.Username)).
+				Times(1).
+				Return(user, nil)
+			store.EXPECT().
+				GetPermissionsForUser(gomock.Any(), gomock.Eq(user.ID)).
+				Times(1).
+				Return([]string{"VIEW_SCREEN_MEDICINE"}, nil)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			request, err := http.NewRequest(http.MethodDelete, "/categories/3", nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
@@ -0,0 +1,464 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"html/template"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	db "github.com/toannguyen3105/nht-bsihuyen.com-api/db/sqlc"
+	"github.com/toannguyen3105/nht-bsihuyen.com-api/token"
+	"github.com/toannguyen3105/nht-bsihuyen.com-api/utils"
+	"github.com/xuri/excelize/v2"
+)
+
+var (
+	errInvalidWitness  = errors.New("witness username or password is incorrect")
+	errWitnessIsCaller = errors.New("the witness must be someone other than you")
+	errInvalidPeriod   = errors.New("the period must not end before it starts")
+)
+
+// witnessRequest is the second person witnessing a movement of a controlled
+// medicine. They confirm it with their own password.
+type witnessRequest struct {
+	Username string `json:"username" binding:"required,alphanum,max=255"`
+	Password string `json:"password" binding:"required,min=6,max=255"`
+}
+
+// authenticateWitness checks the witness's password and returns their
+// username. Without a witness it returns NULL, and the store decides whether
+// the movement needed one. On failure the response has been written and ok
+// is false.
+func (server *Server) authenticateWitness(ctx *gin.Context, req *witnessRequest) (witnessedBy sql.NullString, ok bool) {
+	if req == nil {
+		return witnessedBy, true
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	if req.Username == authPayload.Username {
+		ctx.JSON(http.StatusBadRequest, errorResponse(errWitnessIsCaller))
+		return witnessedBy, false
+	}
+
+	user, err := server.store.GetUser(ctx, req.Username)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusUnauthorized, errorResponse(errInvalidWitness))
+			return witnessedBy, false
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return witnessedBy, false
+	}
+
+	err = utils.CheckPassword(req.Password, user.HashedPassword)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(errInvalidWitness))
+		return witnessedBy, false
+	}
+
+	return sql.NullString{String: user.Username, Valid: true}, true
+}
+
+type setMedicineControlledRequest struct {
+	Controlled *bool           `json:"controlled" binding:"required"`
+	Note       *string         `json:"note" binding:"omitempty,max=255"`
+	Witness    *witnessRequest `json:"witness" binding:"required"`
+}
+
+// setMedicineControlled puts a medicine on the controlled register or takes
+// it off. Like a movement of a controlled medicine it is witnessed, and the
+// register gets an entry with the stock at that moment.
+func (server *Server) setMedicineControlled(ctx *gin.Context) {
+	var reqURI getMedicineRequest
+	if err := ctx.ShouldBindUri(&reqURI); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req setMedicineControlledRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	witnessedBy, ok := server.authenticateWitness(ctx, req.Witness)
+	if !ok {
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	arg := db.SetMedicineControlledTxParams{
+		MedicineID:  reqURI.ID,
+		Controlled:  *req.Controlled,
+		RecordedBy:  sql.NullString{String: authPayload.Username, Valid: true},
+		WitnessedBy: witnessedBy,
+	}
+	if req.Note != nil {
+		arg.Note = sql.NullString{String: *req.Note, Valid: true}
+	}
+
+	medicine, err := server.store.SetMedicineControlledTx(ctx, arg)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		if errors.Is(err, db.ErrWitnessRequired) {
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, successResponse("Medicine updated successfully", medicine))
+}
+
+const (
+	registerFormatCSV  = "csv"
+	registerFormatXLSX = "xlsx"
+	registerFormatHTML = "html"
+)
+
+const registerSheet = "Register"
+
+var registerColumns = []string{"medicine", "unit", "date", "entry", "lot_number", "location", "received", "issued", "balance", "recorded_by", "witnessed_by", "note"}
+
+type getControlledRegisterRequest struct {
+	From       string `form:"from" binding:"required"`
+	To         string `form:"to" binding:"required"`
+	MedicineID int32  `form:"medicine_id" binding:"omitempty,min=1"`
+	Format     string `form:"format" binding:"omitempty,oneof=json csv xlsx html"`
+}
+
+type controlledRegisterEntryResponse struct {
+	ID              int64     `json:"id"`
+	StockMovementID *int64    `json:"stock_movement_id"`
+	MovementType    string    `json:"movement_type"`
+	Quantity        int32     `json:"quantity"`
+	Balance         int32     `json:"balance"`
+	BatchID         *int64    `json:"batch_id"`
+	LotNumber       *string   `json:"lot_number"`
+	LocationID      *int32    `json:"location_id"`
+	LocationName    *string   `json:"location_name"`
+	Note            *string   `json:"note"`
+	RecordedBy      string    `json:"recorded_by"`
+	WitnessedBy     string    `json:"witnessed_by"`
+	CreatedAt       time.Time `json:"created_at"`
+}
+
+type controlledRegisterMedicineResponse struct {
+	MedicineID     int32                             `json:"medicine_id"`
+	MedicineName   string                            `json:"medicine_name"`
+	Unit           string                            `json:"unit"`
+	OpeningBalance int32                             `json:"opening_balance"`
+	Received       int32                             `json:"received"`
+	Issued         int32                             `json:"issued"`
+	ClosingBalance int32                             `json:"closing_balance"`
+	Entries        []controlledRegisterEntryResponse `json:"entries"`
+}
+
+type controlledRegisterResponse struct {
+	From      string                               `json:"from"`
+	To        string                               `json:"to"`
+	Medicines []controlledRegisterMedicineResponse `json:"medicines"`
+}
+
+func newControlledRegisterResponse(from, to string, report []db.ControlledRegisterMedicine) controlledRegisterResponse {
+	rsp := controlledRegisterResponse{
+		From:      from,
+		To:        to,
+		Medicines: make([]controlledRegisterMedicineResponse, len(report)),
+	}
+	for i, medicine := range report {
+		rsp.Medicines[i] = controlledRegisterMedicineResponse{
+			MedicineID:     medicine.MedicineID,
+			MedicineName:   medicine.MedicineName,
+			Unit:           medicine.Unit,
+			OpeningBalance: medicine.OpeningBalance,
+			Received:       medicine.Received,
+			Issued:         medicine.Issued,
+			ClosingBalance: medicine.ClosingBalance,
+			Entries:        make([]controlledRegisterEntryResponse, len(medicine.Entries)),
+		}
+		for j, entry := range medicine.Entries {
+			rsp.Medicines[i].Entries[j] = newControlledRegisterEntryResponse(entry)
+		}
+	}
+	return rsp
+}
+
+func newControlledRegisterEntryResponse(entry db.ListControlledRegisterEntriesRow) controlledRegisterEntryResponse {
+	rsp := controlledRegisterEntryResponse{
+		ID:           entry.ID,
+		MovementType: entry.MovementType,
+		Quantity:     entry.Quantity,
+		Balance:      entry.Balance,
+		RecordedBy:   entry.RecordedBy,
+		WitnessedBy:  entry.WitnessedBy,
+		CreatedAt:    entry.CreatedAt,
+	}
+	if entry.StockMovementID.Valid {
+		rsp.StockMovementID = &entry.StockMovementID.Int64
+	}
+	if entry.BatchID.Valid {
+		rsp.BatchID = &entry.BatchID.Int64
+	}
+	if entry.LotNumber.Valid {
+		rsp.LotNumber = &entry.LotNumber.String
+	}
+	if entry.LocationID.Valid {
+		rsp.LocationID = &entry.LocationID.Int32
+	}
+	if entry.LocationName.Valid {
+		rsp.LocationName = &entry.LocationName.String
+	}
+	if entry.Note.Valid {
+		rsp.Note = &entry.Note.String
+	}
+	return rsp
+}
+
+// getControlledRegister reports the controlled substance register from one
+// day to another, both included: per medicine the opening balance, every
+// witnessed entry with its running balance, and the closing balance. The
+// report comes as JSON, as csv or xlsx for export, or as an html page to
+// print.
+func (server *Server) getControlledRegister(ctx *gin.Context) {
+	var req getControlledRegisterRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	from, err := time.ParseInLocation(time.DateOnly, req.From, time.Local)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	to, err := time.ParseInLocation(time.DateOnly, req.To, time.Local)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if to.Before(from) {
+		ctx.JSON(http.StatusBadRequest, errorResponse(errInvalidPeriod))
+		return
+	}
+
+	arg := db.ControlledRegisterReportParams{
+		PeriodStart: from,
+		PeriodEnd:   to.AddDate(0, 0, 1),
+	}
+	if req.MedicineID != 0 {
+		arg.MedicineID = sql.NullInt32{Int32: req.MedicineID, Valid: true}
+	}
+
+	report, err := server.store.ControlledRegisterReport(ctx, arg)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	rsp := newControlledRegisterResponse(req.From, req.To, report)
+	filename := fmt.Sprintf("controlled-register-%s-%s", req.From, req.To)
+
+	var data []byte
+	switch req.Format {
+	case registerFormatCSV:
+		data, err = writeRegisterCSV(rsp)
+	case registerFormatXLSX:
+		data, err = writeRegisterXLSX(rsp)
+	case registerFormatHTML:
+		data, err = writeRegisterHTML(rsp)
+	default:
+		ctx.JSON(http.StatusOK, successResponse("Controlled register retrieved successfully", rsp))
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	switch req.Format {
+	case registerFormatCSV:
+		ctx.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s.csv"`, filename))
+		ctx.Data(http.StatusOK, "text/csv; charset=utf-8", data)
+	case registerFormatXLSX:
+		ctx.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s.xlsx"`, filename))
+		ctx.Data(http.StatusOK, "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", data)
+	default:
+		ctx.Data(http.StatusOK, "text/html; charset=utf-8", data)
+	}
+}
+
+// registerRows lays the register out as rows of registerColumns: each
+// medicine opens with its opening balance, lists its entries and closes with
+// its closing balance.
+func registerRows(rsp controlledRegisterResponse) [][]interface{} {
+	var rows [][]interface{}
+	for _, medicine := range rsp.Medicines {
+		rows = append(rows, []interface{}{
+			medicine.MedicineName, medicine.Unit, rsp.From, "opening balance",
+			"", "", "", "", medicine.OpeningBalance, "", "", "",
+		})
+
+		for _, entry := range medicine.Entries {
+			var received, issued interface{} = "", ""
+			switch {
+			case entry.Quantity > 0:
+				received = entry.Quantity
+			case entry.Quantity < 0:
+				issued = -entry.Quantity
+			}
+
+			row := []interface{}{
+				medicine.MedicineName, medicine.Unit, entry.CreatedAt.Local().Format(time.DateTime), entry.MovementType,
+				"", "", received, issued, entry.Balance, entry.RecordedBy, entry.WitnessedBy, "",
+			}
+			if entry.LotNumber != nil {
+				row[4] = *entry.LotNumber
+			}
+			if entry.LocationName != nil {
+				row[5] = *entry.LocationName
+			}
+			if entry.Note != nil {
+				row[11] = *entry.Note
+			}
+			rows = append(rows, row)
+		}
+
+		rows = append(rows, []interface{}{
+			medicine.MedicineName, medicine.Unit, rsp.To, "closing balance",
+			"", "", medicine.Received, medicine.Issued, medicine.ClosingBalance, "", "", "",
+		})
+	}
+	return rows
+}
+
+func writeRegisterCSV(rsp controlledRegisterResponse) ([]byte, error) {
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+
+	err := writer.Write(registerColumns)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, row := range registerRows(rsp) {
+		record := make([]string, len(row))
+		for i, value := range row {
+			record[i] = fmt.Sprint(value)
+		}
+
+		err = writer.Write(record)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	writer.Flush()
+	return buf.Bytes(), writer.Error()
+}
+
+func writeRegisterXLSX(rsp controlledRegisterResponse) ([]byte, error) {
+	file := excelize.NewFile()
+	defer file.Close()
+
+	err := file.SetSheetName(file.GetSheetName(0), registerSheet)
+	if err != nil {
+		return nil, err
+	}
+
+	header := make([]interface{}, len(registerColumns))
+	for i, column := range registerColumns {
+		header[i] = column
+	}
+	err = file.SetSheetRow(registerSheet, "A1", &header)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, row := range registerRows(rsp) {
+		cell, err := excelize.CoordinatesToCellName(1, i+2)
+		if err != nil {
+			return nil, err
+		}
+
+		err = file.SetSheetRow(registerSheet, cell, &row)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	buf, err := file.WriteToBuffer()
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+var registerTemplate = template.Must(template.New("register").Funcs(template.FuncMap{
+	"datetime": func(t time.Time) string { return t.Local().Format(time.DateTime) },
+	"abs": func(n int32) int32 {
+		if n < 0 {
+			return -n
+		}
+		return n
+	},
+}).Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Controlled substance register {{.From}} to {{.To}}</title>
+<style>
+body { font-family: sans-serif; font-size: 12px; }
+table { border-collapse: collapse; width: 100%; margin-bottom: 24px; }
+th, td { border: 1px solid #444; padding: 4px; text-align: left; }
+td.number { text-align: right; }
+section { page-break-after: always; }
+</style>
+</head>
+<body>
+{{range .Medicines}}
+<section>
+<h1>Controlled substance register</h1>
+<h2>{{.MedicineName}} ({{.Unit}})</h2>
+<p>Period {{$.From}} to {{$.To}}</p>
+<table>
+<tr><th>Date</th><th>Entry</th><th>Lot</th><th>Location</th><th>Received</th><th>Issued</th><th>Balance</th><th>Recorded by</th><th>Witnessed by</th><th>Note</th></tr>
+<tr><td>{{$.From}}</td><td>Opening balance</td><td></td><td></td><td></td><td></td><td class="number">{{.OpeningBalance}}</td><td></td><td></td><td></td></tr>
+{{range .Entries}}
+<tr><td>{{datetime .CreatedAt}}</td><td>{{.MovementType}}</td><td>{{with .LotNumber}}{{.}}{{end}}</td><td>{{with .LocationName}}{{.}}{{end}}</td><td class="number">{{if gt .Quantity 0}}{{.Quantity}}{{end}}</td><td class="number">{{if lt .Quantity 0}}{{abs .Quantity}}{{end}}</td><td class="number">{{.Balance}}</td><td>{{.RecordedBy}}</td><td>{{.WitnessedBy}}</td><td>{{with .Note}}{{.}}{{end}}</td></tr>
+{{end}}
+<tr><td>{{$.To}}</td><td>Closing balance</td><td></td><td></td><td class="number">{{.Received}}</td><td class="number">{{.Issued}}</td><td class="number">{{.ClosingBalance}}</td><td></td><td></td><td></td></tr>
+</table>
+<p>Checked by: ______________________ Date: ______________</p>
+</section>
+{{else}}
+<p>No controlled medicines.</p>
+{{end}}
+</body>
+</html>
+`))
+
+func writeRegisterHTML(rsp controlledRegisterResponse) ([]byte, error) {
+	var buf bytes.Buffer
+	err := registerTemplate.Execute(&buf, rsp)
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
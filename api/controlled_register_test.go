@@ -0,0 +1,398 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	mockdb "github.com/toannguyen3105/nht-bsihuyen.com-api/db/mock"
+	db "github.com/toannguyen3105/nht-bsihuyen.com-api/db/sqlc"
+)
+
+func randomControlledRegister(medicine db.Medicine, recordedBy, witnessedBy string) db.ControlledRegisterMedicine {
+	return db.ControlledRegisterMedicine{
+		MedicineID:     medicine.ID,
+		MedicineName:   medicine.Name,
+		Unit:           medicine.Unit,
+		OpeningBalance: 50,
+		Received:       20,
+		Issued:         5,
+		ClosingBalance: 65,
+		Entries: []db.ListControlledRegisterEntriesRow{
+			{
+				ID:              1,
+				MedicineID:      medicine.ID,
+				StockMovementID: sql.NullInt64{Int64: 10, Valid: true},
+				MovementType:    db.MovementReceipt,
+				Quantity:        20,
+				Balance:         70,
+				BatchID:         sql.NullInt64{Int64: 3, Valid: true},
+				LotNumber:       sql.NullString{String: "LOT-1", Valid: true},
+				RecordedBy:      recordedBy,
+				WitnessedBy:     witnessedBy,
+				CreatedAt:       time.Now(),
+			},
+			{
+				ID:              2,
+				MedicineID:      medicine.ID,
+				StockMovementID: sql.NullInt64{Int64: 11, Valid: true},
+				MovementType:    db.MovementDispense,
+				Quantity:        -5,
+				Balance:         65,
+				BatchID:         sql.NullInt64{Int64: 3, Valid: true},
+				LotNumber:       sql.NullString{String: "LOT-1", Valid: true},
+				RecordedBy:      recordedBy,
+				WitnessedBy:     witnessedBy,
+				CreatedAt:       time.Now(),
+			},
+		},
+	}
+}
+
+func TestGetControlledRegisterAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	witness, _ := randomUser(t)
+	medicine := randomMedicine()
+	register := randomControlledRegister(medicine, user.Username, witness.Username)
+
+	from := time.Date(2026, time.September, 1, 0, 0, 0, 0, time.Local)
+	period := db.ControlledRegisterReportParams{
+		PeriodStart: from,
+		PeriodEnd:   from.AddDate(0, 1, 0),
+	}
+
+	testCases := []struct {
+		name          string
+		query         string
+		permissions   []string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:        "OK",
+			query:       "?from=2026-09-01&to=2026-09-30",
+			permissions: []string{"VIEW_CONTROLLED_REGISTER"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ControlledRegisterReport(gomock.Any(), gomock.Eq(period)).
+					Times(1).
+					Return([]db.ControlledRegisterMedicine{register}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var response struct {
+					Data controlledRegisterResponse `json:"data"`
+				}
+				err := json.Unmarshal(recorder.Body.Bytes(), &response)
+				require.NoError(t, err)
+				require.Equal(t, "2026-09-01", response.Data.From)
+				require.Len(t, response.Data.Medicines, 1)
+				require.Equal(t, int32(50), response.Data.Medicines[0].OpeningBalance)
+				require.Equal(t, int32(65), response.Data.Medicines[0].ClosingBalance)
+				require.Len(t, response.Data.Medicines[0].Entries, 2)
+				require.Equal(t, witness.Username, response.Data.Medicines[0].Entries[0].WitnessedBy)
+			},
+		},
+		{
+			name:        "CSV",
+			query:       "?from=2026-09-01&to=2026-09-30&format=csv",
+			permissions: []string{"VIEW_CONTROLLED_REGISTER"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ControlledRegisterReport(gomock.Any(), gomock.Eq(period)).
+					Times(1).
+					Return([]db.ControlledRegisterMedicine{register}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Contains(t, recorder.Header().Get("Content-Disposition"), "controlled-register-2026-09-01-2026-09-30.csv")
+
+				lines := strings.Split(strings.TrimSpace(recorder.Body.String()), "\n")
+				// header, opening balance, two entries and closing balance
+				require.Len(t, lines, 5)
+				require.Contains(t, lines[1], "opening balance")
+				require.Contains(t, lines[3], witness.Username)
+				require.Contains(t, lines[4], "closing balance")
+			},
+		},
+		{
+			name:        "XLSX",
+			query:       "?from=2026-09-01&to=2026-09-30&format=xlsx",
+			permissions: []string{"VIEW_CONTROLLED_REGISTER"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ControlledRegisterReport(gomock.Any(), gomock.Eq(period)).
+					Times(1).
+					Return([]db.ControlledRegisterMedicine{register}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Contains(t, recorder.Header().Get("Content-Disposition"), ".xlsx")
+				require.NotEmpty(t, recorder.Body.Bytes())
+			},
+		},
+		{
+			name:        "Printable",
+			query:       fmt.Sprintf("?from=2026-09-01&to=2026-09-30&format=html&medicine_id=%d", medicine.ID),
+			permissions: []string{"VIEW_CONTROLLED_REGISTER"},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := period
+				arg.MedicineID = sql.NullInt32{Int32: medicine.ID, Valid: true}
+				store.EXPECT().
+					ControlledRegisterReport(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return([]db.ControlledRegisterMedicine{register}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Contains(t, recorder.Header().Get("Content-Type"), "text/html")
+				require.Contains(t, recorder.Body.String(), "Opening balance")
+				require.Contains(t, recorder.Body.String(), "LOT-1")
+			},
+		},
+		{
+			name:        "InvalidPeriod",
+			query:       "?from=2026-09-30&to=2026-09-01",
+			permissions: []string{"VIEW_CONTROLLED_REGISTER"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ControlledRegisterReport(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:        "MedicineNotFound",
+			query:       "?from=2026-09-01&to=2026-09-30&medicine_id=99",
+			permissions: []string{"VIEW_CONTROLLED_REGISTER"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ControlledRegisterReport(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, sql.ErrNoRows)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:        "NoPermission",
+			query:       "?from=2026-09-01&to=2026-09-30",
+			permissions: []string{"VIEW_SCREEN_MEDICINE"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ControlledRegisterReport(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().
+				GetUser(gomock.Any(), gomock.Eq(user.Username)).
+				Times(1).
+				Return(user, nil)
+			store.EXPECT().
+				GetPermissionsForUser(gomock.Any(), gomock.Eq(user.ID)).
+				Times(1).
+				Return(tc.permissions, nil)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			request, err := http.NewRequest(http.MethodGet, "/controlled-register"+tc.query, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestSetMedicineControlledAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	witness, witnessPassword := randomUser(t)
+	medicine := randomMedicine()
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		permissions   []string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{
+				"controlled": false,
+				"note":       "Delisted by the ministry",
+				"witness":    gin.H{"username": witness.Username, "password": witnessPassword},
+			},
+			permissions: []string{"SET_CONTROLLED_MEDICINE"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(witness.Username)).
+					Times(1).
+					Return(witness, nil)
+
+				arg := db.SetMedicineControlledTxParams{
+					MedicineID:  medicine.ID,
+					Controlled:  false,
+					Note:        sql.NullString{String: "Delisted by the ministry", Valid: true},
+					RecordedBy:  sql.NullString{String: user.Username, Valid: true},
+					WitnessedBy: sql.NullString{String: witness.Username, Valid: true},
+				}
+				store.EXPECT().
+					SetMedicineControlledTx(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(medicine, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:        "MissingWitness",
+			body:        gin.H{"controlled": true},
+			permissions: []string{"SET_CONTROLLED_MEDICINE"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					SetMedicineControlledTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "MissingFlag",
+			body: gin.H{
+				"witness": gin.H{"username": witness.Username, "password": witnessPassword},
+			},
+			permissions: []string{"SET_CONTROLLED_MEDICINE"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					SetMedicineControlledTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "WrongWitnessPassword",
+			body: gin.H{
+				"controlled": true,
+				"witness":    gin.H{"username": witness.Username, "password": "wrong-password"},
+			},
+			permissions: []string{"SET_CONTROLLED_MEDICINE"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(witness.Username)).
+					Times(1).
+					Return(witness, nil)
+				store.EXPECT().
+					SetMedicineControlledTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name: "NotFound",
+			body: gin.H{
+				"controlled": true,
+				"witness":    gin.H{"username": witness.Username, "password": witnessPassword},
+			},
+			permissions: []string{"SET_CONTROLLED_MEDICINE"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(witness.Username)).
+					Times(1).
+					Return(witness, nil)
+				store.EXPECT().
+					SetMedicineControlledTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.Medicine{}, sql.ErrNoRows)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name: "NoPermission",
+			body: gin.H{
+				"controlled": false,
+				"witness":    gin.H{"username": witness.Username, "password": witnessPassword},
+			},
+			permissions: []string{"VIEW_SCREEN_MEDICINE"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					SetMedicineControlledTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().
+				GetUser(gomock.Any(), gomock.Eq(user.Username)).
+				Times(1).
+				Return(user, nil)
+			store.EXPECT().
+				GetPermissionsForUser(gomock.Any(), gomock.Eq(user.ID)).
+				Times(1).
+				Return(tc.permissions, nil)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/medicines/%d/controlled", medicine.ID)
+			request, err := http.NewRequest(http.MethodPut, url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
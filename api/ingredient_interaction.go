@@ -0,0 +1,128 @@
+package api
+
+import (
+	"database/sql"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/lib/pq"
+	db "github.com/toannguyen3105/nht-bsihuyen.com-api/db/sqlc"
+)
+
+type upsertIngredientInteractionRequest struct {
+	IngredientAID int32  `json:"ingredient_a_id" binding:"required,min=1"`
+	IngredientBID int32  `json:"ingredient_b_id" binding:"required,min=1,nefield=IngredientAID"`
+	Severity      string `json:"severity" binding:"required,oneof=minor moderate major contraindicated"`
+	Description   string `json:"description" binding:"required,max=1000"`
+}
+
+// upsertIngredientInteraction records how two ingredients interact, replacing
+// what was recorded for the pair before. The pair can be given in either
+// order.
+func (server *Server) upsertIngredientInteraction(ctx *gin.Context) {
+	var req upsertIngredientInteractionRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	arg := db.UpsertIngredientInteractionParams{
+		IngredientAID: req.IngredientAID,
+		IngredientBID: req.IngredientBID,
+		Severity:      req.Severity,
+		Description:   req.Description,
+	}
+	if arg.IngredientBID < arg.IngredientAID {
+		arg.IngredientAID, arg.IngredientBID = arg.IngredientBID, arg.IngredientAID
+	}
+
+	interaction, err := server.store.UpsertIngredientInteraction(ctx, arg)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code.Name() == "foreign_key_violation" {
+			ctx.JSON(http.StatusForbidden, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, successResponse("Ingredient interaction saved successfully", interaction))
+}
+
+type ingredientRequest struct {
+	ID int32 `uri:"id" binding:"required,min=1"`
+}
+
+func (server *Server) listIngredientInteractions(ctx *gin.Context) {
+	var req ingredientRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	interactions, err := server.store.ListInteractionsForIngredient(ctx, req.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, successResponse("Ingredient interactions retrieved successfully", interactions))
+}
+
+type deleteIngredientInteractionRequest struct {
+	ID int32 `uri:"id" binding:"required,min=1"`
+}
+
+func (server *Server) deleteIngredientInteraction(ctx *gin.Context) {
+	var req deleteIngredientInteractionRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	deleted, err := server.store.DeleteIngredientInteraction(ctx, req.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if deleted == 0 {
+		ctx.JSON(http.StatusNotFound, errorResponse(sql.ErrNoRows))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, successResponse("Ingredient interaction deleted successfully", nil))
+}
+
+type checkMedicineWarningsRequest struct {
+	MedicineIDs []int32 `json:"medicine_ids" binding:"required,min=1,max=50,unique,dive,min=1"`
+	// AllergyIngredientIDs are the ingredients the patient is allergic to.
+	// There are no patient records here, so the prescribing workflow sends
+	// them along.
+	AllergyIngredientIDs []int32 `json:"allergy_ingredient_ids" binding:"omitempty,unique,dive,min=1"`
+}
+
+// checkMedicineWarnings returns the interaction and allergy warnings for
+// prescribing the medicines together, most serious first.
+func (server *Server) checkMedicineWarnings(ctx *gin.Context) {
+	var req checkMedicineWarningsRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	result, err := server.store.CheckMedicineWarnings(ctx, db.CheckMedicineWarningsParams{
+		MedicineIDs:          req.MedicineIDs,
+		AllergyIngredientIDs: req.AllergyIngredientIDs,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, successResponse("Medicine warnings retrieved successfully", result))
+}
@@ -0,0 +1,255 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+	mockdb "github.com/toannguyen3105/nht-bsihuyen.com-api/db/mock"
+	db "github.com/toannguyen3105/nht-bsihuyen.com-api/db/sqlc"
+)
+
+func TestUpsertIngredientInteractionAPI(t *testing.T) {
+	user, _ := randomUser(t)
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{
+				"ingredient_a_id": 7,
+				"ingredient_b_id": 3,
+				"severity":        db.SeverityMajor,
+				"description":     "Increased risk of bleeding",
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				// the pair is stored lower ID first
+				arg := db.UpsertIngredientInteractionParams{
+					IngredientAID: 3,
+					IngredientBID: 7,
+					Severity:      db.SeverityMajor,
+					Description:   "Increased risk of bleeding",
+				}
+				store.EXPECT().
+					UpsertIngredientInteraction(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(db.IngredientInteraction{ID: 1, IngredientAID: 3, IngredientBID: 7, Severity: db.SeverityMajor}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "SameIngredient",
+			body: gin.H{
+				"ingredient_a_id": 3,
+				"ingredient_b_id": 3,
+				"severity":        db.SeverityMajor,
+				"description":     "Increased risk of bleeding",
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					UpsertIngredientInteraction(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "InvalidSeverity",
+			body: gin.H{
+				"ingredient_a_id": 3,
+				"ingredient_b_id": 7,
+				"severity":        "severe",
+				"description":     "Increased risk of bleeding",
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					UpsertIngredientInteraction(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "UnknownIngredient",
+			body: gin.H{
+				"ingredient_a_id": 3,
+				"ingredient_b_id": 99,
+				"severity":        db.SeverityMinor,
+				"description":     "Reduced absorption",
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					UpsertIngredientInteraction(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.IngredientInteraction{}, &pq.Error{Code: "23503"})
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().
+				GetUser(gomock.Any(), gomock.Eq(user.Username)).
+				Times(1).
+				Return(user, nil)
+			store.EXPECT().
+				GetPermissionsForUser(gomock.Any(), gomock.Eq(user.ID)).
+				Times(1).
+				Return([]string{"VIEW_SCREEN_MEDICINE"}, nil)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			request, err := http.NewRequest(http.MethodPut, "/ingredient-interactions", bytes.NewReader(data))
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestCheckMedicineWarningsAPI(t *testing.T) {
+	user, _ := randomUser(t)
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{"medicine_ids": []int32{1, 2}, "allergy_ingredient_ids": []int32{5}},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.CheckMedicineWarningsParams{
+					MedicineIDs:          []int32{1, 2},
+					AllergyIngredientIDs: []int32{5},
+				}
+				store.EXPECT().
+					CheckMedicineWarnings(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(db.CheckMedicineWarningsResult{
+						Warnings: []db.MedicineWarning{{
+							Type:        db.WarningInteraction,
+							Severity:    db.SeverityMajor,
+							MedicineIDs: []int32{1, 2},
+							Ingredients: []string{"Aspirin", "Warfarin"},
+							Description: "Increased risk of bleeding",
+						}},
+						UncheckedMedicineIDs: []int32{},
+					}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var response struct {
+					Data db.CheckMedicineWarningsResult `json:"data"`
+				}
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+				require.Len(t, response.Data.Warnings, 1)
+				require.Equal(t, db.SeverityMajor, response.Data.Warnings[0].Severity)
+			},
+		},
+		{
+			name: "MedicineNotFound",
+			body: gin.H{"medicine_ids": []int32{1, 999}},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CheckMedicineWarnings(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.CheckMedicineWarningsResult{}, sql.ErrNoRows)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name: "NoMedicines",
+			body: gin.H{"medicine_ids": []int32{}},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CheckMedicineWarnings(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "DuplicateMedicine",
+			body: gin.H{"medicine_ids": []int32{1, 1}},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CheckMedicineWarnings(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().
+				GetUser(gomock.Any(), gomock.Eq(user.Username)).
+				Times(1).
+				Return(user, nil)
+			store.EXPECT().
+				GetPermissionsForUser(gomock.Any(), gomock.Eq(user.ID)).
+				Times(1).
+				Return([]string{"VIEW_SCREEN_MEDICINE"}, nil)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			request, err := http.NewRequest(http.MethodPost, "/medicines/warnings", bytes.NewReader(data))
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
@@ -1,6 +1,8 @@
 package api
 
 import (
+	"io"
+	"log"
 	"os"
 	"testing"
 	"time"
@@ -8,6 +10,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/require"
 	db "github.com/toannguyen3105/nht-bsihuyen.com-api/db/sqlc"
+	"github.com/toannguyen3105/nht-bsihuyen.com-api/notify"
 	"github.com/toannguyen3105/nht-bsihuyen.com-api/utils"
 )
 
@@ -18,7 +21,7 @@ func newTestServer(t *testing.T, store db.Store) *Server {
 		RefreshTokenDuration: time.Minute,
 	}
 
-	server, err := NewServer(config, store)
+	server, err := NewServer(config, store, notify.NewLogNotifier(log.New(io.Discard, "", 0)))
 	require.NoError(t, err)
 
 	return server
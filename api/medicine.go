@@ -2,19 +2,42 @@ package api
 
 import (
 	"database/sql"
-	"fmt"
+	"errors"
 	"net/http"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/lib/pq"
 	db "github.com/toannguyen3105/nht-bsihuyen.com-api/db/sqlc"
+	"github.com/toannguyen3105/nht-bsihuyen.com-api/token"
+	"github.com/toannguyen3105/nht-bsihuyen.com-api/utils"
 )
 
+// defaultReorderLevel is the stock level at or below which a new medicine
+// counts as running low, unless the request sets its own.
+const defaultReorderLevel = 10
+
+// defaultCurrency is the currency of prices given without one.
+const defaultCurrency = utils.VND
+
+// defaultPriceReason goes into the price history when a medicine update
+// changes the price without saying why.
+const defaultPriceReason = "Price updated"
+
 type createMedicineRequest struct {
-	Name        string  `json:"name" binding:"required"`
-	Unit        string  `json:"unit" binding:"required,oneof=tablet capsule box bottle"`
-	Price       float64 `json:"price" binding:"required,min=0"`
-	Stock       int32   `json:"stock" binding:"required,min=0"`
-	Description *string `json:"description"`
+	Name         string        `json:"name" binding:"required"`
+	Sku          *string       `json:"sku" binding:"omitempty,min=1,max=64"`
+	Unit         string        `json:"unit" binding:"required,max=50"`
+	Price        utils.Decimal `json:"price" binding:"required,min=0"`
+	Currency     string        `json:"currency" binding:"omitempty,price_currency"`
+	Stock        int32         `json:"stock" binding:"min=0"`
+	LotNumber    string        `json:"lot_number" binding:"required_with=Stock,max=100"`
+	ExpiryDate   string        `json:"expiry_date" binding:"required_with=Stock"`
+	Description  *string       `json:"description"`
+	ReorderLevel *int32        `json:"reorder_level" binding:"omitempty,min=0"`
+	DosageForm   *string       `json:"dosage_form" binding:"omitempty,min=1,max=50"`
+	Manufacturer *string       `json:"manufacturer" binding:"omitempty,min=1,max=255"`
 }
 
 func (server *Server) createMedicine(ctx *gin.Context) {
@@ -24,19 +47,64 @@ func (server *Server) createMedicine(ctx *gin.Context) {
 		return
 	}
 
-	arg := db.CreateMedicineParams{
-		Name:  req.Name,
-		Unit:  req.Unit,
-		Price: fmt.Sprintf("%.2f", req.Price),
-		Stock: req.Stock,
+	price := utils.NewMoney(req.Price, defaultCurrency)
+	if req.Currency != "" {
+		price.Currency = req.Currency
+	}
+	if err := price.Validate(); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	arg := db.CreateMedicineTxParams{
+		Medicine: db.CreateMedicineParams{
+			Name:         req.Name,
+			Unit:         req.Unit,
+			Price:        price.Amount,
+			Currency:     price.Currency,
+			ReorderLevel: defaultReorderLevel,
+		},
+		Stock:     req.Stock,
+		CreatedBy: sql.NullString{String: authPayload.Username, Valid: true},
+	}
+
+	if req.Stock > 0 {
+		expiryDate, err := time.Parse(time.DateOnly, req.ExpiryDate)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+		arg.LotNumber = req.LotNumber
+		arg.ExpiryDate = expiryDate
 	}
 
 	if req.Description != nil {
-		arg.Description = sql.NullString{String: *req.Description, Valid: true}
+		arg.Medicine.Description = sql.NullString{String: *req.Description, Valid: true}
+	}
+	if req.ReorderLevel != nil {
+		arg.Medicine.ReorderLevel = *req.ReorderLevel
+	}
+	if req.Sku != nil {
+		arg.Medicine.Sku = sql.NullString{String: *req.Sku, Valid: true}
+	}
+	if req.DosageForm != nil {
+		arg.Medicine.DosageForm = sql.NullString{String: *req.DosageForm, Valid: true}
+	}
+	if req.Manufacturer != nil {
+		arg.Medicine.Manufacturer = sql.NullString{String: *req.Manufacturer, Valid: true}
 	}
 
-	medicine, err := server.store.CreateMedicine(ctx, arg)
+	medicine, err := server.store.CreateMedicineTx(ctx, arg)
 	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok {
+			switch pqErr.Code.Name() {
+			case "foreign_key_violation", "unique_violation":
+				ctx.JSON(http.StatusForbidden, errorResponse(err))
+				return
+			}
+		}
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
@@ -48,6 +116,121 @@ type getMedicineRequest struct {
 	ID int32 `uri:"id" binding:"required,min=1"`
 }
 
+type medicineBatchResponse struct {
+	ID                int64     `json:"id"`
+	LotNumber         string    `json:"lot_number"`
+	ExpiryDate        *string   `json:"expiry_date"`
+	Expired           bool      `json:"expired"`
+	ReceivedQuantity  int32     `json:"received_quantity"`
+	RemainingQuantity int32     `json:"remaining_quantity"`
+	ReceivedAt        time.Time `json:"received_at"`
+}
+
+type medicineUnitConversionResponse struct {
+	Unit   string `json:"unit"`
+	Factor int32  `json:"factor"`
+}
+
+// medicineDetailResponse is a medicine with the batches its stock is made of,
+// earliest expiry first, the other units it can be received or dispensed in,
+// the barcodes it is scanned by, its categories and its active ingredients.
+type medicineDetailResponse struct {
+	db.Medicine
+	Batches     []medicineBatchResponse          `json:"batches"`
+	Conversions []medicineUnitConversionResponse `json:"conversions"`
+	Barcodes    []string                         `json:"barcodes"`
+	Categories  []categoryResponse               `json:"categories"`
+	Ingredients []medicineIngredientResponse     `json:"ingredients"`
+}
+
+// medicineDetail holds what a medicineDetailResponse is built from.
+type medicineDetail struct {
+	batches     []db.MedicineBatch
+	conversions []db.MedicineUnitConversion
+	barcodes    []db.MedicineBarcode
+	categories  []db.Category
+	ingredients []db.ListMedicineIngredientsRow
+}
+
+func newMedicineDetailResponse(medicine db.Medicine, detail medicineDetail) medicineDetailResponse {
+	rsp := medicineDetailResponse{
+		Medicine:    medicine,
+		Batches:     make([]medicineBatchResponse, len(detail.batches)),
+		Conversions: make([]medicineUnitConversionResponse, len(detail.conversions)),
+		Barcodes:    make([]string, len(detail.barcodes)),
+		Categories:  newCategoriesResponse(detail.categories),
+		Ingredients: newMedicineIngredientsResponse(detail.ingredients),
+	}
+
+	for i, conversion := range detail.conversions {
+		rsp.Conversions[i] = medicineUnitConversionResponse{
+			Unit:   conversion.Unit,
+			Factor: conversion.Factor,
+		}
+	}
+
+	for i, barcode := range detail.barcodes {
+		rsp.Barcodes[i] = barcode.Barcode
+	}
+
+	today := time.Now().Format(time.DateOnly)
+	for i, batch := range detail.batches {
+		rsp.Batches[i] = medicineBatchResponse{
+			ID:                batch.ID,
+			LotNumber:         batch.LotNumber,
+			ReceivedQuantity:  batch.ReceivedQuantity,
+			RemainingQuantity: batch.RemainingQuantity,
+			ReceivedAt:        batch.ReceivedAt,
+		}
+		if batch.ExpiryDate.Valid {
+			expiryDate := batch.ExpiryDate.Time.Format(time.DateOnly)
+			rsp.Batches[i].ExpiryDate = &expiryDate
+			rsp.Batches[i].Expired = expiryDate < today
+		}
+	}
+
+	return rsp
+}
+
+// writeMedicineDetail loads everything a medicineDetailResponse shows besides
+// the medicine itself and writes them out together.
+func (server *Server) writeMedicineDetail(ctx *gin.Context, medicine db.Medicine) {
+	var detail medicineDetail
+	var err error
+
+	detail.batches, err = server.store.ListMedicineBatches(ctx, medicine.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	detail.conversions, err = server.store.ListMedicineUnitConversions(ctx, medicine.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	detail.barcodes, err = server.store.ListMedicineBarcodes(ctx, medicine.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	detail.categories, err = server.store.ListMedicineCategories(ctx, medicine.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	detail.ingredients, err = server.store.ListMedicineIngredients(ctx, []int32{medicine.ID})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, successResponse("Medicine retrieved successfully", newMedicineDetailResponse(medicine, detail)))
+}
+
 func (server *Server) getMedicine(ctx *gin.Context) {
 	var req getMedicineRequest
 	if err := ctx.ShouldBindUri(&req); err != nil {
@@ -65,12 +248,30 @@ func (server *Server) getMedicine(ctx *gin.Context) {
 		return
 	}
 
-	ctx.JSON(http.StatusOK, successResponse("Medicine retrieved successfully", medicine))
+	server.writeMedicineDetail(ctx, medicine)
 }
 
+var (
+	errInvalidPriceRange   = errors.New("min_price must not be greater than max_price")
+	errPriceFilterCurrency = errors.New("currency is required to filter or sort by price")
+)
+
 type listMedicinesRequest struct {
-	PageID   int32 `form:"page_id" binding:"required,min=1"`
-	PageSize int32 `form:"page_size" binding:"required,min=5,max=100"`
+	PageID          int32          `form:"page_id" binding:"required,min=1"`
+	PageSize        int32          `form:"page_size" binding:"required,min=5,max=100"`
+	Search          string         `form:"search" binding:"max=255"`
+	Unit            string         `form:"unit" binding:"max=50"`
+	MinPrice        *utils.Decimal `form:"min_price" binding:"omitempty,min=0"`
+	MaxPrice        *utils.Decimal `form:"max_price" binding:"omitempty,min=0"`
+	LowStock        bool           `form:"low_stock"`
+	IncludeArchived bool           `form:"include_archived"`
+	DosageForm      string         `form:"dosage_form" binding:"max=50"`
+	Manufacturer    string         `form:"manufacturer" binding:"max=255"`
+	CategoryID      int32          `form:"category_id" binding:"omitempty,min=1"`
+	IngredientID    int32          `form:"ingredient_id" binding:"omitempty,min=1"`
+	Currency        string         `form:"currency" binding:"omitempty,price_currency"`
+	SortBy          string         `form:"sort_by" binding:"omitempty,oneof=name price stock updated_at"`
+	SortOrder       string         `form:"sort_order" binding:"omitempty,oneof=asc desc"`
 }
 
 type medicinesResponse struct {
@@ -89,9 +290,64 @@ func (server *Server) listMedicines(ctx *gin.Context) {
 		return
 	}
 
+	if req.MinPrice != nil && req.MaxPrice != nil && req.MinPrice.Cmp(*req.MaxPrice) > 0 {
+		ctx.JSON(http.StatusBadRequest, errorResponse(errInvalidPriceRange))
+		return
+	}
+
+	// prices in different currencies cannot be compared
+	if (req.MinPrice != nil || req.MaxPrice != nil || req.SortBy == "price") && req.Currency == "" {
+		ctx.JSON(http.StatusBadRequest, errorResponse(errPriceFilterCurrency))
+		return
+	}
+
+	filter := db.CountMedicinesParams{}
+	if search := strings.TrimSpace(req.Search); search != "" {
+		filter.Search = sql.NullString{String: search, Valid: true}
+	}
+	if req.Unit != "" {
+		filter.Unit = sql.NullString{String: req.Unit, Valid: true}
+	}
+	if req.MinPrice != nil {
+		filter.MinPrice = utils.NullDecimal{Decimal: *req.MinPrice, Valid: true}
+	}
+	if req.MaxPrice != nil {
+		filter.MaxPrice = utils.NullDecimal{Decimal: *req.MaxPrice, Valid: true}
+	}
+	filter.LowStock = req.LowStock
+	filter.IncludeArchived = req.IncludeArchived
+	if req.DosageForm != "" {
+		filter.DosageForm = sql.NullString{String: req.DosageForm, Valid: true}
+	}
+	if manufacturer := strings.TrimSpace(req.Manufacturer); manufacturer != "" {
+		filter.Manufacturer = sql.NullString{String: manufacturer, Valid: true}
+	}
+	if req.CategoryID != 0 {
+		filter.CategoryID = sql.NullInt32{Int32: req.CategoryID, Valid: true}
+	}
+	if req.IngredientID != 0 {
+		filter.IngredientID = sql.NullInt32{Int32: req.IngredientID, Valid: true}
+	}
+	if req.Currency != "" {
+		filter.Currency = sql.NullString{String: req.Currency, Valid: true}
+	}
+
 	arg := db.ListMedicinesParams{
-		Limit:  req.PageSize,
-		Offset: (req.PageID - 1) * req.PageSize,
+		Search:          filter.Search,
+		Unit:            filter.Unit,
+		MinPrice:        filter.MinPrice,
+		MaxPrice:        filter.MaxPrice,
+		LowStock:        filter.LowStock,
+		IncludeArchived: filter.IncludeArchived,
+		DosageForm:      filter.DosageForm,
+		Manufacturer:    filter.Manufacturer,
+		CategoryID:      filter.CategoryID,
+		IngredientID:    filter.IngredientID,
+		Currency:        filter.Currency,
+		SortBy:          req.SortBy,
+		SortDesc:        req.SortOrder == "desc",
+		Limit:           req.PageSize,
+		Offset:          (req.PageID - 1) * req.PageSize,
 	}
 
 	medicines, err := server.store.ListMedicines(ctx, arg)
@@ -100,7 +356,7 @@ func (server *Server) listMedicines(ctx *gin.Context) {
 		return
 	}
 
-	totalCount, err := server.store.CountMedicines(ctx)
+	totalCount, err := server.store.CountMedicines(ctx, filter)
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
@@ -128,11 +384,16 @@ func (server *Server) listMedicines(ctx *gin.Context) {
 }
 
 type updateMedicineRequest struct {
-	Name        *string  `json:"name"`
-	Unit        *string  `json:"unit" binding:"omitempty,oneof=tablet capsule box bottle"`
-	Price       *float64 `json:"price" binding:"omitempty,min=0"`
-	Stock       *int32   `json:"stock" binding:"omitempty,min=0"`
-	Description *string  `json:"description"`
+	Name         *string        `json:"name"`
+	Sku          *string        `json:"sku" binding:"omitempty,min=1,max=64"`
+	Unit         *string        `json:"unit" binding:"omitempty,max=50"`
+	Price        *utils.Decimal `json:"price" binding:"omitempty,min=0"`
+	Currency     *string        `json:"currency" binding:"omitempty,price_currency"`
+	PriceReason  string         `json:"price_reason" binding:"max=255"`
+	Description  *string        `json:"description"`
+	ReorderLevel *int32         `json:"reorder_level" binding:"omitempty,min=0"`
+	DosageForm   *string        `json:"dosage_form" binding:"omitempty,min=1,max=50"`
+	Manufacturer *string        `json:"manufacturer" binding:"omitempty,min=1,max=255"`
 }
 
 func (server *Server) updateMedicine(ctx *gin.Context) {
@@ -155,25 +416,80 @@ func (server *Server) updateMedicine(ctx *gin.Context) {
 	if reqBody.Name != nil {
 		arg.Name = sql.NullString{String: *reqBody.Name, Valid: true}
 	}
-	if reqBody.Unit != nil {
-		arg.Unit = *reqBody.Unit
+	if reqBody.Sku != nil {
+		arg.Sku = sql.NullString{String: *reqBody.Sku, Valid: true}
 	}
-	if reqBody.Price != nil {
-		arg.Price = sql.NullString{String: fmt.Sprintf("%.2f", *reqBody.Price), Valid: true}
+	if reqBody.Unit != nil {
+		arg.Unit = sql.NullString{String: *reqBody.Unit, Valid: true}
 	}
-	if reqBody.Stock != nil {
-		arg.Stock = sql.NullInt32{Int32: *reqBody.Stock, Valid: true}
+	if reqBody.Price != nil || reqBody.Currency != nil {
+		// the new price is checked against the currency it ends up in
+		medicine, err := server.store.GetMedicine(ctx, reqUri.ID)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				ctx.JSON(http.StatusNotFound, errorResponse(err))
+				return
+			}
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+
+		price := utils.NewMoney(medicine.Price, medicine.Currency)
+		if reqBody.Price != nil {
+			price.Amount = *reqBody.Price
+		}
+		if reqBody.Currency != nil {
+			price.Currency = *reqBody.Currency
+		}
+		if err := price.Validate(); err != nil {
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+
+		arg.Price = utils.NullDecimal{Decimal: price.Amount, Valid: true}
+		arg.Currency = sql.NullString{String: price.Currency, Valid: true}
 	}
 	if reqBody.Description != nil {
 		arg.Description = sql.NullString{String: *reqBody.Description, Valid: true}
 	}
+	if reqBody.ReorderLevel != nil {
+		arg.ReorderLevel = sql.NullInt32{Int32: *reqBody.ReorderLevel, Valid: true}
+	}
+	if reqBody.DosageForm != nil {
+		arg.DosageForm = sql.NullString{String: *reqBody.DosageForm, Valid: true}
+	}
+	if reqBody.Manufacturer != nil {
+		arg.Manufacturer = sql.NullString{String: *reqBody.Manufacturer, Valid: true}
+	}
+
+	priceReason := reqBody.PriceReason
+	if priceReason == "" {
+		priceReason = defaultPriceReason
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
 
-	medicine, err := server.store.UpdateMedicine(ctx, arg)
+	medicine, err := server.store.UpdateMedicineTx(ctx, db.UpdateMedicineTxParams{
+		Medicine:    arg,
+		PriceReason: priceReason,
+		CreatedBy:   sql.NullString{String: authPayload.Username, Valid: true},
+	})
 	if err != nil {
 		if err == sql.ErrNoRows {
 			ctx.JSON(http.StatusNotFound, errorResponse(err))
 			return
 		}
+		if errors.Is(err, db.ErrUnitInUse) {
+			ctx.JSON(http.StatusConflict, errorResponse(err))
+			return
+		}
+		if pqErr, ok := err.(*pq.Error); ok {
+			switch pqErr.Code.Name() {
+			case "foreign_key_violation", "unique_violation":
+				ctx.JSON(http.StatusForbidden, errorResponse(err))
+				return
+			}
+		}
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
@@ -181,14 +497,81 @@ func (server *Server) updateMedicine(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, successResponse("Medicine updated successfully", medicine))
 }
 
+var (
+	errMedicineArchived    = errors.New("medicine is already archived")
+	errMedicineNotArchived = errors.New("medicine is not archived")
+	errMedicineReferenced  = errors.New("medicine is referenced by stock records; archive it instead")
+)
+
+type deleteMedicineRequest struct {
+	// Permanent deletes the row instead of archiving it, which is only
+	// possible while nothing refers to the medicine.
+	Permanent bool `form:"permanent"`
+}
+
+// deleteMedicine archives a medicine: it is hidden from the catalog and can no
+// longer be received or dispensed, but its history stays and it can be
+// restored.
 func (server *Server) deleteMedicine(ctx *gin.Context) {
+	var reqURI getMedicineRequest
+	if err := ctx.ShouldBindUri(&reqURI); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req deleteMedicineRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	medicine, err := server.store.GetMedicine(ctx, reqURI.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if req.Permanent {
+		err = server.store.DeleteMedicine(ctx, reqURI.ID)
+		if err != nil {
+			if pqErr, ok := err.(*pq.Error); ok && pqErr.Code.Name() == "foreign_key_violation" {
+				ctx.JSON(http.StatusConflict, errorResponse(errMedicineReferenced))
+				return
+			}
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+
+		ctx.JSON(http.StatusOK, successResponse("Medicine deleted successfully", nil))
+		return
+	}
+
+	if medicine.DeletedAt.Valid {
+		ctx.JSON(http.StatusConflict, errorResponse(errMedicineArchived))
+		return
+	}
+
+	medicine, err = server.store.ArchiveMedicine(ctx, reqURI.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, successResponse("Medicine archived successfully", medicine))
+}
+
+func (server *Server) restoreMedicine(ctx *gin.Context) {
 	var req getMedicineRequest
 	if err := ctx.ShouldBindUri(&req); err != nil {
 		ctx.JSON(http.StatusBadRequest, errorResponse(err))
 		return
 	}
 
-	err := server.store.DeleteMedicine(ctx, req.ID)
+	medicine, err := server.store.GetMedicine(ctx, req.ID)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			ctx.JSON(http.StatusNotFound, errorResponse(err))
@@ -198,5 +581,16 @@ func (server *Server) deleteMedicine(ctx *gin.Context) {
 		return
 	}
 
-	ctx.JSON(http.StatusOK, successResponse("Medicine deleted successfully", nil))
+	if !medicine.DeletedAt.Valid {
+		ctx.JSON(http.StatusConflict, errorResponse(errMedicineNotArchived))
+		return
+	}
+
+	medicine, err = server.store.RestoreMedicine(ctx, req.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, successResponse("Medicine restored successfully", medicine))
 }
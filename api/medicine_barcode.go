@@ -0,0 +1,116 @@
+package api
+
+import (
+	"database/sql"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/lib/pq"
+	db "github.com/toannguyen3105/nht-bsihuyen.com-api/db/sqlc"
+)
+
+type lookupMedicineRequest struct {
+	Barcode string `form:"barcode" binding:"required,ean13"`
+}
+
+// lookupMedicine finds the medicine a scanned barcode belongs to and returns
+// it the way getMedicine does, with its current stock and price.
+func (server *Server) lookupMedicine(ctx *gin.Context) {
+	var req lookupMedicineRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	medicine, err := server.store.GetMedicineByBarcode(ctx, req.Barcode)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	server.writeMedicineDetail(ctx, medicine)
+}
+
+type addMedicineBarcodeRequest struct {
+	Barcode string `json:"barcode" binding:"required,ean13"`
+}
+
+type medicineBarcodeResponse struct {
+	Barcode    string `json:"barcode"`
+	MedicineID int32  `json:"medicine_id"`
+}
+
+// addMedicineBarcode adds a barcode to a medicine. A barcode identifies one
+// medicine only, so one already in use returns 403.
+func (server *Server) addMedicineBarcode(ctx *gin.Context) {
+	var reqURI getMedicineRequest
+	if err := ctx.ShouldBindUri(&reqURI); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req addMedicineBarcodeRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	barcode, err := server.store.CreateMedicineBarcode(ctx, db.CreateMedicineBarcodeParams{
+		Barcode:    req.Barcode,
+		MedicineID: reqURI.ID,
+	})
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok {
+			switch pqErr.Code.Name() {
+			case "unique_violation":
+				ctx.JSON(http.StatusForbidden, errorResponse(err))
+				return
+			case "foreign_key_violation":
+				ctx.JSON(http.StatusNotFound, errorResponse(err))
+				return
+			}
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	rsp := medicineBarcodeResponse{
+		Barcode:    barcode.Barcode,
+		MedicineID: barcode.MedicineID,
+	}
+
+	ctx.JSON(http.StatusOK, successResponse("Barcode added successfully", rsp))
+}
+
+type medicineBarcodeRequest struct {
+	ID      int32  `uri:"id" binding:"required,min=1"`
+	Barcode string `uri:"barcode" binding:"required,max=13"`
+}
+
+func (server *Server) deleteMedicineBarcode(ctx *gin.Context) {
+	var req medicineBarcodeRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	deleted, err := server.store.DeleteMedicineBarcode(ctx, db.DeleteMedicineBarcodeParams{
+		MedicineID: req.ID,
+		Barcode:    req.Barcode,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if deleted == 0 {
+		ctx.JSON(http.StatusNotFound, errorResponse(sql.ErrNoRows))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, successResponse("Barcode deleted successfully", nil))
+}
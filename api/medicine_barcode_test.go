@@ -0,0 +1,243 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+	mockdb "github.com/toannguyen3105/nht-bsihuyen.com-api/db/mock"
+	db "github.com/toannguyen3105/nht-bsihuyen.com-api/db/sqlc"
+)
+
+func TestLookupMedicineAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	medicine := randomMedicine()
+	barcode := "8934588012228"
+
+	testCases := []struct {
+		name          string
+		query         string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "OK",
+			query: "?barcode=" + barcode,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetMedicineByBarcode(gomock.Any(), gomock.Eq(barcode)).
+					Times(1).
+					Return(medicine, nil)
+				store.EXPECT().
+					ListMedicineBatches(gomock.Any(), gomock.Eq(medicine.ID)).
+					Times(1).
+					Return([]db.MedicineBatch{}, nil)
+				store.EXPECT().
+					ListMedicineUnitConversions(gomock.Any(), gomock.Eq(medicine.ID)).
+					Times(1).
+					Return([]db.MedicineUnitConversion{}, nil)
+				store.EXPECT().
+					ListMedicineBarcodes(gomock.Any(), gomock.Eq(medicine.ID)).
+					Times(1).
+					Return([]db.MedicineBarcode{{Barcode: barcode, MedicineID: medicine.ID}}, nil)
+				store.EXPECT().
+					ListMedicineCategories(gomock.Any(), gomock.Eq(medicine.ID)).
+					Times(1).
+					Return([]db.Category{}, nil)
+				store.EXPECT().
+					ListMedicineIngredients(gomock.Any(), gomock.Eq([]int32{medicine.ID})).
+					Times(1).
+					Return([]db.ListMedicineIngredientsRow{}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var response struct {
+					Data medicineDetailResponse `json:"data"`
+				}
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+				require.Equal(t, medicine, response.Data.Medicine)
+				require.Equal(t, []string{barcode}, response.Data.Barcodes)
+			},
+		},
+		{
+			name:  "NotFound",
+			query: "?barcode=" + barcode,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetMedicineByBarcode(gomock.Any(), gomock.Eq(barcode)).
+					Times(1).
+					Return(db.Medicine{}, sql.ErrNoRows)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:  "InvalidChecksum",
+			query: "?barcode=8934588012229",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetMedicineByBarcode(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:  "MissingBarcode",
+			query: "",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetMedicineByBarcode(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().
+				GetUser(gomock.Any(), gomock.Eq(user.Username)).
+				Times(1).
+				Return(user, nil)
+			store.EXPECT().
+				GetPermissionsForUser(gomock.Any(), gomock.Eq(user.ID)).
+				Times(1).
+				Return([]string{"VIEW_SCREEN_MEDICINE"}, nil)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			request, err := http.NewRequest(http.MethodGet, "/medicines/lookup"+tc.query, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestAddMedicineBarcodeAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	medicine := randomMedicine()
+	barcode := "4006381333931"
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{"barcode": barcode},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.CreateMedicineBarcodeParams{
+					Barcode:    barcode,
+					MedicineID: medicine.ID,
+				}
+				store.EXPECT().
+					CreateMedicineBarcode(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(db.MedicineBarcode{Barcode: barcode, MedicineID: medicine.ID}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "DuplicateBarcode",
+			body: gin.H{"barcode": barcode},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateMedicineBarcode(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.MedicineBarcode{}, &pq.Error{Code: "23505"})
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name: "MedicineNotFound",
+			body: gin.H{"barcode": barcode},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateMedicineBarcode(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.MedicineBarcode{}, &pq.Error{Code: "23503"})
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name: "InvalidChecksum",
+			body: gin.H{"barcode": "4006381333932"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateMedicineBarcode(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().
+				GetUser(gomock.Any(), gomock.Eq(user.Username)).
+				Times(1).
+				Return(user, nil)
+			store.EXPECT().
+				GetPermissionsForUser(gomock.Any(), gomock.Eq(user.ID)).
+				Times(1).
+				Return([]string{"VIEW_SCREEN_MEDICINE"}, nil)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/medicines/%d/barcodes", medicine.ID)
+			request, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
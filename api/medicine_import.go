@@ -0,0 +1,340 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	db "github.com/toannguyen3105/nht-bsihuyen.com-api/db/sqlc"
+	"github.com/toannguyen3105/nht-bsihuyen.com-api/token"
+	"github.com/toannguyen3105/nht-bsihuyen.com-api/utils"
+	"github.com/xuri/excelize/v2"
+)
+
+const (
+	catalogFormatCSV  = "csv"
+	catalogFormatXLSX = "xlsx"
+)
+
+const catalogSheet = "Medicines"
+
+// catalogColumns are the columns of an exported catalog. An import needs
+// name, unit and price; stock is ignored, as it only changes through
+// movements.
+var catalogColumns = []string{"sku", "name", "unit", "price", "currency", "stock", "reorder_level", "description"}
+
+var errMissingColumn = errors.New("missing column")
+
+type exportMedicinesRequest struct {
+	Format string `form:"format" binding:"omitempty,oneof=csv xlsx"`
+}
+
+func (server *Server) exportMedicines(ctx *gin.Context) {
+	var req exportMedicinesRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	medicines, err := server.store.ListAllMedicines(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if req.Format == catalogFormatXLSX {
+		data, err := writeCatalogXLSX(medicines)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+
+		ctx.Header("Content-Disposition", `attachment; filename="medicines.xlsx"`)
+		ctx.Data(http.StatusOK, "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", data)
+		return
+	}
+
+	data, err := writeCatalogCSV(medicines)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.Header("Content-Disposition", `attachment; filename="medicines.csv"`)
+	ctx.Data(http.StatusOK, "text/csv; charset=utf-8", data)
+}
+
+func writeCatalogCSV(medicines []db.Medicine) ([]byte, error) {
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+
+	err := writer.Write(catalogColumns)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, medicine := range medicines {
+		err = writer.Write([]string{
+			medicine.Sku.String,
+			medicine.Name,
+			medicine.Unit,
+			medicine.Price.String(),
+			medicine.Currency,
+			strconv.Itoa(int(medicine.Stock)),
+			strconv.Itoa(int(medicine.ReorderLevel)),
+			medicine.Description.String,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	writer.Flush()
+	return buf.Bytes(), writer.Error()
+}
+
+func writeCatalogXLSX(medicines []db.Medicine) ([]byte, error) {
+	file := excelize.NewFile()
+	defer file.Close()
+
+	err := file.SetSheetName(file.GetSheetName(0), catalogSheet)
+	if err != nil {
+		return nil, err
+	}
+
+	header := make([]interface{}, len(catalogColumns))
+	for i, column := range catalogColumns {
+		header[i] = column
+	}
+	err = file.SetSheetRow(catalogSheet, "A1", &header)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, medicine := range medicines {
+		cell, err := excelize.CoordinatesToCellName(1, i+2)
+		if err != nil {
+			return nil, err
+		}
+
+		// prices go in as text so that they come back exactly as exported
+		err = file.SetSheetRow(catalogSheet, cell, &[]interface{}{
+			medicine.Sku.String,
+			medicine.Name,
+			medicine.Unit,
+			medicine.Price.String(),
+			medicine.Currency,
+			medicine.Stock,
+			medicine.ReorderLevel,
+			medicine.Description.String,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	buf, err := file.WriteToBuffer()
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+type importMedicinesRequest struct {
+	Format string `form:"format" binding:"omitempty,oneof=csv xlsx"`
+	DryRun bool   `form:"dry_run"`
+}
+
+// importMedicines loads a catalog in the export format from the request
+// body. Every row is checked before anything is saved; when any row is
+// invalid the response lists the problems by row and nothing is imported.
+func (server *Server) importMedicines(ctx *gin.Context) {
+	var req importMedicinesRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	records, err := readCatalog(req.Format, ctx.Request.Body)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	rows, rowErrors, err := parseCatalog(records)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	// rows that could not be parsed are still reported together with
+	// whatever is wrong with the others, but nothing is saved
+	result, err := server.store.ImportMedicinesTx(ctx, db.ImportMedicinesTxParams{
+		Rows:                rows,
+		DefaultReorderLevel: defaultReorderLevel,
+		CreatedBy:           sql.NullString{String: authPayload.Username, Valid: true},
+		DryRun:              req.DryRun || len(rowErrors) > 0,
+	})
+	if err != nil && !errors.Is(err, db.ErrInvalidImport) {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if err != nil || len(rowErrors) > 0 {
+		result.DryRun = req.DryRun
+		result.Errors = append(result.Errors, rowErrors...)
+		sort.SliceStable(result.Errors, func(i, j int) bool {
+			return result.Errors[i].Row < result.Errors[j].Row
+		})
+
+		ctx.JSON(http.StatusBadRequest, APIResponse{
+			Status:  "error",
+			Message: db.ErrInvalidImport.Error(),
+			Data:    result,
+		})
+		return
+	}
+
+	message := "Medicines imported successfully"
+	if result.DryRun {
+		message = "Medicine import previewed successfully"
+	}
+	ctx.JSON(http.StatusOK, successResponse(message, result))
+}
+
+// readCatalog reads the rows of a CSV file or of the first sheet of an XLSX
+// workbook.
+func readCatalog(format string, body io.Reader) ([][]string, error) {
+	if format == catalogFormatXLSX {
+		file, err := excelize.OpenReader(body)
+		if err != nil {
+			return nil, err
+		}
+		defer file.Close()
+
+		return file.GetRows(file.GetSheetName(0))
+	}
+
+	reader := csv.NewReader(body)
+	reader.FieldsPerRecord = -1
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+
+	// Excel starts the CSV files it saves with a byte order mark
+	if len(records) > 0 && len(records[0]) > 0 {
+		records[0][0] = strings.TrimPrefix(records[0][0], "\ufeff")
+	}
+	return records, nil
+}
+
+// parseCatalog turns spreadsheet rows into import rows. The first row names
+// the columns, in any order; rows that cannot be parsed are returned as
+// errors with their line number.
+func parseCatalog(records [][]string) ([]db.MedicineImportRow, []db.MedicineImportError, error) {
+	if len(records) == 0 {
+		return nil, nil, fmt.Errorf("%w: the file is empty", errMissingColumn)
+	}
+
+	columns := make(map[string]int)
+	for i, name := range records[0] {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, required := range []string{"name", "unit", "price"} {
+		if _, ok := columns[required]; !ok {
+			return nil, nil, fmt.Errorf("%w: %s", errMissingColumn, required)
+		}
+	}
+
+	rows := []db.MedicineImportRow{}
+	rowErrors := []db.MedicineImportError{}
+	for i, record := range records[1:] {
+		line := i + 2
+
+		value := func(column string) string {
+			index, ok := columns[column]
+			if !ok || index >= len(record) {
+				return ""
+			}
+			return strings.TrimSpace(record[index])
+		}
+
+		if strings.TrimSpace(strings.Join(record, "")) == "" {
+			continue
+		}
+
+		row, err := parseCatalogRow(line, value)
+		if err != nil {
+			rowErrors = append(rowErrors, db.MedicineImportError{Row: line, Message: err.Error()})
+			continue
+		}
+		rows = append(rows, row)
+	}
+
+	return rows, rowErrors, nil
+}
+
+func parseCatalogRow(line int, value func(column string) string) (db.MedicineImportRow, error) {
+	row := db.MedicineImportRow{
+		Row:  line,
+		Name: value("name"),
+		Unit: value("unit"),
+	}
+
+	if row.Name == "" {
+		return row, errors.New("name is required")
+	}
+	if row.Unit == "" {
+		return row, errors.New("unit is required")
+	}
+
+	if sku := value("sku"); sku != "" {
+		if len(sku) > 64 {
+			return row, errors.New("sku is longer than 64 characters")
+		}
+		row.Sku = sql.NullString{String: sku, Valid: true}
+	}
+
+	amount, err := utils.ParseDecimal(value("price"))
+	if err != nil {
+		return row, fmt.Errorf("price: %w", err)
+	}
+	if amount.IsNegative() {
+		return row, errors.New("price cannot be negative")
+	}
+
+	// without a currency the store keeps the medicine's own, so a blank cell
+	// does not turn a USD price into đồng
+	row.Price = utils.NewMoney(amount, "")
+	if currency := value("currency"); currency != "" {
+		row.Price.Currency = strings.ToUpper(currency)
+		if err := row.Price.Validate(); err != nil {
+			return row, err
+		}
+	}
+
+	if reorderLevel := value("reorder_level"); reorderLevel != "" {
+		level, err := strconv.ParseInt(reorderLevel, 10, 32)
+		if err != nil || level < 0 {
+			return row, fmt.Errorf("reorder_level %q is not a whole number of at least 0", reorderLevel)
+		}
+		row.ReorderLevel = sql.NullInt32{Int32: int32(level), Valid: true}
+	}
+
+	if description := value("description"); description != "" {
+		row.Description = sql.NullString{String: description, Valid: true}
+	}
+
+	return row, nil
+}
@@ -0,0 +1,313 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/csv"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	mockdb "github.com/toannguyen3105/nht-bsihuyen.com-api/db/mock"
+	db "github.com/toannguyen3105/nht-bsihuyen.com-api/db/sqlc"
+	"github.com/toannguyen3105/nht-bsihuyen.com-api/utils"
+)
+
+func TestExportMedicinesAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	medicine := randomMedicine()
+	medicine.Sku = sql.NullString{String: "PARA-500", Valid: true}
+
+	testCases := []struct {
+		name          string
+		query         string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "CSV",
+			query: "",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ListAllMedicines(gomock.Any()).
+					Times(1).
+					Return([]db.Medicine{medicine}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Contains(t, recorder.Header().Get("Content-Type"), "text/csv")
+
+				records, err := csv.NewReader(recorder.Body).ReadAll()
+				require.NoError(t, err)
+				require.Len(t, records, 2)
+				require.Equal(t, catalogColumns, records[0])
+				require.Equal(t, "PARA-500", records[1][0])
+				require.Equal(t, medicine.Name, records[1][1])
+				require.Equal(t, medicine.Price.String(), records[1][3])
+			},
+		},
+		{
+			name:  "XLSX",
+			query: "?format=xlsx",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ListAllMedicines(gomock.Any()).
+					Times(1).
+					Return([]db.Medicine{medicine}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				records, err := readCatalog(catalogFormatXLSX, recorder.Body)
+				require.NoError(t, err)
+				require.Len(t, records, 2)
+				require.Equal(t, catalogColumns, records[0])
+				require.Equal(t, medicine.Name, records[1][1])
+				require.Equal(t, medicine.Price.String(), records[1][3])
+			},
+		},
+		{
+			name:  "InvalidFormat",
+			query: "?format=pdf",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ListAllMedicines(gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().
+				GetUser(gomock.Any(), gomock.Eq(user.Username)).
+				Times(1).
+				Return(user, nil)
+			store.EXPECT().
+				GetPermissionsForUser(gomock.Any(), gomock.Eq(user.ID)).
+				Times(1).
+				Return([]string{"VIEW_SCREEN_MEDICINE"}, nil)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			request, err := http.NewRequest(http.MethodGet, "/medicines/export"+tc.query, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestImportMedicinesAPI(t *testing.T) {
+	user, _ := randomUser(t)
+
+	const catalog = "\ufeffName,Unit,Price,Currency,SKU,Reorder_Level\n" +
+		"Paracetamol 500mg,tablet,2500,,PARA-500,20\n" +
+		",,,,,\n" +
+		"Vitamin C,bottle,4.50,USD,,\n"
+
+	rows := []db.MedicineImportRow{
+		{
+			Row:          2,
+			Sku:          sql.NullString{String: "PARA-500", Valid: true},
+			Name:         "Paracetamol 500mg",
+			Unit:         "tablet",
+			Price:        utils.NewMoney(utils.MustParseDecimal("2500"), ""),
+			ReorderLevel: sql.NullInt32{Int32: 20, Valid: true},
+		},
+		{
+			Row:   4,
+			Name:  "Vitamin C",
+			Unit:  "bottle",
+			Price: utils.NewMoney(utils.MustParseDecimal("4.50"), utils.USD),
+		},
+	}
+
+	testCases := []struct {
+		name          string
+		query         string
+		body          []byte
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: []byte(catalog),
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.ImportMedicinesTxParams{
+					Rows:                rows,
+					DefaultReorderLevel: defaultReorderLevel,
+					CreatedBy:           sql.NullString{String: user.Username, Valid: true},
+				}
+				store.EXPECT().
+					ImportMedicinesTx(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(db.ImportMedicinesTxResult{Created: 1, Updated: 1}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:  "DryRun",
+			query: "?dry_run=true",
+			body:  []byte(catalog),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ImportMedicinesTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, arg db.ImportMedicinesTxParams) (db.ImportMedicinesTxResult, error) {
+						require.True(t, arg.DryRun)
+						return db.ImportMedicinesTxResult{DryRun: true, Created: 2}, nil
+					})
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "InvalidRows",
+			body: []byte("name,unit,price,currency\n" +
+				"Paracetamol,tablet,abc,\n" +
+				"Vitamin C,bottle,4500,\n" +
+				"Ibuprofen,tablet,99.99,VND\n"),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ImportMedicinesTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, arg db.ImportMedicinesTxParams) (db.ImportMedicinesTxResult, error) {
+						// the valid rows are still checked, without saving them
+						require.True(t, arg.DryRun)
+						require.Len(t, arg.Rows, 1)
+						return db.ImportMedicinesTxResult{
+							DryRun: true,
+							Errors: []db.MedicineImportError{{Row: 3, Message: `unknown unit "bottle"`}},
+						}, db.ErrInvalidImport
+					})
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+
+				var rsp struct {
+					Data db.ImportMedicinesTxResult `json:"data"`
+				}
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &rsp))
+				require.False(t, rsp.Data.DryRun)
+				require.Len(t, rsp.Data.Errors, 3)
+				for i, row := range []int{2, 3, 4} {
+					require.Equal(t, row, rsp.Data.Errors[i].Row)
+				}
+			},
+		},
+		{
+			name: "MissingColumn",
+			body: []byte("name,price\nParacetamol,2500\n"),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ImportMedicinesTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:  "XLSX",
+			query: "?format=xlsx",
+			body: func() []byte {
+				data, err := writeCatalogXLSX([]db.Medicine{{
+					Name:         "Paracetamol 500mg",
+					Unit:         "tablet",
+					Price:        utils.MustParseDecimal("2500"),
+					Currency:     utils.VND,
+					Stock:        100,
+					ReorderLevel: 20,
+					Sku:          sql.NullString{String: "PARA-500", Valid: true},
+				}})
+				require.NoError(t, err)
+				return data
+			}(),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ImportMedicinesTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, arg db.ImportMedicinesTxParams) (db.ImportMedicinesTxResult, error) {
+						// the export names the currency, so the row carries it
+						want := rows[0]
+						want.Price.Currency = utils.VND
+						require.Equal(t, []db.MedicineImportRow{want}, arg.Rows)
+						return db.ImportMedicinesTxResult{Updated: 1}, nil
+					})
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().
+				GetUser(gomock.Any(), gomock.Eq(user.Username)).
+				Times(1).
+				Return(user, nil)
+			store.EXPECT().
+				GetPermissionsForUser(gomock.Any(), gomock.Eq(user.ID)).
+				Times(1).
+				Return([]string{"VIEW_SCREEN_MEDICINE"}, nil)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			request, err := http.NewRequest(http.MethodPost, "/medicines/import"+tc.query, bytes.NewReader(tc.body))
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestParseCatalog(t *testing.T) {
+	records := [][]string{
+		{"name", "unit", "price", "currency", "reorder_level", "sku"},
+		{"A", "tablet", "-1", "", "", ""},
+		{"B", "tablet", "10", "XYZ", "", ""},
+		{"C", "tablet", "10", "", "-5", ""},
+		{"D", "tablet", "10", "", "", strings.Repeat("x", 65)},
+		{"", "tablet", "10", "", "", ""},
+		{"E", "tablet", "2500.50", "VND", "", ""},
+	}
+
+	rows, rowErrors, err := parseCatalog(records)
+	require.NoError(t, err)
+	require.Empty(t, rows)
+	require.Len(t, rowErrors, len(records)-1)
+}
@@ -0,0 +1,264 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/lib/pq"
+	db "github.com/toannguyen3105/nht-bsihuyen.com-api/db/sqlc"
+	"github.com/toannguyen3105/nht-bsihuyen.com-api/token"
+	"github.com/toannguyen3105/nht-bsihuyen.com-api/utils"
+)
+
+// minAdjustPercent is the lowest bulk adjustment; anything at or below -100%
+// would make prices zero or negative.
+var minAdjustPercent = utils.MustParseDecimal("-100")
+
+var errInvalidAdjustPercent = errors.New("percent must be greater than -100")
+
+type changeMedicinePriceRequest struct {
+	Price         *utils.Decimal `json:"price" binding:"required,min=0"`
+	Currency      string         `json:"currency" binding:"omitempty,price_currency"`
+	EffectiveFrom *time.Time     `json:"effective_from"`
+	Reason        string         `json:"reason" binding:"required,max=255"`
+}
+
+// changeMedicinePrice records a new price for a medicine. Without
+// effective_from the price applies right away; otherwise it is scheduled and
+// the price job applies it when the time comes.
+func (server *Server) changeMedicinePrice(ctx *gin.Context) {
+	var reqURI getMedicineRequest
+	if err := ctx.ShouldBindUri(&reqURI); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req changeMedicinePriceRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	arg := db.ChangeMedicinePriceTxParams{
+		MedicineID: reqURI.ID,
+		Price:      utils.NewMoney(*req.Price, req.Currency),
+		Reason:     req.Reason,
+		CreatedBy:  sql.NullString{String: authPayload.Username, Valid: true},
+	}
+	if req.EffectiveFrom != nil {
+		arg.EffectiveFrom = *req.EffectiveFrom
+	}
+
+	result, err := server.store.ChangeMedicinePriceTx(ctx, arg)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		if errors.Is(err, db.ErrPastPriceChange) || errors.Is(err, utils.ErrInvalidMoney) {
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+		// another price already takes effect at that moment
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code.Name() == "unique_violation" {
+			ctx.JSON(http.StatusConflict, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, successResponse("Medicine price recorded successfully", result))
+}
+
+type listMedicinePricesRequest struct {
+	PageID   int32 `form:"page_id" binding:"required,min=1"`
+	PageSize int32 `form:"page_size" binding:"required,min=10,max=100"`
+}
+
+type medicinePricesResponse struct {
+	Meta struct {
+		Page       int32 `json:"page"`
+		TotalPages int32 `json:"total_pages"`
+		TotalCount int64 `json:"total_count"`
+	} `json:"meta"`
+	Data []db.MedicinePrice `json:"data"`
+}
+
+// listMedicinePrices lists the price history of a medicine, latest first,
+// including prices scheduled for later.
+func (server *Server) listMedicinePrices(ctx *gin.Context) {
+	var reqURI getMedicineRequest
+	if err := ctx.ShouldBindUri(&reqURI); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req listMedicinePricesRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	_, err := server.store.GetMedicine(ctx, reqURI.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	arg := db.ListMedicinePricesParams{
+		MedicineID: reqURI.ID,
+		Limit:      req.PageSize,
+		Offset:     (req.PageID - 1) * req.PageSize,
+	}
+
+	prices, err := server.store.ListMedicinePrices(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	totalCount, err := server.store.CountMedicinePrices(ctx, reqURI.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	totalPages := int32(totalCount) / req.PageSize
+	if int32(totalCount)%req.PageSize != 0 {
+		totalPages++
+	}
+
+	rsp := medicinePricesResponse{
+		Meta: struct {
+			Page       int32 `json:"page"`
+			TotalPages int32 `json:"total_pages"`
+			TotalCount int64 `json:"total_count"`
+		}{
+			Page:       req.PageID,
+			TotalPages: totalPages,
+			TotalCount: totalCount,
+		},
+		Data: prices,
+	}
+
+	ctx.JSON(http.StatusOK, successResponse("Medicine prices retrieved successfully", rsp))
+}
+
+type getMedicinePriceAtRequest struct {
+	At time.Time `form:"at"`
+}
+
+// getMedicinePriceAt returns the price a medicine had, or will have, at the
+// given time; now when no time is given.
+func (server *Server) getMedicinePriceAt(ctx *gin.Context) {
+	var reqURI getMedicineRequest
+	if err := ctx.ShouldBindUri(&reqURI); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req getMedicinePriceAtRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	at := req.At
+	if at.IsZero() {
+		at = time.Now()
+	}
+
+	price, err := server.store.GetMedicinePriceAt(ctx, db.GetMedicinePriceAtParams{
+		MedicineID: reqURI.ID,
+		At:         at,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, successResponse("Medicine price retrieved successfully", price))
+}
+
+type bulkAdjustPricesRequest struct {
+	Percent       utils.Decimal `json:"percent" binding:"required"`
+	MedicineIDs   []int32       `json:"medicine_ids" binding:"omitempty,dive,min=1"`
+	Search        string        `json:"search" binding:"max=255"`
+	Unit          string        `json:"unit" binding:"max=50"`
+	CategoryID    *int32        `json:"category_id" binding:"omitempty,min=1"`
+	EffectiveFrom *time.Time    `json:"effective_from"`
+	Reason        string        `json:"reason" binding:"required,max=255"`
+	Preview       bool          `json:"preview"`
+}
+
+// bulkAdjustPrices raises or lowers the price of every medicine matching the
+// filter by a percentage. With preview the adjustments are returned without
+// being saved.
+func (server *Server) bulkAdjustPrices(ctx *gin.Context) {
+	var req bulkAdjustPricesRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if req.Percent.Cmp(minAdjustPercent) <= 0 {
+		ctx.JSON(http.StatusBadRequest, errorResponse(errInvalidAdjustPercent))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	arg := db.BulkAdjustPricesTxParams{
+		Filter: db.ListMedicinesForUpdateParams{
+			Ids: req.MedicineIDs,
+		},
+		Percent:   req.Percent,
+		Reason:    req.Reason,
+		CreatedBy: sql.NullString{String: authPayload.Username, Valid: true},
+		DryRun:    req.Preview,
+	}
+	if search := strings.TrimSpace(req.Search); search != "" {
+		arg.Filter.Search = sql.NullString{String: search, Valid: true}
+	}
+	if req.Unit != "" {
+		arg.Filter.Unit = sql.NullString{String: req.Unit, Valid: true}
+	}
+	if req.CategoryID != nil {
+		arg.Filter.CategoryID = sql.NullInt32{Int32: *req.CategoryID, Valid: true}
+	}
+	if req.EffectiveFrom != nil {
+		arg.EffectiveFrom = *req.EffectiveFrom
+	}
+
+	result, err := server.store.BulkAdjustPricesTx(ctx, arg)
+	if err != nil {
+		if errors.Is(err, db.ErrPastPriceChange) || errors.Is(err, utils.ErrInvalidMoney) || errors.Is(err, utils.ErrDecimalOverflow) {
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+		// another price already takes effect at that moment
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code.Name() == "unique_violation" {
+			ctx.JSON(http.StatusConflict, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, successResponse("Medicine prices adjusted successfully", result))
+}
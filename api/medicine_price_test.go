@@ -0,0 +1,412 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+	mockdb "github.com/toannguyen3105/nht-bsihuyen.com-api/db/mock"
+	db "github.com/toannguyen3105/nht-bsihuyen.com-api/db/sqlc"
+	"github.com/toannguyen3105/nht-bsihuyen.com-api/utils"
+)
+
+func TestChangeMedicinePriceAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	medicine := randomMedicine()
+	effectiveFrom := time.Now().Add(24 * time.Hour).Truncate(time.Second)
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "Immediate",
+			body: gin.H{"price": "3000", "reason": "Supplier price increase"},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.ChangeMedicinePriceTxParams{
+					MedicineID: medicine.ID,
+					Price:      utils.NewMoney(utils.MustParseDecimal("3000"), ""),
+					Reason:     "Supplier price increase",
+					CreatedBy:  sql.NullString{String: user.Username, Valid: true},
+				}
+				store.EXPECT().
+					ChangeMedicinePriceTx(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(db.ChangeMedicinePriceTxResult{Medicine: medicine}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "Scheduled",
+			body: gin.H{
+				"price":          "3000",
+				"effective_from": effectiveFrom.Format(time.RFC3339),
+				"reason":         "New price list",
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ChangeMedicinePriceTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, arg db.ChangeMedicinePriceTxParams) (db.ChangeMedicinePriceTxResult, error) {
+						require.True(t, arg.EffectiveFrom.Equal(effectiveFrom))
+						return db.ChangeMedicinePriceTxResult{Medicine: medicine}, nil
+					})
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "PastEffectiveFrom",
+			body: gin.H{
+				"price":          "3000",
+				"effective_from": time.Now().Add(-time.Hour).Format(time.RFC3339),
+				"reason":         "Backdated",
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ChangeMedicinePriceTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.ChangeMedicinePriceTxResult{}, db.ErrPastPriceChange)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "SameEffectiveFrom",
+			body: gin.H{
+				"price":          "3000",
+				"effective_from": effectiveFrom.Format(time.RFC3339),
+				"reason":         "New price list",
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ChangeMedicinePriceTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.ChangeMedicinePriceTxResult{}, &pq.Error{Code: "23505"})
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name: "NotFound",
+			body: gin.H{"price": "3000", "reason": "Supplier price increase"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ChangeMedicinePriceTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.ChangeMedicinePriceTxResult{}, sql.ErrNoRows)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name: "MissingReason",
+			body: gin.H{"price": "3000"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ChangeMedicinePriceTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().
+				GetUser(gomock.Any(), gomock.Eq(user.Username)).
+				Times(1).
+				Return(user, nil)
+			store.EXPECT().
+				GetPermissionsForUser(gomock.Any(), gomock.Eq(user.ID)).
+				Times(1).
+				Return([]string{"VIEW_SCREEN_MEDICINE"}, nil)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/medicines/%d/prices", medicine.ID)
+			request, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestGetMedicinePriceAtAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	medicine := randomMedicine()
+	at := time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)
+	price := db.MedicinePrice{
+		ID:            1,
+		MedicineID:    medicine.ID,
+		Price:         utils.MustParseDecimal("2500"),
+		Currency:      utils.VND,
+		EffectiveFrom: at.Add(-48 * time.Hour),
+		Reason:        "Initial price",
+	}
+
+	testCases := []struct {
+		name          string
+		query         url.Values
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "At",
+			query: url.Values{"at": {at.Format(time.RFC3339)}},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetMedicinePriceAt(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, arg db.GetMedicinePriceAtParams) (db.MedicinePrice, error) {
+						require.Equal(t, medicine.ID, arg.MedicineID)
+						require.True(t, arg.At.Equal(at))
+						return price, nil
+					})
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var rsp struct {
+					Data db.MedicinePrice `json:"data"`
+				}
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &rsp))
+				require.Equal(t, price.Price, rsp.Data.Price)
+			},
+		},
+		{
+			name:  "Now",
+			query: url.Values{},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetMedicinePriceAt(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, arg db.GetMedicinePriceAtParams) (db.MedicinePrice, error) {
+						require.WithinDuration(t, time.Now(), arg.At, time.Minute)
+						return price, nil
+					})
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:  "NoPriceYet",
+			query: url.Values{"at": {"2000-01-01T00:00:00Z"}},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetMedicinePriceAt(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.MedicinePrice{}, sql.ErrNoRows)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:  "InvalidAt",
+			query: url.Values{"at": {"yesterday"}},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetMedicinePriceAt(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().
+				GetUser(gomock.Any(), gomock.Eq(user.Username)).
+				Times(1).
+				Return(user, nil)
+			store.EXPECT().
+				GetPermissionsForUser(gomock.Any(), gomock.Eq(user.ID)).
+				Times(1).
+				Return([]string{"VIEW_SCREEN_MEDICINE"}, nil)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/medicines/%d/price?%s", medicine.ID, tc.query.Encode())
+			request, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestBulkAdjustPricesAPI(t *testing.T) {
+	user, _ := randomUser(t)
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "Preview",
+			body: gin.H{
+				"percent": "5",
+				"unit":    "box",
+				"reason":  "Annual increase",
+				"preview": true,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.BulkAdjustPricesTxParams{
+					Filter: db.ListMedicinesForUpdateParams{
+						Unit: sql.NullString{String: "box", Valid: true},
+					},
+					Percent:   utils.MustParseDecimal("5"),
+					Reason:    "Annual increase",
+					CreatedBy: sql.NullString{String: user.Username, Valid: true},
+					DryRun:    true,
+				}
+				store.EXPECT().
+					BulkAdjustPricesTx(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(db.BulkAdjustPricesTxResult{
+						DryRun: true,
+						Adjustments: []db.PriceAdjustment{
+							{
+								MedicineID: 1,
+								Name:       "Paracetamol",
+								OldPrice:   utils.NewMoney(utils.MustParseDecimal("2000"), utils.VND),
+								NewPrice:   utils.NewMoney(utils.MustParseDecimal("2100"), utils.VND),
+							},
+						},
+					}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var rsp struct {
+					Data db.BulkAdjustPricesTxResult `json:"data"`
+				}
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &rsp))
+				require.True(t, rsp.Data.DryRun)
+				require.Len(t, rsp.Data.Adjustments, 1)
+				require.Equal(t, "2100.00", rsp.Data.Adjustments[0].NewPrice.Amount.String())
+			},
+		},
+		{
+			name: "Overflow",
+			body: gin.H{"percent": "90000000000", "reason": "Typo"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					BulkAdjustPricesTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.BulkAdjustPricesTxResult{}, utils.ErrDecimalOverflow)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "PercentTooLow",
+			body: gin.H{"percent": "-100", "reason": "Clearance"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					BulkAdjustPricesTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "ZeroPercent",
+			body: gin.H{"percent": "0", "reason": "Nothing"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					BulkAdjustPricesTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().
+				GetUser(gomock.Any(), gomock.Eq(user.Username)).
+				Times(1).
+				Return(user, nil)
+			store.EXPECT().
+				GetPermissionsForUser(gomock.Any(), gomock.Eq(user.ID)).
+				Times(1).
+				Return([]string{"VIEW_SCREEN_MEDICINE"}, nil)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			request, err := http.NewRequest(http.MethodPost, "/medicine-prices/adjust", bytes.NewReader(data))
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
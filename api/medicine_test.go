@@ -2,6 +2,7 @@ package api
 
 import (
 	"bytes"
+	"context"
 	"database/sql"
 	"encoding/json"
 	"fmt"
@@ -13,6 +14,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/golang/mock/gomock"
+	"github.com/lib/pq"
 	"github.com/stretchr/testify/require"
 	mockdb "github.com/toannguyen3105/nht-bsihuyen.com-api/db/mock"
 	db "github.com/toannguyen3105/nht-bsihuyen.com-api/db/sqlc"
@@ -36,8 +38,10 @@ func TestCreateMedicineAPI(t *testing.T) {
 			body: gin.H{
 				"name":        medicine.Name,
 				"unit":        medicine.Unit,
-				"price":       100.0, // Use float for request
+				"price":       100,
 				"stock":       medicine.Stock,
+				"lot_number":  "LOT-2027A",
+				"expiry_date": "2027-05-31",
 				"description": medicine.Description.String,
 			},
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
@@ -53,8 +57,22 @@ func TestCreateMedicineAPI(t *testing.T) {
 					Times(1).
 					Return([]string{"VIEW_SCREEN_MEDICINE"}, nil)
 
+				arg := db.CreateMedicineTxParams{
+					Medicine: db.CreateMedicineParams{
+						Name:         medicine.Name,
+						Unit:         medicine.Unit,
+						Price:        medicine.Price,
+						Currency:     utils.VND,
+						Description:  medicine.Description,
+						ReorderLevel: defaultReorderLevel,
+					},
+					Stock:      medicine.Stock,
+					LotNumber:  "LOT-2027A",
+					ExpiryDate: time.Date(2027, time.May, 31, 0, 0, 0, 0, time.UTC),
+					CreatedBy:  sql.NullString{String: user.Username, Valid: true},
+				}
 				store.EXPECT().
-					CreateMedicine(gomock.Any(), gomock.Any()).
+					CreateMedicineTx(gomock.Any(), gomock.Eq(arg)).
 					Times(1).
 					Return(medicine, nil)
 			},
@@ -63,18 +81,101 @@ func TestCreateMedicineAPI(t *testing.T) {
 				requireBodyMatchMedicine(t, recorder.Body, medicine)
 			},
 		},
+		{
+			name: "FractionalVND",
+			body: gin.H{
+				"name":  medicine.Name,
+				"unit":  medicine.Unit,
+				"price": "2500.50",
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					GetPermissionsForUser(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return([]string{"VIEW_SCREEN_MEDICINE"}, nil)
+				store.EXPECT().CreateMedicineTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "TooPrecise",
+			body: gin.H{
+				"name":     medicine.Name,
+				"unit":     medicine.Unit,
+				"price":    "2.505",
+				"currency": utils.USD,
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					GetPermissionsForUser(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return([]string{"VIEW_SCREEN_MEDICINE"}, nil)
+				store.EXPECT().CreateMedicineTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "DuplicateSku",
+			body: gin.H{
+				"name":  medicine.Name,
+				"sku":   "PARA-500",
+				"unit":  medicine.Unit,
+				"price": 100,
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					GetPermissionsForUser(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return([]string{"VIEW_SCREEN_MEDICINE"}, nil)
+				store.EXPECT().
+					CreateMedicineTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ context.Context, arg db.CreateMedicineTxParams) (db.Medicine, error) {
+						require.Equal(t, sql.NullString{String: "PARA-500", Valid: true}, arg.Medicine.Sku)
+						return db.Medicine{}, &pq.Error{Code: "23505"}
+					})
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
 		{
 			name: "NoAuthorization",
 			body: gin.H{
 				"name":  medicine.Name,
 				"unit":  medicine.Unit,
-				"price": 100.0,
+				"price": 100,
 				"stock": medicine.Stock,
 			},
 			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
 			},
 			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().CreateMedicine(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().CreateMedicineTx(gomock.Any(), gomock.Any()).Times(0)
 			},
 			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusUnauthorized, recorder.Code)
@@ -112,6 +213,24 @@ func TestCreateMedicineAPI(t *testing.T) {
 func TestGetMedicineAPI(t *testing.T) {
 	user, _ := randomUser(t)
 	medicine := randomMedicine()
+	batches := []db.MedicineBatch{
+		{
+			ID:                1,
+			MedicineID:        medicine.ID,
+			LotNumber:         "LOT-OLD",
+			ExpiryDate:        sql.NullTime{Time: time.Date(2020, time.January, 31, 0, 0, 0, 0, time.UTC), Valid: true},
+			ReceivedQuantity:  10,
+			RemainingQuantity: 1,
+		},
+		{
+			ID:                2,
+			MedicineID:        medicine.ID,
+			LotNumber:         "LOT-NEW",
+			ExpiryDate:        sql.NullTime{Time: time.Now().AddDate(1, 0, 0), Valid: true},
+			ReceivedQuantity:  medicine.Stock,
+			RemainingQuantity: medicine.Stock - 1,
+		},
+	}
 
 	testCases := []struct {
 		name          string
@@ -139,10 +258,55 @@ func TestGetMedicineAPI(t *testing.T) {
 					GetMedicine(gomock.Any(), gomock.Eq(medicine.ID)).
 					Times(1).
 					Return(medicine, nil)
+				store.EXPECT().
+					ListMedicineBatches(gomock.Any(), gomock.Eq(medicine.ID)).
+					Times(1).
+					Return(batches, nil)
+				store.EXPECT().
+					ListMedicineUnitConversions(gomock.Any(), gomock.Eq(medicine.ID)).
+					Times(1).
+					Return([]db.MedicineUnitConversion{{MedicineID: medicine.ID, Unit: "box", Factor: 100}}, nil)
+				store.EXPECT().
+					ListMedicineBarcodes(gomock.Any(), gomock.Eq(medicine.ID)).
+					Times(1).
+					Return([]db.MedicineBarcode{{Barcode: "8934588012228", MedicineID: medicine.ID}}, nil)
+				store.EXPECT().
+					ListMedicineCategories(gomock.Any(), gomock.Eq(medicine.ID)).
+					Times(1).
+					Return([]db.Category{{ID: 1, Name: "Analgesics"}}, nil)
+				store.EXPECT().
+					ListMedicineIngredients(gomock.Any(), gomock.Eq([]int32{medicine.ID})).
+					Times(1).
+					Return([]db.ListMedicineIngredientsRow{{
+						MedicineID:   medicine.ID,
+						IngredientID: 1,
+						Name:         "Paracetamol",
+						Strength:     utils.MustParseDecimal("500"),
+						StrengthUnit: "mg",
+					}}, nil)
 			},
 			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusOK, recorder.Code)
-				requireBodyMatchMedicine(t, recorder.Body, medicine)
+
+				var response struct {
+					Data medicineDetailResponse `json:"data"`
+				}
+				err := json.Unmarshal(recorder.Body.Bytes(), &response)
+				require.NoError(t, err)
+				require.Equal(t, medicine, response.Data.Medicine)
+				require.Len(t, response.Data.Batches, 2)
+				require.True(t, response.Data.Batches[0].Expired)
+				require.Equal(t, "2020-01-31", *response.Data.Batches[0].ExpiryDate)
+				require.False(t, response.Data.Batches[1].Expired)
+				require.Equal(t, []medicineUnitConversionResponse{{Unit: "box", Factor: 100}}, response.Data.Conversions)
+				require.Equal(t, []string{"8934588012228"}, response.Data.Barcodes)
+				require.Equal(t, "Analgesics", response.Data.Categories[0].Name)
+				require.Equal(t, []medicineIngredientResponse{{
+					IngredientID: 1,
+					Name:         "Paracetamol",
+					Strength:     utils.MustParseDecimal("500"),
+					StrengthUnit: "mg",
+				}}, response.Data.Ingredients)
 			},
 		},
 		{
@@ -195,13 +359,536 @@ func TestGetMedicineAPI(t *testing.T) {
 	}
 }
 
+func TestListMedicinesAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	medicines := []db.Medicine{randomMedicine(), randomMedicine()}
+
+	testCases := []struct {
+		name          string
+		query         string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "OK",
+			query: "page_id=1&page_size=5",
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.ListMedicinesParams{
+					Limit:  5,
+					Offset: 0,
+				}
+				store.EXPECT().
+					ListMedicines(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(medicines, nil)
+				store.EXPECT().
+					CountMedicines(gomock.Any(), gomock.Eq(db.CountMedicinesParams{})).
+					Times(1).
+					Return(int64(len(medicines)), nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:  "Filtered",
+			query: "page_id=2&page_size=5&search=%20thu%E1%BB%91c%20&unit=box&min_price=10&max_price=99.5&low_stock=true&include_archived=true&dosage_form=tablet&manufacturer=%20DHG%20&category_id=3&ingredient_id=7&currency=USD&sort_by=price&sort_order=desc",
+			buildStubs: func(store *mockdb.MockStore) {
+				filter := db.CountMedicinesParams{
+					Search:          sql.NullString{String: "thuốc", Valid: true},
+					Unit:            sql.NullString{String: "box", Valid: true},
+					MinPrice:        utils.NullDecimal{Decimal: utils.MustParseDecimal("10"), Valid: true},
+					MaxPrice:        utils.NullDecimal{Decimal: utils.MustParseDecimal("99.5"), Valid: true},
+					LowStock:        true,
+					IncludeArchived: true,
+					DosageForm:      sql.NullString{String: "tablet", Valid: true},
+					Manufacturer:    sql.NullString{String: "DHG", Valid: true},
+					CategoryID:      sql.NullInt32{Int32: 3, Valid: true},
+					IngredientID:    sql.NullInt32{Int32: 7, Valid: true},
+					Currency:        sql.NullString{String: utils.USD, Valid: true},
+				}
+				arg := db.ListMedicinesParams{
+					Search:          filter.Search,
+					Unit:            filter.Unit,
+					MinPrice:        filter.MinPrice,
+					MaxPrice:        filter.MaxPrice,
+					LowStock:        filter.LowStock,
+					IncludeArchived: filter.IncludeArchived,
+					DosageForm:      filter.DosageForm,
+					Manufacturer:    filter.Manufacturer,
+					CategoryID:      filter.CategoryID,
+					IngredientID:    filter.IngredientID,
+					Currency:        filter.Currency,
+					SortBy:          "price",
+					SortDesc:        true,
+					Limit:           5,
+					Offset:          5,
+				}
+				store.EXPECT().
+					ListMedicines(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(medicines, nil)
+				store.EXPECT().
+					CountMedicines(gomock.Any(), gomock.Eq(filter)).
+					Times(1).
+					Return(int64(7), nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var response struct {
+					Data medicinesResponse `json:"data"`
+				}
+				err := json.Unmarshal(recorder.Body.Bytes(), &response)
+				require.NoError(t, err)
+				require.Equal(t, int64(7), response.Data.Meta.TotalCount)
+				require.Equal(t, int32(2), response.Data.Meta.TotalPages)
+			},
+		},
+		{
+			name:  "InvalidSortBy",
+			query: "page_id=1&page_size=5&sort_by=id",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ListMedicines(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:  "PriceFilterWithoutCurrency",
+			query: "page_id=1&page_size=5&min_price=10",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ListMedicines(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:  "PriceSortWithoutCurrency",
+			query: "page_id=1&page_size=5&sort_by=price",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ListMedicines(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:  "InvalidCurrency",
+			query: "page_id=1&page_size=5&currency=XYZ",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ListMedicines(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:  "InvalidPriceRange",
+			query: "page_id=1&page_size=5&min_price=50&max_price=10",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ListMedicines(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().
+				GetUser(gomock.Any(), gomock.Eq(user.Username)).
+				Times(1).
+				Return(user, nil)
+			store.EXPECT().
+				GetPermissionsForUser(gomock.Any(), gomock.Eq(user.ID)).
+				Times(1).
+				Return([]string{"VIEW_SCREEN_MEDICINE"}, nil)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			request, err := http.NewRequest(http.MethodGet, "/medicines?"+tc.query, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestUpdateMedicineAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	medicine := randomMedicine()
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "Price",
+			body: gin.H{"price": "2.50", "currency": utils.USD, "price_reason": "Supplier price increase"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetMedicine(gomock.Any(), gomock.Eq(medicine.ID)).
+					Times(1).
+					Return(medicine, nil)
+				arg := db.UpdateMedicineParams{
+					ID:       medicine.ID,
+					Price:    utils.NullDecimal{Decimal: utils.MustParseDecimal("2.50"), Valid: true},
+					Currency: sql.NullString{String: utils.USD, Valid: true},
+				}
+				updated := medicine
+				updated.Price = arg.Price.Decimal
+				updated.Currency = arg.Currency.String
+				store.EXPECT().
+					UpdateMedicineTx(gomock.Any(), gomock.Eq(db.UpdateMedicineTxParams{
+						Medicine:    arg,
+						PriceReason: "Supplier price increase",
+						CreatedBy:   sql.NullString{String: user.Username, Valid: true},
+					})).
+					Times(1).
+					Return(updated, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "FractionalVND",
+			body: gin.H{"price": "99.99"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetMedicine(gomock.Any(), gomock.Eq(medicine.ID)).
+					Times(1).
+					Return(medicine, nil)
+				store.EXPECT().
+					UpdateMedicineTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "UnitInUse",
+			body: gin.H{"unit": "box"},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.UpdateMedicineParams{
+					ID:   medicine.ID,
+					Unit: sql.NullString{String: "box", Valid: true},
+				}
+				store.EXPECT().
+					UpdateMedicineTx(gomock.Any(), gomock.Eq(db.UpdateMedicineTxParams{
+						Medicine:    arg,
+						PriceReason: defaultPriceReason,
+						CreatedBy:   sql.NullString{String: user.Username, Valid: true},
+					})).
+					Times(1).
+					Return(db.Medicine{}, db.ErrUnitInUse)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name: "NameOnly",
+			body: gin.H{"name": "Paracetamol 500mg"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetMedicine(gomock.Any(), gomock.Any()).
+					Times(0)
+				arg := db.UpdateMedicineParams{
+					ID:   medicine.ID,
+					Name: sql.NullString{String: "Paracetamol 500mg", Valid: true},
+				}
+				store.EXPECT().
+					UpdateMedicineTx(gomock.Any(), gomock.Eq(db.UpdateMedicineTxParams{
+						Medicine:    arg,
+						PriceReason: "Price updated",
+						CreatedBy:   sql.NullString{String: user.Username, Valid: true},
+					})).
+					Times(1).
+					Return(medicine, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().
+				GetUser(gomock.Any(), gomock.Eq(user.Username)).
+				Times(1).
+				Return(user, nil)
+			store.EXPECT().
+				GetPermissionsForUser(gomock.Any(), gomock.Eq(user.ID)).
+				Times(1).
+				Return([]string{"VIEW_SCREEN_MEDICINE"}, nil)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/medicines/%d", medicine.ID)
+			request, err := http.NewRequest(http.MethodPut, url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestDeleteMedicineAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	medicine := randomMedicine()
+	archived := medicine
+	archived.DeletedAt = sql.NullTime{Time: time.Now(), Valid: true}
+
+	testCases := []struct {
+		name          string
+		query         string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "Archive",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetMedicine(gomock.Any(), gomock.Eq(medicine.ID)).
+					Times(1).
+					Return(medicine, nil)
+				store.EXPECT().
+					ArchiveMedicine(gomock.Any(), gomock.Eq(medicine.ID)).
+					Times(1).
+					Return(archived, nil)
+				store.EXPECT().
+					DeleteMedicine(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "AlreadyArchived",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetMedicine(gomock.Any(), gomock.Eq(medicine.ID)).
+					Times(1).
+					Return(archived, nil)
+				store.EXPECT().
+					ArchiveMedicine(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name:  "Permanent",
+			query: "?permanent=true",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetMedicine(gomock.Any(), gomock.Eq(medicine.ID)).
+					Times(1).
+					Return(medicine, nil)
+				store.EXPECT().
+					DeleteMedicine(gomock.Any(), gomock.Eq(medicine.ID)).
+					Times(1).
+					Return(nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:  "PermanentReferenced",
+			query: "?permanent=true",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetMedicine(gomock.Any(), gomock.Eq(medicine.ID)).
+					Times(1).
+					Return(archived, nil)
+				store.EXPECT().
+					DeleteMedicine(gomock.Any(), gomock.Eq(medicine.ID)).
+					Times(1).
+					Return(&pq.Error{Code: "23503"})
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name: "NotFound",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetMedicine(gomock.Any(), gomock.Eq(medicine.ID)).
+					Times(1).
+					Return(db.Medicine{}, sql.ErrNoRows)
+				store.EXPECT().
+					ArchiveMedicine(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().
+				GetUser(gomock.Any(), gomock.Eq(user.Username)).
+				Times(1).
+				Return(user, nil)
+			store.EXPECT().
+				GetPermissionsForUser(gomock.Any(), gomock.Eq(user.ID)).
+				Times(1).
+				Return([]string{"VIEW_SCREEN_MEDICINE"}, nil)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/medicines/%d%s", medicine.ID, tc.query)
+			request, err := http.NewRequest(http.MethodDelete, url, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestRestoreMedicineAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	medicine := randomMedicine()
+	archived := medicine
+	archived.DeletedAt = sql.NullTime{Time: time.Now(), Valid: true}
+
+	testCases := []struct {
+		name          string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetMedicine(gomock.Any(), gomock.Eq(medicine.ID)).
+					Times(1).
+					Return(archived, nil)
+				store.EXPECT().
+					RestoreMedicine(gomock.Any(), gomock.Eq(medicine.ID)).
+					Times(1).
+					Return(medicine, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchMedicine(t, recorder.Body, medicine)
+			},
+		},
+		{
+			name: "NotArchived",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetMedicine(gomock.Any(), gomock.Eq(medicine.ID)).
+					Times(1).
+					Return(medicine, nil)
+				store.EXPECT().
+					RestoreMedicine(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().
+				GetUser(gomock.Any(), gomock.Eq(user.Username)).
+				Times(1).
+				Return(user, nil)
+			store.EXPECT().
+				GetPermissionsForUser(gomock.Any(), gomock.Eq(user.ID)).
+				Times(1).
+				Return([]string{"VIEW_SCREEN_MEDICINE"}, nil)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/medicines/%d/restore", medicine.ID)
+			request, err := http.NewRequest(http.MethodPost, url, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
 func randomMedicine() db.Medicine {
 	return db.Medicine{
-		ID:    int32(utils.RandomInt(1, 1000)),
-		Name:  utils.RandomString(6),
-		Unit:  "tablet", // Use valid enum value
-		Price: "100.00",
-		Stock: int32(utils.RandomInt(1, 100)),
+		ID:       int32(utils.RandomInt(1, 1000)),
+		Name:     utils.RandomString(6),
+		Unit:     "tablet",
+		Price:    utils.MustParseDecimal("100.00"),
+		Currency: utils.VND,
+		Stock:    int32(utils.RandomInt(1, 100)),
 		Description: sql.NullString{
 			String: utils.RandomString(20),
 			Valid:  true,
@@ -3,6 +3,7 @@ package api
 import (
 	"database/sql"
 	"errors"
+	"log/slog"
 	"net/http"
 	"strings"
 
@@ -51,6 +52,18 @@ func authMiddleware(tokenMaker token.Maker) gin.HandlerFunc {
 	}
 }
 
+// logDenial records every refused authorization check so admins can tell who
+// was blocked from which route.
+func logDenial(ctx *gin.Context, username string, required string, reason string) {
+	slog.Warn("authorization denied",
+		"user", username,
+		"method", ctx.Request.Method,
+		"route", ctx.FullPath(),
+		"required", required,
+		"reason", reason,
+	)
+}
+
 func (server *Server) requireAuthorization(requiredRole string) gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 		payload, exists := ctx.Get(authorizationPayloadKey)
@@ -81,6 +94,7 @@ func (server *Server) requireAuthorization(requiredRole string) gin.HandlerFunc
 		if err != nil {
 			if err == sql.ErrNoRows {
 				err := errors.New("user has no roles")
+				logDenial(ctx, user.Username, requiredRole, err.Error())
 				ctx.AbortWithStatusJSON(http.StatusForbidden, errorResponse(err))
 				return
 			}
@@ -98,6 +112,7 @@ func (server *Server) requireAuthorization(requiredRole string) gin.HandlerFunc
 
 		if !hasPermission {
 			err := errors.New("user does not have the required permission")
+			logDenial(ctx, user.Username, requiredRole, err.Error())
 			ctx.AbortWithStatusJSON(http.StatusForbidden, errorResponse(err))
 			return
 		}
@@ -107,6 +122,13 @@ func (server *Server) requireAuthorization(requiredRole string) gin.HandlerFunc
 }
 
 func (server *Server) requirePermission(requiredPermission string) gin.HandlerFunc {
+	return server.requireAllPermissions(requiredPermission)
+}
+
+// requireAllPermissions lets the request through only when the user holds
+// every one of the permissions, for routes that change what several screens
+// manage.
+func (server *Server) requireAllPermissions(requiredPermissions ...string) gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 		payload, exists := ctx.Get(authorizationPayloadKey)
 		if !exists {
@@ -136,6 +158,7 @@ func (server *Server) requirePermission(requiredPermission string) gin.HandlerFu
 		if err != nil {
 			if err == sql.ErrNoRows {
 				err := errors.New("user has no permissions")
+				logDenial(ctx, user.Username, requiredPermissions[0], err.Error())
 				ctx.AbortWithStatusJSON(http.StatusForbidden, errorResponse(err))
 				return
 			}
@@ -143,18 +166,23 @@ func (server *Server) requirePermission(requiredPermission string) gin.HandlerFu
 			return
 		}
 
-		hasPermission := false
-		for _, permission := range permissions {
-			if permission == requiredPermission {
-				hasPermission = true
-				break
+		ctx.Set(authorizationPermissionsKey, permissions)
+
+		for _, requiredPermission := range requiredPermissions {
+			hasPermission := false
+			for _, permission := range permissions {
+				if permission == requiredPermission {
+					hasPermission = true
+					break
+				}
 			}
-		}
 
-		if !hasPermission {
-			err := errors.New("user does not have the required permission")
-			ctx.AbortWithStatusJSON(http.StatusForbidden, errorResponse(err))
-			return
+			if !hasPermission {
+				err := errors.New("user does not have the required permission")
+				logDenial(ctx, user.Username, requiredPermission, err.Error())
+				ctx.AbortWithStatusJSON(http.StatusForbidden, errorResponse(err))
+				return
+			}
 		}
 
 		ctx.Next()
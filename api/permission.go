@@ -19,6 +19,8 @@ type permissionResponse struct {
 	ID          int32     `json:"id"`
 	Name        string    `json:"name"`
 	Description string    `json:"description"`
+	IsSystem    bool      `json:"is_system"`
+	BreakGlass  bool      `json:"break_glass"`
 	CreatedAt   time.Time `json:"created_at"`
 	UpdatedAt   time.Time `json:"updated_at"`
 }
@@ -28,6 +30,8 @@ func newPermissionResponse(permission db.Permission) permissionResponse {
 		ID:          permission.ID,
 		Name:        permission.Name,
 		Description: permission.Description.String,
+		IsSystem:    permission.IsSystem,
+		BreakGlass:  permission.BreakGlass,
 		CreatedAt:   permission.CreatedAt,
 		UpdatedAt:   permission.UpdatedAt,
 	}
@@ -168,6 +172,23 @@ func (server *Server) updatePermission(ctx *gin.Context) {
 		return
 	}
 
+	if reqJSON.Name != "" {
+		permission, err := server.store.GetPermission(ctx, reqURI.ID)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				ctx.JSON(http.StatusNotFound, errorResponse(err))
+				return
+			}
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+
+		if permission.IsSystem && permission.Name != reqJSON.Name {
+			ctx.JSON(http.StatusConflict, errorResponse(db.ErrProtectedPermission))
+			return
+		}
+	}
+
 	arg := db.UpdatePermissionParams{
 		ID: reqURI.ID,
 		Name: sql.NullString{
@@ -201,7 +222,22 @@ func (server *Server) deletePermission(ctx *gin.Context) {
 		return
 	}
 
-	err := server.store.DeletePermission(ctx, req.ID)
+	permission, err := server.store.GetPermission(ctx, req.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if permission.IsSystem {
+		ctx.JSON(http.StatusConflict, errorResponse(db.ErrProtectedPermission))
+		return
+	}
+
+	err = server.store.DeletePermission(ctx, req.ID)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			ctx.JSON(http.StatusNotFound, errorResponse(err))
@@ -0,0 +1,172 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/toannguyen3105/nht-bsihuyen.com-api/token"
+)
+
+const authorizationPermissionsKey = "authorization_permissions"
+
+// policy combines permissions with an ownership predicate on a resource.
+// The owner of a record may act on it when they hold ownPermission (or when
+// ownPermission is empty); anyone holding allPermission may act on every record.
+// Resources without an owner (medicines, roles, permissions, stock and the
+// other catalogue tables) are shared by the whole pharmacy and are guarded with
+// requirePermission instead; accounts are the only owned resource.
+type policy struct {
+	resource      string
+	ownPermission string
+	allPermission string
+}
+
+var accountPolicy = policy{
+	resource:      "account",
+	allPermission: "MANAGE_ALL_ACCOUNTS",
+}
+
+// transferPolicy guards the account money leaves. Only its owner may move it;
+// MANAGE_ALL_ACCOUNTS is for seeing and managing accounts, not for spending
+// from them.
+var transferPolicy = policy{
+	resource: "account",
+}
+
+// ownerLoader returns the username owning the resource addressed by the request.
+// Errors wrapping errInvalidResourceRequest are reported as 400.
+type ownerLoader func(ctx *gin.Context) (string, error)
+
+var errInvalidResourceRequest = errors.New("invalid resource request")
+
+// hasPermission reports whether the user holds the permission. The permissions
+// are loaded once per request and cached on the context.
+func (server *Server) hasPermission(ctx *gin.Context, username string, permission string) (bool, error) {
+	var permissions []string
+	if cached, ok := ctx.Get(authorizationPermissionsKey); ok {
+		permissions = cached.([]string)
+	} else {
+		user, err := server.store.GetUser(ctx, username)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return false, nil
+			}
+			return false, err
+		}
+
+		permissions, err = server.store.GetPermissionsForUser(ctx, user.ID)
+		if err != nil {
+			return false, err
+		}
+		ctx.Set(authorizationPermissionsKey, permissions)
+	}
+
+	for _, p := range permissions {
+		if p == permission {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// authorizeOwner applies the policy to a loaded resource. On failure it aborts
+// the request with a 403 response and returns false.
+func (server *Server) authorizeOwner(ctx *gin.Context, p policy, owner string) bool {
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	if owner == authPayload.Username {
+		if p.ownPermission == "" {
+			return true
+		}
+
+		allowed, err := server.hasPermission(ctx, authPayload.Username, p.ownPermission)
+		if err != nil {
+			ctx.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(err))
+			return false
+		}
+		if allowed {
+			return true
+		}
+	}
+
+	if p.allPermission != "" {
+		allowed, err := server.hasPermission(ctx, authPayload.Username, p.allPermission)
+		if err != nil {
+			ctx.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(err))
+			return false
+		}
+		if allowed {
+			return true
+		}
+	}
+
+	err := fmt.Errorf("%s does not belong to the authenticated user", p.resource)
+	logDenial(ctx, authPayload.Username, p.allPermission, err.Error())
+	ctx.AbortWithStatusJSON(http.StatusForbidden, errorResponse(err))
+	return false
+}
+
+// ownerScope applies the policy to a listing. It returns an invalid owner when
+// the user may see every record, the user's own name when they may only see
+// their own records, and aborts the request with a 403 response otherwise.
+func (server *Server) ownerScope(ctx *gin.Context, p policy) (sql.NullString, bool) {
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	if p.allPermission != "" {
+		allowed, err := server.hasPermission(ctx, authPayload.Username, p.allPermission)
+		if err != nil {
+			ctx.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(err))
+			return sql.NullString{}, false
+		}
+		if allowed {
+			return sql.NullString{}, true
+		}
+	}
+
+	own := sql.NullString{String: authPayload.Username, Valid: true}
+	if p.ownPermission == "" {
+		return own, true
+	}
+
+	allowed, err := server.hasPermission(ctx, authPayload.Username, p.ownPermission)
+	if err != nil {
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(err))
+		return sql.NullString{}, false
+	}
+	if !allowed {
+		err := fmt.Errorf("listing %s records is not allowed", p.resource)
+		logDenial(ctx, authPayload.Username, p.ownPermission, err.Error())
+		ctx.AbortWithStatusJSON(http.StatusForbidden, errorResponse(err))
+		return sql.NullString{}, false
+	}
+	return own, true
+}
+
+// requireOwnership is the middleware form of authorizeOwner for routes that
+// address a single resource.
+func (server *Server) requireOwnership(p policy, loadOwner ownerLoader) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		owner, err := loadOwner(ctx)
+		if err != nil {
+			if errors.Is(err, errInvalidResourceRequest) {
+				ctx.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(err))
+				return
+			}
+			if err == sql.ErrNoRows {
+				ctx.AbortWithStatusJSON(http.StatusNotFound, errorResponse(err))
+				return
+			}
+			ctx.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+
+		if !server.authorizeOwner(ctx, p, owner) {
+			return
+		}
+
+		ctx.Next()
+	}
+}
@@ -0,0 +1,567 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/lib/pq"
+	db "github.com/toannguyen3105/nht-bsihuyen.com-api/db/sqlc"
+	"github.com/toannguyen3105/nht-bsihuyen.com-api/token"
+	"github.com/toannguyen3105/nht-bsihuyen.com-api/utils"
+)
+
+type purchaseOrderItemRequest struct {
+	MedicineID int32          `json:"medicine_id" binding:"required,min=1"`
+	Quantity   int32          `json:"quantity" binding:"required,min=1"`
+	UnitCost   *utils.Decimal `json:"unit_cost" binding:"required,min=0"`
+	Currency   string         `json:"currency" binding:"omitempty,price_currency"`
+}
+
+type createPurchaseOrderRequest struct {
+	SupplierID int32                      `json:"supplier_id" binding:"required,min=1"`
+	Note       string                     `json:"note"`
+	Items      []purchaseOrderItemRequest `json:"items" binding:"dive"`
+}
+
+type purchaseOrderItemResponse struct {
+	ID               int64       `json:"id"`
+	MedicineID       int32       `json:"medicine_id"`
+	MedicineName     string      `json:"medicine_name"`
+	Unit             string      `json:"unit"`
+	Quantity         int32       `json:"quantity"`
+	ReceivedQuantity int32       `json:"received_quantity"`
+	UnitCost         utils.Money `json:"unit_cost"`
+}
+
+type goodsReceiptItemResponse struct {
+	ID                  int64       `json:"id"`
+	PurchaseOrderItemID int64       `json:"purchase_order_item_id"`
+	MedicineID          int32       `json:"medicine_id"`
+	BatchID             int64       `json:"batch_id"`
+	LotNumber           string      `json:"lot_number"`
+	ExpiryDate          *time.Time  `json:"expiry_date"`
+	Quantity            int32       `json:"quantity"`
+	UnitCost            utils.Money `json:"unit_cost"`
+}
+
+type goodsReceiptResponse struct {
+	ID         int32                      `json:"id"`
+	Note       string                     `json:"note"`
+	ReceivedBy string                     `json:"received_by"`
+	ReceivedAt time.Time                  `json:"received_at"`
+	Items      []goodsReceiptItemResponse `json:"items"`
+}
+
+type purchaseOrderResponse struct {
+	ID         int32                       `json:"id"`
+	SupplierID int32                       `json:"supplier_id"`
+	Status     string                      `json:"status"`
+	Note       string                      `json:"note"`
+	CreatedBy  string                      `json:"created_by"`
+	OrderedAt  *time.Time                  `json:"ordered_at"`
+	ReceivedAt *time.Time                  `json:"received_at"`
+	CreatedAt  time.Time                   `json:"created_at"`
+	UpdatedAt  time.Time                   `json:"updated_at"`
+	Items      []purchaseOrderItemResponse `json:"items,omitempty"`
+	Receipts   []goodsReceiptResponse      `json:"receipts,omitempty"`
+}
+
+func newPurchaseOrderResponse(order db.PurchaseOrder) purchaseOrderResponse {
+	rsp := purchaseOrderResponse{
+		ID:         order.ID,
+		SupplierID: order.SupplierID,
+		Status:     order.Status,
+		Note:       order.Note.String,
+		CreatedBy:  order.CreatedBy.String,
+		CreatedAt:  order.CreatedAt,
+		UpdatedAt:  order.UpdatedAt,
+	}
+	if order.OrderedAt.Valid {
+		rsp.OrderedAt = &order.OrderedAt.Time
+	}
+	if order.ReceivedAt.Valid {
+		rsp.ReceivedAt = &order.ReceivedAt.Time
+	}
+	return rsp
+}
+
+func newPurchaseOrderItemsResponse(items []db.ListPurchaseOrderItemsRow) []purchaseOrderItemResponse {
+	rsp := make([]purchaseOrderItemResponse, len(items))
+	for i, item := range items {
+		rsp[i] = purchaseOrderItemResponse{
+			ID:               item.ID,
+			MedicineID:       item.MedicineID,
+			MedicineName:     item.MedicineName,
+			Unit:             item.Unit,
+			Quantity:         item.Quantity,
+			ReceivedQuantity: item.ReceivedQuantity,
+			UnitCost:         utils.NewMoney(item.UnitCost, item.Currency),
+		}
+	}
+	return rsp
+}
+
+func newGoodsReceiptResponse(receipt db.GoodsReceipt) goodsReceiptResponse {
+	return goodsReceiptResponse{
+		ID:         receipt.ID,
+		Note:       receipt.Note.String,
+		ReceivedBy: receipt.ReceivedBy.String,
+		ReceivedAt: receipt.ReceivedAt,
+		Items:      []goodsReceiptItemResponse{},
+	}
+}
+
+// newPurchaseOrderItems converts the requested items, with prices given
+// without a currency in the default one.
+func newPurchaseOrderItems(items []purchaseOrderItemRequest) ([]db.PurchaseOrderItemParams, error) {
+	params := make([]db.PurchaseOrderItemParams, len(items))
+	for i, item := range items {
+		currency := item.Currency
+		if currency == "" {
+			currency = defaultCurrency
+		}
+
+		params[i] = db.PurchaseOrderItemParams{
+			MedicineID: item.MedicineID,
+			Quantity:   item.Quantity,
+			UnitCost:   utils.NewMoney(*item.UnitCost, currency),
+		}
+		if err := params[i].UnitCost.Validate(); err != nil {
+			return nil, err
+		}
+	}
+	return params, nil
+}
+
+// createPurchaseOrder starts a draft purchase order. Its items can change
+// until the order is placed.
+func (server *Server) createPurchaseOrder(ctx *gin.Context) {
+	var req createPurchaseOrderRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	items, err := newPurchaseOrderItems(req.Items)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	result, err := server.store.CreatePurchaseOrderTx(ctx, db.CreatePurchaseOrderTxParams{
+		SupplierID: req.SupplierID,
+		Note:       sql.NullString{String: req.Note, Valid: req.Note != ""},
+		Items:      items,
+		CreatedBy:  sql.NullString{String: authPayload.Username, Valid: true},
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code.Name() == "foreign_key_violation" {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		if errors.Is(err, db.ErrInvalidPurchaseOrder) {
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+		if errors.Is(err, db.ErrMedicineArchived) {
+			ctx.JSON(http.StatusConflict, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	rsp := newPurchaseOrderResponse(result.PurchaseOrder)
+	rsp.Items = newPurchaseOrderItemsResponse(result.Items)
+	ctx.JSON(http.StatusOK, successResponse("Purchase order created successfully", rsp))
+}
+
+type listPurchaseOrdersRequest struct {
+	PageID     int32  `form:"page_id" binding:"required,min=1"`
+	PageSize   int32  `form:"page_size" binding:"required,min=5,max=100"`
+	Status     string `form:"status" binding:"omitempty,oneof=draft ordered partially_received received"`
+	SupplierID int32  `form:"supplier_id" binding:"omitempty,min=1"`
+}
+
+type purchaseOrdersResponse struct {
+	Meta struct {
+		Page       int32 `json:"page"`
+		TotalPages int32 `json:"total_pages"`
+		TotalCount int64 `json:"total_count"`
+	} `json:"meta"`
+	Data []purchaseOrderResponse `json:"data"`
+}
+
+func (server *Server) listPurchaseOrders(ctx *gin.Context) {
+	var req listPurchaseOrdersRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	status := sql.NullString{String: req.Status, Valid: req.Status != ""}
+	supplierID := sql.NullInt32{Int32: req.SupplierID, Valid: req.SupplierID != 0}
+
+	orders, err := server.store.ListPurchaseOrders(ctx, db.ListPurchaseOrdersParams{
+		Status:     status,
+		SupplierID: supplierID,
+		Limit:      req.PageSize,
+		Offset:     (req.PageID - 1) * req.PageSize,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	totalCount, err := server.store.CountPurchaseOrders(ctx, db.CountPurchaseOrdersParams{
+		Status:     status,
+		SupplierID: supplierID,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	totalPages := int32(totalCount) / req.PageSize
+	if int32(totalCount)%req.PageSize != 0 {
+		totalPages++
+	}
+
+	rsp := purchaseOrdersResponse{
+		Meta: struct {
+			Page       int32 `json:"page"`
+			TotalPages int32 `json:"total_pages"`
+			TotalCount int64 `json:"total_count"`
+		}{
+			Page:       req.PageID,
+			TotalPages: totalPages,
+			TotalCount: totalCount,
+		},
+		Data: make([]purchaseOrderResponse, len(orders)),
+	}
+	for i, order := range orders {
+		rsp.Data[i] = newPurchaseOrderResponse(order)
+	}
+
+	ctx.JSON(http.StatusOK, successResponse("Purchase orders retrieved successfully", rsp))
+}
+
+type getPurchaseOrderRequest struct {
+	ID int32 `uri:"id" binding:"required,min=1"`
+}
+
+// getPurchaseOrder returns a purchase order with its items and the goods
+// received against it so far.
+func (server *Server) getPurchaseOrder(ctx *gin.Context) {
+	var req getPurchaseOrderRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	order, err := server.store.GetPurchaseOrder(ctx, req.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	items, err := server.store.ListPurchaseOrderItems(ctx, order.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	receipts, err := server.store.ListGoodsReceipts(ctx, order.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	rsp := newPurchaseOrderResponse(order)
+	rsp.Items = newPurchaseOrderItemsResponse(items)
+	rsp.Receipts = make([]goodsReceiptResponse, len(receipts))
+
+	if len(receipts) > 0 {
+		ids := make([]int32, len(receipts))
+		byID := make(map[int32]int, len(receipts))
+		for i, receipt := range receipts {
+			ids[i] = receipt.ID
+			byID[receipt.ID] = i
+			rsp.Receipts[i] = newGoodsReceiptResponse(receipt)
+		}
+
+		receiptItems, err := server.store.ListGoodsReceiptItems(ctx, ids)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+
+		for _, item := range receiptItems {
+			receipt := &rsp.Receipts[byID[item.GoodsReceiptID]]
+			itemRsp := goodsReceiptItemResponse{
+				ID:                  item.ID,
+				PurchaseOrderItemID: item.PurchaseOrderItemID,
+				MedicineID:          item.MedicineID,
+				BatchID:             item.BatchID,
+				LotNumber:           item.LotNumber,
+				Quantity:            item.Quantity,
+				UnitCost:            utils.NewMoney(item.UnitCost, item.Currency),
+			}
+			if item.ExpiryDate.Valid {
+				itemRsp.ExpiryDate = &item.ExpiryDate.Time
+			}
+			receipt.Items = append(receipt.Items, itemRsp)
+		}
+	}
+
+	ctx.JSON(http.StatusOK, successResponse("Purchase order retrieved successfully", rsp))
+}
+
+type updatePurchaseOrderRequest struct {
+	SupplierID *int32                     `json:"supplier_id" binding:"omitempty,min=1"`
+	Note       *string                    `json:"note"`
+	Items      []purchaseOrderItemRequest `json:"items" binding:"omitempty,dive"`
+}
+
+// updatePurchaseOrder changes a draft purchase order. Items, when given,
+// replace all of the items of the order.
+func (server *Server) updatePurchaseOrder(ctx *gin.Context) {
+	var reqURI getPurchaseOrderRequest
+	if err := ctx.ShouldBindUri(&reqURI); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req updatePurchaseOrderRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	arg := db.UpdatePurchaseOrderTxParams{
+		ID: reqURI.ID,
+	}
+	if req.SupplierID != nil {
+		arg.SupplierID = sql.NullInt32{Int32: *req.SupplierID, Valid: true}
+	}
+	if req.Note != nil {
+		arg.Note = sql.NullString{String: *req.Note, Valid: true}
+	}
+	if req.Items != nil {
+		items, err := newPurchaseOrderItems(req.Items)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+		arg.Items = items
+	}
+
+	result, err := server.store.UpdatePurchaseOrderTx(ctx, arg)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code.Name() == "foreign_key_violation" {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		if errors.Is(err, db.ErrInvalidPurchaseOrder) {
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+		if errors.Is(err, db.ErrPurchaseOrderStatus) || errors.Is(err, db.ErrMedicineArchived) {
+			ctx.JSON(http.StatusConflict, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	rsp := newPurchaseOrderResponse(result.PurchaseOrder)
+	rsp.Items = newPurchaseOrderItemsResponse(result.Items)
+	ctx.JSON(http.StatusOK, successResponse("Purchase order updated successfully", rsp))
+}
+
+// placePurchaseOrder marks a draft purchase order as sent to the supplier,
+// after which goods can be received against it.
+func (server *Server) placePurchaseOrder(ctx *gin.Context) {
+	var req getPurchaseOrderRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	order, err := server.store.PlacePurchaseOrderTx(ctx, req.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		if errors.Is(err, db.ErrInvalidPurchaseOrder) {
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+		if errors.Is(err, db.ErrPurchaseOrderStatus) {
+			ctx.JSON(http.StatusConflict, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, successResponse("Purchase order placed successfully", newPurchaseOrderResponse(order)))
+}
+
+// deletePurchaseOrder deletes a draft purchase order. Orders that have been
+// placed are kept.
+func (server *Server) deletePurchaseOrder(ctx *gin.Context) {
+	var req getPurchaseOrderRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	deleted, err := server.store.DeletePurchaseOrder(ctx, req.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if deleted == 0 {
+		_, err := server.store.GetPurchaseOrder(ctx, req.ID)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				ctx.JSON(http.StatusNotFound, errorResponse(err))
+				return
+			}
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+
+		ctx.JSON(http.StatusConflict, errorResponse(db.ErrPurchaseOrderStatus))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, successResponse("Purchase order deleted successfully", nil))
+}
+
+type receiveGoodsItemRequest struct {
+	ItemID     int64          `json:"item_id" binding:"required,min=1"`
+	Quantity   int32          `json:"quantity" binding:"required,min=1"`
+	LotNumber  string         `json:"lot_number" binding:"required,max=100"`
+	ExpiryDate string         `json:"expiry_date" binding:"required"`
+	UnitCost   *utils.Decimal `json:"unit_cost" binding:"omitempty,min=0"`
+}
+
+type receiveGoodsRequest struct {
+	Note  string                    `json:"note"`
+	Items []receiveGoodsItemRequest `json:"items" binding:"required,min=1,dive"`
+	// Witness is needed when the delivery has controlled medicines.
+	Witness *witnessRequest `json:"witness"`
+}
+
+type receiveGoodsResponse struct {
+	PurchaseOrder purchaseOrderResponse `json:"purchase_order"`
+	Receipt       goodsReceiptResponse  `json:"receipt"`
+}
+
+// receiveGoods records a delivery against a placed purchase order. Each line
+// goes into stock as a receipt into its lot, with the cost price paid; lines
+// without a unit cost were paid at the ordered cost. A delivery may cover
+// part of the order, but never more than is still outstanding.
+func (server *Server) receiveGoods(ctx *gin.Context) {
+	var reqURI getPurchaseOrderRequest
+	if err := ctx.ShouldBindUri(&reqURI); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req receiveGoodsRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	witnessedBy, ok := server.authenticateWitness(ctx, req.Witness)
+	if !ok {
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	arg := db.ReceiveGoodsTxParams{
+		PurchaseOrderID: reqURI.ID,
+		Note:            sql.NullString{String: req.Note, Valid: req.Note != ""},
+		Items:           make([]db.ReceiveGoodsItemParams, len(req.Items)),
+		ReceivedBy:      sql.NullString{String: authPayload.Username, Valid: true},
+		WitnessedBy:     witnessedBy,
+	}
+	for i, item := range req.Items {
+		expiryDate, err := time.Parse(time.DateOnly, item.ExpiryDate)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+
+		arg.Items[i] = db.ReceiveGoodsItemParams{
+			PurchaseOrderItemID: item.ItemID,
+			Quantity:            item.Quantity,
+			LotNumber:           item.LotNumber,
+			ExpiryDate:          expiryDate,
+		}
+		if item.UnitCost != nil {
+			arg.Items[i].UnitCost = utils.NullDecimal{Decimal: *item.UnitCost, Valid: true}
+		}
+	}
+
+	result, err := server.store.ReceiveGoodsTx(ctx, arg)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		if errors.Is(err, db.ErrInvalidPurchaseOrder) || errors.Is(err, db.ErrInvalidStockMovement) || errors.Is(err, db.ErrWitnessRequired) {
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+		if errors.Is(err, db.ErrPurchaseOrderStatus) || errors.Is(err, db.ErrOverReceipt) || errors.Is(err, db.ErrBatchExpiryMismatch) || errors.Is(err, db.ErrMedicineArchived) {
+			ctx.JSON(http.StatusConflict, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	rsp := receiveGoodsResponse{
+		PurchaseOrder: newPurchaseOrderResponse(result.PurchaseOrder),
+		Receipt:       newGoodsReceiptResponse(result.Receipt),
+	}
+	for i, item := range result.Items {
+		rsp.Receipt.Items = append(rsp.Receipt.Items, goodsReceiptItemResponse{
+			ID:                  item.ID,
+			PurchaseOrderItemID: item.PurchaseOrderItemID,
+			MedicineID:          result.Movements[i].Medicine.ID,
+			BatchID:             item.BatchID,
+			LotNumber:           arg.Items[i].LotNumber,
+			ExpiryDate:          &arg.Items[i].ExpiryDate,
+			Quantity:            item.Quantity,
+			UnitCost:            utils.NewMoney(item.UnitCost, item.Currency),
+		})
+	}
+
+	ctx.JSON(http.StatusOK, successResponse("Goods received successfully", rsp))
+}
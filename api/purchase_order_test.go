@@ -0,0 +1,417 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+	mockdb "github.com/toannguyen3105/nht-bsihuyen.com-api/db/mock"
+	db "github.com/toannguyen3105/nht-bsihuyen.com-api/db/sqlc"
+	"github.com/toannguyen3105/nht-bsihuyen.com-api/utils"
+)
+
+func TestCreatePurchaseOrderAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	medicine := randomMedicine()
+	order := randomPurchaseOrder(db.PurchaseOrderDraft)
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{
+				"supplier_id": order.SupplierID,
+				"items":       []gin.H{{"medicine_id": medicine.ID, "quantity": 100, "unit_cost": "1500"}},
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.CreatePurchaseOrderTxParams{
+					SupplierID: order.SupplierID,
+					Items: []db.PurchaseOrderItemParams{{
+						MedicineID: medicine.ID,
+						Quantity:   100,
+						UnitCost:   utils.NewMoney(utils.MustParseDecimal("1500"), utils.VND),
+					}},
+					CreatedBy: sql.NullString{String: user.Username, Valid: true},
+				}
+				store.EXPECT().
+					CreatePurchaseOrderTx(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(db.PurchaseOrderTxResult{
+						PurchaseOrder: order,
+						Items: []db.ListPurchaseOrderItemsRow{{
+							ID:           1,
+							MedicineID:   medicine.ID,
+							Quantity:     100,
+							UnitCost:     utils.MustParseDecimal("1500"),
+							Currency:     utils.VND,
+							MedicineName: medicine.Name,
+						}},
+					}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var response struct {
+					Data purchaseOrderResponse `json:"data"`
+				}
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+				require.Equal(t, db.PurchaseOrderDraft, response.Data.Status)
+				require.Len(t, response.Data.Items, 1)
+			},
+		},
+		{
+			name: "InvalidCost",
+			body: gin.H{
+				"supplier_id": order.SupplierID,
+				"items":       []gin.H{{"medicine_id": medicine.ID, "quantity": 100, "unit_cost": "1500.50"}},
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreatePurchaseOrderTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "UnknownSupplier",
+			body: gin.H{"supplier_id": 99},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreatePurchaseOrderTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.PurchaseOrderTxResult{}, &pq.Error{Code: "23503"})
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name: "ArchivedMedicine",
+			body: gin.H{
+				"supplier_id": order.SupplierID,
+				"items":       []gin.H{{"medicine_id": medicine.ID, "quantity": 100, "unit_cost": "1500"}},
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreatePurchaseOrderTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.PurchaseOrderTxResult{}, db.ErrMedicineArchived)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().
+				GetUser(gomock.Any(), gomock.Eq(user.Username)).
+				Times(1).
+				Return(user, nil)
+			store.EXPECT().
+				GetPermissionsForUser(gomock.Any(), gomock.Eq(user.ID)).
+				Times(1).
+				Return([]string{"VIEW_SCREEN_MEDICINE"}, nil)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			request, err := http.NewRequest(http.MethodPost, "/purchase-orders", bytes.NewReader(data))
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestReceiveGoodsAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	medicine := randomMedicine()
+	order := randomPurchaseOrder(db.PurchaseOrderPartiallyReceived)
+	expiryDate := time.Date(2030, 1, 31, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{
+				"items": []gin.H{{"item_id": 1, "quantity": 40, "lot_number": "LOT-1", "expiry_date": "2030-01-31", "unit_cost": "1400"}},
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.ReceiveGoodsTxParams{
+					PurchaseOrderID: order.ID,
+					Items: []db.ReceiveGoodsItemParams{{
+						PurchaseOrderItemID: 1,
+						Quantity:            40,
+						LotNumber:           "LOT-1",
+						ExpiryDate:          expiryDate,
+						UnitCost:            utils.NullDecimal{Decimal: utils.MustParseDecimal("1400"), Valid: true},
+					}},
+					ReceivedBy: sql.NullString{String: user.Username, Valid: true},
+				}
+				store.EXPECT().
+					ReceiveGoodsTx(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(db.ReceiveGoodsTxResult{
+						PurchaseOrder: order,
+						Receipt:       db.GoodsReceipt{ID: 1, PurchaseOrderID: order.ID},
+						Items: []db.GoodsReceiptItem{{
+							ID:                  1,
+							PurchaseOrderItemID: 1,
+							BatchID:             7,
+							Quantity:            40,
+							UnitCost:            utils.MustParseDecimal("1400"),
+							Currency:            utils.VND,
+						}},
+						Movements: []db.StockMovementTxResult{{Medicine: medicine}},
+					}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var response struct {
+					Data receiveGoodsResponse `json:"data"`
+				}
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+				require.Equal(t, db.PurchaseOrderPartiallyReceived, response.Data.PurchaseOrder.Status)
+				require.Len(t, response.Data.Receipt.Items, 1)
+				require.Equal(t, int64(7), response.Data.Receipt.Items[0].BatchID)
+				require.Equal(t, medicine.ID, response.Data.Receipt.Items[0].MedicineID)
+			},
+		},
+		{
+			name: "OverReceipt",
+			body: gin.H{
+				"items": []gin.H{{"item_id": 1, "quantity": 500, "lot_number": "LOT-1", "expiry_date": "2030-01-31"}},
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ReceiveGoodsTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.ReceiveGoodsTxResult{}, db.ErrOverReceipt)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name: "NotOrdered",
+			body: gin.H{
+				"items": []gin.H{{"item_id": 1, "quantity": 40, "lot_number": "LOT-1", "expiry_date": "2030-01-31"}},
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ReceiveGoodsTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.ReceiveGoodsTxResult{}, db.ErrPurchaseOrderStatus)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name: "ItemNotOnOrder",
+			body: gin.H{
+				"items": []gin.H{{"item_id": 99, "quantity": 40, "lot_number": "LOT-1", "expiry_date": "2030-01-31"}},
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ReceiveGoodsTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.ReceiveGoodsTxResult{}, db.ErrInvalidPurchaseOrder)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "InvalidExpiryDate",
+			body: gin.H{
+				"items": []gin.H{{"item_id": 1, "quantity": 40, "lot_number": "LOT-1", "expiry_date": "31/01/2030"}},
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ReceiveGoodsTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "NoItems",
+			body: gin.H{"items": []gin.H{}},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ReceiveGoodsTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().
+				GetUser(gomock.Any(), gomock.Eq(user.Username)).
+				Times(1).
+				Return(user, nil)
+			store.EXPECT().
+				GetPermissionsForUser(gomock.Any(), gomock.Eq(user.ID)).
+				Times(1).
+				Return([]string{"VIEW_SCREEN_MEDICINE"}, nil)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/purchase-orders/%d/receipts", order.ID)
+			request, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestDeletePurchaseOrderAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	order := randomPurchaseOrder(db.PurchaseOrderOrdered)
+
+	testCases := []struct {
+		name          string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					DeletePurchaseOrder(gomock.Any(), gomock.Eq(order.ID)).
+					Times(1).
+					Return(int64(1), nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "NotDraft",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					DeletePurchaseOrder(gomock.Any(), gomock.Eq(order.ID)).
+					Times(1).
+					Return(int64(0), nil)
+				store.EXPECT().
+					GetPurchaseOrder(gomock.Any(), gomock.Eq(order.ID)).
+					Times(1).
+					Return(order, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name: "NotFound",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					DeletePurchaseOrder(gomock.Any(), gomock.Eq(order.ID)).
+					Times(1).
+					Return(int64(0), nil)
+				store.EXPECT().
+					GetPurchaseOrder(gomock.Any(), gomock.Eq(order.ID)).
+					Times(1).
+					Return(db.PurchaseOrder{}, sql.ErrNoRows)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().
+				GetUser(gomock.Any(), gomock.Eq(user.Username)).
+				Times(1).
+				Return(user, nil)
+			store.EXPECT().
+				GetPermissionsForUser(gomock.Any(), gomock.Eq(user.ID)).
+				Times(1).
+				Return([]string{"VIEW_SCREEN_MEDICINE"}, nil)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/purchase-orders/%d", order.ID)
+			request, err := http.NewRequest(http.MethodDelete, url, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func randomPurchaseOrder(status string) db.PurchaseOrder {
+	return db.PurchaseOrder{
+		ID:         int32(utils.RandomInt(1, 1000)),
+		SupplierID: int32(utils.RandomInt(1, 1000)),
+		Status:     status,
+		CreatedAt:  time.Now(),
+		UpdatedAt:  time.Now(),
+	}
+}
@@ -0,0 +1,200 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/lib/pq"
+	db "github.com/toannguyen3105/nht-bsihuyen.com-api/db/sqlc"
+	"github.com/toannguyen3105/nht-bsihuyen.com-api/token"
+	"github.com/toannguyen3105/nht-bsihuyen.com-api/utils"
+)
+
+const (
+	defaultReorderWindowDays = 30
+	defaultReorderSafetyDays = 7
+	defaultReorderCoverDays  = 30
+)
+
+var errNothingToReorder = errors.New("none of the medicines needs ordering")
+
+// reorderPlanRequest holds the planning settings shared by the report and its
+// export. Omitted settings take their defaults.
+type reorderPlanRequest struct {
+	WindowDays *int32 `form:"window_days" json:"window_days" binding:"omitempty,min=1,max=365"`
+	SafetyDays *int32 `form:"safety_days" json:"safety_days" binding:"omitempty,min=0,max=365"`
+	CoverDays  *int32 `form:"cover_days" json:"cover_days" binding:"omitempty,min=0,max=365"`
+}
+
+func (req reorderPlanRequest) params() db.ReorderSuggestionsParams {
+	arg := db.ReorderSuggestionsParams{
+		WindowDays: defaultReorderWindowDays,
+		SafetyDays: defaultReorderSafetyDays,
+		CoverDays:  defaultReorderCoverDays,
+	}
+	if req.WindowDays != nil {
+		arg.WindowDays = *req.WindowDays
+	}
+	if req.SafetyDays != nil {
+		arg.SafetyDays = *req.SafetyDays
+	}
+	if req.CoverDays != nil {
+		arg.CoverDays = *req.CoverDays
+	}
+	return arg
+}
+
+type listReorderSuggestionsRequest struct {
+	reorderPlanRequest
+	SupplierID int32 `form:"supplier_id" binding:"omitempty,min=1"`
+	All        bool  `form:"all"`
+}
+
+type reorderSuggestionResponse struct {
+	MedicineID              int32       `json:"medicine_id"`
+	MedicineName            string      `json:"medicine_name"`
+	Unit                    string      `json:"unit"`
+	Stock                   int32       `json:"stock"`
+	OnOrder                 int32       `json:"on_order"`
+	Consumed                int32       `json:"consumed"`
+	AverageDailyConsumption float64     `json:"average_daily_consumption"`
+	DaysLeft                *int32      `json:"days_left"`
+	SupplierID              *int32      `json:"supplier_id"`
+	SupplierName            *string     `json:"supplier_name"`
+	LeadTimeDays            int32       `json:"lead_time_days"`
+	SafetyStock             int32       `json:"safety_stock"`
+	ReorderPoint            int32       `json:"reorder_point"`
+	SuggestedQuantity       int32       `json:"suggested_quantity"`
+	UnitCost                utils.Money `json:"unit_cost"`
+}
+
+func newReorderSuggestionResponse(suggestion db.ReorderSuggestion) reorderSuggestionResponse {
+	rsp := reorderSuggestionResponse{
+		MedicineID:              suggestion.MedicineID,
+		MedicineName:            suggestion.MedicineName,
+		Unit:                    suggestion.Unit,
+		Stock:                   suggestion.Stock,
+		OnOrder:                 suggestion.OnOrder,
+		Consumed:                suggestion.Consumed,
+		AverageDailyConsumption: suggestion.AverageDailyConsumption,
+		LeadTimeDays:            suggestion.LeadTimeDays,
+		SafetyStock:             suggestion.SafetyStock,
+		ReorderPoint:            suggestion.ReorderPoint,
+		SuggestedQuantity:       suggestion.SuggestedQuantity,
+		UnitCost:                suggestion.UnitCost,
+	}
+	if suggestion.DaysLeft.Valid {
+		rsp.DaysLeft = &suggestion.DaysLeft.Int32
+	}
+	if suggestion.SupplierID.Valid {
+		rsp.SupplierID = &suggestion.SupplierID.Int32
+	}
+	if suggestion.SupplierName.Valid {
+		rsp.SupplierName = &suggestion.SupplierName.String
+	}
+	return rsp
+}
+
+// listReorderSuggestions reports what to order, most urgent first, from the
+// average daily consumption over window_days of dispensing. all=true also
+// lists the medicines that need no order yet.
+func (server *Server) listReorderSuggestions(ctx *gin.Context) {
+	var req listReorderSuggestionsRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	arg := req.params()
+	arg.All = req.All
+	if req.SupplierID != 0 {
+		arg.SupplierID = sql.NullInt32{Int32: req.SupplierID, Valid: true}
+	}
+
+	suggestions, err := server.store.ReorderSuggestions(ctx, arg)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	rsp := make([]reorderSuggestionResponse, len(suggestions))
+	for i, suggestion := range suggestions {
+		rsp[i] = newReorderSuggestionResponse(suggestion)
+	}
+
+	ctx.JSON(http.StatusOK, successResponse("Reorder suggestions retrieved successfully", rsp))
+}
+
+type exportReorderSuggestionsRequest struct {
+	reorderPlanRequest
+	SupplierID  int32   `json:"supplier_id" binding:"required,min=1"`
+	MedicineIDs []int32 `json:"medicine_ids" binding:"dive,min=1"`
+	Note        string  `json:"note"`
+}
+
+// exportReorderSuggestions turns the suggestions for a supplier into a draft
+// purchase order, to be checked and placed as usual. Without medicine_ids it
+// takes the medicines last ordered from the supplier. Unit costs are those
+// paid on the latest delivery; medicines never received come in at zero.
+func (server *Server) exportReorderSuggestions(ctx *gin.Context) {
+	var req exportReorderSuggestionsRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	arg := req.params()
+	arg.SupplierID = sql.NullInt32{Int32: req.SupplierID, Valid: true}
+	arg.MedicineIDs = req.MedicineIDs
+
+	suggestions, err := server.store.ReorderSuggestions(ctx, arg)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if len(suggestions) == 0 {
+		ctx.JSON(http.StatusConflict, errorResponse(errNothingToReorder))
+		return
+	}
+
+	items := make([]db.PurchaseOrderItemParams, len(suggestions))
+	for i, suggestion := range suggestions {
+		items[i] = db.PurchaseOrderItemParams{
+			MedicineID: suggestion.MedicineID,
+			Quantity:   suggestion.SuggestedQuantity,
+			UnitCost:   suggestion.UnitCost,
+		}
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	result, err := server.store.CreatePurchaseOrderTx(ctx, db.CreatePurchaseOrderTxParams{
+		SupplierID: req.SupplierID,
+		Note:       sql.NullString{String: req.Note, Valid: req.Note != ""},
+		Items:      items,
+		CreatedBy:  sql.NullString{String: authPayload.Username, Valid: true},
+	})
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code.Name() == "foreign_key_violation" {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	rsp := newPurchaseOrderResponse(result.PurchaseOrder)
+	rsp.Items = newPurchaseOrderItemsResponse(result.Items)
+	ctx.JSON(http.StatusOK, successResponse("Purchase order created successfully", rsp))
+}
@@ -0,0 +1,307 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	mockdb "github.com/toannguyen3105/nht-bsihuyen.com-api/db/mock"
+	db "github.com/toannguyen3105/nht-bsihuyen.com-api/db/sqlc"
+	"github.com/toannguyen3105/nht-bsihuyen.com-api/utils"
+)
+
+func randomReorderSuggestion(medicine db.Medicine) db.ReorderSuggestion {
+	return db.ReorderSuggestion{
+		MedicineID:              medicine.ID,
+		MedicineName:            medicine.Name,
+		Unit:                    medicine.Unit,
+		Stock:                   20,
+		Consumed:                60,
+		AverageDailyConsumption: 2,
+		DaysLeft:                sql.NullInt32{Int32: 10, Valid: true},
+		LeadTimeDays:            db.DefaultLeadTimeDays,
+		SafetyStock:             14,
+		ReorderPoint:            28,
+		SuggestedQuantity:       68,
+		UnitCost:                utils.NewMoney(utils.MustParseDecimal("1500"), utils.VND),
+	}
+}
+
+func TestListReorderSuggestionsAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	suggestion := randomReorderSuggestion(randomMedicine())
+
+	testCases := []struct {
+		name          string
+		query         string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "Defaults",
+			query: "",
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.ReorderSuggestionsParams{
+					WindowDays: defaultReorderWindowDays,
+					SafetyDays: defaultReorderSafetyDays,
+					CoverDays:  defaultReorderCoverDays,
+				}
+				store.EXPECT().
+					ReorderSuggestions(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return([]db.ReorderSuggestion{suggestion}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var response struct {
+					Data []reorderSuggestionResponse `json:"data"`
+				}
+				err := json.Unmarshal(recorder.Body.Bytes(), &response)
+				require.NoError(t, err)
+				require.Len(t, response.Data, 1)
+				require.Equal(t, int32(10), *response.Data[0].DaysLeft)
+				require.Nil(t, response.Data[0].SupplierID)
+				require.Equal(t, int32(68), response.Data[0].SuggestedQuantity)
+			},
+		},
+		{
+			name:  "Settings",
+			query: "?window_days=90&safety_days=0&cover_days=14&supplier_id=3&all=true",
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.ReorderSuggestionsParams{
+					WindowDays: 90,
+					SafetyDays: 0,
+					CoverDays:  14,
+					SupplierID: sql.NullInt32{Int32: 3, Valid: true},
+					All:        true,
+				}
+				store.EXPECT().
+					ReorderSuggestions(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return([]db.ReorderSuggestion{}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:  "InvalidWindow",
+			query: "?window_days=0",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ReorderSuggestions(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:  "SupplierNotFound",
+			query: "?supplier_id=99",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ReorderSuggestions(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, sql.ErrNoRows)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().
+				GetUser(gomock.Any(), gomock.Eq(user.Username)).
+				Times(1).
+				Return(user, nil)
+			store.EXPECT().
+				GetPermissionsForUser(gomock.Any(), gomock.Eq(user.ID)).
+				Times(1).
+				Return([]string{"VIEW_SCREEN_MEDICINE"}, nil)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			request, err := http.NewRequest(http.MethodGet, "/reorder-suggestions"+tc.query, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestExportReorderSuggestionsAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	medicine := randomMedicine()
+	suggestion := randomReorderSuggestion(medicine)
+	order := randomPurchaseOrder(db.PurchaseOrderDraft)
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{
+				"supplier_id":  order.SupplierID,
+				"medicine_ids": []int32{medicine.ID},
+				"cover_days":   60,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ReorderSuggestions(gomock.Any(), gomock.Eq(db.ReorderSuggestionsParams{
+						WindowDays:  defaultReorderWindowDays,
+						SafetyDays:  defaultReorderSafetyDays,
+						CoverDays:   60,
+						SupplierID:  sql.NullInt32{Int32: order.SupplierID, Valid: true},
+						MedicineIDs: []int32{medicine.ID},
+					})).
+					Times(1).
+					Return([]db.ReorderSuggestion{suggestion}, nil)
+
+				arg := db.CreatePurchaseOrderTxParams{
+					SupplierID: order.SupplierID,
+					Items: []db.PurchaseOrderItemParams{{
+						MedicineID: medicine.ID,
+						Quantity:   suggestion.SuggestedQuantity,
+						UnitCost:   suggestion.UnitCost,
+					}},
+					CreatedBy: sql.NullString{String: user.Username, Valid: true},
+				}
+				store.EXPECT().
+					CreatePurchaseOrderTx(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(db.PurchaseOrderTxResult{
+						PurchaseOrder: order,
+						Items: []db.ListPurchaseOrderItemsRow{{
+							ID:              1,
+							PurchaseOrderID: order.ID,
+							MedicineID:      medicine.ID,
+							Quantity:        suggestion.SuggestedQuantity,
+							UnitCost:        suggestion.UnitCost.Amount,
+							Currency:        suggestion.UnitCost.Currency,
+							MedicineName:    medicine.Name,
+							Unit:            medicine.Unit,
+						}},
+					}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var response struct {
+					Data purchaseOrderResponse `json:"data"`
+				}
+				err := json.Unmarshal(recorder.Body.Bytes(), &response)
+				require.NoError(t, err)
+				require.Equal(t, db.PurchaseOrderDraft, response.Data.Status)
+				require.Len(t, response.Data.Items, 1)
+				require.Equal(t, suggestion.SuggestedQuantity, response.Data.Items[0].Quantity)
+			},
+		},
+		{
+			name: "NothingToReorder",
+			body: gin.H{
+				"supplier_id": order.SupplierID,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ReorderSuggestions(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return([]db.ReorderSuggestion{}, nil)
+				store.EXPECT().
+					CreatePurchaseOrderTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name: "SupplierNotFound",
+			body: gin.H{
+				"supplier_id": 99,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ReorderSuggestions(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, sql.ErrNoRows)
+				store.EXPECT().
+					CreatePurchaseOrderTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name: "MissingSupplier",
+			body: gin.H{
+				"medicine_ids": []int32{medicine.ID},
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ReorderSuggestions(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().
+				GetUser(gomock.Any(), gomock.Eq(user.Username)).
+				Times(1).
+				Return(user, nil)
+			store.EXPECT().
+				GetPermissionsForUser(gomock.Any(), gomock.Eq(user.ID)).
+				Times(1).
+				Return([]string{"VIEW_SCREEN_MEDICINE"}, nil)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			request, err := http.NewRequest(http.MethodPost, "/reorder-suggestions/purchase-order", bytes.NewReader(data))
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
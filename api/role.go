@@ -2,6 +2,7 @@ package api
 
 import (
 	"database/sql"
+	"errors"
 	"net/http"
 	"time"
 
@@ -19,6 +20,7 @@ type roleResponse struct {
 	ID          int32     `json:"id"`
 	Name        string    `json:"name"`
 	Description string    `json:"description"`
+	IsSystem    bool      `json:"is_system"`
 	CreatedAt   time.Time `json:"created_at"`
 	UpdatedAt   time.Time `json:"updated_at"`
 }
@@ -28,6 +30,7 @@ func newRoleResponse(role db.Role) roleResponse {
 		ID:          role.ID,
 		Name:        role.Name,
 		Description: role.Description.String,
+		IsSystem:    role.IsSystem,
 		CreatedAt:   role.CreatedAt,
 		UpdatedAt:   role.UpdatedAt,
 	}
@@ -93,13 +96,34 @@ type listRolesRequest struct {
 	PageSize int32 `form:"page_size" binding:"required,min=10,max=100"`
 }
 
+type roleSummaryResponse struct {
+	roleResponse
+	MemberCount     int64 `json:"member_count"`
+	PermissionCount int64 `json:"permission_count"`
+}
+
+func newRoleSummaryResponse(role db.ListRolesRow) roleSummaryResponse {
+	return roleSummaryResponse{
+		roleResponse: newRoleResponse(db.Role{
+			ID:          role.ID,
+			Name:        role.Name,
+			Description: role.Description,
+			CreatedAt:   role.CreatedAt,
+			UpdatedAt:   role.UpdatedAt,
+			IsSystem:    role.IsSystem,
+		}),
+		MemberCount:     role.MemberCount,
+		PermissionCount: role.PermissionCount,
+	}
+}
+
 type rolesResponse struct {
 	Meta struct {
 		Page       int32 `json:"page"`
 		TotalPages int32 `json:"total_pages"`
 		TotalCount int64 `json:"total_count"`
 	} `json:"meta"`
-	Data []roleResponse `json:"data"`
+	Data []roleSummaryResponse `json:"data"`
 }
 
 func (server *Server) listRoles(ctx *gin.Context) {
@@ -141,10 +165,10 @@ func (server *Server) listRoles(ctx *gin.Context) {
 			TotalPages: totalPages,
 			TotalCount: totalCount,
 		},
-		Data: make([]roleResponse, len(roles)),
+		Data: make([]roleSummaryResponse, len(roles)),
 	}
 	for i, role := range roles {
-		rsp.Data[i] = newRoleResponse(role)
+		rsp.Data[i] = newRoleSummaryResponse(role)
 	}
 
 	ctx.JSON(http.StatusOK, successResponse("Roles retrieved successfully", rsp))
@@ -168,6 +192,23 @@ func (server *Server) updateRole(ctx *gin.Context) {
 		return
 	}
 
+	if reqJSON.Name != "" {
+		role, err := server.store.GetRole(ctx, reqURI.ID)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				ctx.JSON(http.StatusNotFound, errorResponse(err))
+				return
+			}
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+
+		if role.IsSystem && role.Name != reqJSON.Name {
+			ctx.JSON(http.StatusConflict, errorResponse(db.ErrProtectedRole))
+			return
+		}
+	}
+
 	arg := db.UpdateRoleParams{
 		ID: reqURI.ID,
 		Name: sql.NullString{
@@ -201,15 +242,218 @@ func (server *Server) deleteRole(ctx *gin.Context) {
 		return
 	}
 
-	err := server.store.DeleteRole(ctx, req.ID)
+	err := server.store.DeleteRoleTx(ctx, req.ID)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			ctx.JSON(http.StatusNotFound, errorResponse(err))
 			return
 		}
+		if errors.Is(err, db.ErrProtectedRole) || errors.Is(err, db.ErrLastAdmin) {
+			ctx.JSON(http.StatusConflict, errorResponse(err))
+			return
+		}
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
 
 	ctx.JSON(http.StatusOK, successResponse("Role deleted successfully", nil))
 }
+
+type listRoleDetailsRequest struct {
+	PageID   int32 `form:"page_id" binding:"required,min=1"`
+	PageSize int32 `form:"page_size" binding:"required,min=10,max=100"`
+}
+
+type rolePermissionDetailResponse struct {
+	permissionResponse
+	GrantedAt time.Time `json:"granted_at"`
+}
+
+type rolePermissionDetailsResponse struct {
+	Meta struct {
+		Page       int32 `json:"page"`
+		TotalPages int32 `json:"total_pages"`
+		TotalCount int64 `json:"total_count"`
+	} `json:"meta"`
+	Data []rolePermissionDetailResponse `json:"data"`
+}
+
+func (server *Server) listPermissionsForRole(ctx *gin.Context) {
+	var reqURI getRoleRequest
+	if err := ctx.ShouldBindUri(&reqURI); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req listRoleDetailsRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	_, err := server.store.GetRole(ctx, reqURI.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	arg := db.ListPermissionsForRoleParams{
+		RoleID: reqURI.ID,
+		Limit:  req.PageSize,
+		Offset: (req.PageID - 1) * req.PageSize,
+	}
+
+	permissions, err := server.store.ListPermissionsForRole(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	totalCount, err := server.store.CountPermissionsForRole(ctx, reqURI.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	totalPages := int32(totalCount) / req.PageSize
+	if int32(totalCount)%req.PageSize != 0 {
+		totalPages++
+	}
+
+	rsp := rolePermissionDetailsResponse{
+		Meta: struct {
+			Page       int32 `json:"page"`
+			TotalPages int32 `json:"total_pages"`
+			TotalCount int64 `json:"total_count"`
+		}{
+			Page:       req.PageID,
+			TotalPages: totalPages,
+			TotalCount: totalCount,
+		},
+		Data: make([]rolePermissionDetailResponse, len(permissions)),
+	}
+	for i, permission := range permissions {
+		rsp.Data[i] = rolePermissionDetailResponse{
+			permissionResponse: newPermissionResponse(db.Permission{
+				ID:          permission.ID,
+				Name:        permission.Name,
+				Description: permission.Description,
+				CreatedAt:   permission.CreatedAt,
+				UpdatedAt:   permission.UpdatedAt,
+				IsSystem:    permission.IsSystem,
+			}),
+			GrantedAt: permission.GrantedAt,
+		}
+	}
+
+	ctx.JSON(http.StatusOK, successResponse("Role permissions retrieved successfully", rsp))
+}
+
+type roleMemberResponse struct {
+	ID         int32      `json:"id"`
+	Username   string     `json:"username"`
+	FullName   string     `json:"full_name"`
+	Email      string     `json:"email"`
+	Phone      string     `json:"phone"`
+	ValidFrom  *time.Time `json:"valid_from"`
+	ValidUntil *time.Time `json:"valid_until"`
+	Active     bool       `json:"active"`
+	AssignedAt time.Time  `json:"assigned_at"`
+}
+
+func newRoleMemberResponse(member db.ListUsersForRoleRow) roleMemberResponse {
+	rsp := roleMemberResponse{
+		ID:         member.ID,
+		Username:   member.Username,
+		FullName:   member.FullName,
+		Email:      member.Email,
+		Phone:      member.Phone.String,
+		Active:     member.Active,
+		AssignedAt: member.AssignedAt,
+	}
+	if member.ValidFrom.Valid {
+		rsp.ValidFrom = &member.ValidFrom.Time
+	}
+	if member.ValidUntil.Valid {
+		rsp.ValidUntil = &member.ValidUntil.Time
+	}
+	return rsp
+}
+
+type roleMembersResponse struct {
+	Meta struct {
+		Page       int32 `json:"page"`
+		TotalPages int32 `json:"total_pages"`
+		TotalCount int64 `json:"total_count"`
+	} `json:"meta"`
+	Data []roleMemberResponse `json:"data"`
+}
+
+func (server *Server) listUsersForRole(ctx *gin.Context) {
+	var reqURI getRoleRequest
+	if err := ctx.ShouldBindUri(&reqURI); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req listRoleDetailsRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	_, err := server.store.GetRole(ctx, reqURI.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	arg := db.ListUsersForRoleParams{
+		RoleID: reqURI.ID,
+		Limit:  req.PageSize,
+		Offset: (req.PageID - 1) * req.PageSize,
+	}
+
+	members, err := server.store.ListUsersForRole(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	totalCount, err := server.store.CountUsersForRole(ctx, reqURI.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	totalPages := int32(totalCount) / req.PageSize
+	if int32(totalCount)%req.PageSize != 0 {
+		totalPages++
+	}
+
+	rsp := roleMembersResponse{
+		Meta: struct {
+			Page       int32 `json:"page"`
+			TotalPages int32 `json:"total_pages"`
+			TotalCount int64 `json:"total_count"`
+		}{
+			Page:       req.PageID,
+			TotalPages: totalPages,
+			TotalCount: totalCount,
+		},
+		Data: make([]roleMemberResponse, len(members)),
+	}
+	for i, member := range members {
+		rsp.Data[i] = newRoleMemberResponse(member)
+	}
+
+	ctx.JSON(http.StatusOK, successResponse("Role users retrieved successfully", rsp))
+}
@@ -2,6 +2,7 @@ package api
 
 import (
 	"database/sql"
+	"errors"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
@@ -60,12 +61,16 @@ func (server *Server) deleteRolePermission(ctx *gin.Context) {
 		PermissionID: req.PermissionID,
 	}
 
-	err := server.store.DeleteRolePermission(ctx, arg)
+	err := server.store.DeleteRolePermissionTx(ctx, arg)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			ctx.JSON(http.StatusNotFound, errorResponse(err))
 			return
 		}
+		if errors.Is(err, db.ErrProtectedPermission) || errors.Is(err, db.ErrLastAdmin) {
+			ctx.JSON(http.StatusConflict, errorResponse(err))
+			return
+		}
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
@@ -180,12 +185,16 @@ func (server *Server) updateRolePermission(ctx *gin.Context) {
 		PermissionID_2: reqJSON.PermissionID,
 	}
 
-	rolePermission, err := server.store.UpdateRolePermission(ctx, arg)
+	rolePermission, err := server.store.UpdateRolePermissionTx(ctx, arg)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			ctx.JSON(http.StatusNotFound, errorResponse(err))
 			return
 		}
+		if errors.Is(err, db.ErrProtectedPermission) || errors.Is(err, db.ErrLastAdmin) {
+			ctx.JSON(http.StatusConflict, errorResponse(err))
+			return
+		}
 		if pqErr, ok := err.(*pq.Error); ok {
 			switch pqErr.Code.Name() {
 			case "foreign_key_violation", "unique_violation":
@@ -0,0 +1,148 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/lib/pq"
+	db "github.com/toannguyen3105/nht-bsihuyen.com-api/db/sqlc"
+)
+
+type createRolePermissionDenyRequest struct {
+	RoleID       int32 `json:"role_id" binding:"required,min=1"`
+	PermissionID int32 `json:"permission_id" binding:"required,min=1"`
+}
+
+func (server *Server) createRolePermissionDeny(ctx *gin.Context) {
+	var req createRolePermissionDenyRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	arg := db.CreateRolePermissionDenyParams{
+		RoleID:       req.RoleID,
+		PermissionID: req.PermissionID,
+	}
+
+	deny, err := server.store.CreateRolePermissionDenyTx(ctx, arg)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		if errors.Is(err, db.ErrProtectedPermission) || errors.Is(err, db.ErrLastAdmin) {
+			ctx.JSON(http.StatusConflict, errorResponse(err))
+			return
+		}
+		if pqErr, ok := err.(*pq.Error); ok {
+			switch pqErr.Code.Name() {
+			case "foreign_key_violation", "unique_violation":
+				ctx.JSON(http.StatusForbidden, errorResponse(err))
+				return
+			}
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, successResponse("Role permission deny created successfully", deny))
+}
+
+type listRolePermissionDeniesRequest struct {
+	PageID   int32 `form:"page_id" binding:"required,min=1"`
+	PageSize int32 `form:"page_size" binding:"required,min=10,max=100"`
+}
+
+type rolePermissionDeniesResponse struct {
+	Meta struct {
+		Page       int32 `json:"page"`
+		TotalPages int32 `json:"total_pages"`
+		TotalCount int64 `json:"total_count"`
+	} `json:"meta"`
+	Data []db.ListRolePermissionDeniesRow `json:"data"`
+}
+
+func (server *Server) listRolePermissionDenies(ctx *gin.Context) {
+	var req listRolePermissionDeniesRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	arg := db.ListRolePermissionDeniesParams{
+		Limit:  req.PageSize,
+		Offset: (req.PageID - 1) * req.PageSize,
+	}
+
+	denies, err := server.store.ListRolePermissionDenies(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	totalCount, err := server.store.CountRolePermissionDenies(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	totalPages := int32(totalCount) / req.PageSize
+	if int32(totalCount)%req.PageSize != 0 {
+		totalPages++
+	}
+
+	rsp := rolePermissionDeniesResponse{
+		Meta: struct {
+			Page       int32 `json:"page"`
+			TotalPages int32 `json:"total_pages"`
+			TotalCount int64 `json:"total_count"`
+		}{
+			Page:       req.PageID,
+			TotalPages: totalPages,
+			TotalCount: totalCount,
+		},
+		Data: denies,
+	}
+
+	ctx.JSON(http.StatusOK, successResponse("Role permission denies retrieved successfully", rsp))
+}
+
+type deleteRolePermissionDenyRequest struct {
+	RoleID       int32 `uri:"role_id" binding:"required,min=1"`
+	PermissionID int32 `uri:"permission_id" binding:"required,min=1"`
+}
+
+func (server *Server) deleteRolePermissionDeny(ctx *gin.Context) {
+	var req deleteRolePermissionDenyRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	_, err := server.store.GetRolePermissionDeny(ctx, db.GetRolePermissionDenyParams{
+		RoleID:       req.RoleID,
+		PermissionID: req.PermissionID,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	err = server.store.DeleteRolePermissionDeny(ctx, db.DeleteRolePermissionDenyParams{
+		RoleID:       req.RoleID,
+		PermissionID: req.PermissionID,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, successResponse("Role permission deny deleted successfully", nil))
+}
@@ -0,0 +1,272 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	mockdb "github.com/toannguyen3105/nht-bsihuyen.com-api/db/mock"
+	db "github.com/toannguyen3105/nht-bsihuyen.com-api/db/sqlc"
+)
+
+func TestCreateRolePermissionDenyAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	deny := db.RolePermissionDeny{
+		RoleID:       int32(2),
+		PermissionID: int32(5),
+	}
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{
+				"role_id":       deny.RoleID,
+				"permission_id": deny.PermissionID,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.CreateRolePermissionDenyParams{
+					RoleID:       deny.RoleID,
+					PermissionID: deny.PermissionID,
+				}
+				store.EXPECT().
+					CreateRolePermissionDenyTx(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(deny, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "ProtectedPermission",
+			body: gin.H{
+				"role_id":       deny.RoleID,
+				"permission_id": deny.PermissionID,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateRolePermissionDenyTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.RolePermissionDeny{}, db.ErrProtectedPermission)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name: "LastAdmin",
+			body: gin.H{
+				"role_id":       deny.RoleID,
+				"permission_id": deny.PermissionID,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateRolePermissionDenyTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.RolePermissionDeny{}, db.ErrLastAdmin)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name: "RoleNotFound",
+			body: gin.H{
+				"role_id":       deny.RoleID,
+				"permission_id": deny.PermissionID,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateRolePermissionDenyTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.RolePermissionDeny{}, sql.ErrNoRows)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name: "InvalidRoleID",
+			body: gin.H{
+				"role_id":       0,
+				"permission_id": deny.PermissionID,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateRolePermissionDenyTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().
+				GetUser(gomock.Any(), gomock.Eq(user.Username)).
+				Times(1).
+				Return(user, nil)
+			store.EXPECT().
+				GetPermissionsForUser(gomock.Any(), gomock.Eq(user.ID)).
+				Times(1).
+				Return([]string{"VIEW_SCREEN_ROLE_PERMISSION"}, nil)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			request, err := http.NewRequest(http.MethodPost, "/role_permission_denies", bytes.NewReader(data))
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestListRolePermissionDeniesAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	denies := []db.ListRolePermissionDeniesRow{
+		{RoleID: 2, RoleName: "doctor", PermissionID: 5, PermissionName: "DELETE_MEDICINE"},
+	}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().
+		GetUser(gomock.Any(), gomock.Eq(user.Username)).
+		Times(1).
+		Return(user, nil)
+	store.EXPECT().
+		GetPermissionsForUser(gomock.Any(), gomock.Eq(user.ID)).
+		Times(1).
+		Return([]string{"VIEW_SCREEN_ROLE_PERMISSION"}, nil)
+	store.EXPECT().
+		ListRolePermissionDenies(gomock.Any(), gomock.Eq(db.ListRolePermissionDeniesParams{Limit: 10, Offset: 0})).
+		Times(1).
+		Return(denies, nil)
+	store.EXPECT().
+		CountRolePermissionDenies(gomock.Any()).
+		Times(1).
+		Return(int64(len(denies)), nil)
+
+	server := newTestServer(t, store)
+	recorder := httptest.NewRecorder()
+
+	request, err := http.NewRequest(http.MethodGet, "/role_permission_denies?page_id=1&page_size=10", nil)
+	require.NoError(t, err)
+
+	addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+	server.router.ServeHTTP(recorder, request)
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	var response struct {
+		Data rolePermissionDeniesResponse `json:"data"`
+	}
+	err = json.Unmarshal(recorder.Body.Bytes(), &response)
+	require.NoError(t, err)
+	require.Equal(t, denies, response.Data.Data)
+}
+
+func TestDeleteRolePermissionDenyAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	deny := db.RolePermissionDeny{
+		RoleID:       int32(2),
+		PermissionID: int32(5),
+	}
+
+	testCases := []struct {
+		name          string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetRolePermissionDeny(gomock.Any(), gomock.Eq(db.GetRolePermissionDenyParams{RoleID: deny.RoleID, PermissionID: deny.PermissionID})).
+					Times(1).
+					Return(deny, nil)
+				store.EXPECT().
+					DeleteRolePermissionDeny(gomock.Any(), gomock.Eq(db.DeleteRolePermissionDenyParams{RoleID: deny.RoleID, PermissionID: deny.PermissionID})).
+					Times(1).
+					Return(nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "NotFound",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetRolePermissionDeny(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.RolePermissionDeny{}, sql.ErrNoRows)
+				store.EXPECT().
+					DeleteRolePermissionDeny(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().
+				GetUser(gomock.Any(), gomock.Eq(user.Username)).
+				Times(1).
+				Return(user, nil)
+			store.EXPECT().
+				GetPermissionsForUser(gomock.Any(), gomock.Eq(user.ID)).
+				Times(1).
+				Return([]string{"VIEW_SCREEN_ROLE_PERMISSION"}, nil)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/role_permission_denies/%d/%d", deny.RoleID, deny.PermissionID)
+			request, err := http.NewRequest(http.MethodDelete, url, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
@@ -123,7 +123,7 @@ func TestDeleteRolePermissionAPI(t *testing.T) {
 					PermissionID: rolePermission.PermissionID,
 				}
 				store.EXPECT().
-					DeleteRolePermission(gomock.Any(), gomock.Eq(arg)).
+					DeleteRolePermissionTx(gomock.Any(), gomock.Eq(arg)).
 					Times(1).
 					Return(nil)
 			},
@@ -152,7 +152,7 @@ func TestDeleteRolePermissionAPI(t *testing.T) {
 					PermissionID: rolePermission.PermissionID,
 				}
 				store.EXPECT().
-					DeleteRolePermission(gomock.Any(), gomock.Eq(arg)).
+					DeleteRolePermissionTx(gomock.Any(), gomock.Eq(arg)).
 					Times(1).
 					Return(sql.ErrNoRows)
 			},
@@ -160,6 +160,56 @@ func TestDeleteRolePermissionAPI(t *testing.T) {
 				require.Equal(t, http.StatusNotFound, recorder.Code)
 			},
 		},
+		{
+			name:         "ProtectedPermission",
+			roleID:       rolePermission.RoleID,
+			permissionID: rolePermission.PermissionID,
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					GetPermissionsForUser(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return([]string{"VIEW_SCREEN_ROLE_PERMISSION"}, nil)
+				store.EXPECT().
+					DeleteRolePermissionTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.ErrProtectedPermission)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name:         "LastAdmin",
+			roleID:       rolePermission.RoleID,
+			permissionID: rolePermission.PermissionID,
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					GetPermissionsForUser(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return([]string{"VIEW_SCREEN_ROLE_PERMISSION"}, nil)
+				store.EXPECT().
+					DeleteRolePermissionTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.ErrLastAdmin)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
 	}
 
 	for i := range testCases {
@@ -365,7 +415,7 @@ func TestUpdateRolePermissionAPI(t *testing.T) {
 					PermissionID_2: newPermissionID,
 				}
 				store.EXPECT().
-					UpdateRolePermission(gomock.Any(), gomock.Eq(arg)).
+					UpdateRolePermissionTx(gomock.Any(), gomock.Eq(arg)).
 					Times(1).
 					Return(rolePermission, nil)
 			},
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"database/sql"
 	"encoding/json"
+	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
@@ -200,9 +201,16 @@ func randomRole() db.Role {
 func TestListRoles(t *testing.T) {
 	user, _ := randomUser(t)
 	n := 10
-	roles := make([]db.Role, n)
+	roles := make([]db.ListRolesRow, n)
 	for i := 0; i < n; i++ {
-		roles[i] = randomRole()
+		role := randomRole()
+		roles[i] = db.ListRolesRow{
+			ID:              role.ID,
+			Name:            role.Name,
+			Description:     role.Description,
+			MemberCount:     int64(i),
+			PermissionCount: int64(n - i),
+		}
 	}
 
 	testCases := []struct {
@@ -242,6 +250,18 @@ func TestListRoles(t *testing.T) {
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var response struct {
+					Data rolesResponse `json:"data"`
+				}
+				err := json.Unmarshal(recorder.Body.Bytes(), &response)
+				require.NoError(t, err)
+				require.Len(t, response.Data.Data, n)
+				for i, role := range response.Data.Data {
+					require.Equal(t, roles[i].ID, role.ID)
+					require.Equal(t, roles[i].MemberCount, role.MemberCount)
+					require.Equal(t, roles[i].PermissionCount, role.PermissionCount)
+				}
 			},
 		},
 		{
@@ -296,7 +316,7 @@ func TestListRoles(t *testing.T) {
 				store.EXPECT().
 					ListRoles(gomock.Any(), gomock.Any()).
 					Times(1).
-					Return([]db.Role{}, sql.ErrConnDone)
+					Return([]db.ListRolesRow{}, sql.ErrConnDone)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusInternalServerError, recorder.Code)
@@ -351,6 +371,217 @@ func TestListRoles(t *testing.T) {
 	}
 }
 
+func TestDeleteRoleAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	role := randomRole()
+
+	testCases := []struct {
+		name          string
+		roleID        int32
+		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:   "OK",
+			roleID: role.ID,
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					GetPermissionsForUser(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return([]string{"VIEW_SCREEN_ROLE"}, nil)
+				store.EXPECT().
+					DeleteRoleTx(gomock.Any(), gomock.Eq(role.ID)).
+					Times(1).
+					Return(nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:   "NotFound",
+			roleID: role.ID,
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					GetPermissionsForUser(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return([]string{"VIEW_SCREEN_ROLE"}, nil)
+				store.EXPECT().
+					DeleteRoleTx(gomock.Any(), gomock.Eq(role.ID)).
+					Times(1).
+					Return(sql.ErrNoRows)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:   "ProtectedRole",
+			roleID: role.ID,
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					GetPermissionsForUser(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return([]string{"VIEW_SCREEN_ROLE"}, nil)
+				store.EXPECT().
+					DeleteRoleTx(gomock.Any(), gomock.Eq(role.ID)).
+					Times(1).
+					Return(db.ErrProtectedRole)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/roles/%d", tc.roleID)
+			request, err := http.NewRequest(http.MethodDelete, url, nil)
+			require.NoError(t, err)
+
+			tc.setupAuth(t, request, server.tokenMaker)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestUpdateRoleAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	role := randomRole()
+	role.IsSystem = true
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{
+				"name":        role.Name,
+				"description": "Updated",
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					GetPermissionsForUser(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return([]string{"VIEW_SCREEN_ROLE"}, nil)
+				store.EXPECT().
+					GetRole(gomock.Any(), gomock.Eq(role.ID)).
+					Times(1).
+					Return(role, nil)
+				store.EXPECT().
+					UpdateRole(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(role, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchRole(t, recorder.Body, role)
+			},
+		},
+		{
+			name: "RenameProtectedRole",
+			body: gin.H{
+				"name": "superuser",
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					GetPermissionsForUser(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return([]string{"VIEW_SCREEN_ROLE"}, nil)
+				store.EXPECT().
+					GetRole(gomock.Any(), gomock.Eq(role.ID)).
+					Times(1).
+					Return(role, nil)
+				store.EXPECT().
+					UpdateRole(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/roles/%d", role.ID)
+			request, err := http.NewRequest(http.MethodPut, url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			tc.setupAuth(t, request, server.tokenMaker)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
 func requireBodyMatchRole(t *testing.T, body *bytes.Buffer, role db.Role) {
 	data, err := io.ReadAll(body)
 	require.NoError(t, err)
@@ -369,3 +600,299 @@ func requireBodyMatchRole(t *testing.T, body *bytes.Buffer, role db.Role) {
 	require.Equal(t, role.Name, gotRole.Name)
 	require.Equal(t, role.Description.String, gotRole.Description)
 }
+
+func TestListPermissionsForRoleAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	role := randomRole()
+
+	n := 3
+	permissions := make([]db.ListPermissionsForRoleRow, n)
+	for i := 0; i < n; i++ {
+		permission := randomPermission()
+		permissions[i] = db.ListPermissionsForRoleRow{
+			ID:          permission.ID,
+			Name:        permission.Name,
+			Description: permission.Description,
+			GrantedAt:   time.Now().Truncate(time.Second).UTC(),
+		}
+	}
+
+	testCases := []struct {
+		name          string
+		roleID        int32
+		query         string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:   "OK",
+			roleID: role.ID,
+			query:  "?page_id=1&page_size=10",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetRole(gomock.Any(), gomock.Eq(role.ID)).
+					Times(1).
+					Return(role, nil)
+				arg := db.ListPermissionsForRoleParams{
+					RoleID: role.ID,
+					Limit:  10,
+					Offset: 0,
+				}
+				store.EXPECT().
+					ListPermissionsForRole(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(permissions, nil)
+				store.EXPECT().
+					CountPermissionsForRole(gomock.Any(), gomock.Eq(role.ID)).
+					Times(1).
+					Return(int64(n), nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var response struct {
+					Data rolePermissionDetailsResponse `json:"data"`
+				}
+				err := json.Unmarshal(recorder.Body.Bytes(), &response)
+				require.NoError(t, err)
+				require.Equal(t, int64(n), response.Data.Meta.TotalCount)
+				require.Equal(t, int32(1), response.Data.Meta.TotalPages)
+				require.Len(t, response.Data.Data, n)
+				for i, permission := range response.Data.Data {
+					require.Equal(t, permissions[i].Name, permission.Name)
+					require.Equal(t, permissions[i].GrantedAt, permission.GrantedAt)
+				}
+			},
+		},
+		{
+			name:   "RoleNotFound",
+			roleID: role.ID,
+			query:  "?page_id=1&page_size=10",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetRole(gomock.Any(), gomock.Eq(role.ID)).
+					Times(1).
+					Return(db.Role{}, sql.ErrNoRows)
+				store.EXPECT().
+					ListPermissionsForRole(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:   "InvalidPageSize",
+			roleID: role.ID,
+			query:  "?page_id=1&page_size=1000",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetRole(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:   "InternalError",
+			roleID: role.ID,
+			query:  "?page_id=1&page_size=10",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetRole(gomock.Any(), gomock.Eq(role.ID)).
+					Times(1).
+					Return(role, nil)
+				store.EXPECT().
+					ListPermissionsForRole(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return([]db.ListPermissionsForRoleRow{}, sql.ErrConnDone)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().
+				GetUser(gomock.Any(), gomock.Eq(user.Username)).
+				Times(1).
+				Return(user, nil)
+			store.EXPECT().
+				GetPermissionsForUser(gomock.Any(), gomock.Eq(user.ID)).
+				Times(1).
+				Return([]string{"VIEW_SCREEN_ROLE"}, nil)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/roles/%d/permissions%s", tc.roleID, tc.query)
+			request, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestListUsersForRoleAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	role := randomRole()
+
+	member, _ := randomUser(t)
+	until := time.Now().Add(time.Hour).Truncate(time.Second).UTC()
+	members := []db.ListUsersForRoleRow{
+		{
+			ID:         member.ID,
+			Username:   member.Username,
+			FullName:   member.FullName,
+			Email:      member.Email,
+			ValidUntil: sql.NullTime{Time: until, Valid: true},
+			Active:     true,
+		},
+	}
+
+	testCases := []struct {
+		name          string
+		roleID        int32
+		query         string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:   "OK",
+			roleID: role.ID,
+			query:  "?page_id=1&page_size=10",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetRole(gomock.Any(), gomock.Eq(role.ID)).
+					Times(1).
+					Return(role, nil)
+				arg := db.ListUsersForRoleParams{
+					RoleID: role.ID,
+					Limit:  10,
+					Offset: 0,
+				}
+				store.EXPECT().
+					ListUsersForRole(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(members, nil)
+				store.EXPECT().
+					CountUsersForRole(gomock.Any(), gomock.Eq(role.ID)).
+					Times(1).
+					Return(int64(len(members)), nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var response struct {
+					Data roleMembersResponse `json:"data"`
+				}
+				err := json.Unmarshal(recorder.Body.Bytes(), &response)
+				require.NoError(t, err)
+				require.Len(t, response.Data.Data, 1)
+
+				got := response.Data.Data[0]
+				require.Equal(t, member.Username, got.Username)
+				require.True(t, got.Active)
+				require.Nil(t, got.ValidFrom)
+				require.NotNil(t, got.ValidUntil)
+				require.True(t, until.Equal(*got.ValidUntil))
+			},
+		},
+		{
+			name:   "RoleNotFound",
+			roleID: role.ID,
+			query:  "?page_id=1&page_size=10",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetRole(gomock.Any(), gomock.Eq(role.ID)).
+					Times(1).
+					Return(db.Role{}, sql.ErrNoRows)
+				store.EXPECT().
+					ListUsersForRole(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:   "InvalidID",
+			roleID: 0,
+			query:  "?page_id=1&page_size=10",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetRole(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:   "CountError",
+			roleID: role.ID,
+			query:  "?page_id=1&page_size=10",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetRole(gomock.Any(), gomock.Eq(role.ID)).
+					Times(1).
+					Return(role, nil)
+				store.EXPECT().
+					ListUsersForRole(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(members, nil)
+				store.EXPECT().
+					CountUsersForRole(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(int64(0), sql.ErrConnDone)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().
+				GetUser(gomock.Any(), gomock.Eq(user.Username)).
+				Times(1).
+				Return(user, nil)
+			store.EXPECT().
+				GetPermissionsForUser(gomock.Any(), gomock.Eq(user.ID)).
+				Times(1).
+				Return([]string{"VIEW_SCREEN_ROLE"}, nil)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/roles/%d/users%s", tc.roleID, tc.query)
+			request, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
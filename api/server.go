@@ -9,6 +9,7 @@ import (
 	"github.com/gin-gonic/gin/binding"
 	"github.com/go-playground/validator/v10"
 	db "github.com/toannguyen3105/nht-bsihuyen.com-api/db/sqlc"
+	"github.com/toannguyen3105/nht-bsihuyen.com-api/notify"
 	"github.com/toannguyen3105/nht-bsihuyen.com-api/token"
 	"github.com/toannguyen3105/nht-bsihuyen.com-api/utils"
 )
@@ -16,11 +17,12 @@ import (
 type Server struct {
 	config     utils.Config
 	store      db.Store
+	notifier   notify.Notifier
 	tokenMaker token.Maker
 	router     *gin.Engine
 }
 
-func NewServer(config utils.Config, store db.Store) (*Server, error) {
+func NewServer(config utils.Config, store db.Store, notifier notify.Notifier) (*Server, error) {
 	tokenMaker, err := token.NewPasetoMaker(config.TokenSymmetricKey)
 	if err != nil {
 		return nil, fmt.Errorf("cannot create token maker: %w", err)
@@ -29,11 +31,14 @@ func NewServer(config utils.Config, store db.Store) (*Server, error) {
 	server := &Server{
 		config:     config,
 		store:      store,
+		notifier:   notifier,
 		tokenMaker: tokenMaker,
 	}
 
 	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
 		v.RegisterValidation("currency", validCurrency)
+		v.RegisterValidation("price_currency", validPriceCurrency)
+		v.RegisterValidation("ean13", validEAN13)
 	}
 
 	server.setupRouter()
@@ -59,7 +64,7 @@ func (server *Server) setupRouter() {
 	authRoutes.GET("/users", server.requirePermission("VIEW_SCREEN_USER"), server.listUsers)
 
 	authRoutes.POST("/accounts", server.createAccount)
-	authRoutes.GET("/accounts/:id", server.getAccount)
+	authRoutes.GET("/accounts/:id", server.requireOwnership(accountPolicy, server.accountOwner), server.getAccount)
 	authRoutes.GET("/accounts", server.listAccount)
 
 	authRoutes.POST("/transfers", server.createTransfer)
@@ -67,6 +72,8 @@ func (server *Server) setupRouter() {
 	authRoutes.POST("/roles", server.requirePermission("VIEW_SCREEN_ROLE"), server.createRole)
 	authRoutes.GET("/roles", server.requirePermission("VIEW_SCREEN_ROLE"), server.listRoles)
 	authRoutes.GET("/roles/:id", server.requirePermission("VIEW_SCREEN_ROLE"), server.getRole)
+	authRoutes.GET("/roles/:id/permissions", server.requirePermission("VIEW_SCREEN_ROLE"), server.listPermissionsForRole)
+	authRoutes.GET("/roles/:id/users", server.requirePermission("VIEW_SCREEN_ROLE"), server.listUsersForRole)
 	authRoutes.PUT("/roles/:id", server.requirePermission("VIEW_SCREEN_ROLE"), server.updateRole)
 	authRoutes.DELETE("/roles/:id", server.requirePermission("VIEW_SCREEN_ROLE"), server.deleteRole)
 
@@ -80,20 +87,96 @@ func (server *Server) setupRouter() {
 	authRoutes.GET("/role_permissions", server.requirePermission("VIEW_SCREEN_ROLE_PERMISSION"), server.listRolePermissions)
 
 	authRoutes.POST("/medicines", server.requirePermission("VIEW_SCREEN_MEDICINE"), server.createMedicine)
+	authRoutes.GET("/medicines/export", server.requirePermission("VIEW_SCREEN_MEDICINE"), server.exportMedicines)
+	authRoutes.POST("/medicines/import", server.requirePermission("VIEW_SCREEN_MEDICINE"), server.importMedicines)
+	authRoutes.POST("/medicines/warnings", server.requirePermission("VIEW_SCREEN_MEDICINE"), server.checkMedicineWarnings)
+	authRoutes.GET("/medicines/lookup", server.requirePermission("VIEW_SCREEN_MEDICINE"), server.lookupMedicine)
 	authRoutes.GET("/medicines/:id", server.requirePermission("VIEW_SCREEN_MEDICINE"), server.getMedicine)
 	authRoutes.GET("/medicines", server.requirePermission("VIEW_SCREEN_MEDICINE"), server.listMedicines)
 	authRoutes.PUT("/medicines/:id", server.requirePermission("VIEW_SCREEN_MEDICINE"), server.updateMedicine)
 	authRoutes.DELETE("/medicines/:id", server.requirePermission("VIEW_SCREEN_MEDICINE"), server.deleteMedicine)
+	authRoutes.POST("/medicines/:id/restore", server.requirePermission("VIEW_SCREEN_MEDICINE"), server.restoreMedicine)
+	authRoutes.POST("/medicines/:id/movements", server.requirePermission("VIEW_SCREEN_MEDICINE"), server.createStockMovement)
+	authRoutes.GET("/medicines/:id/movements", server.requirePermission("VIEW_SCREEN_MEDICINE"), server.listStockMovements)
+	authRoutes.POST("/medicines/:id/prices", server.requirePermission("VIEW_SCREEN_MEDICINE"), server.changeMedicinePrice)
+	authRoutes.GET("/medicines/:id/prices", server.requirePermission("VIEW_SCREEN_MEDICINE"), server.listMedicinePrices)
+	authRoutes.GET("/medicines/:id/price", server.requirePermission("VIEW_SCREEN_MEDICINE"), server.getMedicinePriceAt)
+	authRoutes.POST("/medicine-prices/adjust", server.requirePermission("VIEW_SCREEN_MEDICINE"), server.bulkAdjustPrices)
+	authRoutes.POST("/medicines/:id/barcodes", server.requirePermission("VIEW_SCREEN_MEDICINE"), server.addMedicineBarcode)
+	authRoutes.DELETE("/medicines/:id/barcodes/:barcode", server.requirePermission("VIEW_SCREEN_MEDICINE"), server.deleteMedicineBarcode)
+	authRoutes.PUT("/medicines/:id/categories", server.requirePermission("VIEW_SCREEN_MEDICINE"), server.setMedicineCategories)
+	authRoutes.PUT("/medicines/:id/ingredients", server.requirePermission("VIEW_SCREEN_MEDICINE"), server.setMedicineIngredients)
+	authRoutes.PUT("/medicines/:id/units/:unit", server.requirePermission("VIEW_SCREEN_MEDICINE"), server.setMedicineUnitConversion)
+	authRoutes.DELETE("/medicines/:id/units/:unit", server.requirePermission("VIEW_SCREEN_MEDICINE"), server.deleteMedicineUnitConversion)
+	authRoutes.POST("/units", server.requirePermission("VIEW_SCREEN_MEDICINE"), server.createUnit)
+	authRoutes.GET("/units", server.requirePermission("VIEW_SCREEN_MEDICINE"), server.listUnits)
+	authRoutes.DELETE("/units/:name", server.requirePermission("VIEW_SCREEN_MEDICINE"), server.deleteUnit)
+	authRoutes.POST("/categories", server.requirePermission("VIEW_SCREEN_MEDICINE"), server.createCategory)
+	authRoutes.GET("/categories", server.requirePermission("VIEW_SCREEN_MEDICINE"), server.listCategories)
+	authRoutes.DELETE("/categories/:id", server.requirePermission("VIEW_SCREEN_MEDICINE"), server.deleteCategory)
+	authRoutes.POST("/ingredients", server.requirePermission("VIEW_SCREEN_MEDICINE"), server.createIngredient)
+	authRoutes.GET("/ingredients", server.requirePermission("VIEW_SCREEN_MEDICINE"), server.listIngredients)
+	authRoutes.DELETE("/ingredients/:id", server.requirePermission("VIEW_SCREEN_MEDICINE"), server.deleteIngredient)
+	authRoutes.GET("/ingredients/:id/interactions", server.requirePermission("VIEW_SCREEN_MEDICINE"), server.listIngredientInteractions)
+	authRoutes.PUT("/ingredient-interactions", server.requirePermission("VIEW_SCREEN_MEDICINE"), server.upsertIngredientInteraction)
+	authRoutes.DELETE("/ingredient-interactions/:id", server.requirePermission("VIEW_SCREEN_MEDICINE"), server.deleteIngredientInteraction)
+	authRoutes.POST("/suppliers", server.requirePermission("VIEW_SCREEN_MEDICINE"), server.createSupplier)
+	authRoutes.GET("/suppliers", server.requirePermission("VIEW_SCREEN_MEDICINE"), server.listSuppliers)
+	authRoutes.GET("/suppliers/:id", server.requirePermission("VIEW_SCREEN_MEDICINE"), server.getSupplier)
+	authRoutes.PUT("/suppliers/:id", server.requirePermission("VIEW_SCREEN_MEDICINE"), server.updateSupplier)
+	authRoutes.DELETE("/suppliers/:id", server.requirePermission("VIEW_SCREEN_MEDICINE"), server.deleteSupplier)
+	authRoutes.POST("/purchase-orders", server.requirePermission("VIEW_SCREEN_MEDICINE"), server.createPurchaseOrder)
+	authRoutes.GET("/purchase-orders", server.requirePermission("VIEW_SCREEN_MEDICINE"), server.listPurchaseOrders)
+	authRoutes.GET("/purchase-orders/:id", server.requirePermission("VIEW_SCREEN_MEDICINE"), server.getPurchaseOrder)
+	authRoutes.PUT("/purchase-orders/:id", server.requirePermission("VIEW_SCREEN_MEDICINE"), server.updatePurchaseOrder)
+	authRoutes.DELETE("/purchase-orders/:id", server.requirePermission("VIEW_SCREEN_MEDICINE"), server.deletePurchaseOrder)
+	authRoutes.POST("/purchase-orders/:id/order", server.requirePermission("VIEW_SCREEN_MEDICINE"), server.placePurchaseOrder)
+	authRoutes.POST("/purchase-orders/:id/receipts", server.requirePermission("VIEW_SCREEN_MEDICINE"), server.receiveGoods)
+	authRoutes.POST("/stocktakes", server.requirePermission("VIEW_SCREEN_MEDICINE"), server.createStocktake)
+	authRoutes.GET("/stocktakes", server.requirePermission("VIEW_SCREEN_MEDICINE"), server.listStocktakes)
+	authRoutes.GET("/stocktakes/:id", server.requirePermission("VIEW_SCREEN_MEDICINE"), server.getStocktake)
+	authRoutes.DELETE("/stocktakes/:id", server.requirePermission("VIEW_SCREEN_MEDICINE"), server.deleteStocktake)
+	authRoutes.POST("/stocktakes/:id/counts", server.requirePermission("VIEW_SCREEN_MEDICINE"), server.recordStocktakeCounts)
+	authRoutes.POST("/stocktakes/:id/approve", server.requirePermission("APPROVE_STOCKTAKE"), server.approveStocktake)
+	authRoutes.POST("/stock-locations", server.requirePermission("VIEW_SCREEN_MEDICINE"), server.createStockLocation)
+	authRoutes.GET("/stock-locations", server.requirePermission("VIEW_SCREEN_MEDICINE"), server.listStockLocations)
+	authRoutes.PUT("/stock-locations/:id", server.requirePermission("VIEW_SCREEN_MEDICINE"), server.updateStockLocation)
+	authRoutes.GET("/stock-locations/:id/stock", server.requirePermission("VIEW_SCREEN_MEDICINE"), server.listLocationStock)
+	authRoutes.GET("/medicines/:id/locations", server.requirePermission("VIEW_SCREEN_MEDICINE"), server.listMedicineLocations)
+	authRoutes.POST("/stock-transfers", server.requirePermission("VIEW_SCREEN_MEDICINE"), server.createStockTransfer)
+	authRoutes.GET("/reorder-suggestions", server.requirePermission("VIEW_SCREEN_MEDICINE"), server.listReorderSuggestions)
+	authRoutes.POST("/reorder-suggestions/purchase-order", server.requirePermission("VIEW_SCREEN_MEDICINE"), server.exportReorderSuggestions)
+	authRoutes.GET("/controlled-register", server.requirePermission("VIEW_CONTROLLED_REGISTER"), server.getControlledRegister)
+	authRoutes.PUT("/medicines/:id/controlled", server.requirePermission("SET_CONTROLLED_MEDICINE"), server.setMedicineControlled)
+	authRoutes.GET("/stock-alerts", server.requirePermission("VIEW_SCREEN_MEDICINE"), server.listStockAlerts)
+	authRoutes.POST("/stock-alerts/:id/acknowledge", server.requirePermission("MANAGE_STOCK_ALERTS"), server.acknowledgeStockAlert)
 	authRoutes.GET("/role_permissions/:role_id/:permission_id", server.requirePermission("VIEW_SCREEN_ROLE_PERMISSION"), server.getRolePermission)
 	authRoutes.PUT("/role_permissions/:role_id/:permission_id", server.requirePermission("VIEW_SCREEN_ROLE_PERMISSION"), server.updateRolePermission)
 	authRoutes.DELETE("/role_permissions/:role_id/:permission_id", server.requirePermission("VIEW_SCREEN_ROLE_PERMISSION"), server.deleteRolePermission)
 
+	authRoutes.POST("/role_permission_denies", server.requirePermission("VIEW_SCREEN_ROLE_PERMISSION"), server.createRolePermissionDeny)
+	authRoutes.GET("/role_permission_denies", server.requirePermission("VIEW_SCREEN_ROLE_PERMISSION"), server.listRolePermissionDenies)
+	authRoutes.DELETE("/role_permission_denies/:role_id/:permission_id", server.requirePermission("VIEW_SCREEN_ROLE_PERMISSION"), server.deleteRolePermissionDeny)
+
+	authRoutes.POST("/break-glass", server.breakGlass)
+	authRoutes.GET("/break-glass/pending", server.requirePermission("REVIEW_BREAK_GLASS"), server.listPendingBreakGlassGrants)
+	authRoutes.POST("/break-glass/:id/review", server.requirePermission("REVIEW_BREAK_GLASS"), server.reviewBreakGlassGrant)
+
+	authRoutes.GET("/authz/explain", server.requirePermission("VIEW_SCREEN_AUTHORIZATION"), server.explainAuthorization)
+	authRoutes.GET("/authz/matrix", server.requirePermission("VIEW_SCREEN_AUTHORIZATION"), server.getAuthorizationMatrix)
+	authRoutes.GET("/authz/policy", server.requirePermission("VIEW_SCREEN_AUTHORIZATION"), server.exportPolicy)
+	authRoutes.POST("/authz/policy", server.requireAllPermissions(policyImportPermissions...), server.importPolicy)
+
 	authRoutes.POST("/user-roles", server.requirePermission("VIEW_SCREEN_USER_ROLE"), server.addUserRole)
 	authRoutes.GET("/users/:id/roles", server.requirePermission("VIEW_SCREEN_USER_ROLE"), server.getUserRoles)
 	authRoutes.DELETE("/user-roles", server.requirePermission("VIEW_SCREEN_USER_ROLE"), server.deleteUserRole)
 	authRoutes.PUT("/user-roles", server.requirePermission("VIEW_SCREEN_USER_ROLE"), server.updateUserRole)
 	authRoutes.GET("/user-roles", server.requirePermission("VIEW_SCREEN_USER_ROLE"), server.listUserRoles)
 
+	authRoutes.POST("/user-permission-overrides", server.requirePermission("VIEW_SCREEN_USER_ROLE"), server.setUserPermissionOverride)
+	authRoutes.GET("/users/:id/permission-overrides", server.requirePermission("VIEW_SCREEN_USER_ROLE"), server.listUserPermissionOverrides)
+	authRoutes.DELETE("/user-permission-overrides", server.requirePermission("VIEW_SCREEN_USER_ROLE"), server.deleteUserPermissionOverride)
+
 	// For testing
 	router.GET("/ping", func(c *gin.Context) {
 		c.JSON(200, gin.H{"message": "pong"})
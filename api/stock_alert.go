@@ -0,0 +1,174 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	db "github.com/toannguyen3105/nht-bsihuyen.com-api/db/sqlc"
+	"github.com/toannguyen3105/nht-bsihuyen.com-api/token"
+)
+
+var errStockAlertAcknowledged = errors.New("stock alert has already been acknowledged")
+
+type stockAlertResponse struct {
+	ID             int64      `json:"id"`
+	AlertType      string     `json:"alert_type"`
+	MedicineID     int32      `json:"medicine_id"`
+	MedicineName   string     `json:"medicine_name,omitempty"`
+	BatchID        *int64     `json:"batch_id"`
+	LotNumber      string     `json:"lot_number,omitempty"`
+	ExpiryDate     string     `json:"expiry_date,omitempty"`
+	Message        string     `json:"message"`
+	AcknowledgedBy string     `json:"acknowledged_by"`
+	AcknowledgedAt *time.Time `json:"acknowledged_at"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+func newStockAlertResponse(alert db.StockAlert) stockAlertResponse {
+	rsp := stockAlertResponse{
+		ID:             alert.ID,
+		AlertType:      alert.AlertType,
+		MedicineID:     alert.MedicineID,
+		Message:        alert.Message,
+		AcknowledgedBy: alert.AcknowledgedBy.String,
+		CreatedAt:      alert.CreatedAt,
+	}
+	if alert.BatchID.Valid {
+		rsp.BatchID = &alert.BatchID.Int64
+	}
+	if alert.AcknowledgedAt.Valid {
+		rsp.AcknowledgedAt = &alert.AcknowledgedAt.Time
+	}
+	return rsp
+}
+
+type listStockAlertsRequest struct {
+	PageID       int32 `form:"page_id" binding:"required,min=1"`
+	PageSize     int32 `form:"page_size" binding:"required,min=10,max=100"`
+	Acknowledged *bool `form:"acknowledged"`
+}
+
+type stockAlertsResponse struct {
+	Meta struct {
+		Page       int32 `json:"page"`
+		TotalPages int32 `json:"total_pages"`
+		TotalCount int64 `json:"total_count"`
+	} `json:"meta"`
+	Data []stockAlertResponse `json:"data"`
+}
+
+// listStockAlerts lists the open stock alerts, newest first. acknowledged
+// narrows the list to alerts someone has or has not acknowledged yet.
+func (server *Server) listStockAlerts(ctx *gin.Context) {
+	var req listStockAlertsRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var acknowledged sql.NullBool
+	if req.Acknowledged != nil {
+		acknowledged = sql.NullBool{Bool: *req.Acknowledged, Valid: true}
+	}
+
+	alerts, err := server.store.ListStockAlerts(ctx, db.ListStockAlertsParams{
+		Acknowledged: acknowledged,
+		Limit:        req.PageSize,
+		Offset:       (req.PageID - 1) * req.PageSize,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	totalCount, err := server.store.CountStockAlerts(ctx, acknowledged)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	totalPages := int32(totalCount) / req.PageSize
+	if int32(totalCount)%req.PageSize != 0 {
+		totalPages++
+	}
+
+	data := make([]stockAlertResponse, len(alerts))
+	for i, alert := range alerts {
+		data[i] = newStockAlertResponse(db.StockAlert{
+			ID:             alert.ID,
+			AlertType:      alert.AlertType,
+			MedicineID:     alert.MedicineID,
+			BatchID:        alert.BatchID,
+			Message:        alert.Message,
+			AcknowledgedBy: alert.AcknowledgedBy,
+			AcknowledgedAt: alert.AcknowledgedAt,
+			ResolvedAt:     alert.ResolvedAt,
+			CreatedAt:      alert.CreatedAt,
+		})
+		data[i].MedicineName = alert.MedicineName
+		data[i].LotNumber = alert.LotNumber.String
+		if alert.ExpiryDate.Valid {
+			data[i].ExpiryDate = alert.ExpiryDate.Time.Format(time.DateOnly)
+		}
+	}
+
+	rsp := stockAlertsResponse{
+		Meta: struct {
+			Page       int32 `json:"page"`
+			TotalPages int32 `json:"total_pages"`
+			TotalCount int64 `json:"total_count"`
+		}{
+			Page:       req.PageID,
+			TotalPages: totalPages,
+			TotalCount: totalCount,
+		},
+		Data: data,
+	}
+
+	ctx.JSON(http.StatusOK, successResponse("Stock alerts retrieved successfully", rsp))
+}
+
+type getStockAlertRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+// acknowledgeStockAlert records who has seen an alert. The alert stays open
+// until the job finds its condition cleared.
+func (server *Server) acknowledgeStockAlert(ctx *gin.Context) {
+	var req getStockAlertRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	alert, err := server.store.GetStockAlert(ctx, req.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if alert.AcknowledgedAt.Valid {
+		ctx.JSON(http.StatusConflict, errorResponse(errStockAlertAcknowledged))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	alert, err = server.store.AcknowledgeStockAlert(ctx, db.AcknowledgeStockAlertParams{
+		ID:             req.ID,
+		AcknowledgedBy: sql.NullString{String: authPayload.Username, Valid: true},
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, successResponse("Stock alert acknowledged successfully", newStockAlertResponse(alert)))
+}
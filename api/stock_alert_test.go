@@ -0,0 +1,218 @@
+package api
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	mockdb "github.com/toannguyen3105/nht-bsihuyen.com-api/db/mock"
+	db "github.com/toannguyen3105/nht-bsihuyen.com-api/db/sqlc"
+)
+
+func TestListStockAlertsAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	alerts := []db.ListStockAlertsRow{
+		{
+			ID:           4,
+			AlertType:    "near_expiry",
+			MedicineID:   9,
+			BatchID:      sql.NullInt64{Int64: 12, Valid: true},
+			Message:      "Lot LOT-2026A of Paracetamol expires on 2026-11-01 with 30 left.",
+			CreatedAt:    time.Now(),
+			MedicineName: "Paracetamol",
+			LotNumber:    sql.NullString{String: "LOT-2026A", Valid: true},
+			ExpiryDate:   sql.NullTime{Time: time.Date(2026, time.November, 1, 0, 0, 0, 0, time.UTC), Valid: true},
+		},
+	}
+
+	testCases := []struct {
+		name          string
+		query         string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "Unacknowledged",
+			query: "page_id=1&page_size=10&acknowledged=false",
+			buildStubs: func(store *mockdb.MockStore) {
+				acknowledged := sql.NullBool{Bool: false, Valid: true}
+				store.EXPECT().
+					ListStockAlerts(gomock.Any(), gomock.Eq(db.ListStockAlertsParams{
+						Acknowledged: acknowledged,
+						Limit:        10,
+						Offset:       0,
+					})).
+					Times(1).
+					Return(alerts, nil)
+				store.EXPECT().
+					CountStockAlerts(gomock.Any(), gomock.Eq(acknowledged)).
+					Times(1).
+					Return(int64(len(alerts)), nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				data, err := io.ReadAll(recorder.Body)
+				require.NoError(t, err)
+
+				var rsp struct {
+					Data stockAlertsResponse `json:"data"`
+				}
+				require.NoError(t, json.Unmarshal(data, &rsp))
+				require.Len(t, rsp.Data.Data, 1)
+				require.Equal(t, "2026-11-01", rsp.Data.Data[0].ExpiryDate)
+				require.Equal(t, "LOT-2026A", rsp.Data.Data[0].LotNumber)
+			},
+		},
+		{
+			name:  "InvalidPageSize",
+			query: "page_id=1&page_size=5",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ListStockAlerts(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().
+				GetUser(gomock.Any(), gomock.Eq(user.Username)).
+				Times(1).
+				Return(user, nil)
+			store.EXPECT().
+				GetPermissionsForUser(gomock.Any(), gomock.Eq(user.ID)).
+				Times(1).
+				Return([]string{"VIEW_SCREEN_MEDICINE"}, nil)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			request, err := http.NewRequest(http.MethodGet, "/stock-alerts?"+tc.query, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestAcknowledgeStockAlertAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	alert := db.StockAlert{
+		ID:         4,
+		AlertType:  "low_stock",
+		MedicineID: 9,
+		Message:    "Paracetamol is running low: 3 left, reorder level 10.",
+		CreatedAt:  time.Now(),
+	}
+
+	testCases := []struct {
+		name          string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetStockAlert(gomock.Any(), gomock.Eq(alert.ID)).
+					Times(1).
+					Return(alert, nil)
+				arg := db.AcknowledgeStockAlertParams{
+					ID:             alert.ID,
+					AcknowledgedBy: sql.NullString{String: user.Username, Valid: true},
+				}
+				acknowledged := alert
+				acknowledged.AcknowledgedBy = arg.AcknowledgedBy
+				acknowledged.AcknowledgedAt = sql.NullTime{Time: time.Now(), Valid: true}
+				store.EXPECT().
+					AcknowledgeStockAlert(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(acknowledged, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "AlreadyAcknowledged",
+			buildStubs: func(store *mockdb.MockStore) {
+				acknowledged := alert
+				acknowledged.AcknowledgedAt = sql.NullTime{Time: time.Now(), Valid: true}
+				store.EXPECT().
+					GetStockAlert(gomock.Any(), gomock.Eq(alert.ID)).
+					Times(1).
+					Return(acknowledged, nil)
+				store.EXPECT().
+					AcknowledgeStockAlert(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name: "NotFound",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetStockAlert(gomock.Any(), gomock.Eq(alert.ID)).
+					Times(1).
+					Return(db.StockAlert{}, sql.ErrNoRows)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().
+				GetUser(gomock.Any(), gomock.Eq(user.Username)).
+				Times(1).
+				Return(user, nil)
+			store.EXPECT().
+				GetPermissionsForUser(gomock.Any(), gomock.Eq(user.ID)).
+				Times(1).
+				Return([]string{"MANAGE_STOCK_ALERTS"}, nil)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/stock-alerts/%d/acknowledge", alert.ID)
+			request, err := http.NewRequest(http.MethodPost, url, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
@@ -0,0 +1,187 @@
+package api
+
+import (
+	"database/sql"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/lib/pq"
+	db "github.com/toannguyen3105/nht-bsihuyen.com-api/db/sqlc"
+)
+
+type stockLocationRequest struct {
+	Name string `json:"name" binding:"required,max=100"`
+}
+
+func (server *Server) createStockLocation(ctx *gin.Context) {
+	var req stockLocationRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	location, err := server.store.CreateStockLocation(ctx, req.Name)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code.Name() == "unique_violation" {
+			ctx.JSON(http.StatusForbidden, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, successResponse("Stock location created successfully", location))
+}
+
+func (server *Server) listStockLocations(ctx *gin.Context) {
+	locations, err := server.store.ListStockLocations(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, successResponse("Stock locations retrieved successfully", locations))
+}
+
+type getStockLocationRequest struct {
+	ID int32 `uri:"id" binding:"required,min=1"`
+}
+
+func (server *Server) updateStockLocation(ctx *gin.Context) {
+	var reqURI getStockLocationRequest
+	if err := ctx.ShouldBindUri(&reqURI); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req stockLocationRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	location, err := server.store.UpdateStockLocation(ctx, db.UpdateStockLocationParams{
+		ID:   reqURI.ID,
+		Name: req.Name,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code.Name() == "unique_violation" {
+			ctx.JSON(http.StatusForbidden, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, successResponse("Stock location updated successfully", location))
+}
+
+type locationStockResponse struct {
+	Location db.StockLocation          `json:"location"`
+	Items    []db.ListLocationStockRow `json:"items"`
+}
+
+// listLocationStock returns how much of each medicine is kept at a location.
+func (server *Server) listLocationStock(ctx *gin.Context) {
+	var req getStockLocationRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	location, err := server.store.GetStockLocation(ctx, req.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	items, err := server.store.ListLocationStock(ctx, location.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, successResponse("Location stock retrieved successfully", locationStockResponse{
+		Location: location,
+		Items:    items,
+	}))
+}
+
+type medicineLocationBatchResponse struct {
+	BatchID    int64   `json:"batch_id"`
+	LotNumber  string  `json:"lot_number"`
+	ExpiryDate *string `json:"expiry_date"`
+	Quantity   int32   `json:"quantity"`
+}
+
+type medicineLocationResponse struct {
+	LocationID   int32                           `json:"location_id"`
+	LocationName string                          `json:"location_name"`
+	Quantity     int32                           `json:"quantity"`
+	Batches      []medicineLocationBatchResponse `json:"batches"`
+}
+
+// newMedicineLocationsResponse groups the batches of a medicine by location.
+// Rows come ordered by location.
+func newMedicineLocationsResponse(rows []db.ListMedicineLocationStockRow) []medicineLocationResponse {
+	rsp := []medicineLocationResponse{}
+	for _, row := range rows {
+		if len(rsp) == 0 || rsp[len(rsp)-1].LocationID != row.LocationID {
+			rsp = append(rsp, medicineLocationResponse{
+				LocationID:   row.LocationID,
+				LocationName: row.LocationName,
+			})
+		}
+
+		location := &rsp[len(rsp)-1]
+		batch := medicineLocationBatchResponse{
+			BatchID:   row.BatchID,
+			LotNumber: row.LotNumber,
+			Quantity:  row.Quantity,
+		}
+		if row.ExpiryDate.Valid {
+			expiryDate := row.ExpiryDate.Time.Format(time.DateOnly)
+			batch.ExpiryDate = &expiryDate
+		}
+		location.Quantity += row.Quantity
+		location.Batches = append(location.Batches, batch)
+	}
+	return rsp
+}
+
+// listMedicineLocations returns where the stock of a medicine is kept, per
+// location and lot.
+func (server *Server) listMedicineLocations(ctx *gin.Context) {
+	var req getMedicineRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	_, err := server.store.GetMedicine(ctx, req.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	rows, err := server.store.ListMedicineLocationStock(ctx, req.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, successResponse("Medicine locations retrieved successfully", newMedicineLocationsResponse(rows)))
+}
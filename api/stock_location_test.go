@@ -0,0 +1,108 @@
+package api
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	mockdb "github.com/toannguyen3105/nht-bsihuyen.com-api/db/mock"
+	db "github.com/toannguyen3105/nht-bsihuyen.com-api/db/sqlc"
+)
+
+func TestListMedicineLocationsAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	medicine := randomMedicine()
+	expiry := sql.NullTime{Time: time.Date(2027, time.March, 31, 0, 0, 0, 0, time.UTC), Valid: true}
+
+	testCases := []struct {
+		name          string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetMedicine(gomock.Any(), gomock.Eq(medicine.ID)).
+					Times(1).
+					Return(medicine, nil)
+				store.EXPECT().
+					ListMedicineLocationStock(gomock.Any(), gomock.Eq(medicine.ID)).
+					Times(1).
+					Return([]db.ListMedicineLocationStockRow{
+						{LocationID: 1, LocationName: "Main pharmacy", BatchID: 7, LotNumber: "LEGACY", Quantity: 5},
+						{LocationID: 1, LocationName: "Main pharmacy", BatchID: 8, LotNumber: "A1", ExpiryDate: expiry, Quantity: 10},
+						{LocationID: 2, LocationName: "Consultation room cabinet", BatchID: 8, LotNumber: "A1", ExpiryDate: expiry, Quantity: 4},
+					}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var response struct {
+					Data []medicineLocationResponse `json:"data"`
+				}
+				err := json.Unmarshal(recorder.Body.Bytes(), &response)
+				require.NoError(t, err)
+				require.Len(t, response.Data, 2)
+				require.Equal(t, int32(15), response.Data[0].Quantity)
+				require.Len(t, response.Data[0].Batches, 2)
+				require.Nil(t, response.Data[0].Batches[0].ExpiryDate)
+				require.Equal(t, "2027-03-31", *response.Data[0].Batches[1].ExpiryDate)
+				require.Equal(t, "Consultation room cabinet", response.Data[1].LocationName)
+				require.Equal(t, int32(4), response.Data[1].Quantity)
+			},
+		},
+		{
+			name: "NotFound",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetMedicine(gomock.Any(), gomock.Eq(medicine.ID)).
+					Times(1).
+					Return(db.Medicine{}, sql.ErrNoRows)
+				store.EXPECT().
+					ListMedicineLocationStock(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().
+				GetUser(gomock.Any(), gomock.Eq(user.Username)).
+				Times(1).
+				Return(user, nil)
+			store.EXPECT().
+				GetPermissionsForUser(gomock.Any(), gomock.Eq(user.ID)).
+				Times(1).
+				Return([]string{"VIEW_SCREEN_MEDICINE"}, nil)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/medicines/%d/locations", medicine.ID)
+			request, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
@@ -0,0 +1,242 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	db "github.com/toannguyen3105/nht-bsihuyen.com-api/db/sqlc"
+	"github.com/toannguyen3105/nht-bsihuyen.com-api/token"
+)
+
+var errNonPositiveQuantity = errors.New("quantity must be positive; only adjustments can be negative")
+
+type createStockMovementRequest struct {
+	MovementType string          `json:"movement_type" binding:"required,oneof=receipt dispense adjustment return write_off"`
+	Quantity     int32           `json:"quantity" binding:"required"`
+	Unit         string          `json:"unit" binding:"max=50"`
+	BatchID      *int64          `json:"batch_id" binding:"omitempty,min=1"`
+	LotNumber    string          `json:"lot_number" binding:"required_if=MovementType receipt,max=100"`
+	ExpiryDate   string          `json:"expiry_date" binding:"required_if=MovementType receipt"`
+	LocationID   *int32          `json:"location_id" binding:"omitempty,min=1"`
+	Note         *string         `json:"note"`
+	Witness      *witnessRequest `json:"witness"`
+}
+
+type stockMovementResponse struct {
+	ID           int64     `json:"id"`
+	MedicineID   int32     `json:"medicine_id"`
+	MovementType string    `json:"movement_type"`
+	Quantity     int32     `json:"quantity"`
+	Balance      int32     `json:"balance"`
+	BatchID      *int64    `json:"batch_id"`
+	LocationID   *int32    `json:"location_id"`
+	Note         *string   `json:"note"`
+	CreatedBy    *string   `json:"created_by"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+func newStockMovementResponse(movement db.StockMovement) stockMovementResponse {
+	rsp := stockMovementResponse{
+		ID:           movement.ID,
+		MedicineID:   movement.MedicineID,
+		MovementType: movement.MovementType,
+		Quantity:     movement.Quantity,
+		Balance:      movement.Balance,
+		CreatedAt:    movement.CreatedAt,
+	}
+	if movement.Note.Valid {
+		rsp.Note = &movement.Note.String
+	}
+	if movement.CreatedBy.Valid {
+		rsp.CreatedBy = &movement.CreatedBy.String
+	}
+	if movement.BatchID.Valid {
+		rsp.BatchID = &movement.BatchID.Int64
+	}
+	if movement.LocationID.Valid {
+		rsp.LocationID = &movement.LocationID.Int32
+	}
+	return rsp
+}
+
+type stockMovementResultResponse struct {
+	Medicine  db.Medicine             `json:"medicine"`
+	Movements []stockMovementResponse `json:"movements"`
+}
+
+// createStockMovement is the only way to change the stock of a medicine. The
+// quantity is given as a positive number and dispenses and write-offs remove
+// it; adjustments take the sign as given. A quantity in another unit, such as
+// boxes of a medicine counted in tablets, is converted to the medicine's unit.
+// A dispense spanning several batches records one movement per batch. Without
+// a location, stock is added to the default location and taken from there
+// first. Moving a controlled medicine needs a witness.
+func (server *Server) createStockMovement(ctx *gin.Context) {
+	var reqURI getMedicineRequest
+	if err := ctx.ShouldBindUri(&reqURI); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req createStockMovementRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	quantity := req.Quantity
+	if req.MovementType != db.MovementAdjustment {
+		if quantity < 0 {
+			ctx.JSON(http.StatusBadRequest, errorResponse(errNonPositiveQuantity))
+			return
+		}
+		if req.MovementType == db.MovementDispense || req.MovementType == db.MovementWriteOff {
+			quantity = -quantity
+		}
+	}
+
+	witnessedBy, ok := server.authenticateWitness(ctx, req.Witness)
+	if !ok {
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	arg := db.StockMovementTxParams{
+		MedicineID:   reqURI.ID,
+		MovementType: req.MovementType,
+		Quantity:     quantity,
+		Unit:         req.Unit,
+		CreatedBy:    sql.NullString{String: authPayload.Username, Valid: true},
+		WitnessedBy:  witnessedBy,
+	}
+	if req.BatchID != nil {
+		arg.BatchID = sql.NullInt64{Int64: *req.BatchID, Valid: true}
+	}
+	if req.LocationID != nil {
+		arg.LocationID = sql.NullInt32{Int32: *req.LocationID, Valid: true}
+	}
+	if req.MovementType == db.MovementReceipt {
+		expiryDate, err := time.Parse(time.DateOnly, req.ExpiryDate)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+		arg.LotNumber = req.LotNumber
+		arg.ExpiryDate = expiryDate
+	}
+	if req.Note != nil {
+		arg.Note = sql.NullString{String: *req.Note, Valid: true}
+	}
+
+	result, err := server.store.StockMovementTx(ctx, arg)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		if errors.Is(err, db.ErrInvalidStockMovement) || errors.Is(err, db.ErrUnknownUnit) || errors.Is(err, db.ErrWitnessRequired) {
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+		if errors.Is(err, db.ErrInsufficientStock) || errors.Is(err, db.ErrBatchExpired) || errors.Is(err, db.ErrBatchExpiryMismatch) || errors.Is(err, db.ErrMedicineArchived) {
+			ctx.JSON(http.StatusConflict, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	rsp := stockMovementResultResponse{
+		Medicine:  result.Medicine,
+		Movements: make([]stockMovementResponse, len(result.Movements)),
+	}
+	for i, movement := range result.Movements {
+		rsp.Movements[i] = newStockMovementResponse(movement)
+	}
+
+	ctx.JSON(http.StatusOK, successResponse("Stock movement recorded successfully", rsp))
+}
+
+type listStockMovementsRequest struct {
+	PageID   int32 `form:"page_id" binding:"required,min=1"`
+	PageSize int32 `form:"page_size" binding:"required,min=5,max=100"`
+}
+
+type stockMovementsResponse struct {
+	Meta struct {
+		Page       int32 `json:"page"`
+		TotalPages int32 `json:"total_pages"`
+		TotalCount int64 `json:"total_count"`
+	} `json:"meta"`
+	Data []stockMovementResponse `json:"data"`
+}
+
+// listStockMovements returns the stock history of a medicine, newest first.
+func (server *Server) listStockMovements(ctx *gin.Context) {
+	var reqURI getMedicineRequest
+	if err := ctx.ShouldBindUri(&reqURI); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req listStockMovementsRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	_, err := server.store.GetMedicine(ctx, reqURI.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	arg := db.ListStockMovementsParams{
+		MedicineID: reqURI.ID,
+		Limit:      req.PageSize,
+		Offset:     (req.PageID - 1) * req.PageSize,
+	}
+
+	movements, err := server.store.ListStockMovements(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	totalCount, err := server.store.CountStockMovements(ctx, reqURI.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	totalPages := int32(totalCount) / req.PageSize
+	if int32(totalCount)%req.PageSize != 0 {
+		totalPages++
+	}
+
+	rsp := stockMovementsResponse{
+		Meta: struct {
+			Page       int32 `json:"page"`
+			TotalPages int32 `json:"total_pages"`
+			TotalCount int64 `json:"total_count"`
+		}{
+			Page:       req.PageID,
+			TotalPages: totalPages,
+			TotalCount: totalCount,
+		},
+		Data: make([]stockMovementResponse, len(movements)),
+	}
+	for i, movement := range movements {
+		rsp.Data[i] = newStockMovementResponse(movement)
+	}
+
+	ctx.JSON(http.StatusOK, successResponse("Stock movements retrieved successfully", rsp))
+}
@@ -0,0 +1,548 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	mockdb "github.com/toannguyen3105/nht-bsihuyen.com-api/db/mock"
+	db "github.com/toannguyen3105/nht-bsihuyen.com-api/db/sqlc"
+)
+
+func TestCreateStockMovementAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	witness, witnessPassword := randomUser(t)
+	medicine := randomMedicine()
+
+	testCases := []struct {
+		name          string
+		medicineID    int32
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:       "Dispense",
+			medicineID: medicine.ID,
+			body: gin.H{
+				"movement_type": "dispense",
+				"quantity":      3,
+				"note":          "prescription 42",
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.StockMovementTxParams{
+					MedicineID:   medicine.ID,
+					MovementType: db.MovementDispense,
+					Quantity:     -3,
+					Note:         sql.NullString{String: "prescription 42", Valid: true},
+					CreatedBy:    sql.NullString{String: user.Username, Valid: true},
+				}
+				updated := medicine
+				updated.Stock -= 3
+				store.EXPECT().
+					StockMovementTx(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(db.StockMovementTxResult{
+						Medicine: updated,
+						Movements: []db.StockMovement{
+							{
+								ID:           1,
+								MedicineID:   medicine.ID,
+								MovementType: arg.MovementType,
+								Quantity:     -1,
+								Balance:      updated.Stock + 2,
+								BatchID:      sql.NullInt64{Int64: 7, Valid: true},
+								Note:         arg.Note,
+								CreatedBy:    arg.CreatedBy,
+							},
+							{
+								ID:           2,
+								MedicineID:   medicine.ID,
+								MovementType: arg.MovementType,
+								Quantity:     -2,
+								Balance:      updated.Stock,
+								BatchID:      sql.NullInt64{Int64: 8, Valid: true},
+								Note:         arg.Note,
+								CreatedBy:    arg.CreatedBy,
+							},
+						},
+					}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var response struct {
+					Data stockMovementResultResponse `json:"data"`
+				}
+				err := json.Unmarshal(recorder.Body.Bytes(), &response)
+				require.NoError(t, err)
+				require.Len(t, response.Data.Movements, 2)
+				require.Equal(t, int64(7), *response.Data.Movements[0].BatchID)
+				require.Equal(t, medicine.Stock-3, response.Data.Movements[1].Balance)
+				require.Equal(t, user.Username, *response.Data.Movements[1].CreatedBy)
+			},
+		},
+		{
+			name:       "Receipt",
+			medicineID: medicine.ID,
+			body: gin.H{
+				"movement_type": "receipt",
+				"quantity":      50,
+				"lot_number":    "LOT-2027A",
+				"expiry_date":   "2027-05-31",
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.StockMovementTxParams{
+					MedicineID:   medicine.ID,
+					MovementType: db.MovementReceipt,
+					Quantity:     50,
+					LotNumber:    "LOT-2027A",
+					ExpiryDate:   time.Date(2027, time.May, 31, 0, 0, 0, 0, time.UTC),
+					CreatedBy:    sql.NullString{String: user.Username, Valid: true},
+				}
+				store.EXPECT().
+					StockMovementTx(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(db.StockMovementTxResult{Medicine: medicine}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:       "DispenseFromLocation",
+			medicineID: medicine.ID,
+			body: gin.H{
+				"movement_type": "dispense",
+				"quantity":      1,
+				"location_id":   2,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.StockMovementTxParams{
+					MedicineID:   medicine.ID,
+					MovementType: db.MovementDispense,
+					Quantity:     -1,
+					LocationID:   sql.NullInt32{Int32: 2, Valid: true},
+					CreatedBy:    sql.NullString{String: user.Username, Valid: true},
+				}
+				store.EXPECT().
+					StockMovementTx(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(db.StockMovementTxResult{
+						Medicine: medicine,
+						Movements: []db.StockMovement{{
+							ID:           3,
+							MedicineID:   medicine.ID,
+							MovementType: arg.MovementType,
+							Quantity:     -1,
+							BatchID:      sql.NullInt64{Int64: 7, Valid: true},
+							LocationID:   arg.LocationID,
+						}},
+					}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var response struct {
+					Data stockMovementResultResponse `json:"data"`
+				}
+				err := json.Unmarshal(recorder.Body.Bytes(), &response)
+				require.NoError(t, err)
+				require.Equal(t, int32(2), *response.Data.Movements[0].LocationID)
+			},
+		},
+		{
+			name:       "ReceiptWithoutLot",
+			medicineID: medicine.ID,
+			body: gin.H{
+				"movement_type": "receipt",
+				"quantity":      50,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					StockMovementTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:       "NegativeAdjustment",
+			medicineID: medicine.ID,
+			body: gin.H{
+				"movement_type": "adjustment",
+				"quantity":      -2,
+				"batch_id":      7,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.StockMovementTxParams{
+					MedicineID:   medicine.ID,
+					MovementType: db.MovementAdjustment,
+					Quantity:     -2,
+					BatchID:      sql.NullInt64{Int64: 7, Valid: true},
+					CreatedBy:    sql.NullString{String: user.Username, Valid: true},
+				}
+				store.EXPECT().
+					StockMovementTx(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(db.StockMovementTxResult{Medicine: medicine}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:       "ExpiredBatch",
+			medicineID: medicine.ID,
+			body: gin.H{
+				"movement_type": "dispense",
+				"quantity":      1,
+				"batch_id":      7,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					StockMovementTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.StockMovementTxResult{}, db.ErrBatchExpired)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name:       "LotExpiryMismatch",
+			medicineID: medicine.ID,
+			body: gin.H{
+				"movement_type": "receipt",
+				"quantity":      50,
+				"lot_number":    "LOT-2027A",
+				"expiry_date":   "2027-11-30",
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					StockMovementTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.StockMovementTxResult{}, db.ErrBatchExpiryMismatch)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name:       "NegativeReceipt",
+			medicineID: medicine.ID,
+			body: gin.H{
+				"movement_type": "receipt",
+				"quantity":      -2,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					StockMovementTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:       "InvalidMovementType",
+			medicineID: medicine.ID,
+			body: gin.H{
+				"movement_type": "theft",
+				"quantity":      1,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					StockMovementTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:       "InsufficientStock",
+			medicineID: medicine.ID,
+			body: gin.H{
+				"movement_type": "write_off",
+				"quantity":      1000,
+				"batch_id":      7,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					StockMovementTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.StockMovementTxResult{}, fmt.Errorf("%w: %s has %d left", db.ErrInsufficientStock, medicine.Name, medicine.Stock))
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name:       "UnknownUnit",
+			medicineID: medicine.ID,
+			body: gin.H{
+				"movement_type": "dispense",
+				"quantity":      2,
+				"unit":          "blister",
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.StockMovementTxParams{
+					MedicineID:   medicine.ID,
+					MovementType: db.MovementDispense,
+					Quantity:     -2,
+					Unit:         "blister",
+					CreatedBy:    sql.NullString{String: user.Username, Valid: true},
+				}
+				store.EXPECT().
+					StockMovementTx(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(db.StockMovementTxResult{}, fmt.Errorf("%w: blister of %s", db.ErrUnknownUnit, medicine.Name))
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:       "NotFound",
+			medicineID: medicine.ID,
+			body: gin.H{
+				"movement_type": "return",
+				"quantity":      1,
+				"batch_id":      7,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					StockMovementTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.StockMovementTxResult{}, sql.ErrNoRows)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:       "Witnessed",
+			medicineID: medicine.ID,
+			body: gin.H{
+				"movement_type": "dispense",
+				"quantity":      1,
+				"witness":       gin.H{"username": witness.Username, "password": witnessPassword},
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(witness.Username)).
+					Times(1).
+					Return(witness, nil)
+
+				arg := db.StockMovementTxParams{
+					MedicineID:   medicine.ID,
+					MovementType: db.MovementDispense,
+					Quantity:     -1,
+					CreatedBy:    sql.NullString{String: user.Username, Valid: true},
+					WitnessedBy:  sql.NullString{String: witness.Username, Valid: true},
+				}
+				store.EXPECT().
+					StockMovementTx(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(db.StockMovementTxResult{Medicine: medicine}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:       "WrongWitnessPassword",
+			medicineID: medicine.ID,
+			body: gin.H{
+				"movement_type": "dispense",
+				"quantity":      1,
+				"witness":       gin.H{"username": witness.Username, "password": "wrong-password"},
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(witness.Username)).
+					Times(1).
+					Return(witness, nil)
+				store.EXPECT().
+					StockMovementTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name:       "WitnessIsCaller",
+			medicineID: medicine.ID,
+			body: gin.H{
+				"movement_type": "dispense",
+				"quantity":      1,
+				"witness":       gin.H{"username": user.Username, "password": "secret"},
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					StockMovementTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:       "WitnessRequired",
+			medicineID: medicine.ID,
+			body: gin.H{
+				"movement_type": "dispense",
+				"quantity":      1,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					StockMovementTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.StockMovementTxResult{}, db.ErrWitnessRequired)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().
+				GetUser(gomock.Any(), gomock.Eq(user.Username)).
+				Times(1).
+				Return(user, nil)
+			store.EXPECT().
+				GetPermissionsForUser(gomock.Any(), gomock.Eq(user.ID)).
+				Times(1).
+				Return([]string{"VIEW_SCREEN_MEDICINE"}, nil)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/medicines/%d/movements", tc.medicineID)
+			request, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestListStockMovementsAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	medicine := randomMedicine()
+	movements := []db.StockMovement{
+		{ID: 2, MedicineID: medicine.ID, MovementType: db.MovementDispense, Quantity: -5, Balance: medicine.Stock},
+		{ID: 1, MedicineID: medicine.ID, MovementType: db.MovementReceipt, Quantity: medicine.Stock + 5, Balance: medicine.Stock + 5},
+	}
+
+	testCases := []struct {
+		name          string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetMedicine(gomock.Any(), gomock.Eq(medicine.ID)).
+					Times(1).
+					Return(medicine, nil)
+				arg := db.ListStockMovementsParams{
+					MedicineID: medicine.ID,
+					Limit:      5,
+					Offset:     0,
+				}
+				store.EXPECT().
+					ListStockMovements(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(movements, nil)
+				store.EXPECT().
+					CountStockMovements(gomock.Any(), gomock.Eq(medicine.ID)).
+					Times(1).
+					Return(int64(len(movements)), nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var response struct {
+					Data stockMovementsResponse `json:"data"`
+				}
+				err := json.Unmarshal(recorder.Body.Bytes(), &response)
+				require.NoError(t, err)
+				require.Len(t, response.Data.Data, len(movements))
+				require.Equal(t, movements[0].ID, response.Data.Data[0].ID)
+			},
+		},
+		{
+			name: "NotFound",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetMedicine(gomock.Any(), gomock.Eq(medicine.ID)).
+					Times(1).
+					Return(db.Medicine{}, sql.ErrNoRows)
+				store.EXPECT().
+					ListStockMovements(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().
+				GetUser(gomock.Any(), gomock.Eq(user.Username)).
+				Times(1).
+				Return(user, nil)
+			store.EXPECT().
+				GetPermissionsForUser(gomock.Any(), gomock.Eq(user.ID)).
+				Times(1).
+				Return([]string{"VIEW_SCREEN_MEDICINE"}, nil)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/medicines/%d/movements?page_id=1&page_size=5", medicine.ID)
+			request, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
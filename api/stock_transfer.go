@@ -0,0 +1,96 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	db "github.com/toannguyen3105/nht-bsihuyen.com-api/db/sqlc"
+	"github.com/toannguyen3105/nht-bsihuyen.com-api/token"
+)
+
+type createStockTransferRequest struct {
+	MedicineID     int32           `json:"medicine_id" binding:"required,min=1"`
+	FromLocationID int32           `json:"from_location_id" binding:"required,min=1"`
+	ToLocationID   int32           `json:"to_location_id" binding:"required,min=1,nefield=FromLocationID"`
+	Quantity       int32           `json:"quantity" binding:"required,min=1"`
+	Unit           string          `json:"unit" binding:"max=50"`
+	BatchID        *int64          `json:"batch_id" binding:"omitempty,min=1"`
+	Note           *string         `json:"note"`
+	Witness        *witnessRequest `json:"witness"`
+}
+
+type stockTransferResponse struct {
+	Transfer      db.StockTransfer        `json:"transfer"`
+	Medicine      db.Medicine             `json:"medicine"`
+	FromMovements []stockMovementResponse `json:"from_movements"`
+	ToMovements   []stockMovementResponse `json:"to_movements"`
+}
+
+// createStockTransfer moves stock between locations, such as restocking the
+// consultation room cabinet from the back storeroom. A transfer without a
+// batch moves the earliest-expiring unexpired stock first.
+func (server *Server) createStockTransfer(ctx *gin.Context) {
+	var req createStockTransferRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	witnessedBy, ok := server.authenticateWitness(ctx, req.Witness)
+	if !ok {
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	arg := db.StockTransferTxParams{
+		MedicineID:     req.MedicineID,
+		FromLocationID: req.FromLocationID,
+		ToLocationID:   req.ToLocationID,
+		Quantity:       req.Quantity,
+		Unit:           req.Unit,
+		CreatedBy:      sql.NullString{String: authPayload.Username, Valid: true},
+		WitnessedBy:    witnessedBy,
+	}
+	if req.BatchID != nil {
+		arg.BatchID = sql.NullInt64{Int64: *req.BatchID, Valid: true}
+	}
+	if req.Note != nil {
+		arg.Note = sql.NullString{String: *req.Note, Valid: true}
+	}
+
+	result, err := server.store.StockTransferTx(ctx, arg)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		if errors.Is(err, db.ErrInvalidStockTransfer) || errors.Is(err, db.ErrUnknownUnit) || errors.Is(err, db.ErrWitnessRequired) {
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+		if errors.Is(err, db.ErrInsufficientStock) || errors.Is(err, db.ErrMedicineArchived) {
+			ctx.JSON(http.StatusConflict, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	rsp := stockTransferResponse{
+		Transfer:      result.Transfer,
+		Medicine:      result.Medicine,
+		FromMovements: make([]stockMovementResponse, len(result.FromMovements)),
+		ToMovements:   make([]stockMovementResponse, len(result.ToMovements)),
+	}
+	for i, movement := range result.FromMovements {
+		rsp.FromMovements[i] = newStockMovementResponse(movement)
+	}
+	for i, movement := range result.ToMovements {
+		rsp.ToMovements[i] = newStockMovementResponse(movement)
+	}
+
+	ctx.JSON(http.StatusOK, successResponse("Stock transferred successfully", rsp))
+}
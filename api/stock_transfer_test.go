@@ -0,0 +1,229 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	mockdb "github.com/toannguyen3105/nht-bsihuyen.com-api/db/mock"
+	db "github.com/toannguyen3105/nht-bsihuyen.com-api/db/sqlc"
+)
+
+func TestCreateStockTransferAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	medicine := randomMedicine()
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{
+				"medicine_id":      medicine.ID,
+				"from_location_id": 3,
+				"to_location_id":   2,
+				"quantity":         20,
+				"note":             "restock cabinet",
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.StockTransferTxParams{
+					MedicineID:     medicine.ID,
+					FromLocationID: 3,
+					ToLocationID:   2,
+					Quantity:       20,
+					Note:           sql.NullString{String: "restock cabinet", Valid: true},
+					CreatedBy:      sql.NullString{String: user.Username, Valid: true},
+				}
+				movement := db.StockMovement{
+					MedicineID:   medicine.ID,
+					MovementType: db.MovementTransfer,
+					Balance:      medicine.Stock,
+					BatchID:      sql.NullInt64{Int64: 7, Valid: true},
+				}
+				from, to := movement, movement
+				from.ID, from.Quantity, from.LocationID = 1, -20, sql.NullInt32{Int32: 3, Valid: true}
+				to.ID, to.Quantity, to.LocationID = 2, 20, sql.NullInt32{Int32: 2, Valid: true}
+				store.EXPECT().
+					StockTransferTx(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(db.StockTransferTxResult{
+						Transfer: db.StockTransfer{
+							ID:             1,
+							MedicineID:     medicine.ID,
+							FromLocationID: 3,
+							ToLocationID:   2,
+							Quantity:       20,
+						},
+						Medicine:      medicine,
+						FromMovements: []db.StockMovement{from},
+						ToMovements:   []db.StockMovement{to},
+					}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var response struct {
+					Data stockTransferResponse `json:"data"`
+				}
+				err := json.Unmarshal(recorder.Body.Bytes(), &response)
+				require.NoError(t, err)
+				require.Equal(t, int32(-20), response.Data.FromMovements[0].Quantity)
+				require.Equal(t, int32(3), *response.Data.FromMovements[0].LocationID)
+				require.Equal(t, int32(20), response.Data.ToMovements[0].Quantity)
+				require.Equal(t, int32(2), *response.Data.ToMovements[0].LocationID)
+				require.Equal(t, medicine.Stock, response.Data.ToMovements[0].Balance)
+			},
+		},
+		{
+			name: "SameLocation",
+			body: gin.H{
+				"medicine_id":      medicine.ID,
+				"from_location_id": 2,
+				"to_location_id":   2,
+				"quantity":         20,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					StockTransferTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "NonPositiveQuantity",
+			body: gin.H{
+				"medicine_id":      medicine.ID,
+				"from_location_id": 3,
+				"to_location_id":   2,
+				"quantity":         -1,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					StockTransferTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "InsufficientStock",
+			body: gin.H{
+				"medicine_id":      medicine.ID,
+				"from_location_id": 3,
+				"to_location_id":   2,
+				"quantity":         1000,
+				"batch_id":         7,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					StockTransferTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.StockTransferTxResult{}, fmt.Errorf("%w: %s has 40 of that lot in Back storeroom", db.ErrInsufficientStock, medicine.Name))
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name: "ArchivedMedicine",
+			body: gin.H{
+				"medicine_id":      medicine.ID,
+				"from_location_id": 3,
+				"to_location_id":   2,
+				"quantity":         5,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					StockTransferTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.StockTransferTxResult{}, fmt.Errorf("%w: %s", db.ErrMedicineArchived, medicine.Name))
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name: "LocationNotFound",
+			body: gin.H{
+				"medicine_id":      medicine.ID,
+				"from_location_id": 3,
+				"to_location_id":   99,
+				"quantity":         5,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					StockTransferTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.StockTransferTxResult{}, sql.ErrNoRows)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name: "InternalError",
+			body: gin.H{
+				"medicine_id":      medicine.ID,
+				"from_location_id": 3,
+				"to_location_id":   2,
+				"quantity":         5,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					StockTransferTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.StockTransferTxResult{}, sql.ErrConnDone)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().
+				GetUser(gomock.Any(), gomock.Eq(user.Username)).
+				Times(1).
+				Return(user, nil)
+			store.EXPECT().
+				GetPermissionsForUser(gomock.Any(), gomock.Eq(user.ID)).
+				Times(1).
+				Return([]string{"VIEW_SCREEN_MEDICINE"}, nil)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			request, err := http.NewRequest(http.MethodPost, "/stock-transfers", bytes.NewReader(data))
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
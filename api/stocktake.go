@@ -0,0 +1,428 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	db "github.com/toannguyen3105/nht-bsihuyen.com-api/db/sqlc"
+	"github.com/toannguyen3105/nht-bsihuyen.com-api/token"
+)
+
+type stocktakeCountResponse struct {
+	CountedBy string    `json:"counted_by"`
+	Quantity  int32     `json:"quantity"`
+	CountedAt time.Time `json:"counted_at"`
+}
+
+type stocktakeItemResponse struct {
+	ID               int64      `json:"id"`
+	MedicineID       int32      `json:"medicine_id"`
+	MedicineName     string     `json:"medicine_name"`
+	Unit             string     `json:"unit"`
+	BatchID          int64      `json:"batch_id"`
+	LotNumber        string     `json:"lot_number"`
+	ExpiryDate       *time.Time `json:"expiry_date"`
+	LocationID       int32      `json:"location_id"`
+	LocationName     string     `json:"location_name"`
+	ExpectedQuantity int32      `json:"expected_quantity"`
+	// MovedQuantity is the stock that moved in or out of the batch between
+	// the snapshot and the last count.
+	MovedQuantity int32 `json:"moved_quantity"`
+	// CountedQuantity and Variance are null until the item has been counted.
+	CountedQuantity *int32                   `json:"counted_quantity"`
+	Variance        *int32                   `json:"variance"`
+	Disputed        bool                     `json:"disputed"`
+	Counts          []stocktakeCountResponse `json:"counts,omitempty"`
+}
+
+type stocktakeResponse struct {
+	ID         int32                   `json:"id"`
+	Status     string                  `json:"status"`
+	Note       string                  `json:"note"`
+	CreatedBy  string                  `json:"created_by"`
+	CreatedAt  time.Time               `json:"created_at"`
+	ApprovedBy string                  `json:"approved_by"`
+	ApprovedAt *time.Time              `json:"approved_at"`
+	Items      []stocktakeItemResponse `json:"items,omitempty"`
+}
+
+func newStocktakeResponse(stocktake db.Stocktake) stocktakeResponse {
+	rsp := stocktakeResponse{
+		ID:         stocktake.ID,
+		Status:     stocktake.Status,
+		Note:       stocktake.Note.String,
+		CreatedBy:  stocktake.CreatedBy.String,
+		CreatedAt:  stocktake.CreatedAt,
+		ApprovedBy: stocktake.ApprovedBy.String,
+	}
+	if stocktake.ApprovedAt.Valid {
+		rsp.ApprovedAt = &stocktake.ApprovedAt.Time
+	}
+	return rsp
+}
+
+func newStocktakeItemResponse(item db.ListStocktakeItemsRow) stocktakeItemResponse {
+	rsp := stocktakeItemResponse{
+		ID:               item.ID,
+		MedicineID:       item.MedicineID,
+		MedicineName:     item.MedicineName,
+		Unit:             item.Unit,
+		BatchID:          item.BatchID,
+		LotNumber:        item.LotNumber,
+		LocationID:       item.LocationID,
+		LocationName:     item.LocationName,
+		ExpectedQuantity: item.ExpectedQuantity,
+		MovedQuantity:    item.MovedQuantity,
+		Disputed:         item.Disputed,
+	}
+	if item.ExpiryDate.Valid {
+		rsp.ExpiryDate = &item.ExpiryDate.Time
+	}
+	if item.Counters > 0 {
+		variance := item.CountedQuantity - item.ExpectedQuantity - item.MovedQuantity
+		rsp.CountedQuantity = &item.CountedQuantity
+		rsp.Variance = &variance
+	}
+	return rsp
+}
+
+func newStocktakeItemsResponse(items []db.ListStocktakeItemsRow) []stocktakeItemResponse {
+	rsp := make([]stocktakeItemResponse, len(items))
+	for i, item := range items {
+		rsp[i] = newStocktakeItemResponse(item)
+	}
+	return rsp
+}
+
+type createStocktakeRequest struct {
+	MedicineIDs []int32 `json:"medicine_ids" binding:"omitempty,unique,dive,min=1"`
+	Note        string  `json:"note"`
+}
+
+// createStocktake starts a stocktake of the given medicines, or of all of
+// them, taking the batches in stock as the expected quantities.
+func (server *Server) createStocktake(ctx *gin.Context) {
+	var req createStocktakeRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	result, err := server.store.CreateStocktakeTx(ctx, db.CreateStocktakeTxParams{
+		MedicineIDs: req.MedicineIDs,
+		Note:        sql.NullString{String: req.Note, Valid: req.Note != ""},
+		CreatedBy:   sql.NullString{String: authPayload.Username, Valid: true},
+	})
+	if err != nil {
+		if errors.Is(err, db.ErrInvalidStocktake) {
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	rsp := newStocktakeResponse(result.Stocktake)
+	rsp.Items = newStocktakeItemsResponse(result.Items)
+	ctx.JSON(http.StatusOK, successResponse("Stocktake created successfully", rsp))
+}
+
+type listStocktakesRequest struct {
+	PageID   int32 `form:"page_id" binding:"required,min=1"`
+	PageSize int32 `form:"page_size" binding:"required,min=5,max=100"`
+}
+
+type stocktakesResponse struct {
+	Meta struct {
+		Page       int32 `json:"page"`
+		TotalPages int32 `json:"total_pages"`
+		TotalCount int64 `json:"total_count"`
+	} `json:"meta"`
+	Data []stocktakeResponse `json:"data"`
+}
+
+func (server *Server) listStocktakes(ctx *gin.Context) {
+	var req listStocktakesRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	stocktakes, err := server.store.ListStocktakes(ctx, db.ListStocktakesParams{
+		Limit:  req.PageSize,
+		Offset: (req.PageID - 1) * req.PageSize,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	totalCount, err := server.store.CountStocktakes(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	totalPages := int32(totalCount) / req.PageSize
+	if int32(totalCount)%req.PageSize != 0 {
+		totalPages++
+	}
+
+	rsp := stocktakesResponse{
+		Meta: struct {
+			Page       int32 `json:"page"`
+			TotalPages int32 `json:"total_pages"`
+			TotalCount int64 `json:"total_count"`
+		}{
+			Page:       req.PageID,
+			TotalPages: totalPages,
+			TotalCount: totalCount,
+		},
+		Data: make([]stocktakeResponse, len(stocktakes)),
+	}
+	for i, stocktake := range stocktakes {
+		rsp.Data[i] = newStocktakeResponse(stocktake)
+	}
+
+	ctx.JSON(http.StatusOK, successResponse("Stocktakes retrieved successfully", rsp))
+}
+
+type getStocktakeRequest struct {
+	ID int32 `uri:"id" binding:"required,min=1"`
+}
+
+type getStocktakeQuery struct {
+	VariancesOnly bool `form:"variances_only"`
+}
+
+// getStocktake returns a stocktake with its items and who counted what.
+// variances_only=true leaves out the items that are uncounted or match, but
+// keeps disputed ones.
+func (server *Server) getStocktake(ctx *gin.Context) {
+	var req getStocktakeRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var query getStocktakeQuery
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	stocktake, err := server.store.GetStocktake(ctx, req.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	items, err := server.store.ListStocktakeItems(ctx, stocktake.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	counts, err := server.store.ListStocktakeCounts(ctx, stocktake.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	countsByItem := make(map[int64][]stocktakeCountResponse)
+	for _, count := range counts {
+		countsByItem[count.StocktakeItemID] = append(countsByItem[count.StocktakeItemID], stocktakeCountResponse{
+			CountedBy: count.CountedBy,
+			Quantity:  count.Quantity,
+			CountedAt: count.CountedAt,
+		})
+	}
+
+	rsp := newStocktakeResponse(stocktake)
+	rsp.Items = []stocktakeItemResponse{}
+	for _, item := range items {
+		itemRsp := newStocktakeItemResponse(item)
+		if query.VariancesOnly && !itemRsp.Disputed && (itemRsp.Variance == nil || *itemRsp.Variance == 0) {
+			continue
+		}
+		itemRsp.Counts = countsByItem[item.ID]
+		rsp.Items = append(rsp.Items, itemRsp)
+	}
+
+	ctx.JSON(http.StatusOK, successResponse("Stocktake retrieved successfully", rsp))
+}
+
+type stocktakeCountRequest struct {
+	ItemID   int64  `json:"item_id" binding:"required,min=1"`
+	Quantity *int32 `json:"quantity" binding:"required,min=0"`
+}
+
+type recordStocktakeCountsRequest struct {
+	Counts []stocktakeCountRequest `json:"counts" binding:"required,min=1,dive"`
+}
+
+// recordStocktakeCounts saves the caller's counts. Several people can count
+// the same stocktake, each their own part of the shelves; an item's counted
+// quantity is the sum of everyone's counts, and counting an item again
+// replaces the caller's earlier count of it.
+func (server *Server) recordStocktakeCounts(ctx *gin.Context) {
+	var reqURI getStocktakeRequest
+	if err := ctx.ShouldBindUri(&reqURI); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req recordStocktakeCountsRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	arg := db.RecordStocktakeCountsTxParams{
+		StocktakeID: reqURI.ID,
+		CountedBy:   authPayload.Username,
+		Counts:      make([]db.StocktakeCountParams, len(req.Counts)),
+	}
+	for i, count := range req.Counts {
+		arg.Counts[i] = db.StocktakeCountParams{
+			ItemID:   count.ItemID,
+			Quantity: *count.Quantity,
+		}
+	}
+
+	result, err := server.store.RecordStocktakeCountsTx(ctx, arg)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		if errors.Is(err, db.ErrInvalidStocktake) {
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+		if errors.Is(err, db.ErrStocktakeStatus) {
+			ctx.JSON(http.StatusConflict, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	rsp := newStocktakeResponse(result.Stocktake)
+	rsp.Items = newStocktakeItemsResponse(result.Items)
+	ctx.JSON(http.StatusOK, successResponse("Stocktake counts recorded successfully", rsp))
+}
+
+// approveStocktakeRequest is the optional body of an approval. A witness is
+// needed when a controlled medicine has a variance.
+type approveStocktakeRequest struct {
+	Witness *witnessRequest `json:"witness"`
+}
+
+type approveStocktakeResponse struct {
+	Stocktake stocktakeResponse       `json:"stocktake"`
+	Movements []stockMovementResponse `json:"movements"`
+}
+
+// approveStocktake posts the variances of a fully counted stocktake as
+// adjustments and records who approved it.
+func (server *Server) approveStocktake(ctx *gin.Context) {
+	var req getStocktakeRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var body approveStocktakeRequest
+	if ctx.Request.ContentLength != 0 {
+		if err := ctx.ShouldBindJSON(&body); err != nil {
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+	}
+
+	witnessedBy, ok := server.authenticateWitness(ctx, body.Witness)
+	if !ok {
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	result, err := server.store.ApproveStocktakeTx(ctx, db.ApproveStocktakeTxParams{
+		StocktakeID: req.ID,
+		ApprovedBy:  sql.NullString{String: authPayload.Username, Valid: true},
+		WitnessedBy: witnessedBy,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		if errors.Is(err, db.ErrWitnessRequired) {
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+		if errors.Is(err, db.ErrStocktakeStatus) || errors.Is(err, db.ErrStocktakeIncomplete) || errors.Is(err, db.ErrStocktakeDisputed) || errors.Is(err, db.ErrInsufficientStock) {
+			ctx.JSON(http.StatusConflict, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	rsp := approveStocktakeResponse{
+		Stocktake: newStocktakeResponse(result.Stocktake),
+		Movements: []stockMovementResponse{},
+	}
+	rsp.Stocktake.Items = newStocktakeItemsResponse(result.Items)
+	for _, movement := range result.Movements {
+		for _, m := range movement.Movements {
+			rsp.Movements = append(rsp.Movements, newStockMovementResponse(m))
+		}
+	}
+
+	ctx.JSON(http.StatusOK, successResponse("Stocktake approved successfully", rsp))
+}
+
+// deleteStocktake discards an open stocktake with its counts.
+func (server *Server) deleteStocktake(ctx *gin.Context) {
+	var req getStocktakeRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	deleted, err := server.store.DeleteStocktake(ctx, req.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if deleted == 0 {
+		_, err := server.store.GetStocktake(ctx, req.ID)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				ctx.JSON(http.StatusNotFound, errorResponse(err))
+				return
+			}
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+
+		ctx.JSON(http.StatusConflict, errorResponse(db.ErrStocktakeStatus))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, successResponse("Stocktake deleted successfully", nil))
+}
@@ -0,0 +1,364 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	mockdb "github.com/toannguyen3105/nht-bsihuyen.com-api/db/mock"
+	db "github.com/toannguyen3105/nht-bsihuyen.com-api/db/sqlc"
+)
+
+func TestRecordStocktakeCountsAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	stocktake := db.Stocktake{ID: 3, Status: db.StocktakeOpen}
+	item := randomStocktakeItem(stocktake.ID)
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{"counts": []gin.H{{"item_id": item.ID, "quantity": 0}}},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.RecordStocktakeCountsTxParams{
+					StocktakeID: stocktake.ID,
+					CountedBy:   user.Username,
+					Counts:      []db.StocktakeCountParams{{ItemID: item.ID, Quantity: 0}},
+				}
+				counted := item
+				counted.Counters = 1
+				store.EXPECT().
+					RecordStocktakeCountsTx(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(db.StocktakeTxResult{Stocktake: stocktake, Items: []db.ListStocktakeItemsRow{counted}}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var response struct {
+					Data stocktakeResponse `json:"data"`
+				}
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+				require.Len(t, response.Data.Items, 1)
+				require.Equal(t, int32(0), *response.Data.Items[0].CountedQuantity)
+				require.Equal(t, -item.ExpectedQuantity, *response.Data.Items[0].Variance)
+			},
+		},
+		{
+			name: "MissingQuantity",
+			body: gin.H{"counts": []gin.H{{"item_id": item.ID}}},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					RecordStocktakeCountsTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "ItemNotOnStocktake",
+			body: gin.H{"counts": []gin.H{{"item_id": 999, "quantity": 5}}},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					RecordStocktakeCountsTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.StocktakeTxResult{}, db.ErrInvalidStocktake)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "Approved",
+			body: gin.H{"counts": []gin.H{{"item_id": item.ID, "quantity": 5}}},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					RecordStocktakeCountsTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.StocktakeTxResult{}, db.ErrStocktakeStatus)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().
+				GetUser(gomock.Any(), gomock.Eq(user.Username)).
+				Times(1).
+				Return(user, nil)
+			store.EXPECT().
+				GetPermissionsForUser(gomock.Any(), gomock.Eq(user.ID)).
+				Times(1).
+				Return([]string{"VIEW_SCREEN_MEDICINE"}, nil)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/stocktakes/%d/counts", stocktake.ID)
+			request, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestApproveStocktakeAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	stocktake := db.Stocktake{ID: 3, Status: db.StocktakeOpen}
+	item := randomStocktakeItem(stocktake.ID)
+
+	testCases := []struct {
+		name          string
+		permissions   []string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:        "OK",
+			permissions: []string{"APPROVE_STOCKTAKE"},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.ApproveStocktakeTxParams{
+					StocktakeID: stocktake.ID,
+					ApprovedBy:  sql.NullString{String: user.Username, Valid: true},
+				}
+				approved := stocktake
+				approved.Status = db.StocktakeApproved
+				approved.ApprovedBy = arg.ApprovedBy
+				store.EXPECT().
+					ApproveStocktakeTx(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(db.ApproveStocktakeTxResult{
+						Stocktake: approved,
+						Items:     []db.ListStocktakeItemsRow{item},
+						Movements: []db.StockMovementTxResult{{
+							Movements: []db.StockMovement{{
+								MedicineID:   item.MedicineID,
+								MovementType: db.MovementAdjustment,
+								Quantity:     -2,
+								BatchID:      sql.NullInt64{Int64: item.BatchID, Valid: true},
+							}},
+						}},
+					}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var response struct {
+					Data approveStocktakeResponse `json:"data"`
+				}
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+				require.Equal(t, db.StocktakeApproved, response.Data.Stocktake.Status)
+				require.Equal(t, user.Username, response.Data.Stocktake.ApprovedBy)
+				require.Len(t, response.Data.Movements, 1)
+			},
+		},
+		{
+			name:        "NoPermission",
+			permissions: []string{"VIEW_SCREEN_MEDICINE"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ApproveStocktakeTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:        "Incomplete",
+			permissions: []string{"APPROVE_STOCKTAKE"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ApproveStocktakeTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.ApproveStocktakeTxResult{}, db.ErrStocktakeIncomplete)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name:        "Disputed",
+			permissions: []string{"APPROVE_STOCKTAKE"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ApproveStocktakeTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.ApproveStocktakeTxResult{}, fmt.Errorf("%w: lot LOT-1 of Paracetamol", db.ErrStocktakeDisputed))
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name:        "NotFound",
+			permissions: []string{"APPROVE_STOCKTAKE"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ApproveStocktakeTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.ApproveStocktakeTxResult{}, sql.ErrNoRows)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().
+				GetUser(gomock.Any(), gomock.Eq(user.Username)).
+				Times(1).
+				Return(user, nil)
+			store.EXPECT().
+				GetPermissionsForUser(gomock.Any(), gomock.Eq(user.ID)).
+				Times(1).
+				Return(tc.permissions, nil)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/stocktakes/%d/approve", stocktake.ID)
+			request, err := http.NewRequest(http.MethodPost, url, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestGetStocktakeAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	stocktake := db.Stocktake{ID: 3, Status: db.StocktakeOpen}
+
+	matching := randomStocktakeItem(stocktake.ID)
+	matching.Counters = 1
+	matching.CountedQuantity = matching.ExpectedQuantity
+
+	short := randomStocktakeItem(stocktake.ID)
+	short.ID++
+	short.Counters = 2
+	short.CountedQuantity = short.ExpectedQuantity - 1
+
+	uncounted := randomStocktakeItem(stocktake.ID)
+	uncounted.ID += 2
+
+	// five were dispensed before the count, so the shelf matches
+	dispensed := randomStocktakeItem(stocktake.ID)
+	dispensed.ID += 3
+	dispensed.Counters = 1
+	dispensed.MovedQuantity = -5
+	dispensed.CountedQuantity = dispensed.ExpectedQuantity - 5
+
+	disputed := randomStocktakeItem(stocktake.ID)
+	disputed.ID += 4
+	disputed.Counters = 2
+	disputed.CountedQuantity = disputed.ExpectedQuantity
+	disputed.Disputed = true
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().
+		GetUser(gomock.Any(), gomock.Eq(user.Username)).
+		Times(1).
+		Return(user, nil)
+	store.EXPECT().
+		GetPermissionsForUser(gomock.Any(), gomock.Eq(user.ID)).
+		Times(1).
+		Return([]string{"VIEW_SCREEN_MEDICINE"}, nil)
+	store.EXPECT().
+		GetStocktake(gomock.Any(), gomock.Eq(stocktake.ID)).
+		Times(1).
+		Return(stocktake, nil)
+	store.EXPECT().
+		ListStocktakeItems(gomock.Any(), gomock.Eq(stocktake.ID)).
+		Times(1).
+		Return([]db.ListStocktakeItemsRow{matching, short, uncounted, dispensed, disputed}, nil)
+	store.EXPECT().
+		ListStocktakeCounts(gomock.Any(), gomock.Eq(stocktake.ID)).
+		Times(1).
+		Return([]db.StocktakeCount{
+			{StocktakeItemID: short.ID, CountedBy: "an", Quantity: short.CountedQuantity},
+			{StocktakeItemID: short.ID, CountedBy: "binh", Quantity: short.CountedQuantity},
+		}, nil)
+
+	server := newTestServer(t, store)
+	recorder := httptest.NewRecorder()
+
+	url := fmt.Sprintf("/stocktakes/%d?variances_only=true", stocktake.ID)
+	request, err := http.NewRequest(http.MethodGet, url, nil)
+	require.NoError(t, err)
+
+	addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+	server.router.ServeHTTP(recorder, request)
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	var response struct {
+		Data stocktakeResponse `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+	require.Len(t, response.Data.Items, 2)
+	require.Equal(t, short.ID, response.Data.Items[0].ID)
+	require.Equal(t, int32(-1), *response.Data.Items[0].Variance)
+	require.Equal(t, short.LocationName, response.Data.Items[0].LocationName)
+	require.Len(t, response.Data.Items[0].Counts, 2)
+	require.Equal(t, disputed.ID, response.Data.Items[1].ID)
+	require.True(t, response.Data.Items[1].Disputed)
+}
+
+func randomStocktakeItem(stocktakeID int32) db.ListStocktakeItemsRow {
+	medicine := randomMedicine()
+	return db.ListStocktakeItemsRow{
+		ID:               10,
+		StocktakeID:      stocktakeID,
+		MedicineID:       medicine.ID,
+		BatchID:          20,
+		LocationID:       1,
+		LocationName:     "Main pharmacy",
+		ExpectedQuantity: 50,
+		MedicineName:     medicine.Name,
+		Unit:             medicine.Unit,
+		LotNumber:        "LOT-1",
+	}
+}
@@ -0,0 +1,204 @@
+package api
+
+import (
+	"database/sql"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/lib/pq"
+	db "github.com/toannguyen3105/nht-bsihuyen.com-api/db/sqlc"
+)
+
+type createSupplierRequest struct {
+	Name         string `json:"name" binding:"required,max=255"`
+	ContactName  string `json:"contact_name" binding:"max=255"`
+	Phone        string `json:"phone" binding:"max=50"`
+	Email        string `json:"email" binding:"omitempty,email,max=255"`
+	Address      string `json:"address"`
+	LeadTimeDays *int32 `json:"lead_time_days" binding:"omitempty,min=0"`
+}
+
+type supplierResponse struct {
+	ID           int32     `json:"id"`
+	Name         string    `json:"name"`
+	ContactName  string    `json:"contact_name"`
+	Phone        string    `json:"phone"`
+	Email        string    `json:"email"`
+	Address      string    `json:"address"`
+	LeadTimeDays int32     `json:"lead_time_days"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+func newSupplierResponse(supplier db.Supplier) supplierResponse {
+	return supplierResponse{
+		ID:           supplier.ID,
+		Name:         supplier.Name,
+		ContactName:  supplier.ContactName.String,
+		Phone:        supplier.Phone.String,
+		Email:        supplier.Email.String,
+		Address:      supplier.Address.String,
+		LeadTimeDays: supplier.LeadTimeDays,
+		CreatedAt:    supplier.CreatedAt,
+		UpdatedAt:    supplier.UpdatedAt,
+	}
+}
+
+func (server *Server) createSupplier(ctx *gin.Context) {
+	var req createSupplierRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	arg := db.CreateSupplierParams{
+		Name:         req.Name,
+		ContactName:  sql.NullString{String: req.ContactName, Valid: req.ContactName != ""},
+		Phone:        sql.NullString{String: req.Phone, Valid: req.Phone != ""},
+		Email:        sql.NullString{String: req.Email, Valid: req.Email != ""},
+		Address:      sql.NullString{String: req.Address, Valid: req.Address != ""},
+		LeadTimeDays: db.DefaultLeadTimeDays,
+	}
+	if req.LeadTimeDays != nil {
+		arg.LeadTimeDays = *req.LeadTimeDays
+	}
+
+	supplier, err := server.store.CreateSupplier(ctx, arg)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code.Name() == "unique_violation" {
+			ctx.JSON(http.StatusForbidden, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, successResponse("Supplier created successfully", newSupplierResponse(supplier)))
+}
+
+type getSupplierRequest struct {
+	ID int32 `uri:"id" binding:"required,min=1"`
+}
+
+func (server *Server) getSupplier(ctx *gin.Context) {
+	var req getSupplierRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	supplier, err := server.store.GetSupplier(ctx, req.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, successResponse("Supplier retrieved successfully", newSupplierResponse(supplier)))
+}
+
+func (server *Server) listSuppliers(ctx *gin.Context) {
+	suppliers, err := server.store.ListSuppliers(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	rsp := make([]supplierResponse, len(suppliers))
+	for i, supplier := range suppliers {
+		rsp[i] = newSupplierResponse(supplier)
+	}
+
+	ctx.JSON(http.StatusOK, successResponse("Suppliers retrieved successfully", rsp))
+}
+
+type updateSupplierRequest struct {
+	Name         *string `json:"name" binding:"omitempty,min=1,max=255"`
+	ContactName  *string `json:"contact_name" binding:"omitempty,max=255"`
+	Phone        *string `json:"phone" binding:"omitempty,max=50"`
+	Email        *string `json:"email" binding:"omitempty,email,max=255"`
+	Address      *string `json:"address"`
+	LeadTimeDays *int32  `json:"lead_time_days" binding:"omitempty,min=0"`
+}
+
+func (server *Server) updateSupplier(ctx *gin.Context) {
+	var reqURI getSupplierRequest
+	if err := ctx.ShouldBindUri(&reqURI); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req updateSupplierRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	arg := db.UpdateSupplierParams{
+		ID: reqURI.ID,
+	}
+	if req.Name != nil {
+		arg.Name = sql.NullString{String: *req.Name, Valid: true}
+	}
+	if req.ContactName != nil {
+		arg.ContactName = sql.NullString{String: *req.ContactName, Valid: true}
+	}
+	if req.Phone != nil {
+		arg.Phone = sql.NullString{String: *req.Phone, Valid: true}
+	}
+	if req.Email != nil {
+		arg.Email = sql.NullString{String: *req.Email, Valid: true}
+	}
+	if req.Address != nil {
+		arg.Address = sql.NullString{String: *req.Address, Valid: true}
+	}
+	if req.LeadTimeDays != nil {
+		arg.LeadTimeDays = sql.NullInt32{Int32: *req.LeadTimeDays, Valid: true}
+	}
+
+	supplier, err := server.store.UpdateSupplier(ctx, arg)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code.Name() == "unique_violation" {
+			ctx.JSON(http.StatusForbidden, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, successResponse("Supplier updated successfully", newSupplierResponse(supplier)))
+}
+
+// deleteSupplier refuses suppliers that purchase orders were placed with.
+func (server *Server) deleteSupplier(ctx *gin.Context) {
+	var req getSupplierRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	deleted, err := server.store.DeleteSupplier(ctx, req.ID)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code.Name() == "foreign_key_violation" {
+			ctx.JSON(http.StatusForbidden, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if deleted == 0 {
+		ctx.JSON(http.StatusNotFound, errorResponse(sql.ErrNoRows))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, successResponse("Supplier deleted successfully", nil))
+}
@@ -0,0 +1,135 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+	mockdb "github.com/toannguyen3105/nht-bsihuyen.com-api/db/mock"
+	db "github.com/toannguyen3105/nht-bsihuyen.com-api/db/sqlc"
+)
+
+func TestCreateSupplierAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	supplier := db.Supplier{
+		ID:           1,
+		Name:         "Phuong Dong Pharma",
+		Email:        sql.NullString{String: "sales@phuongdong.vn", Valid: true},
+		LeadTimeDays: db.DefaultLeadTimeDays,
+	}
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{"name": supplier.Name, "email": supplier.Email.String},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.CreateSupplierParams{
+					Name:         supplier.Name,
+					Email:        supplier.Email,
+					LeadTimeDays: db.DefaultLeadTimeDays,
+				}
+				store.EXPECT().
+					CreateSupplier(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(supplier, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var response struct {
+					Data supplierResponse `json:"data"`
+				}
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+				require.Equal(t, supplier.Email.String, response.Data.Email)
+				require.Equal(t, int32(db.DefaultLeadTimeDays), response.Data.LeadTimeDays)
+			},
+		},
+		{
+			name: "LeadTime",
+			body: gin.H{"name": supplier.Name, "lead_time_days": 0},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.CreateSupplierParams{
+					Name: supplier.Name,
+				}
+				store.EXPECT().
+					CreateSupplier(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(supplier, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "InvalidEmail",
+			body: gin.H{"name": supplier.Name, "email": "sales"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateSupplier(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "DuplicateName",
+			body: gin.H{"name": supplier.Name},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					CreateSupplier(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.Supplier{}, &pq.Error{Code: "23505"})
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().
+				GetUser(gomock.Any(), gomock.Eq(user.Username)).
+				Times(1).
+				Return(user, nil)
+			store.EXPECT().
+				GetPermissionsForUser(gomock.Any(), gomock.Eq(user.ID)).
+				Times(1).
+				Return([]string{"VIEW_SCREEN_MEDICINE"}, nil)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			request, err := http.NewRequest(http.MethodPost, "/suppliers", bytes.NewReader(data))
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
@@ -2,13 +2,11 @@ package api
 
 import (
 	"database/sql"
-	"errors"
 	"fmt"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
 	db "github.com/toannguyen3105/nht-bsihuyen.com-api/db/sqlc"
-	"github.com/toannguyen3105/nht-bsihuyen.com-api/token"
 )
 
 type transferRequest struct {
@@ -31,10 +29,7 @@ func (server *Server) createTransfer(ctx *gin.Context) {
 		return
 	}
 
-	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
-	if fromAccount.Owner != authPayload.Username {
-		err := errors.New("from account does not belong to the authenticated user")
-		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+	if !server.authorizeOwner(ctx, transferPolicy, fromAccount.Owner) {
 		return
 	}
 
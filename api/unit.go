@@ -0,0 +1,192 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/lib/pq"
+	db "github.com/toannguyen3105/nht-bsihuyen.com-api/db/sqlc"
+)
+
+var errOwnUnitConversion = errors.New("a medicine's own unit needs no conversion")
+
+type createUnitRequest struct {
+	Name        string `json:"name" binding:"required,max=50"`
+	Description string `json:"description" binding:"max=255"`
+}
+
+type unitResponse struct {
+	ID          int32     `json:"id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+func newUnitResponse(unit db.Unit) unitResponse {
+	return unitResponse{
+		ID:          unit.ID,
+		Name:        unit.Name,
+		Description: unit.Description.String,
+		CreatedAt:   unit.CreatedAt,
+	}
+}
+
+func (server *Server) createUnit(ctx *gin.Context) {
+	var req createUnitRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	unit, err := server.store.CreateUnit(ctx, db.CreateUnitParams{
+		Name:        req.Name,
+		Description: sql.NullString{String: req.Description, Valid: req.Description != ""},
+	})
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code.Name() == "unique_violation" {
+			ctx.JSON(http.StatusForbidden, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, successResponse("Unit created successfully", newUnitResponse(unit)))
+}
+
+// listUnits returns every unit; there are few enough to fill a picker without
+// paging.
+func (server *Server) listUnits(ctx *gin.Context) {
+	units, err := server.store.ListUnits(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	rsp := make([]unitResponse, len(units))
+	for i, unit := range units {
+		rsp[i] = newUnitResponse(unit)
+	}
+
+	ctx.JSON(http.StatusOK, successResponse("Units retrieved successfully", rsp))
+}
+
+type deleteUnitRequest struct {
+	Name string `uri:"name" binding:"required,max=50"`
+}
+
+// deleteUnit refuses units still used by a medicine or a conversion.
+func (server *Server) deleteUnit(ctx *gin.Context) {
+	var req deleteUnitRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	deleted, err := server.store.DeleteUnit(ctx, req.Name)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code.Name() == "foreign_key_violation" {
+			ctx.JSON(http.StatusForbidden, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if deleted == 0 {
+		ctx.JSON(http.StatusNotFound, errorResponse(sql.ErrNoRows))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, successResponse("Unit deleted successfully", nil))
+}
+
+type medicineUnitRequest struct {
+	ID   int32  `uri:"id" binding:"required,min=1"`
+	Unit string `uri:"unit" binding:"required,max=50"`
+}
+
+type setMedicineUnitConversionRequest struct {
+	Factor int32 `json:"factor" binding:"required,min=1"`
+}
+
+// setMedicineUnitConversion sets how many of the medicine's own unit one of
+// another unit holds. Chains such as box → blister → tablet are set per unit
+// against the medicine's unit: a tablet medicine with 10 tablets per blister
+// and 10 blisters per box has blister = 10 and box = 100.
+func (server *Server) setMedicineUnitConversion(ctx *gin.Context) {
+	var reqURI medicineUnitRequest
+	if err := ctx.ShouldBindUri(&reqURI); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req setMedicineUnitConversionRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	medicine, err := server.store.GetMedicine(ctx, reqURI.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if reqURI.Unit == medicine.Unit {
+		ctx.JSON(http.StatusBadRequest, errorResponse(errOwnUnitConversion))
+		return
+	}
+
+	conversion, err := server.store.UpsertMedicineUnitConversion(ctx, db.UpsertMedicineUnitConversionParams{
+		MedicineID: reqURI.ID,
+		Unit:       reqURI.Unit,
+		Factor:     req.Factor,
+	})
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code.Name() == "foreign_key_violation" {
+			ctx.JSON(http.StatusForbidden, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	rsp := medicineUnitConversionResponse{
+		Unit:   conversion.Unit,
+		Factor: conversion.Factor,
+	}
+
+	ctx.JSON(http.StatusOK, successResponse("Unit conversion saved successfully", rsp))
+}
+
+func (server *Server) deleteMedicineUnitConversion(ctx *gin.Context) {
+	var req medicineUnitRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	deleted, err := server.store.DeleteMedicineUnitConversion(ctx, db.DeleteMedicineUnitConversionParams{
+		MedicineID: req.ID,
+		Unit:       req.Unit,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if deleted == 0 {
+		ctx.JSON(http.StatusNotFound, errorResponse(sql.ErrNoRows))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, successResponse("Unit conversion deleted successfully", nil))
+}
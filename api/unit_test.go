@@ -0,0 +1,229 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+	mockdb "github.com/toannguyen3105/nht-bsihuyen.com-api/db/mock"
+	db "github.com/toannguyen3105/nht-bsihuyen.com-api/db/sqlc"
+)
+
+func TestSetMedicineUnitConversionAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	medicine := randomMedicine()
+
+	testCases := []struct {
+		name          string
+		unit          string
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			unit: "box",
+			body: gin.H{"factor": 100},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetMedicine(gomock.Any(), gomock.Eq(medicine.ID)).
+					Times(1).
+					Return(medicine, nil)
+				arg := db.UpsertMedicineUnitConversionParams{
+					MedicineID: medicine.ID,
+					Unit:       "box",
+					Factor:     100,
+				}
+				store.EXPECT().
+					UpsertMedicineUnitConversion(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(db.MedicineUnitConversion{MedicineID: medicine.ID, Unit: "box", Factor: 100}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "OwnUnit",
+			unit: medicine.Unit,
+			body: gin.H{"factor": 2},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetMedicine(gomock.Any(), gomock.Eq(medicine.ID)).
+					Times(1).
+					Return(medicine, nil)
+				store.EXPECT().
+					UpsertMedicineUnitConversion(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "UnknownUnit",
+			unit: "sachet",
+			body: gin.H{"factor": 5},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetMedicine(gomock.Any(), gomock.Eq(medicine.ID)).
+					Times(1).
+					Return(medicine, nil)
+				store.EXPECT().
+					UpsertMedicineUnitConversion(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.MedicineUnitConversion{}, &pq.Error{Code: "23503"})
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name: "InvalidFactor",
+			unit: "box",
+			body: gin.H{"factor": 0},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetMedicine(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "MedicineNotFound",
+			unit: "box",
+			body: gin.H{"factor": 100},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetMedicine(gomock.Any(), gomock.Eq(medicine.ID)).
+					Times(1).
+					Return(db.Medicine{}, sql.ErrNoRows)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().
+				GetUser(gomock.Any(), gomock.Eq(user.Username)).
+				Times(1).
+				Return(user, nil)
+			store.EXPECT().
+				GetPermissionsForUser(gomock.Any(), gomock.Eq(user.ID)).
+				Times(1).
+				Return([]string{"VIEW_SCREEN_MEDICINE"}, nil)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/medicines/%d/units/%s", medicine.ID, tc.unit)
+			request, err := http.NewRequest(http.MethodPut, url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestDeleteUnitAPI(t *testing.T) {
+	user, _ := randomUser(t)
+
+	testCases := []struct {
+		name          string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					DeleteUnit(gomock.Any(), gomock.Eq("sachet")).
+					Times(1).
+					Return(int64(1), nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "InUse",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					DeleteUnit(gomock.Any(), gomock.Eq("sachet")).
+					Times(1).
+					Return(int64(0), &pq.Error{Code: "23503"})
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name: "NotFound",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					DeleteUnit(gomock.Any(), gomock.Eq("sachet")).
+					Times(1).
+					Return(int64(0), nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().
+				GetUser(gomock.Any(), gomock.Eq(user.Username)).
+				Times(1).
+				Return(user, nil)
+			store.EXPECT().
+				GetPermissionsForUser(gomock.Any(), gomock.Eq(user.ID)).
+				Times(1).
+				Return([]string{"VIEW_SCREEN_MEDICINE"}, nil)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			request, err := http.NewRequest(http.MethodDelete, "/units/sachet", nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
@@ -0,0 +1,111 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/lib/pq"
+	db "github.com/toannguyen3105/nht-bsihuyen.com-api/db/sqlc"
+)
+
+const (
+	permissionEffectAllow = "allow"
+	permissionEffectDeny  = "deny"
+)
+
+type setUserPermissionOverrideRequest struct {
+	UserID       int32  `json:"user_id" binding:"required,min=1"`
+	PermissionID int32  `json:"permission_id" binding:"required,min=1"`
+	Effect       string `json:"effect" binding:"required,oneof=allow deny"`
+	Reason       string `json:"reason" binding:"max=255"`
+}
+
+func (server *Server) setUserPermissionOverride(ctx *gin.Context) {
+	var req setUserPermissionOverrideRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	arg := db.UpsertUserPermissionOverrideParams{
+		UserID:       req.UserID,
+		PermissionID: req.PermissionID,
+		Effect:       req.Effect,
+		Reason:       req.Reason,
+	}
+
+	override, err := server.store.UpsertUserPermissionOverrideTx(ctx, arg)
+	if err != nil {
+		if errors.Is(err, db.ErrLastAdmin) {
+			ctx.JSON(http.StatusConflict, errorResponse(err))
+			return
+		}
+		if pqErr, ok := err.(*pq.Error); ok {
+			switch pqErr.Code.Name() {
+			case "foreign_key_violation":
+				ctx.JSON(http.StatusForbidden, errorResponse(err))
+				return
+			}
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, successResponse("User permission override saved successfully", override))
+}
+
+type getUserPermissionOverridesRequest struct {
+	UserID int32 `uri:"id" binding:"required,min=1"`
+}
+
+func (server *Server) listUserPermissionOverrides(ctx *gin.Context) {
+	var req getUserPermissionOverridesRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	overrides, err := server.store.ListUserPermissionOverrides(ctx, req.UserID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, successResponse("User permission overrides retrieved successfully", overrides))
+}
+
+type deleteUserPermissionOverrideRequest struct {
+	UserID       int32 `json:"user_id" binding:"required,min=1"`
+	PermissionID int32 `json:"permission_id" binding:"required,min=1"`
+}
+
+func (server *Server) deleteUserPermissionOverride(ctx *gin.Context) {
+	var req deleteUserPermissionOverrideRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	arg := db.DeleteUserPermissionOverrideParams{
+		UserID:       req.UserID,
+		PermissionID: req.PermissionID,
+	}
+
+	err := server.store.DeleteUserPermissionOverrideTx(ctx, arg)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		if errors.Is(err, db.ErrLastAdmin) {
+			ctx.JSON(http.StatusConflict, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, successResponse("User permission override deleted successfully", nil))
+}
@@ -0,0 +1,247 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	mockdb "github.com/toannguyen3105/nht-bsihuyen.com-api/db/mock"
+	db "github.com/toannguyen3105/nht-bsihuyen.com-api/db/sqlc"
+)
+
+func TestSetUserPermissionOverrideAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	override := db.UserPermissionOverride{
+		UserID:       int32(7),
+		PermissionID: int32(5),
+		Effect:       permissionEffectDeny,
+		Reason:       "must not delete medicines",
+	}
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{
+				"user_id":       override.UserID,
+				"permission_id": override.PermissionID,
+				"effect":        override.Effect,
+				"reason":        override.Reason,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.UpsertUserPermissionOverrideParams{
+					UserID:       override.UserID,
+					PermissionID: override.PermissionID,
+					Effect:       override.Effect,
+					Reason:       override.Reason,
+				}
+				store.EXPECT().
+					UpsertUserPermissionOverrideTx(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(override, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "InvalidEffect",
+			body: gin.H{
+				"user_id":       override.UserID,
+				"permission_id": override.PermissionID,
+				"effect":        "maybe",
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					UpsertUserPermissionOverrideTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "LastAdmin",
+			body: gin.H{
+				"user_id":       override.UserID,
+				"permission_id": override.PermissionID,
+				"effect":        override.Effect,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					UpsertUserPermissionOverrideTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.UserPermissionOverride{}, db.ErrLastAdmin)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().
+				GetUser(gomock.Any(), gomock.Eq(user.Username)).
+				Times(1).
+				Return(user, nil)
+			store.EXPECT().
+				GetPermissionsForUser(gomock.Any(), gomock.Eq(user.ID)).
+				Times(1).
+				Return([]string{"VIEW_SCREEN_USER_ROLE"}, nil)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			request, err := http.NewRequest(http.MethodPost, "/user-permission-overrides", bytes.NewReader(data))
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestListUserPermissionOverridesAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	overrides := []db.ListUserPermissionOverridesRow{
+		{UserID: 7, PermissionID: 5, PermissionName: "DELETE_MEDICINE", Effect: permissionEffectDeny},
+	}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().
+		GetUser(gomock.Any(), gomock.Eq(user.Username)).
+		Times(1).
+		Return(user, nil)
+	store.EXPECT().
+		GetPermissionsForUser(gomock.Any(), gomock.Eq(user.ID)).
+		Times(1).
+		Return([]string{"VIEW_SCREEN_USER_ROLE"}, nil)
+	store.EXPECT().
+		ListUserPermissionOverrides(gomock.Any(), gomock.Eq(int32(7))).
+		Times(1).
+		Return(overrides, nil)
+
+	server := newTestServer(t, store)
+	recorder := httptest.NewRecorder()
+
+	request, err := http.NewRequest(http.MethodGet, fmt.Sprintf("/users/%d/permission-overrides", 7), nil)
+	require.NoError(t, err)
+
+	addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+	server.router.ServeHTTP(recorder, request)
+	require.Equal(t, http.StatusOK, recorder.Code)
+}
+
+func TestDeleteUserPermissionOverrideAPI(t *testing.T) {
+	user, _ := randomUser(t)
+	arg := db.DeleteUserPermissionOverrideParams{
+		UserID:       int32(7),
+		PermissionID: int32(5),
+	}
+
+	testCases := []struct {
+		name          string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					DeleteUserPermissionOverrideTx(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "NotFound",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					DeleteUserPermissionOverrideTx(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(sql.ErrNoRows)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name: "LastAdmin",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					DeleteUserPermissionOverrideTx(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(db.ErrLastAdmin)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().
+				GetUser(gomock.Any(), gomock.Eq(user.Username)).
+				Times(1).
+				Return(user, nil)
+			store.EXPECT().
+				GetPermissionsForUser(gomock.Any(), gomock.Eq(user.ID)).
+				Times(1).
+				Return([]string{"VIEW_SCREEN_USER_ROLE"}, nil)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(gin.H{
+				"user_id":       arg.UserID,
+				"permission_id": arg.PermissionID,
+			})
+			require.NoError(t, err)
+
+			request, err := http.NewRequest(http.MethodDelete, "/user-permission-overrides", bytes.NewReader(data))
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
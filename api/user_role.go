@@ -2,7 +2,9 @@ package api
 
 import (
 	"database/sql"
+	"errors"
 	"net/http"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/lib/pq"
@@ -14,17 +16,75 @@ type userRoleRequest struct {
 	RoleID int32 `json:"role_id" binding:"required,min=1"`
 }
 
+type userRoleResponse struct {
+	UserID     int32      `json:"user_id"`
+	RoleID     int32      `json:"role_id"`
+	ValidFrom  *time.Time `json:"valid_from"`
+	ValidUntil *time.Time `json:"valid_until"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+}
+
+func newUserRoleResponse(userRole db.UserRole) userRoleResponse {
+	rsp := userRoleResponse{
+		UserID:    userRole.UserID,
+		RoleID:    userRole.RoleID,
+		CreatedAt: userRole.CreatedAt,
+		UpdatedAt: userRole.UpdatedAt,
+	}
+	if userRole.ValidFrom.Valid {
+		rsp.ValidFrom = &userRole.ValidFrom.Time
+	}
+	if userRole.ValidUntil.Valid {
+		rsp.ValidUntil = &userRole.ValidUntil.Time
+	}
+	return rsp
+}
+
+var errInvalidValidity = errors.New("valid_until must be after valid_from")
+
+// validityWindow converts the optional bounds of a role assignment.
+func validityWindow(validFrom *time.Time, validUntil *time.Time) (from sql.NullTime, until sql.NullTime, err error) {
+	if validFrom != nil && validUntil != nil && !validUntil.After(*validFrom) {
+		err = errInvalidValidity
+		return
+	}
+
+	if validFrom != nil {
+		from = sql.NullTime{Time: *validFrom, Valid: true}
+	}
+	if validUntil != nil {
+		until = sql.NullTime{Time: *validUntil, Valid: true}
+	}
+	return
+}
+
+type addUserRoleRequest struct {
+	UserID     int32      `json:"user_id" binding:"required,min=1"`
+	RoleID     int32      `json:"role_id" binding:"required,min=1"`
+	ValidFrom  *time.Time `json:"valid_from"`
+	ValidUntil *time.Time `json:"valid_until"`
+}
+
 func (server *Server) addUserRole(ctx *gin.Context) {
-	var req userRoleRequest
+	var req addUserRoleRequest
 
 	if err := ctx.ShouldBindJSON(&req); err != nil {
 		ctx.JSON(http.StatusBadRequest, errorResponse(err))
 		return
 	}
 
+	validFrom, validUntil, err := validityWindow(req.ValidFrom, req.ValidUntil)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
 	arg := db.AddRoleForUserParams{
-		UserID: req.UserID,
-		RoleID: req.RoleID,
+		UserID:     req.UserID,
+		RoleID:     req.RoleID,
+		ValidFrom:  validFrom,
+		ValidUntil: validUntil,
 	}
 
 	userRole, err := server.store.AddRoleForUser(ctx, arg)
@@ -40,7 +100,7 @@ func (server *Server) addUserRole(ctx *gin.Context) {
 		return
 	}
 
-	ctx.JSON(http.StatusOK, successResponse("User role created successfully", userRole))
+	ctx.JSON(http.StatusOK, successResponse("User role created successfully", newUserRoleResponse(userRole)))
 }
 
 type getUserRolesRequest struct {
@@ -85,12 +145,16 @@ func (server *Server) deleteUserRole(ctx *gin.Context) {
 		RoleID: req.RoleID,
 	}
 
-	err := server.store.RemoveRoleForUser(ctx, arg)
+	err := server.store.RemoveRoleForUserTx(ctx, arg)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			ctx.JSON(http.StatusNotFound, errorResponse(err))
 			return
 		}
+		if errors.Is(err, db.ErrLastAdmin) {
+			ctx.JSON(http.StatusConflict, errorResponse(err))
+			return
+		}
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
@@ -99,9 +163,11 @@ func (server *Server) deleteUserRole(ctx *gin.Context) {
 }
 
 type updateUserRoleRequest struct {
-	UserID    int32 `json:"user_id" binding:"required,min=1"`
-	OldRoleID int32 `json:"old_role_id" binding:"required,min=1"`
-	NewRoleID int32 `json:"new_role_id" binding:"required,min=1"`
+	UserID     int32      `json:"user_id" binding:"required,min=1"`
+	OldRoleID  int32      `json:"old_role_id" binding:"required,min=1"`
+	NewRoleID  int32      `json:"new_role_id" binding:"required,min=1"`
+	ValidFrom  *time.Time `json:"valid_from"`
+	ValidUntil *time.Time `json:"valid_until"`
 }
 
 func (server *Server) updateUserRole(ctx *gin.Context) {
@@ -111,10 +177,18 @@ func (server *Server) updateUserRole(ctx *gin.Context) {
 		return
 	}
 
+	validFrom, validUntil, err := validityWindow(req.ValidFrom, req.ValidUntil)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
 	arg := db.UpdateUserRoleTxParams{
-		UserID:    req.UserID,
-		OldRoleID: req.OldRoleID,
-		NewRoleID: req.NewRoleID,
+		UserID:     req.UserID,
+		OldRoleID:  req.OldRoleID,
+		NewRoleID:  req.NewRoleID,
+		ValidFrom:  validFrom,
+		ValidUntil: validUntil,
 	}
 
 	result, err := server.store.UpdateUserRoleTx(ctx, arg)
@@ -123,11 +197,15 @@ func (server *Server) updateUserRole(ctx *gin.Context) {
 			ctx.JSON(http.StatusNotFound, errorResponse(err))
 			return
 		}
+		if errors.Is(err, db.ErrLastAdmin) {
+			ctx.JSON(http.StatusConflict, errorResponse(err))
+			return
+		}
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
 
-	ctx.JSON(http.StatusOK, successResponse("User role updated successfully", result.UserRole))
+	ctx.JSON(http.StatusOK, successResponse("User role updated successfully", newUserRoleResponse(result.UserRole)))
 }
 
 type listUserRolesRequest struct {
@@ -141,7 +219,7 @@ type userRolesResponse struct {
 		TotalPages int32 `json:"total_pages"`
 		TotalCount int64 `json:"total_count"`
 	} `json:"meta"`
-	Data []db.UserRole `json:"data"`
+	Data []userRoleResponse `json:"data"`
 }
 
 func (server *Server) listUserRoles(ctx *gin.Context) {
@@ -183,7 +261,10 @@ func (server *Server) listUserRoles(ctx *gin.Context) {
 			TotalPages: totalPages,
 			TotalCount: totalCount,
 		},
-		Data: userRoles,
+		Data: make([]userRoleResponse, len(userRoles)),
+	}
+	for i, userRole := range userRoles {
+		rsp.Data[i] = newUserRoleResponse(userRole)
 	}
 
 	ctx.JSON(http.StatusOK, successResponse("User roles retrieved successfully", rsp))
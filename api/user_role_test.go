@@ -31,6 +31,15 @@ func TestAddUserRoleAPI(t *testing.T) {
 		RoleID: role.ID,
 	}
 
+	validFrom := time.Now().UTC().Truncate(time.Second)
+	validUntil := validFrom.Add(30 * 24 * time.Hour)
+	timeBoundUserRole := db.UserRole{
+		UserID:     user.ID,
+		RoleID:     role.ID,
+		ValidFrom:  sql.NullTime{Time: validFrom, Valid: true},
+		ValidUntil: sql.NullTime{Time: validUntil, Valid: true},
+	}
+
 	testCases := []struct {
 		name          string
 		body          gin.H
@@ -38,6 +47,70 @@ func TestAddUserRoleAPI(t *testing.T) {
 		buildStubs    func(store *mockdb.MockStore)
 		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
 	}{
+		{
+			name: "TimeBound",
+			body: gin.H{
+				"user_id":     timeBoundUserRole.UserID,
+				"role_id":     timeBoundUserRole.RoleID,
+				"valid_from":  validFrom,
+				"valid_until": validUntil,
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					GetPermissionsForUser(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return([]string{"VIEW_SCREEN_USER_ROLE"}, nil)
+				arg := db.AddRoleForUserParams{
+					UserID:     timeBoundUserRole.UserID,
+					RoleID:     timeBoundUserRole.RoleID,
+					ValidFrom:  timeBoundUserRole.ValidFrom,
+					ValidUntil: timeBoundUserRole.ValidUntil,
+				}
+				store.EXPECT().
+					AddRoleForUser(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(timeBoundUserRole, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchUserRole(t, recorder.Body, timeBoundUserRole)
+			},
+		},
+		{
+			name: "InvalidValidity",
+			body: gin.H{
+				"user_id":     userRole.UserID,
+				"role_id":     userRole.RoleID,
+				"valid_from":  validUntil,
+				"valid_until": validFrom,
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					GetPermissionsForUser(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return([]string{"VIEW_SCREEN_USER_ROLE"}, nil)
+				store.EXPECT().
+					AddRoleForUser(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
 		{
 			name: "OK",
 			body: gin.H{
@@ -311,11 +384,11 @@ func requireBodyMatchUserRole(t *testing.T, body *bytes.Buffer, userRole db.User
 	require.NoError(t, err)
 
 	var response struct {
-		Data db.UserRole `json:"data"`
+		Data userRoleResponse `json:"data"`
 	}
 	err = json.Unmarshal(data, &response)
 	require.NoError(t, err)
-	require.Equal(t, userRole, response.Data)
+	require.Equal(t, newUserRoleResponse(userRole), response.Data)
 }
 
 func requireBodyMatchRoles(t *testing.T, body *bytes.Buffer, roles []db.Role) {
@@ -378,7 +451,7 @@ func TestDeleteUserRoleAPI(t *testing.T) {
 					RoleID: userRole.RoleID,
 				}
 				store.EXPECT().
-					RemoveRoleForUser(gomock.Any(), gomock.Eq(arg)).
+					RemoveRoleForUserTx(gomock.Any(), gomock.Eq(arg)).
 					Times(1).
 					Return(nil)
 			},
@@ -405,7 +478,7 @@ func TestDeleteUserRoleAPI(t *testing.T) {
 					Times(1).
 					Return([]string{"VIEW_SCREEN_USER_ROLE"}, nil)
 				store.EXPECT().
-					RemoveRoleForUser(gomock.Any(), gomock.Any()).
+					RemoveRoleForUserTx(gomock.Any(), gomock.Any()).
 					Times(1).
 					Return(sql.ErrNoRows)
 			},
@@ -432,7 +505,7 @@ func TestDeleteUserRoleAPI(t *testing.T) {
 					Times(1).
 					Return([]string{"VIEW_SCREEN_USER_ROLE"}, nil)
 				store.EXPECT().
-					RemoveRoleForUser(gomock.Any(), gomock.Any()).
+					RemoveRoleForUserTx(gomock.Any(), gomock.Any()).
 					Times(1).
 					Return(sql.ErrConnDone)
 			},
@@ -440,6 +513,33 @@ func TestDeleteUserRoleAPI(t *testing.T) {
 				require.Equal(t, http.StatusInternalServerError, recorder.Code)
 			},
 		},
+		{
+			name: "LastAdmin",
+			body: gin.H{
+				"user_id": userRole.UserID,
+				"role_id": userRole.RoleID,
+			},
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetUser(gomock.Any(), gomock.Eq(user.Username)).
+					Times(1).
+					Return(user, nil)
+				store.EXPECT().
+					GetPermissionsForUser(gomock.Any(), gomock.Eq(user.ID)).
+					Times(1).
+					Return([]string{"VIEW_SCREEN_USER_ROLE"}, nil)
+				store.EXPECT().
+					RemoveRoleForUserTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.ErrLastAdmin)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
 	}
 
 	for i := range testCases {
@@ -729,19 +829,15 @@ func requireBodyMatchUserRoles(t *testing.T, body *bytes.Buffer, userRoles []db.
 	data, err := io.ReadAll(body)
 	require.NoError(t, err)
 
-	type userRolesResponse struct {
-		Meta struct {
-			Page       int32 `json:"page"`
-			TotalPages int32 `json:"total_pages"`
-			TotalCount int64 `json:"total_count"`
-		} `json:"meta"`
-		Data []db.UserRole `json:"data"`
-	}
-
 	var response struct {
 		Data userRolesResponse `json:"data"`
 	}
 	err = json.Unmarshal(data, &response)
 	require.NoError(t, err)
-	require.Equal(t, userRoles, response.Data.Data)
+
+	expected := make([]userRoleResponse, len(userRoles))
+	for i, userRole := range userRoles {
+		expected[i] = newUserRoleResponse(userRole)
+	}
+	require.Equal(t, expected, response.Data.Data)
 }
@@ -11,3 +11,17 @@ var validCurrency validator.Func = func(fieldLevel validator.FieldLevel) bool {
 	}
 	return false
 }
+
+var validPriceCurrency validator.Func = func(fieldLevel validator.FieldLevel) bool {
+	if currency, ok := fieldLevel.Field().Interface().(string); ok {
+		return utils.IsSupportedPriceCurrency(currency)
+	}
+	return false
+}
+
+var validEAN13 validator.Func = func(fieldLevel validator.FieldLevel) bool {
+	if code, ok := fieldLevel.Field().Interface().(string); ok {
+		return utils.IsValidEAN13(code)
+	}
+	return false
+}
@@ -0,0 +1,2 @@
+ALTER TABLE IF EXISTS permissions DROP COLUMN IF EXISTS is_system;
+ALTER TABLE IF EXISTS roles DROP COLUMN IF EXISTS is_system;
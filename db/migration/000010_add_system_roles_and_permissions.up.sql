@@ -0,0 +1,14 @@
+-- System roles and permissions cannot be deleted or renamed
+ALTER TABLE roles ADD COLUMN is_system BOOLEAN NOT NULL DEFAULT false;
+ALTER TABLE permissions ADD COLUMN is_system BOOLEAN NOT NULL DEFAULT false;
+
+UPDATE roles SET is_system = true WHERE name = 'admin';
+
+-- Permissions an administrator needs to manage access control
+UPDATE permissions SET is_system = true
+WHERE name IN (
+  'VIEW_SCREEN_ROLE',
+  'VIEW_SCREEN_PERMISSION',
+  'VIEW_SCREEN_ROLE_PERMISSION',
+  'VIEW_SCREEN_USER_ROLE'
+);
@@ -0,0 +1,4 @@
+ALTER TABLE IF EXISTS user_roles DROP CONSTRAINT IF EXISTS user_roles_validity_check;
+ALTER TABLE IF EXISTS user_roles DROP COLUMN IF EXISTS expiry_notified_at;
+ALTER TABLE IF EXISTS user_roles DROP COLUMN IF EXISTS valid_until;
+ALTER TABLE IF EXISTS user_roles DROP COLUMN IF EXISTS valid_from;
@@ -0,0 +1,9 @@
+-- Optional validity window for role assignments (locum doctors, interns, ...)
+ALTER TABLE user_roles ADD COLUMN valid_from TIMESTAMPTZ;
+ALTER TABLE user_roles ADD COLUMN valid_until TIMESTAMPTZ;
+ALTER TABLE user_roles ADD COLUMN expiry_notified_at TIMESTAMPTZ;
+
+ALTER TABLE user_roles ADD CONSTRAINT user_roles_validity_check
+CHECK (valid_from IS NULL OR valid_until IS NULL OR valid_from < valid_until);
+
+CREATE INDEX ON user_roles (valid_until);
@@ -0,0 +1,2 @@
+-- Remove VIEW_SCREEN_AUTHORIZATION permission (cascade delete will remove from role_permissions)
+DELETE FROM permissions WHERE name = 'VIEW_SCREEN_AUTHORIZATION';
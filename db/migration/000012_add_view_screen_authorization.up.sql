@@ -0,0 +1,8 @@
+-- Add VIEW_SCREEN_AUTHORIZATION permission
+INSERT INTO permissions (name, description) VALUES ('VIEW_SCREEN_AUTHORIZATION', 'Access screen authorization');
+
+-- Assign VIEW_SCREEN_AUTHORIZATION to admin role
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id
+FROM roles r, permissions p
+WHERE r.name = 'admin' AND p.name = 'VIEW_SCREEN_AUTHORIZATION';
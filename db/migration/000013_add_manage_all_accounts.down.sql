@@ -0,0 +1,2 @@
+-- Remove MANAGE_ALL_ACCOUNTS permission (cascade delete will remove from role_permissions)
+DELETE FROM permissions WHERE name = 'MANAGE_ALL_ACCOUNTS';
@@ -0,0 +1,8 @@
+-- Add MANAGE_ALL_ACCOUNTS permission (act on accounts owned by other users)
+INSERT INTO permissions (name, description) VALUES ('MANAGE_ALL_ACCOUNTS', 'Manage accounts of all users');
+
+-- Assign MANAGE_ALL_ACCOUNTS to admin role
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id
+FROM roles r, permissions p
+WHERE r.name = 'admin' AND p.name = 'MANAGE_ALL_ACCOUNTS';
@@ -0,0 +1,3 @@
+DROP VIEW IF EXISTS user_effective_permissions;
+DROP TABLE IF EXISTS user_permission_overrides;
+DROP TABLE IF EXISTS role_permission_denies;
@@ -0,0 +1,53 @@
+-- Explicit deny entries on roles: a user holding the role loses the permission
+-- even when another role grants it
+CREATE TABLE role_permission_denies (
+  role_id INT NOT NULL REFERENCES roles(id) ON DELETE CASCADE,
+  permission_id INT NOT NULL REFERENCES permissions(id) ON DELETE CASCADE,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  PRIMARY KEY (role_id, permission_id)
+);
+
+-- Per-user overrides: allow grants a single permission, deny withholds it
+CREATE TABLE user_permission_overrides (
+  user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  permission_id INT NOT NULL REFERENCES permissions(id) ON DELETE CASCADE,
+  effect VARCHAR(10) NOT NULL CHECK (effect IN ('allow', 'deny')),
+  reason VARCHAR(255) NOT NULL DEFAULT '',
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  PRIMARY KEY (user_id, permission_id)
+);
+
+CREATE TRIGGER trg_user_permission_overrides_updated_at
+BEFORE UPDATE ON user_permission_overrides
+FOR EACH ROW
+EXECUTE FUNCTION set_updated_at();
+
+-- Permissions a user effectively holds: grants from active roles and allow
+-- overrides, minus denies from active roles and deny overrides. Denies win.
+CREATE VIEW user_effective_permissions AS
+WITH active_roles AS (
+  SELECT user_id, role_id FROM user_roles
+  WHERE (valid_from IS NULL OR valid_from <= now())
+  AND (valid_until IS NULL OR valid_until > now())
+), granted AS (
+  SELECT ar.user_id, rp.permission_id
+  FROM active_roles ar
+  JOIN role_permissions rp ON rp.role_id = ar.role_id
+  UNION
+  SELECT user_id, permission_id FROM user_permission_overrides
+  WHERE effect = 'allow'
+), denied AS (
+  SELECT ar.user_id, d.permission_id
+  FROM active_roles ar
+  JOIN role_permission_denies d ON d.role_id = ar.role_id
+  UNION
+  SELECT user_id, permission_id FROM user_permission_overrides
+  WHERE effect = 'deny'
+)
+SELECT g.user_id, g.permission_id
+FROM granted g
+WHERE NOT EXISTS (
+  SELECT 1 FROM denied d
+  WHERE d.user_id = g.user_id AND d.permission_id = g.permission_id
+);
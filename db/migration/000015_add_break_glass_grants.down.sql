@@ -0,0 +1,30 @@
+DELETE FROM permissions WHERE name = 'REVIEW_BREAK_GLASS';
+
+CREATE OR REPLACE VIEW user_effective_permissions AS
+WITH active_roles AS (
+  SELECT user_id, role_id FROM user_roles
+  WHERE (valid_from IS NULL OR valid_from <= now())
+  AND (valid_until IS NULL OR valid_until > now())
+), granted AS (
+  SELECT ar.user_id, rp.permission_id
+  FROM active_roles ar
+  JOIN role_permissions rp ON rp.role_id = ar.role_id
+  UNION
+  SELECT user_id, permission_id FROM user_permission_overrides
+  WHERE effect = 'allow'
+), denied AS (
+  SELECT ar.user_id, d.permission_id
+  FROM active_roles ar
+  JOIN role_permission_denies d ON d.role_id = ar.role_id
+  UNION
+  SELECT user_id, permission_id FROM user_permission_overrides
+  WHERE effect = 'deny'
+)
+SELECT g.user_id, g.permission_id
+FROM granted g
+WHERE NOT EXISTS (
+  SELECT 1 FROM denied d
+  WHERE d.user_id = g.user_id AND d.permission_id = g.permission_id
+);
+
+DROP TABLE IF EXISTS break_glass_grants;
@@ -0,0 +1,57 @@
+-- Emergency access: a user obtains a permission for a short time after giving
+-- a written justification. Every grant stays in the table for review.
+CREATE TABLE break_glass_grants (
+  id BIGSERIAL PRIMARY KEY,
+  user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  permission_id INT NOT NULL REFERENCES permissions(id) ON DELETE CASCADE,
+  justification TEXT NOT NULL,
+  expires_at TIMESTAMPTZ NOT NULL,
+  reviewed_by VARCHAR(255),
+  reviewed_at TIMESTAMPTZ,
+  review_note TEXT,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX ON break_glass_grants (user_id, expires_at);
+CREATE INDEX ON break_glass_grants (reviewed_at);
+
+-- Active break-glass grants count as grants. Denies still win.
+CREATE OR REPLACE VIEW user_effective_permissions AS
+WITH active_roles AS (
+  SELECT user_id, role_id FROM user_roles
+  WHERE (valid_from IS NULL OR valid_from <= now())
+  AND (valid_until IS NULL OR valid_until > now())
+), granted AS (
+  SELECT ar.user_id, rp.permission_id
+  FROM active_roles ar
+  JOIN role_permissions rp ON rp.role_id = ar.role_id
+  UNION
+  SELECT user_id, permission_id FROM user_permission_overrides
+  WHERE effect = 'allow'
+  UNION
+  SELECT user_id, permission_id FROM break_glass_grants
+  WHERE expires_at > now()
+), denied AS (
+  SELECT ar.user_id, d.permission_id
+  FROM active_roles ar
+  JOIN role_permission_denies d ON d.role_id = ar.role_id
+  UNION
+  SELECT user_id, permission_id FROM user_permission_overrides
+  WHERE effect = 'deny'
+)
+SELECT g.user_id, g.permission_id
+FROM granted g
+WHERE NOT EXISTS (
+  SELECT 1 FROM denied d
+  WHERE d.user_id = g.user_id AND d.permission_id = g.permission_id
+);
+
+-- Add REVIEW_BREAK_GLASS permission. It is a system permission so that it
+-- cannot itself be obtained through break-glass access or revoked from admin.
+INSERT INTO permissions (name, description, is_system) VALUES ('REVIEW_BREAK_GLASS', 'Review emergency break-glass access', true);
+
+-- Assign REVIEW_BREAK_GLASS to admin role
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id
+FROM roles r, permissions p
+WHERE r.name = 'admin' AND p.name = 'REVIEW_BREAK_GLASS';
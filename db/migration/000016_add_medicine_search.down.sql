@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS medicines_updated_at_idx;
+DROP INDEX IF EXISTS medicines_stock_idx;
+DROP INDEX IF EXISTS medicines_price_idx;
+
+DROP EXTENSION IF EXISTS unaccent;
@@ -0,0 +1,6 @@
+-- unaccent lets name search ignore Vietnamese diacritics ("thuoc" matches "thuốc")
+CREATE EXTENSION IF NOT EXISTS unaccent;
+
+CREATE INDEX ON medicines (price);
+CREATE INDEX ON medicines (stock);
+CREATE INDEX ON medicines (updated_at);
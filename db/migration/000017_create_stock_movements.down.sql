@@ -0,0 +1,3 @@
+ALTER TABLE medicines DROP CONSTRAINT IF EXISTS medicines_stock_check;
+
+DROP TABLE IF EXISTS stock_movements;
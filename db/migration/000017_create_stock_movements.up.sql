@@ -0,0 +1,23 @@
+CREATE TABLE stock_movements (
+  id BIGSERIAL PRIMARY KEY,
+  medicine_id INT NOT NULL REFERENCES medicines (id),
+  movement_type VARCHAR(20) NOT NULL CHECK (movement_type IN ('receipt', 'dispense', 'adjustment', 'return', 'write_off')),
+  quantity INT NOT NULL CHECK (quantity <> 0),
+  balance INT NOT NULL,
+  note TEXT,
+  created_by VARCHAR REFERENCES users (username),
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX ON stock_movements (medicine_id, id);
+
+COMMENT ON COLUMN stock_movements.quantity IS 'positive adds stock, negative removes it';
+COMMENT ON COLUMN stock_movements.balance IS 'stock of the medicine after the movement';
+
+ALTER TABLE medicines ADD CONSTRAINT medicines_stock_check CHECK (stock >= 0);
+
+-- Record the current stock as an opening balance so history adds up
+INSERT INTO stock_movements (medicine_id, movement_type, quantity, balance, note)
+SELECT id, 'adjustment', stock, stock, 'Opening balance'
+FROM medicines
+WHERE stock > 0;
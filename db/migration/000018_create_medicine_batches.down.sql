@@ -0,0 +1,3 @@
+ALTER TABLE stock_movements DROP COLUMN IF EXISTS batch_id;
+
+DROP TABLE IF EXISTS medicine_batches;
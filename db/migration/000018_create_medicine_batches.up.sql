@@ -0,0 +1,30 @@
+CREATE TABLE medicine_batches (
+  id BIGSERIAL PRIMARY KEY,
+  medicine_id INT NOT NULL REFERENCES medicines (id),
+  lot_number VARCHAR(100) NOT NULL,
+  expiry_date DATE,
+  received_quantity INT NOT NULL CHECK (received_quantity > 0),
+  remaining_quantity INT NOT NULL CHECK (remaining_quantity >= 0),
+  received_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  UNIQUE (medicine_id, lot_number)
+);
+
+CREATE INDEX ON medicine_batches (medicine_id, expiry_date) WHERE remaining_quantity > 0;
+
+COMMENT ON COLUMN medicine_batches.expiry_date IS 'NULL only for stock recorded before batches were tracked';
+
+CREATE TRIGGER trg_medicine_batches_updated_at
+BEFORE UPDATE ON medicine_batches
+FOR EACH ROW
+EXECUTE FUNCTION set_updated_at();
+
+ALTER TABLE stock_movements ADD COLUMN batch_id BIGINT REFERENCES medicine_batches (id);
+
+CREATE INDEX ON stock_movements (batch_id);
+
+-- Stock on hand before batches were tracked goes into one legacy lot per medicine
+INSERT INTO medicine_batches (medicine_id, lot_number, received_quantity, remaining_quantity)
+SELECT id, 'LEGACY', stock, stock
+FROM medicines
+WHERE stock > 0;
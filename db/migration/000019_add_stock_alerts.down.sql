@@ -0,0 +1,6 @@
+-- Remove MANAGE_STOCK_ALERTS permission (cascade delete will remove from role_permissions)
+DELETE FROM permissions WHERE name = 'MANAGE_STOCK_ALERTS';
+
+DROP TABLE IF EXISTS stock_alerts;
+
+ALTER TABLE medicines DROP COLUMN IF EXISTS reorder_level;
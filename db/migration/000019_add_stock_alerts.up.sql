@@ -0,0 +1,29 @@
+-- Alert when stock falls to or below the reorder level
+ALTER TABLE medicines ADD COLUMN reorder_level INT NOT NULL DEFAULT 10 CHECK (reorder_level >= 0);
+
+CREATE TABLE stock_alerts (
+  id BIGSERIAL PRIMARY KEY,
+  alert_type VARCHAR(20) NOT NULL CHECK (alert_type IN ('low_stock', 'near_expiry')),
+  medicine_id INT NOT NULL REFERENCES medicines (id),
+  batch_id BIGINT REFERENCES medicine_batches (id),
+  message TEXT NOT NULL,
+  acknowledged_by VARCHAR REFERENCES users (username),
+  acknowledged_at TIMESTAMPTZ,
+  resolved_at TIMESTAMPTZ,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+-- One alert per condition until the condition clears
+CREATE UNIQUE INDEX stock_alerts_open_idx ON stock_alerts (alert_type, medicine_id, COALESCE(batch_id, 0))
+WHERE resolved_at IS NULL;
+
+COMMENT ON COLUMN stock_alerts.resolved_at IS 'set once stock is back above the reorder level or the batch is empty';
+
+-- Add MANAGE_STOCK_ALERTS permission (receive and acknowledge stock alerts)
+INSERT INTO permissions (name, description) VALUES ('MANAGE_STOCK_ALERTS', 'Receive and acknowledge stock alerts');
+
+-- Assign MANAGE_STOCK_ALERTS to admin role
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id
+FROM roles r, permissions p
+WHERE r.name = 'admin' AND p.name = 'MANAGE_STOCK_ALERTS';
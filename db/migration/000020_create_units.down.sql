@@ -0,0 +1,8 @@
+DROP TABLE IF EXISTS medicine_unit_conversions;
+
+CREATE TYPE medicine_unit AS ENUM ('tablet', 'capsule', 'box', 'bottle');
+
+ALTER TABLE medicines DROP CONSTRAINT IF EXISTS medicines_unit_fkey;
+ALTER TABLE medicines ALTER COLUMN unit TYPE medicine_unit USING unit::medicine_unit;
+
+DROP TABLE IF EXISTS units;
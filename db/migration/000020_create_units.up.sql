@@ -0,0 +1,30 @@
+CREATE TABLE units (
+  id SERIAL PRIMARY KEY,
+  name VARCHAR(50) NOT NULL UNIQUE,
+  description TEXT,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+INSERT INTO units (name) VALUES
+('tablet'),
+('capsule'),
+('blister'),
+('box'),
+('bottle');
+
+-- the unit a medicine's stock, price and reorder level are counted in
+ALTER TABLE medicines ALTER COLUMN unit TYPE VARCHAR(50) USING unit::text;
+ALTER TABLE medicines
+  ADD CONSTRAINT medicines_unit_fkey FOREIGN KEY (unit) REFERENCES units (name) ON UPDATE CASCADE;
+
+DROP TYPE medicine_unit;
+
+CREATE TABLE medicine_unit_conversions (
+  medicine_id INT NOT NULL REFERENCES medicines (id) ON DELETE CASCADE,
+  unit VARCHAR(50) NOT NULL REFERENCES units (name) ON UPDATE CASCADE,
+  factor INT NOT NULL CHECK (factor > 0),
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  PRIMARY KEY (medicine_id, unit)
+);
+
+COMMENT ON COLUMN medicine_unit_conversions.factor IS 'how many of the medicine''s own unit one of this unit holds';
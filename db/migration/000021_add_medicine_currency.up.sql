@@ -0,0 +1 @@
+ALTER TABLE medicines ADD COLUMN currency VARCHAR(3) NOT NULL DEFAULT 'VND';
@@ -0,0 +1,18 @@
+CREATE TABLE medicine_prices (
+  id BIGSERIAL PRIMARY KEY,
+  medicine_id INT NOT NULL REFERENCES medicines (id) ON DELETE CASCADE,
+  price NUMERIC(15,2) NOT NULL CHECK (price >= 0),
+  currency VARCHAR(3) NOT NULL,
+  effective_from TIMESTAMPTZ NOT NULL,
+  reason TEXT NOT NULL,
+  created_by VARCHAR REFERENCES users (username),
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  UNIQUE (medicine_id, effective_from)
+);
+
+COMMENT ON COLUMN medicine_prices.effective_from IS 'the price applies from this time until the next entry of the medicine';
+
+-- The current prices start the history
+INSERT INTO medicine_prices (medicine_id, price, currency, effective_from, reason)
+SELECT id, price, currency, created_at, 'Initial price'
+FROM medicines;
@@ -0,0 +1 @@
+ALTER TABLE medicines ADD COLUMN sku VARCHAR(64) UNIQUE;
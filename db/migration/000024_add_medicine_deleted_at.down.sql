@@ -0,0 +1 @@
+ALTER TABLE medicines DROP COLUMN IF EXISTS deleted_at;
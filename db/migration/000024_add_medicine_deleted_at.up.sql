@@ -0,0 +1,3 @@
+ALTER TABLE medicines ADD COLUMN deleted_at TIMESTAMPTZ;
+
+COMMENT ON COLUMN medicines.deleted_at IS 'set when the medicine is archived; archived medicines are hidden but keep their history';
@@ -0,0 +1,9 @@
+CREATE TABLE medicine_barcodes (
+  barcode VARCHAR(13) PRIMARY KEY CHECK (barcode ~ '^[0-9]{13}$'),
+  medicine_id INT NOT NULL REFERENCES medicines (id) ON DELETE CASCADE,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX ON medicine_barcodes (medicine_id);
+
+COMMENT ON COLUMN medicine_barcodes.barcode IS 'an EAN-13 code; a medicine sold in several packs has one per pack';
@@ -0,0 +1,8 @@
+DROP TABLE IF EXISTS medicine_ingredients;
+DROP TABLE IF EXISTS active_ingredients;
+DROP TABLE IF EXISTS medicine_categories;
+DROP TABLE IF EXISTS categories;
+
+ALTER TABLE medicines
+  DROP COLUMN IF EXISTS manufacturer,
+  DROP COLUMN IF EXISTS dosage_form;
@@ -0,0 +1,38 @@
+ALTER TABLE medicines
+  ADD COLUMN dosage_form VARCHAR(50),
+  ADD COLUMN manufacturer VARCHAR(255);
+
+COMMENT ON COLUMN medicines.dosage_form IS 'how the medicine is given, such as tablet, syrup or injection';
+
+CREATE TABLE categories (
+  id SERIAL PRIMARY KEY,
+  name VARCHAR(100) NOT NULL UNIQUE,
+  description TEXT,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE TABLE medicine_categories (
+  medicine_id INT NOT NULL REFERENCES medicines (id) ON DELETE CASCADE,
+  category_id INT NOT NULL REFERENCES categories (id),
+  PRIMARY KEY (medicine_id, category_id)
+);
+
+CREATE INDEX ON medicine_categories (category_id);
+
+CREATE TABLE active_ingredients (
+  id SERIAL PRIMARY KEY,
+  name VARCHAR(100) NOT NULL UNIQUE,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE TABLE medicine_ingredients (
+  medicine_id INT NOT NULL REFERENCES medicines (id) ON DELETE CASCADE,
+  ingredient_id INT NOT NULL REFERENCES active_ingredients (id),
+  strength NUMERIC(12,2) NOT NULL CHECK (strength > 0),
+  strength_unit VARCHAR(20) NOT NULL,
+  PRIMARY KEY (medicine_id, ingredient_id)
+);
+
+CREATE INDEX ON medicine_ingredients (ingredient_id);
+
+COMMENT ON COLUMN medicine_ingredients.strength IS 'the amount of the ingredient per unit of the medicine, in strength_unit';
@@ -0,0 +1,15 @@
+CREATE TABLE ingredient_interactions (
+  id SERIAL PRIMARY KEY,
+  ingredient_a_id INT NOT NULL REFERENCES active_ingredients (id),
+  ingredient_b_id INT NOT NULL REFERENCES active_ingredients (id),
+  severity VARCHAR(20) NOT NULL CHECK (severity IN ('minor', 'moderate', 'major', 'contraindicated')),
+  description TEXT NOT NULL,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  CHECK (ingredient_a_id < ingredient_b_id),
+  UNIQUE (ingredient_a_id, ingredient_b_id)
+);
+
+CREATE INDEX ON ingredient_interactions (ingredient_b_id);
+
+COMMENT ON COLUMN ingredient_interactions.ingredient_a_id IS 'the lower ingredient ID of the pair, so each pair is stored once';
@@ -0,0 +1,65 @@
+CREATE TABLE suppliers (
+  id SERIAL PRIMARY KEY,
+  name VARCHAR(255) NOT NULL UNIQUE,
+  contact_name VARCHAR(255),
+  phone VARCHAR(50),
+  email VARCHAR(255),
+  address TEXT,
+  lead_time_days INT NOT NULL DEFAULT 7 CHECK (lead_time_days >= 0),
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+COMMENT ON COLUMN suppliers.lead_time_days IS 'days between placing an order and receiving it';
+
+CREATE TABLE purchase_orders (
+  id SERIAL PRIMARY KEY,
+  supplier_id INT NOT NULL REFERENCES suppliers (id),
+  status VARCHAR(20) NOT NULL DEFAULT 'draft' CHECK (status IN ('draft', 'ordered', 'partially_received', 'received')),
+  note TEXT,
+  created_by VARCHAR REFERENCES users (username),
+  ordered_at TIMESTAMPTZ,
+  received_at TIMESTAMPTZ,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX ON purchase_orders (supplier_id);
+CREATE INDEX ON purchase_orders (status);
+
+CREATE TABLE purchase_order_items (
+  id BIGSERIAL PRIMARY KEY,
+  purchase_order_id INT NOT NULL REFERENCES purchase_orders (id) ON DELETE CASCADE,
+  medicine_id INT NOT NULL REFERENCES medicines (id),
+  quantity INT NOT NULL CHECK (quantity > 0),
+  received_quantity INT NOT NULL DEFAULT 0 CHECK (received_quantity >= 0 AND received_quantity <= quantity),
+  unit_cost NUMERIC(15,2) NOT NULL CHECK (unit_cost >= 0),
+  currency VARCHAR(3) NOT NULL,
+  UNIQUE (purchase_order_id, medicine_id)
+);
+
+COMMENT ON COLUMN purchase_order_items.quantity IS 'in the unit of the medicine';
+
+CREATE TABLE goods_receipts (
+  id SERIAL PRIMARY KEY,
+  purchase_order_id INT NOT NULL REFERENCES purchase_orders (id),
+  note TEXT,
+  received_by VARCHAR REFERENCES users (username),
+  received_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX ON goods_receipts (purchase_order_id);
+
+CREATE TABLE goods_receipt_items (
+  id BIGSERIAL PRIMARY KEY,
+  goods_receipt_id INT NOT NULL REFERENCES goods_receipts (id),
+  purchase_order_item_id BIGINT NOT NULL REFERENCES purchase_order_items (id),
+  batch_id BIGINT NOT NULL REFERENCES medicine_batches (id),
+  quantity INT NOT NULL CHECK (quantity > 0),
+  unit_cost NUMERIC(15,2) NOT NULL CHECK (unit_cost >= 0),
+  currency VARCHAR(3) NOT NULL
+);
+
+CREATE INDEX ON goods_receipt_items (goods_receipt_id);
+
+COMMENT ON COLUMN goods_receipt_items.unit_cost IS 'the cost price paid per unit of the medicine for this delivery';
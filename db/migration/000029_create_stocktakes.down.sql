@@ -0,0 +1,6 @@
+-- Remove APPROVE_STOCKTAKE permission (cascade delete will remove from role_permissions)
+DELETE FROM permissions WHERE name = 'APPROVE_STOCKTAKE';
+
+DROP TABLE IF EXISTS stocktake_counts;
+DROP TABLE IF EXISTS stocktake_items;
+DROP TABLE IF EXISTS stocktakes;
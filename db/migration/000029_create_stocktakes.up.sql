@@ -0,0 +1,39 @@
+CREATE TABLE stocktakes (
+  id SERIAL PRIMARY KEY,
+  status VARCHAR(20) NOT NULL DEFAULT 'open' CHECK (status IN ('open', 'approved')),
+  note TEXT,
+  created_by VARCHAR REFERENCES users (username),
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  approved_by VARCHAR REFERENCES users (username),
+  approved_at TIMESTAMPTZ
+);
+
+-- One row per batch in stock when the stocktake started
+CREATE TABLE stocktake_items (
+  id BIGSERIAL PRIMARY KEY,
+  stocktake_id INT NOT NULL REFERENCES stocktakes (id) ON DELETE CASCADE,
+  medicine_id INT NOT NULL REFERENCES medicines (id),
+  batch_id BIGINT NOT NULL REFERENCES medicine_batches (id),
+  expected_quantity INT NOT NULL,
+  UNIQUE (stocktake_id, batch_id)
+);
+
+COMMENT ON COLUMN stocktake_items.expected_quantity IS 'remaining quantity of the batch when the stocktake started';
+
+-- Counters may split the shelves between them; an item's count is the sum
+CREATE TABLE stocktake_counts (
+  stocktake_item_id BIGINT NOT NULL REFERENCES stocktake_items (id) ON DELETE CASCADE,
+  counted_by VARCHAR NOT NULL REFERENCES users (username),
+  quantity INT NOT NULL CHECK (quantity >= 0),
+  counted_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  PRIMARY KEY (stocktake_item_id, counted_by)
+);
+
+-- Add APPROVE_STOCKTAKE permission (post stocktake variances as adjustments)
+INSERT INTO permissions (name, description) VALUES ('APPROVE_STOCKTAKE', 'Approve stocktakes and post their variances');
+
+-- Assign APPROVE_STOCKTAKE to admin role
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id
+FROM roles r, permissions p
+WHERE r.name = 'admin' AND p.name = 'APPROVE_STOCKTAKE';
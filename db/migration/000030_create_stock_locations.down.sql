@@ -0,0 +1,11 @@
+DELETE FROM stock_movements WHERE movement_type = 'transfer';
+
+ALTER TABLE stock_movements DROP CONSTRAINT stock_movements_movement_type_check;
+ALTER TABLE stock_movements ADD CONSTRAINT stock_movements_movement_type_check
+  CHECK (movement_type IN ('receipt', 'dispense', 'adjustment', 'return', 'write_off'));
+
+ALTER TABLE stock_movements DROP COLUMN IF EXISTS location_id;
+
+DROP TABLE IF EXISTS stock_transfers;
+DROP TABLE IF EXISTS batch_locations;
+DROP TABLE IF EXISTS stock_locations;
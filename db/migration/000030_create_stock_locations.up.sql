@@ -0,0 +1,59 @@
+CREATE TABLE stock_locations (
+  id SERIAL PRIMARY KEY,
+  name VARCHAR(100) UNIQUE NOT NULL,
+  is_default BOOLEAN NOT NULL DEFAULT false,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE UNIQUE INDEX ON stock_locations (is_default) WHERE is_default;
+
+COMMENT ON COLUMN stock_locations.is_default IS 'where stock goes and is taken from first when no location is given';
+
+INSERT INTO stock_locations (name, is_default) VALUES
+  ('Main pharmacy', true),
+  ('Consultation room cabinet', false),
+  ('Back storeroom', false);
+
+-- Where the remaining quantity of each batch is kept; sums to the batch's remaining quantity
+CREATE TABLE batch_locations (
+  batch_id BIGINT NOT NULL REFERENCES medicine_batches (id),
+  location_id INT NOT NULL REFERENCES stock_locations (id),
+  quantity INT NOT NULL CHECK (quantity >= 0),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  PRIMARY KEY (batch_id, location_id)
+);
+
+CREATE INDEX ON batch_locations (location_id) WHERE quantity > 0;
+
+CREATE TRIGGER trg_batch_locations_updated_at
+BEFORE UPDATE ON batch_locations
+FOR EACH ROW
+EXECUTE FUNCTION set_updated_at();
+
+-- Stock on hand so far is in the main pharmacy
+INSERT INTO batch_locations (batch_id, location_id, quantity)
+SELECT b.id, l.id, b.remaining_quantity
+FROM medicine_batches b, stock_locations l
+WHERE l.is_default AND b.remaining_quantity > 0;
+
+CREATE TABLE stock_transfers (
+  id BIGSERIAL PRIMARY KEY,
+  medicine_id INT NOT NULL REFERENCES medicines (id),
+  from_location_id INT NOT NULL REFERENCES stock_locations (id),
+  to_location_id INT NOT NULL REFERENCES stock_locations (id),
+  quantity INT NOT NULL CHECK (quantity > 0),
+  note TEXT,
+  created_by VARCHAR REFERENCES users (username),
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  CHECK (from_location_id <> to_location_id)
+);
+
+CREATE INDEX ON stock_transfers (medicine_id, id);
+
+ALTER TABLE stock_movements ADD COLUMN location_id INT REFERENCES stock_locations (id);
+
+COMMENT ON COLUMN stock_movements.location_id IS 'NULL for movements recorded before stock had locations';
+
+ALTER TABLE stock_movements DROP CONSTRAINT stock_movements_movement_type_check;
+ALTER TABLE stock_movements ADD CONSTRAINT stock_movements_movement_type_check
+  CHECK (movement_type IN ('receipt', 'dispense', 'adjustment', 'return', 'write_off', 'transfer'));
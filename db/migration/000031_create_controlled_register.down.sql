@@ -0,0 +1,7 @@
+-- Remove VIEW_CONTROLLED_REGISTER permission (cascade delete will remove from role_permissions)
+DELETE FROM permissions WHERE name = 'VIEW_CONTROLLED_REGISTER';
+
+DROP TABLE IF EXISTS controlled_register;
+DROP FUNCTION IF EXISTS forbid_controlled_register_change();
+
+ALTER TABLE medicines DROP COLUMN IF EXISTS controlled;
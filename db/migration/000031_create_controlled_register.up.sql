@@ -0,0 +1,52 @@
+ALTER TABLE medicines ADD COLUMN controlled BOOLEAN NOT NULL DEFAULT false;
+
+COMMENT ON COLUMN medicines.controlled IS 'psychotropic or narcotic; every movement is witnessed and entered in the controlled register';
+
+-- The legal register of controlled medicines: one entry per stock movement,
+-- made by one user and witnessed by another. Entries are never changed.
+CREATE TABLE controlled_register (
+  id BIGSERIAL PRIMARY KEY,
+  medicine_id INT NOT NULL REFERENCES medicines (id),
+  stock_movement_id BIGINT UNIQUE NOT NULL REFERENCES stock_movements (id),
+  movement_type VARCHAR(20) NOT NULL,
+  quantity INT NOT NULL,
+  balance INT NOT NULL,
+  batch_id BIGINT REFERENCES medicine_batches (id),
+  location_id INT REFERENCES stock_locations (id),
+  note TEXT,
+  recorded_by VARCHAR NOT NULL REFERENCES users (username),
+  witnessed_by VARCHAR NOT NULL REFERENCES users (username),
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  CHECK (witnessed_by <> recorded_by)
+);
+
+CREATE INDEX ON controlled_register (medicine_id, created_at);
+CREATE INDEX ON controlled_register (created_at);
+
+COMMENT ON COLUMN controlled_register.balance IS 'stock of the medicine after the movement';
+
+CREATE FUNCTION forbid_controlled_register_change()
+RETURNS TRIGGER AS $$
+BEGIN
+  RAISE EXCEPTION 'controlled_register is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trg_controlled_register_append_only
+BEFORE UPDATE OR DELETE ON controlled_register
+FOR EACH ROW
+EXECUTE FUNCTION forbid_controlled_register_change();
+
+CREATE TRIGGER trg_controlled_register_no_truncate
+BEFORE TRUNCATE ON controlled_register
+FOR EACH STATEMENT
+EXECUTE FUNCTION forbid_controlled_register_change();
+
+-- Add VIEW_CONTROLLED_REGISTER permission (print and export the controlled register)
+INSERT INTO permissions (name, description) VALUES ('VIEW_CONTROLLED_REGISTER', 'Print and export the controlled substance register');
+
+-- Assign VIEW_CONTROLLED_REGISTER to admin role
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id
+FROM roles r, permissions p
+WHERE r.name = 'admin' AND p.name = 'VIEW_CONTROLLED_REGISTER';
@@ -0,0 +1,11 @@
+-- Remove SET_CONTROLLED_MEDICINE permission (cascade delete will remove from role_permissions)
+DELETE FROM permissions WHERE name = 'SET_CONTROLLED_MEDICINE';
+
+-- the register is append-only, so the trigger is lifted to drop the entries
+-- that have no stock movement
+ALTER TABLE controlled_register DISABLE TRIGGER trg_controlled_register_append_only;
+DELETE FROM controlled_register WHERE stock_movement_id IS NULL;
+ALTER TABLE controlled_register ENABLE TRIGGER trg_controlled_register_append_only;
+
+COMMENT ON COLUMN controlled_register.stock_movement_id IS NULL;
+ALTER TABLE controlled_register ALTER COLUMN stock_movement_id SET NOT NULL;
@@ -0,0 +1,14 @@
+-- Turning the controlled flag on or off is entered in the register too, with
+-- the balance at that moment, so those entries have no stock movement.
+ALTER TABLE controlled_register ALTER COLUMN stock_movement_id DROP NOT NULL;
+
+COMMENT ON COLUMN controlled_register.stock_movement_id IS 'NULL on the entries that open and close the register of a medicine';
+
+-- Add SET_CONTROLLED_MEDICINE permission (turn the controlled flag of a medicine on or off)
+INSERT INTO permissions (name, description) VALUES ('SET_CONTROLLED_MEDICINE', 'Put medicines on and take them off the controlled register');
+
+-- Assign SET_CONTROLLED_MEDICINE to admin role
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id
+FROM roles r, permissions p
+WHERE r.name = 'admin' AND p.name = 'SET_CONTROLLED_MEDICINE';
@@ -0,0 +1,2 @@
+ALTER TABLE permissions DROP CONSTRAINT permissions_break_glass_check;
+ALTER TABLE permissions DROP COLUMN break_glass;
@@ -0,0 +1,10 @@
+-- Which permissions break-glass access may grant is data, so the policy
+-- document can manage it. System permissions can never be obtained this way.
+ALTER TABLE permissions ADD COLUMN break_glass BOOLEAN NOT NULL DEFAULT false;
+ALTER TABLE permissions ADD CONSTRAINT permissions_break_glass_check CHECK (NOT (break_glass AND is_system));
+
+COMMENT ON COLUMN permissions.break_glass IS 'can be obtained through break-glass access';
+
+-- The read-only screens that were eligible until now
+UPDATE permissions SET break_glass = true
+WHERE name IN ('VIEW_SCREEN_DASHBOARD', 'VIEW_SCREEN_USER');
@@ -0,0 +1,12 @@
+-- Items of one batch at several locations cannot be kept apart anymore
+DELETE FROM stocktake_items i
+USING stocktake_items other
+WHERE other.stocktake_id = i.stocktake_id
+  AND other.batch_id = i.batch_id
+  AND other.id < i.id;
+
+COMMENT ON COLUMN stocktake_items.expected_quantity IS 'remaining quantity of the batch when the stocktake started';
+
+ALTER TABLE stocktake_items DROP CONSTRAINT stocktake_items_stocktake_id_batch_id_location_id_key;
+ALTER TABLE stocktake_items ADD CONSTRAINT stocktake_items_stocktake_id_batch_id_key UNIQUE (stocktake_id, batch_id);
+ALTER TABLE stocktake_items DROP COLUMN location_id;
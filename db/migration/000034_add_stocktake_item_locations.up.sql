@@ -0,0 +1,11 @@
+-- A stocktake counts each batch at each location it is kept, so counters
+-- count one shelf each and adjustments are posted where the stock is.
+ALTER TABLE stocktake_items ADD COLUMN location_id INT REFERENCES stock_locations (id);
+
+UPDATE stocktake_items SET location_id = (SELECT id FROM stock_locations WHERE is_default);
+
+ALTER TABLE stocktake_items ALTER COLUMN location_id SET NOT NULL;
+ALTER TABLE stocktake_items DROP CONSTRAINT stocktake_items_stocktake_id_batch_id_key;
+ALTER TABLE stocktake_items ADD CONSTRAINT stocktake_items_stocktake_id_batch_id_location_id_key UNIQUE (stocktake_id, batch_id, location_id);
+
+COMMENT ON COLUMN stocktake_items.expected_quantity IS 'quantity of the batch at the location when the stocktake started';
@@ -6,7 +6,9 @@ package mock_sqlc
 
 import (
 	context "context"
+	sql "database/sql"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 	uuid "github.com/google/uuid"
@@ -36,686 +38,3104 @@ func (m *MockStore) EXPECT() *MockStoreMockRecorder {
 	return m.recorder
 }
 
+// AcknowledgeStockAlert mocks base method.
+func (m *MockStore) AcknowledgeStockAlert(arg0 context.Context, arg1 db.AcknowledgeStockAlertParams) (db.StockAlert, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AcknowledgeStockAlert", arg0, arg1)
+	ret0, _ := ret[0].(db.StockAlert)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AcknowledgeStockAlert indicates an expected call of AcknowledgeStockAlert.
+func (mr *MockStoreMockRecorder) AcknowledgeStockAlert(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcknowledgeStockAlert", reflect.TypeOf((*MockStore)(nil).AcknowledgeStockAlert), arg0, arg1)
+}
+
 // AddAccountBalance mocks base method.
 func (m *MockStore) AddAccountBalance(arg0 context.Context, arg1 db.AddAccountBalanceParams) (db.Account, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AddAccountBalance", arg0, arg1)
-	ret0, _ := ret[0].(db.Account)
+	ret := m.ctrl.Call(m, "AddAccountBalance", arg0, arg1)
+	ret0, _ := ret[0].(db.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddAccountBalance indicates an expected call of AddAccountBalance.
+func (mr *MockStoreMockRecorder) AddAccountBalance(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddAccountBalance", reflect.TypeOf((*MockStore)(nil).AddAccountBalance), arg0, arg1)
+}
+
+// AddBatchLocationQuantity mocks base method.
+func (m *MockStore) AddBatchLocationQuantity(arg0 context.Context, arg1 db.AddBatchLocationQuantityParams) (db.BatchLocation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddBatchLocationQuantity", arg0, arg1)
+	ret0, _ := ret[0].(db.BatchLocation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddBatchLocationQuantity indicates an expected call of AddBatchLocationQuantity.
+func (mr *MockStoreMockRecorder) AddBatchLocationQuantity(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddBatchLocationQuantity", reflect.TypeOf((*MockStore)(nil).AddBatchLocationQuantity), arg0, arg1)
+}
+
+// AddMedicineBatchQuantity mocks base method.
+func (m *MockStore) AddMedicineBatchQuantity(arg0 context.Context, arg1 db.AddMedicineBatchQuantityParams) (db.MedicineBatch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddMedicineBatchQuantity", arg0, arg1)
+	ret0, _ := ret[0].(db.MedicineBatch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddMedicineBatchQuantity indicates an expected call of AddMedicineBatchQuantity.
+func (mr *MockStoreMockRecorder) AddMedicineBatchQuantity(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddMedicineBatchQuantity", reflect.TypeOf((*MockStore)(nil).AddMedicineBatchQuantity), arg0, arg1)
+}
+
+// AddMedicineCategories mocks base method.
+func (m *MockStore) AddMedicineCategories(arg0 context.Context, arg1 db.AddMedicineCategoriesParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddMedicineCategories", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddMedicineCategories indicates an expected call of AddMedicineCategories.
+func (mr *MockStoreMockRecorder) AddMedicineCategories(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddMedicineCategories", reflect.TypeOf((*MockStore)(nil).AddMedicineCategories), arg0, arg1)
+}
+
+// AddMedicineStock mocks base method.
+func (m *MockStore) AddMedicineStock(arg0 context.Context, arg1 db.AddMedicineStockParams) (db.Medicine, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddMedicineStock", arg0, arg1)
+	ret0, _ := ret[0].(db.Medicine)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddMedicineStock indicates an expected call of AddMedicineStock.
+func (mr *MockStoreMockRecorder) AddMedicineStock(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddMedicineStock", reflect.TypeOf((*MockStore)(nil).AddMedicineStock), arg0, arg1)
+}
+
+// AddPurchaseOrderItemReceived mocks base method.
+func (m *MockStore) AddPurchaseOrderItemReceived(arg0 context.Context, arg1 db.AddPurchaseOrderItemReceivedParams) (db.PurchaseOrderItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddPurchaseOrderItemReceived", arg0, arg1)
+	ret0, _ := ret[0].(db.PurchaseOrderItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddPurchaseOrderItemReceived indicates an expected call of AddPurchaseOrderItemReceived.
+func (mr *MockStoreMockRecorder) AddPurchaseOrderItemReceived(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddPurchaseOrderItemReceived", reflect.TypeOf((*MockStore)(nil).AddPurchaseOrderItemReceived), arg0, arg1)
+}
+
+// AddRoleForUser mocks base method.
+func (m *MockStore) AddRoleForUser(arg0 context.Context, arg1 db.AddRoleForUserParams) (db.UserRole, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddRoleForUser", arg0, arg1)
+	ret0, _ := ret[0].(db.UserRole)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddRoleForUser indicates an expected call of AddRoleForUser.
+func (mr *MockStoreMockRecorder) AddRoleForUser(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddRoleForUser", reflect.TypeOf((*MockStore)(nil).AddRoleForUser), arg0, arg1)
+}
+
+// ApplyDueMedicinePrices mocks base method.
+func (m *MockStore) ApplyDueMedicinePrices(arg0 context.Context) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplyDueMedicinePrices", arg0)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ApplyDueMedicinePrices indicates an expected call of ApplyDueMedicinePrices.
+func (mr *MockStoreMockRecorder) ApplyDueMedicinePrices(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplyDueMedicinePrices", reflect.TypeOf((*MockStore)(nil).ApplyDueMedicinePrices), arg0)
+}
+
+// ApproveStocktake mocks base method.
+func (m *MockStore) ApproveStocktake(arg0 context.Context, arg1 db.ApproveStocktakeParams) (db.Stocktake, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApproveStocktake", arg0, arg1)
+	ret0, _ := ret[0].(db.Stocktake)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ApproveStocktake indicates an expected call of ApproveStocktake.
+func (mr *MockStoreMockRecorder) ApproveStocktake(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApproveStocktake", reflect.TypeOf((*MockStore)(nil).ApproveStocktake), arg0, arg1)
+}
+
+// ApproveStocktakeTx mocks base method.
+func (m *MockStore) ApproveStocktakeTx(arg0 context.Context, arg1 db.ApproveStocktakeTxParams) (db.ApproveStocktakeTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApproveStocktakeTx", arg0, arg1)
+	ret0, _ := ret[0].(db.ApproveStocktakeTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ApproveStocktakeTx indicates an expected call of ApproveStocktakeTx.
+func (mr *MockStoreMockRecorder) ApproveStocktakeTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApproveStocktakeTx", reflect.TypeOf((*MockStore)(nil).ApproveStocktakeTx), arg0, arg1)
+}
+
+// ArchiveMedicine mocks base method.
+func (m *MockStore) ArchiveMedicine(arg0 context.Context, arg1 int32) (db.Medicine, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ArchiveMedicine", arg0, arg1)
+	ret0, _ := ret[0].(db.Medicine)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ArchiveMedicine indicates an expected call of ArchiveMedicine.
+func (mr *MockStoreMockRecorder) ArchiveMedicine(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ArchiveMedicine", reflect.TypeOf((*MockStore)(nil).ArchiveMedicine), arg0, arg1)
+}
+
+// BulkAdjustPricesTx mocks base method.
+func (m *MockStore) BulkAdjustPricesTx(arg0 context.Context, arg1 db.BulkAdjustPricesTxParams) (db.BulkAdjustPricesTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BulkAdjustPricesTx", arg0, arg1)
+	ret0, _ := ret[0].(db.BulkAdjustPricesTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BulkAdjustPricesTx indicates an expected call of BulkAdjustPricesTx.
+func (mr *MockStoreMockRecorder) BulkAdjustPricesTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkAdjustPricesTx", reflect.TypeOf((*MockStore)(nil).BulkAdjustPricesTx), arg0, arg1)
+}
+
+// ChangeMedicinePriceTx mocks base method.
+func (m *MockStore) ChangeMedicinePriceTx(arg0 context.Context, arg1 db.ChangeMedicinePriceTxParams) (db.ChangeMedicinePriceTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangeMedicinePriceTx", arg0, arg1)
+	ret0, _ := ret[0].(db.ChangeMedicinePriceTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ChangeMedicinePriceTx indicates an expected call of ChangeMedicinePriceTx.
+func (mr *MockStoreMockRecorder) ChangeMedicinePriceTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangeMedicinePriceTx", reflect.TypeOf((*MockStore)(nil).ChangeMedicinePriceTx), arg0, arg1)
+}
+
+// CheckMedicineWarnings mocks base method.
+func (m *MockStore) CheckMedicineWarnings(arg0 context.Context, arg1 db.CheckMedicineWarningsParams) (db.CheckMedicineWarningsResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckMedicineWarnings", arg0, arg1)
+	ret0, _ := ret[0].(db.CheckMedicineWarningsResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckMedicineWarnings indicates an expected call of CheckMedicineWarnings.
+func (mr *MockStoreMockRecorder) CheckMedicineWarnings(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckMedicineWarnings", reflect.TypeOf((*MockStore)(nil).CheckMedicineWarnings), arg0, arg1)
+}
+
+// ControlledRegisterReport mocks base method.
+func (m *MockStore) ControlledRegisterReport(arg0 context.Context, arg1 db.ControlledRegisterReportParams) ([]db.ControlledRegisterMedicine, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ControlledRegisterReport", arg0, arg1)
+	ret0, _ := ret[0].([]db.ControlledRegisterMedicine)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ControlledRegisterReport indicates an expected call of ControlledRegisterReport.
+func (mr *MockStoreMockRecorder) ControlledRegisterReport(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ControlledRegisterReport", reflect.TypeOf((*MockStore)(nil).ControlledRegisterReport), arg0, arg1)
+}
+
+// CountMedicinePrices mocks base method.
+func (m *MockStore) CountMedicinePrices(arg0 context.Context, arg1 int32) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountMedicinePrices", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountMedicinePrices indicates an expected call of CountMedicinePrices.
+func (mr *MockStoreMockRecorder) CountMedicinePrices(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountMedicinePrices", reflect.TypeOf((*MockStore)(nil).CountMedicinePrices), arg0, arg1)
+}
+
+// CountMedicines mocks base method.
+func (m *MockStore) CountMedicines(arg0 context.Context, arg1 db.CountMedicinesParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountMedicines", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountMedicines indicates an expected call of CountMedicines.
+func (mr *MockStoreMockRecorder) CountMedicines(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountMedicines", reflect.TypeOf((*MockStore)(nil).CountMedicines), arg0, arg1)
+}
+
+// CountPendingBreakGlassGrants mocks base method.
+func (m *MockStore) CountPendingBreakGlassGrants(arg0 context.Context) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountPendingBreakGlassGrants", arg0)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountPendingBreakGlassGrants indicates an expected call of CountPendingBreakGlassGrants.
+func (mr *MockStoreMockRecorder) CountPendingBreakGlassGrants(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountPendingBreakGlassGrants", reflect.TypeOf((*MockStore)(nil).CountPendingBreakGlassGrants), arg0)
+}
+
+// CountPermissions mocks base method.
+func (m *MockStore) CountPermissions(arg0 context.Context) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountPermissions", arg0)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountPermissions indicates an expected call of CountPermissions.
+func (mr *MockStoreMockRecorder) CountPermissions(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountPermissions", reflect.TypeOf((*MockStore)(nil).CountPermissions), arg0)
+}
+
+// CountPermissionsForRole mocks base method.
+func (m *MockStore) CountPermissionsForRole(arg0 context.Context, arg1 int32) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountPermissionsForRole", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountPermissionsForRole indicates an expected call of CountPermissionsForRole.
+func (mr *MockStoreMockRecorder) CountPermissionsForRole(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountPermissionsForRole", reflect.TypeOf((*MockStore)(nil).CountPermissionsForRole), arg0, arg1)
+}
+
+// CountPurchaseOrders mocks base method.
+func (m *MockStore) CountPurchaseOrders(arg0 context.Context, arg1 db.CountPurchaseOrdersParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountPurchaseOrders", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountPurchaseOrders indicates an expected call of CountPurchaseOrders.
+func (mr *MockStoreMockRecorder) CountPurchaseOrders(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountPurchaseOrders", reflect.TypeOf((*MockStore)(nil).CountPurchaseOrders), arg0, arg1)
+}
+
+// CountRolePermissionDenies mocks base method.
+func (m *MockStore) CountRolePermissionDenies(arg0 context.Context) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountRolePermissionDenies", arg0)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountRolePermissionDenies indicates an expected call of CountRolePermissionDenies.
+func (mr *MockStoreMockRecorder) CountRolePermissionDenies(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountRolePermissionDenies", reflect.TypeOf((*MockStore)(nil).CountRolePermissionDenies), arg0)
+}
+
+// CountRolePermissions mocks base method.
+func (m *MockStore) CountRolePermissions(arg0 context.Context) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountRolePermissions", arg0)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountRolePermissions indicates an expected call of CountRolePermissions.
+func (mr *MockStoreMockRecorder) CountRolePermissions(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountRolePermissions", reflect.TypeOf((*MockStore)(nil).CountRolePermissions), arg0)
+}
+
+// CountRoles mocks base method.
+func (m *MockStore) CountRoles(arg0 context.Context) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountRoles", arg0)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountRoles indicates an expected call of CountRoles.
+func (mr *MockStoreMockRecorder) CountRoles(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountRoles", reflect.TypeOf((*MockStore)(nil).CountRoles), arg0)
+}
+
+// CountStockAlerts mocks base method.
+func (m *MockStore) CountStockAlerts(arg0 context.Context, arg1 sql.NullBool) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountStockAlerts", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountStockAlerts indicates an expected call of CountStockAlerts.
+func (mr *MockStoreMockRecorder) CountStockAlerts(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountStockAlerts", reflect.TypeOf((*MockStore)(nil).CountStockAlerts), arg0, arg1)
+}
+
+// CountStockMovements mocks base method.
+func (m *MockStore) CountStockMovements(arg0 context.Context, arg1 int32) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountStockMovements", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountStockMovements indicates an expected call of CountStockMovements.
+func (mr *MockStoreMockRecorder) CountStockMovements(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountStockMovements", reflect.TypeOf((*MockStore)(nil).CountStockMovements), arg0, arg1)
+}
+
+// CountStocktakes mocks base method.
+func (m *MockStore) CountStocktakes(arg0 context.Context) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountStocktakes", arg0)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountStocktakes indicates an expected call of CountStocktakes.
+func (mr *MockStoreMockRecorder) CountStocktakes(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountStocktakes", reflect.TypeOf((*MockStore)(nil).CountStocktakes), arg0)
+}
+
+// CountSystemAdmins mocks base method.
+func (m *MockStore) CountSystemAdmins(arg0 context.Context) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountSystemAdmins", arg0)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountSystemAdmins indicates an expected call of CountSystemAdmins.
+func (mr *MockStoreMockRecorder) CountSystemAdmins(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountSystemAdmins", reflect.TypeOf((*MockStore)(nil).CountSystemAdmins), arg0)
+}
+
+// CountUserRoles mocks base method.
+func (m *MockStore) CountUserRoles(arg0 context.Context) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountUserRoles", arg0)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountUserRoles indicates an expected call of CountUserRoles.
+func (mr *MockStoreMockRecorder) CountUserRoles(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountUserRoles", reflect.TypeOf((*MockStore)(nil).CountUserRoles), arg0)
+}
+
+// CountUsers mocks base method.
+func (m *MockStore) CountUsers(arg0 context.Context) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountUsers", arg0)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountUsers indicates an expected call of CountUsers.
+func (mr *MockStoreMockRecorder) CountUsers(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountUsers", reflect.TypeOf((*MockStore)(nil).CountUsers), arg0)
+}
+
+// CountUsersForRole mocks base method.
+func (m *MockStore) CountUsersForRole(arg0 context.Context, arg1 int32) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountUsersForRole", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountUsersForRole indicates an expected call of CountUsersForRole.
+func (mr *MockStoreMockRecorder) CountUsersForRole(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountUsersForRole", reflect.TypeOf((*MockStore)(nil).CountUsersForRole), arg0, arg1)
+}
+
+// CreateAccount mocks base method.
+func (m *MockStore) CreateAccount(arg0 context.Context, arg1 db.CreateAccountParams) (db.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAccount", arg0, arg1)
+	ret0, _ := ret[0].(db.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAccount indicates an expected call of CreateAccount.
+func (mr *MockStoreMockRecorder) CreateAccount(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAccount", reflect.TypeOf((*MockStore)(nil).CreateAccount), arg0, arg1)
+}
+
+// CreateActiveIngredient mocks base method.
+func (m *MockStore) CreateActiveIngredient(arg0 context.Context, arg1 string) (db.ActiveIngredient, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateActiveIngredient", arg0, arg1)
+	ret0, _ := ret[0].(db.ActiveIngredient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateActiveIngredient indicates an expected call of CreateActiveIngredient.
+func (mr *MockStoreMockRecorder) CreateActiveIngredient(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateActiveIngredient", reflect.TypeOf((*MockStore)(nil).CreateActiveIngredient), arg0, arg1)
+}
+
+// CreateBreakGlassGrant mocks base method.
+func (m *MockStore) CreateBreakGlassGrant(arg0 context.Context, arg1 db.CreateBreakGlassGrantParams) (db.BreakGlassGrant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateBreakGlassGrant", arg0, arg1)
+	ret0, _ := ret[0].(db.BreakGlassGrant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateBreakGlassGrant indicates an expected call of CreateBreakGlassGrant.
+func (mr *MockStoreMockRecorder) CreateBreakGlassGrant(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBreakGlassGrant", reflect.TypeOf((*MockStore)(nil).CreateBreakGlassGrant), arg0, arg1)
+}
+
+// CreateCategory mocks base method.
+func (m *MockStore) CreateCategory(arg0 context.Context, arg1 db.CreateCategoryParams) (db.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCategory", arg0, arg1)
+	ret0, _ := ret[0].(db.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCategory indicates an expected call of CreateCategory.
+func (mr *MockStoreMockRecorder) CreateCategory(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCategory", reflect.TypeOf((*MockStore)(nil).CreateCategory), arg0, arg1)
+}
+
+// CreateControlledRegisterEntry mocks base method.
+func (m *MockStore) CreateControlledRegisterEntry(arg0 context.Context, arg1 db.CreateControlledRegisterEntryParams) (db.ControlledRegister, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateControlledRegisterEntry", arg0, arg1)
+	ret0, _ := ret[0].(db.ControlledRegister)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateControlledRegisterEntry indicates an expected call of CreateControlledRegisterEntry.
+func (mr *MockStoreMockRecorder) CreateControlledRegisterEntry(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateControlledRegisterEntry", reflect.TypeOf((*MockStore)(nil).CreateControlledRegisterEntry), arg0, arg1)
+}
+
+// CreateEntry mocks base method.
+func (m *MockStore) CreateEntry(arg0 context.Context, arg1 db.CreateEntryParams) (db.Entry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateEntry", arg0, arg1)
+	ret0, _ := ret[0].(db.Entry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateEntry indicates an expected call of CreateEntry.
+func (mr *MockStoreMockRecorder) CreateEntry(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateEntry", reflect.TypeOf((*MockStore)(nil).CreateEntry), arg0, arg1)
+}
+
+// CreateGoodsReceipt mocks base method.
+func (m *MockStore) CreateGoodsReceipt(arg0 context.Context, arg1 db.CreateGoodsReceiptParams) (db.GoodsReceipt, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateGoodsReceipt", arg0, arg1)
+	ret0, _ := ret[0].(db.GoodsReceipt)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateGoodsReceipt indicates an expected call of CreateGoodsReceipt.
+func (mr *MockStoreMockRecorder) CreateGoodsReceipt(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateGoodsReceipt", reflect.TypeOf((*MockStore)(nil).CreateGoodsReceipt), arg0, arg1)
+}
+
+// CreateGoodsReceiptItem mocks base method.
+func (m *MockStore) CreateGoodsReceiptItem(arg0 context.Context, arg1 db.CreateGoodsReceiptItemParams) (db.GoodsReceiptItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateGoodsReceiptItem", arg0, arg1)
+	ret0, _ := ret[0].(db.GoodsReceiptItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateGoodsReceiptItem indicates an expected call of CreateGoodsReceiptItem.
+func (mr *MockStoreMockRecorder) CreateGoodsReceiptItem(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateGoodsReceiptItem", reflect.TypeOf((*MockStore)(nil).CreateGoodsReceiptItem), arg0, arg1)
+}
+
+// CreateMedicine mocks base method.
+func (m *MockStore) CreateMedicine(arg0 context.Context, arg1 db.CreateMedicineParams) (db.Medicine, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateMedicine", arg0, arg1)
+	ret0, _ := ret[0].(db.Medicine)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateMedicine indicates an expected call of CreateMedicine.
+func (mr *MockStoreMockRecorder) CreateMedicine(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMedicine", reflect.TypeOf((*MockStore)(nil).CreateMedicine), arg0, arg1)
+}
+
+// CreateMedicineBarcode mocks base method.
+func (m *MockStore) CreateMedicineBarcode(arg0 context.Context, arg1 db.CreateMedicineBarcodeParams) (db.MedicineBarcode, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateMedicineBarcode", arg0, arg1)
+	ret0, _ := ret[0].(db.MedicineBarcode)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateMedicineBarcode indicates an expected call of CreateMedicineBarcode.
+func (mr *MockStoreMockRecorder) CreateMedicineBarcode(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMedicineBarcode", reflect.TypeOf((*MockStore)(nil).CreateMedicineBarcode), arg0, arg1)
+}
+
+// CreateMedicineIngredient mocks base method.
+func (m *MockStore) CreateMedicineIngredient(arg0 context.Context, arg1 db.CreateMedicineIngredientParams) (db.MedicineIngredient, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateMedicineIngredient", arg0, arg1)
+	ret0, _ := ret[0].(db.MedicineIngredient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateMedicineIngredient indicates an expected call of CreateMedicineIngredient.
+func (mr *MockStoreMockRecorder) CreateMedicineIngredient(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMedicineIngredient", reflect.TypeOf((*MockStore)(nil).CreateMedicineIngredient), arg0, arg1)
+}
+
+// CreateMedicinePrice mocks base method.
+func (m *MockStore) CreateMedicinePrice(arg0 context.Context, arg1 db.CreateMedicinePriceParams) (db.MedicinePrice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateMedicinePrice", arg0, arg1)
+	ret0, _ := ret[0].(db.MedicinePrice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateMedicinePrice indicates an expected call of CreateMedicinePrice.
+func (mr *MockStoreMockRecorder) CreateMedicinePrice(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMedicinePrice", reflect.TypeOf((*MockStore)(nil).CreateMedicinePrice), arg0, arg1)
+}
+
+// CreateMedicineTx mocks base method.
+func (m *MockStore) CreateMedicineTx(arg0 context.Context, arg1 db.CreateMedicineTxParams) (db.Medicine, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateMedicineTx", arg0, arg1)
+	ret0, _ := ret[0].(db.Medicine)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateMedicineTx indicates an expected call of CreateMedicineTx.
+func (mr *MockStoreMockRecorder) CreateMedicineTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMedicineTx", reflect.TypeOf((*MockStore)(nil).CreateMedicineTx), arg0, arg1)
+}
+
+// CreatePermission mocks base method.
+func (m *MockStore) CreatePermission(arg0 context.Context, arg1 db.CreatePermissionParams) (db.Permission, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePermission", arg0, arg1)
+	ret0, _ := ret[0].(db.Permission)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePermission indicates an expected call of CreatePermission.
+func (mr *MockStoreMockRecorder) CreatePermission(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePermission", reflect.TypeOf((*MockStore)(nil).CreatePermission), arg0, arg1)
+}
+
+// CreatePurchaseOrder mocks base method.
+func (m *MockStore) CreatePurchaseOrder(arg0 context.Context, arg1 db.CreatePurchaseOrderParams) (db.PurchaseOrder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePurchaseOrder", arg0, arg1)
+	ret0, _ := ret[0].(db.PurchaseOrder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePurchaseOrder indicates an expected call of CreatePurchaseOrder.
+func (mr *MockStoreMockRecorder) CreatePurchaseOrder(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePurchaseOrder", reflect.TypeOf((*MockStore)(nil).CreatePurchaseOrder), arg0, arg1)
+}
+
+// CreatePurchaseOrderItem mocks base method.
+func (m *MockStore) CreatePurchaseOrderItem(arg0 context.Context, arg1 db.CreatePurchaseOrderItemParams) (db.PurchaseOrderItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePurchaseOrderItem", arg0, arg1)
+	ret0, _ := ret[0].(db.PurchaseOrderItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePurchaseOrderItem indicates an expected call of CreatePurchaseOrderItem.
+func (mr *MockStoreMockRecorder) CreatePurchaseOrderItem(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePurchaseOrderItem", reflect.TypeOf((*MockStore)(nil).CreatePurchaseOrderItem), arg0, arg1)
+}
+
+// CreatePurchaseOrderTx mocks base method.
+func (m *MockStore) CreatePurchaseOrderTx(arg0 context.Context, arg1 db.CreatePurchaseOrderTxParams) (db.PurchaseOrderTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePurchaseOrderTx", arg0, arg1)
+	ret0, _ := ret[0].(db.PurchaseOrderTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePurchaseOrderTx indicates an expected call of CreatePurchaseOrderTx.
+func (mr *MockStoreMockRecorder) CreatePurchaseOrderTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePurchaseOrderTx", reflect.TypeOf((*MockStore)(nil).CreatePurchaseOrderTx), arg0, arg1)
+}
+
+// CreateRole mocks base method.
+func (m *MockStore) CreateRole(arg0 context.Context, arg1 db.CreateRoleParams) (db.Role, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRole", arg0, arg1)
+	ret0, _ := ret[0].(db.Role)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateRole indicates an expected call of CreateRole.
+func (mr *MockStoreMockRecorder) CreateRole(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRole", reflect.TypeOf((*MockStore)(nil).CreateRole), arg0, arg1)
+}
+
+// CreateRolePermission mocks base method.
+func (m *MockStore) CreateRolePermission(arg0 context.Context, arg1 db.CreateRolePermissionParams) (db.RolePermission, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRolePermission", arg0, arg1)
+	ret0, _ := ret[0].(db.RolePermission)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateRolePermission indicates an expected call of CreateRolePermission.
+func (mr *MockStoreMockRecorder) CreateRolePermission(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRolePermission", reflect.TypeOf((*MockStore)(nil).CreateRolePermission), arg0, arg1)
+}
+
+// CreateRolePermissionDeny mocks base method.
+func (m *MockStore) CreateRolePermissionDeny(arg0 context.Context, arg1 db.CreateRolePermissionDenyParams) (db.RolePermissionDeny, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRolePermissionDeny", arg0, arg1)
+	ret0, _ := ret[0].(db.RolePermissionDeny)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateRolePermissionDeny indicates an expected call of CreateRolePermissionDeny.
+func (mr *MockStoreMockRecorder) CreateRolePermissionDeny(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRolePermissionDeny", reflect.TypeOf((*MockStore)(nil).CreateRolePermissionDeny), arg0, arg1)
+}
+
+// CreateRolePermissionDenyTx mocks base method.
+func (m *MockStore) CreateRolePermissionDenyTx(arg0 context.Context, arg1 db.CreateRolePermissionDenyParams) (db.RolePermissionDeny, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRolePermissionDenyTx", arg0, arg1)
+	ret0, _ := ret[0].(db.RolePermissionDeny)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateRolePermissionDenyTx indicates an expected call of CreateRolePermissionDenyTx.
+func (mr *MockStoreMockRecorder) CreateRolePermissionDenyTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRolePermissionDenyTx", reflect.TypeOf((*MockStore)(nil).CreateRolePermissionDenyTx), arg0, arg1)
+}
+
+// CreateSession mocks base method.
+func (m *MockStore) CreateSession(arg0 context.Context, arg1 db.CreateSessionParams) (db.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSession", arg0, arg1)
+	ret0, _ := ret[0].(db.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSession indicates an expected call of CreateSession.
+func (mr *MockStoreMockRecorder) CreateSession(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSession", reflect.TypeOf((*MockStore)(nil).CreateSession), arg0, arg1)
+}
+
+// CreateStockAlert mocks base method.
+func (m *MockStore) CreateStockAlert(arg0 context.Context, arg1 db.CreateStockAlertParams) (db.StockAlert, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateStockAlert", arg0, arg1)
+	ret0, _ := ret[0].(db.StockAlert)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateStockAlert indicates an expected call of CreateStockAlert.
+func (mr *MockStoreMockRecorder) CreateStockAlert(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateStockAlert", reflect.TypeOf((*MockStore)(nil).CreateStockAlert), arg0, arg1)
+}
+
+// CreateStockLocation mocks base method.
+func (m *MockStore) CreateStockLocation(arg0 context.Context, arg1 string) (db.StockLocation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateStockLocation", arg0, arg1)
+	ret0, _ := ret[0].(db.StockLocation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateStockLocation indicates an expected call of CreateStockLocation.
+func (mr *MockStoreMockRecorder) CreateStockLocation(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateStockLocation", reflect.TypeOf((*MockStore)(nil).CreateStockLocation), arg0, arg1)
+}
+
+// CreateStockMovement mocks base method.
+func (m *MockStore) CreateStockMovement(arg0 context.Context, arg1 db.CreateStockMovementParams) (db.StockMovement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateStockMovement", arg0, arg1)
+	ret0, _ := ret[0].(db.StockMovement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateStockMovement indicates an expected call of CreateStockMovement.
+func (mr *MockStoreMockRecorder) CreateStockMovement(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateStockMovement", reflect.TypeOf((*MockStore)(nil).CreateStockMovement), arg0, arg1)
+}
+
+// CreateStockTransfer mocks base method.
+func (m *MockStore) CreateStockTransfer(arg0 context.Context, arg1 db.CreateStockTransferParams) (db.StockTransfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateStockTransfer", arg0, arg1)
+	ret0, _ := ret[0].(db.StockTransfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateStockTransfer indicates an expected call of CreateStockTransfer.
+func (mr *MockStoreMockRecorder) CreateStockTransfer(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateStockTransfer", reflect.TypeOf((*MockStore)(nil).CreateStockTransfer), arg0, arg1)
+}
+
+// CreateStocktake mocks base method.
+func (m *MockStore) CreateStocktake(arg0 context.Context, arg1 db.CreateStocktakeParams) (db.Stocktake, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateStocktake", arg0, arg1)
+	ret0, _ := ret[0].(db.Stocktake)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateStocktake indicates an expected call of CreateStocktake.
+func (mr *MockStoreMockRecorder) CreateStocktake(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateStocktake", reflect.TypeOf((*MockStore)(nil).CreateStocktake), arg0, arg1)
+}
+
+// CreateStocktakeItems mocks base method.
+func (m *MockStore) CreateStocktakeItems(arg0 context.Context, arg1 db.CreateStocktakeItemsParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateStocktakeItems", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateStocktakeItems indicates an expected call of CreateStocktakeItems.
+func (mr *MockStoreMockRecorder) CreateStocktakeItems(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateStocktakeItems", reflect.TypeOf((*MockStore)(nil).CreateStocktakeItems), arg0, arg1)
+}
+
+// CreateStocktakeTx mocks base method.
+func (m *MockStore) CreateStocktakeTx(arg0 context.Context, arg1 db.CreateStocktakeTxParams) (db.StocktakeTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateStocktakeTx", arg0, arg1)
+	ret0, _ := ret[0].(db.StocktakeTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateStocktakeTx indicates an expected call of CreateStocktakeTx.
+func (mr *MockStoreMockRecorder) CreateStocktakeTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateStocktakeTx", reflect.TypeOf((*MockStore)(nil).CreateStocktakeTx), arg0, arg1)
+}
+
+// CreateSupplier mocks base method.
+func (m *MockStore) CreateSupplier(arg0 context.Context, arg1 db.CreateSupplierParams) (db.Supplier, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSupplier", arg0, arg1)
+	ret0, _ := ret[0].(db.Supplier)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSupplier indicates an expected call of CreateSupplier.
+func (mr *MockStoreMockRecorder) CreateSupplier(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSupplier", reflect.TypeOf((*MockStore)(nil).CreateSupplier), arg0, arg1)
+}
+
+// CreateTransfer mocks base method.
+func (m *MockStore) CreateTransfer(arg0 context.Context, arg1 db.CreateTransferParams) (db.Transfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTransfer", arg0, arg1)
+	ret0, _ := ret[0].(db.Transfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTransfer indicates an expected call of CreateTransfer.
+func (mr *MockStoreMockRecorder) CreateTransfer(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTransfer", reflect.TypeOf((*MockStore)(nil).CreateTransfer), arg0, arg1)
+}
+
+// CreateUnit mocks base method.
+func (m *MockStore) CreateUnit(arg0 context.Context, arg1 db.CreateUnitParams) (db.Unit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateUnit", arg0, arg1)
+	ret0, _ := ret[0].(db.Unit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateUnit indicates an expected call of CreateUnit.
+func (mr *MockStoreMockRecorder) CreateUnit(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUnit", reflect.TypeOf((*MockStore)(nil).CreateUnit), arg0, arg1)
+}
+
+// CreateUser mocks base method.
+func (m *MockStore) CreateUser(arg0 context.Context, arg1 db.CreateUserParams) (db.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateUser", arg0, arg1)
+	ret0, _ := ret[0].(db.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateUser indicates an expected call of CreateUser.
+func (mr *MockStoreMockRecorder) CreateUser(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*MockStore)(nil).CreateUser), arg0, arg1)
+}
+
+// DeleteAccount mocks base method.
+func (m *MockStore) DeleteAccount(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAccount", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAccount indicates an expected call of DeleteAccount.
+func (mr *MockStoreMockRecorder) DeleteAccount(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAccount", reflect.TypeOf((*MockStore)(nil).DeleteAccount), arg0, arg1)
+}
+
+// DeleteActiveIngredient mocks base method.
+func (m *MockStore) DeleteActiveIngredient(arg0 context.Context, arg1 int32) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteActiveIngredient", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteActiveIngredient indicates an expected call of DeleteActiveIngredient.
+func (mr *MockStoreMockRecorder) DeleteActiveIngredient(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteActiveIngredient", reflect.TypeOf((*MockStore)(nil).DeleteActiveIngredient), arg0, arg1)
+}
+
+// DeleteCategory mocks base method.
+func (m *MockStore) DeleteCategory(arg0 context.Context, arg1 int32) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCategory", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteCategory indicates an expected call of DeleteCategory.
+func (mr *MockStoreMockRecorder) DeleteCategory(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCategory", reflect.TypeOf((*MockStore)(nil).DeleteCategory), arg0, arg1)
+}
+
+// DeleteEntry mocks base method.
+func (m *MockStore) DeleteEntry(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteEntry", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteEntry indicates an expected call of DeleteEntry.
+func (mr *MockStoreMockRecorder) DeleteEntry(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteEntry", reflect.TypeOf((*MockStore)(nil).DeleteEntry), arg0, arg1)
+}
+
+// DeleteExpiredUserRoles mocks base method.
+func (m *MockStore) DeleteExpiredUserRoles(arg0 context.Context) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteExpiredUserRoles", arg0)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteExpiredUserRoles indicates an expected call of DeleteExpiredUserRoles.
+func (mr *MockStoreMockRecorder) DeleteExpiredUserRoles(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExpiredUserRoles", reflect.TypeOf((*MockStore)(nil).DeleteExpiredUserRoles), arg0)
+}
+
+// DeleteIngredientInteraction mocks base method.
+func (m *MockStore) DeleteIngredientInteraction(arg0 context.Context, arg1 int32) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteIngredientInteraction", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteIngredientInteraction indicates an expected call of DeleteIngredientInteraction.
+func (mr *MockStoreMockRecorder) DeleteIngredientInteraction(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteIngredientInteraction", reflect.TypeOf((*MockStore)(nil).DeleteIngredientInteraction), arg0, arg1)
+}
+
+// DeleteMedicine mocks base method.
+func (m *MockStore) DeleteMedicine(arg0 context.Context, arg1 int32) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteMedicine", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteMedicine indicates an expected call of DeleteMedicine.
+func (mr *MockStoreMockRecorder) DeleteMedicine(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMedicine", reflect.TypeOf((*MockStore)(nil).DeleteMedicine), arg0, arg1)
+}
+
+// DeleteMedicineBarcode mocks base method.
+func (m *MockStore) DeleteMedicineBarcode(arg0 context.Context, arg1 db.DeleteMedicineBarcodeParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteMedicineBarcode", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteMedicineBarcode indicates an expected call of DeleteMedicineBarcode.
+func (mr *MockStoreMockRecorder) DeleteMedicineBarcode(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMedicineBarcode", reflect.TypeOf((*MockStore)(nil).DeleteMedicineBarcode), arg0, arg1)
+}
+
+// DeleteMedicineCategories mocks base method.
+func (m *MockStore) DeleteMedicineCategories(arg0 context.Context, arg1 int32) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteMedicineCategories", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteMedicineCategories indicates an expected call of DeleteMedicineCategories.
+func (mr *MockStoreMockRecorder) DeleteMedicineCategories(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMedicineCategories", reflect.TypeOf((*MockStore)(nil).DeleteMedicineCategories), arg0, arg1)
+}
+
+// DeleteMedicineIngredients mocks base method.
+func (m *MockStore) DeleteMedicineIngredients(arg0 context.Context, arg1 int32) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteMedicineIngredients", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteMedicineIngredients indicates an expected call of DeleteMedicineIngredients.
+func (mr *MockStoreMockRecorder) DeleteMedicineIngredients(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMedicineIngredients", reflect.TypeOf((*MockStore)(nil).DeleteMedicineIngredients), arg0, arg1)
+}
+
+// DeleteMedicineUnitConversion mocks base method.
+func (m *MockStore) DeleteMedicineUnitConversion(arg0 context.Context, arg1 db.DeleteMedicineUnitConversionParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteMedicineUnitConversion", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteMedicineUnitConversion indicates an expected call of DeleteMedicineUnitConversion.
+func (mr *MockStoreMockRecorder) DeleteMedicineUnitConversion(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMedicineUnitConversion", reflect.TypeOf((*MockStore)(nil).DeleteMedicineUnitConversion), arg0, arg1)
+}
+
+// DeletePermission mocks base method.
+func (m *MockStore) DeletePermission(arg0 context.Context, arg1 int32) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePermission", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePermission indicates an expected call of DeletePermission.
+func (mr *MockStoreMockRecorder) DeletePermission(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePermission", reflect.TypeOf((*MockStore)(nil).DeletePermission), arg0, arg1)
+}
+
+// DeletePurchaseOrder mocks base method.
+func (m *MockStore) DeletePurchaseOrder(arg0 context.Context, arg1 int32) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePurchaseOrder", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeletePurchaseOrder indicates an expected call of DeletePurchaseOrder.
+func (mr *MockStoreMockRecorder) DeletePurchaseOrder(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePurchaseOrder", reflect.TypeOf((*MockStore)(nil).DeletePurchaseOrder), arg0, arg1)
+}
+
+// DeletePurchaseOrderItems mocks base method.
+func (m *MockStore) DeletePurchaseOrderItems(arg0 context.Context, arg1 int32) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePurchaseOrderItems", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePurchaseOrderItems indicates an expected call of DeletePurchaseOrderItems.
+func (mr *MockStoreMockRecorder) DeletePurchaseOrderItems(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePurchaseOrderItems", reflect.TypeOf((*MockStore)(nil).DeletePurchaseOrderItems), arg0, arg1)
+}
+
+// DeleteRole mocks base method.
+func (m *MockStore) DeleteRole(arg0 context.Context, arg1 int32) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRole", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteRole indicates an expected call of DeleteRole.
+func (mr *MockStoreMockRecorder) DeleteRole(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRole", reflect.TypeOf((*MockStore)(nil).DeleteRole), arg0, arg1)
+}
+
+// DeleteRolePermission mocks base method.
+func (m *MockStore) DeleteRolePermission(arg0 context.Context, arg1 db.DeleteRolePermissionParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRolePermission", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteRolePermission indicates an expected call of DeleteRolePermission.
+func (mr *MockStoreMockRecorder) DeleteRolePermission(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRolePermission", reflect.TypeOf((*MockStore)(nil).DeleteRolePermission), arg0, arg1)
+}
+
+// DeleteRolePermissionDeny mocks base method.
+func (m *MockStore) DeleteRolePermissionDeny(arg0 context.Context, arg1 db.DeleteRolePermissionDenyParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRolePermissionDeny", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteRolePermissionDeny indicates an expected call of DeleteRolePermissionDeny.
+func (mr *MockStoreMockRecorder) DeleteRolePermissionDeny(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRolePermissionDeny", reflect.TypeOf((*MockStore)(nil).DeleteRolePermissionDeny), arg0, arg1)
+}
+
+// DeleteRolePermissionTx mocks base method.
+func (m *MockStore) DeleteRolePermissionTx(arg0 context.Context, arg1 db.DeleteRolePermissionParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRolePermissionTx", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteRolePermissionTx indicates an expected call of DeleteRolePermissionTx.
+func (mr *MockStoreMockRecorder) DeleteRolePermissionTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRolePermissionTx", reflect.TypeOf((*MockStore)(nil).DeleteRolePermissionTx), arg0, arg1)
+}
+
+// DeleteRoleTx mocks base method.
+func (m *MockStore) DeleteRoleTx(arg0 context.Context, arg1 int32) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRoleTx", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteRoleTx indicates an expected call of DeleteRoleTx.
+func (mr *MockStoreMockRecorder) DeleteRoleTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRoleTx", reflect.TypeOf((*MockStore)(nil).DeleteRoleTx), arg0, arg1)
+}
+
+// DeleteStocktake mocks base method.
+func (m *MockStore) DeleteStocktake(arg0 context.Context, arg1 int32) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteStocktake", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteStocktake indicates an expected call of DeleteStocktake.
+func (mr *MockStoreMockRecorder) DeleteStocktake(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteStocktake", reflect.TypeOf((*MockStore)(nil).DeleteStocktake), arg0, arg1)
+}
+
+// DeleteSupplier mocks base method.
+func (m *MockStore) DeleteSupplier(arg0 context.Context, arg1 int32) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSupplier", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteSupplier indicates an expected call of DeleteSupplier.
+func (mr *MockStoreMockRecorder) DeleteSupplier(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSupplier", reflect.TypeOf((*MockStore)(nil).DeleteSupplier), arg0, arg1)
+}
+
+// DeleteTransfer mocks base method.
+func (m *MockStore) DeleteTransfer(arg0 context.Context, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTransfer", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTransfer indicates an expected call of DeleteTransfer.
+func (mr *MockStoreMockRecorder) DeleteTransfer(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTransfer", reflect.TypeOf((*MockStore)(nil).DeleteTransfer), arg0, arg1)
+}
+
+// DeleteUnit mocks base method.
+func (m *MockStore) DeleteUnit(arg0 context.Context, arg1 string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUnit", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteUnit indicates an expected call of DeleteUnit.
+func (mr *MockStoreMockRecorder) DeleteUnit(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUnit", reflect.TypeOf((*MockStore)(nil).DeleteUnit), arg0, arg1)
+}
+
+// DeleteUserPermissionOverride mocks base method.
+func (m *MockStore) DeleteUserPermissionOverride(arg0 context.Context, arg1 db.DeleteUserPermissionOverrideParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUserPermissionOverride", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteUserPermissionOverride indicates an expected call of DeleteUserPermissionOverride.
+func (mr *MockStoreMockRecorder) DeleteUserPermissionOverride(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUserPermissionOverride", reflect.TypeOf((*MockStore)(nil).DeleteUserPermissionOverride), arg0, arg1)
+}
+
+// DeleteUserPermissionOverrideTx mocks base method.
+func (m *MockStore) DeleteUserPermissionOverrideTx(arg0 context.Context, arg1 db.DeleteUserPermissionOverrideParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUserPermissionOverrideTx", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteUserPermissionOverrideTx indicates an expected call of DeleteUserPermissionOverrideTx.
+func (mr *MockStoreMockRecorder) DeleteUserPermissionOverrideTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUserPermissionOverrideTx", reflect.TypeOf((*MockStore)(nil).DeleteUserPermissionOverrideTx), arg0, arg1)
+}
+
+// ExportPolicy mocks base method.
+func (m *MockStore) ExportPolicy(arg0 context.Context) (db.PolicyDocument, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportPolicy", arg0)
+	ret0, _ := ret[0].(db.PolicyDocument)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExportPolicy indicates an expected call of ExportPolicy.
+func (mr *MockStoreMockRecorder) ExportPolicy(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportPolicy", reflect.TypeOf((*MockStore)(nil).ExportPolicy), arg0)
+}
+
+// GetAccount mocks base method.
+func (m *MockStore) GetAccount(arg0 context.Context, arg1 int64) (db.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccount", arg0, arg1)
+	ret0, _ := ret[0].(db.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccount indicates an expected call of GetAccount.
+func (mr *MockStoreMockRecorder) GetAccount(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccount", reflect.TypeOf((*MockStore)(nil).GetAccount), arg0, arg1)
+}
+
+// GetAccountForUpdate mocks base method.
+func (m *MockStore) GetAccountForUpdate(arg0 context.Context, arg1 int64) (db.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccountForUpdate", arg0, arg1)
+	ret0, _ := ret[0].(db.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccountForUpdate indicates an expected call of GetAccountForUpdate.
+func (mr *MockStoreMockRecorder) GetAccountForUpdate(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountForUpdate", reflect.TypeOf((*MockStore)(nil).GetAccountForUpdate), arg0, arg1)
+}
+
+// GetBreakGlassGrant mocks base method.
+func (m *MockStore) GetBreakGlassGrant(arg0 context.Context, arg1 int64) (db.GetBreakGlassGrantRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBreakGlassGrant", arg0, arg1)
+	ret0, _ := ret[0].(db.GetBreakGlassGrantRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBreakGlassGrant indicates an expected call of GetBreakGlassGrant.
+func (mr *MockStoreMockRecorder) GetBreakGlassGrant(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBreakGlassGrant", reflect.TypeOf((*MockStore)(nil).GetBreakGlassGrant), arg0, arg1)
+}
+
+// GetDefaultStockLocation mocks base method.
+func (m *MockStore) GetDefaultStockLocation(arg0 context.Context) (db.StockLocation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDefaultStockLocation", arg0)
+	ret0, _ := ret[0].(db.StockLocation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDefaultStockLocation indicates an expected call of GetDefaultStockLocation.
+func (mr *MockStoreMockRecorder) GetDefaultStockLocation(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDefaultStockLocation", reflect.TypeOf((*MockStore)(nil).GetDefaultStockLocation), arg0)
+}
+
+// GetEntry mocks base method.
+func (m *MockStore) GetEntry(arg0 context.Context, arg1 int64) (db.Entry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEntry", arg0, arg1)
+	ret0, _ := ret[0].(db.Entry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEntry indicates an expected call of GetEntry.
+func (mr *MockStoreMockRecorder) GetEntry(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEntry", reflect.TypeOf((*MockStore)(nil).GetEntry), arg0, arg1)
+}
+
+// GetMedicine mocks base method.
+func (m *MockStore) GetMedicine(arg0 context.Context, arg1 int32) (db.Medicine, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMedicine", arg0, arg1)
+	ret0, _ := ret[0].(db.Medicine)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMedicine indicates an expected call of GetMedicine.
+func (mr *MockStoreMockRecorder) GetMedicine(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMedicine", reflect.TypeOf((*MockStore)(nil).GetMedicine), arg0, arg1)
+}
+
+// GetMedicineBatch mocks base method.
+func (m *MockStore) GetMedicineBatch(arg0 context.Context, arg1 int64) (db.MedicineBatch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMedicineBatch", arg0, arg1)
+	ret0, _ := ret[0].(db.MedicineBatch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMedicineBatch indicates an expected call of GetMedicineBatch.
+func (mr *MockStoreMockRecorder) GetMedicineBatch(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMedicineBatch", reflect.TypeOf((*MockStore)(nil).GetMedicineBatch), arg0, arg1)
+}
+
+// GetMedicineByBarcode mocks base method.
+func (m *MockStore) GetMedicineByBarcode(arg0 context.Context, arg1 string) (db.Medicine, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMedicineByBarcode", arg0, arg1)
+	ret0, _ := ret[0].(db.Medicine)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMedicineByBarcode indicates an expected call of GetMedicineByBarcode.
+func (mr *MockStoreMockRecorder) GetMedicineByBarcode(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMedicineByBarcode", reflect.TypeOf((*MockStore)(nil).GetMedicineByBarcode), arg0, arg1)
+}
+
+// GetMedicineForUpdate mocks base method.
+func (m *MockStore) GetMedicineForUpdate(arg0 context.Context, arg1 int32) (db.Medicine, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMedicineForUpdate", arg0, arg1)
+	ret0, _ := ret[0].(db.Medicine)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMedicineForUpdate indicates an expected call of GetMedicineForUpdate.
+func (mr *MockStoreMockRecorder) GetMedicineForUpdate(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMedicineForUpdate", reflect.TypeOf((*MockStore)(nil).GetMedicineForUpdate), arg0, arg1)
+}
+
+// GetMedicinePriceAt mocks base method.
+func (m *MockStore) GetMedicinePriceAt(arg0 context.Context, arg1 db.GetMedicinePriceAtParams) (db.MedicinePrice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMedicinePriceAt", arg0, arg1)
+	ret0, _ := ret[0].(db.MedicinePrice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMedicinePriceAt indicates an expected call of GetMedicinePriceAt.
+func (mr *MockStoreMockRecorder) GetMedicinePriceAt(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMedicinePriceAt", reflect.TypeOf((*MockStore)(nil).GetMedicinePriceAt), arg0, arg1)
+}
+
+// GetMedicineUnitConversion mocks base method.
+func (m *MockStore) GetMedicineUnitConversion(arg0 context.Context, arg1 db.GetMedicineUnitConversionParams) (db.MedicineUnitConversion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMedicineUnitConversion", arg0, arg1)
+	ret0, _ := ret[0].(db.MedicineUnitConversion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMedicineUnitConversion indicates an expected call of GetMedicineUnitConversion.
+func (mr *MockStoreMockRecorder) GetMedicineUnitConversion(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMedicineUnitConversion", reflect.TypeOf((*MockStore)(nil).GetMedicineUnitConversion), arg0, arg1)
+}
+
+// GetPermission mocks base method.
+func (m *MockStore) GetPermission(arg0 context.Context, arg1 int32) (db.Permission, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPermission", arg0, arg1)
+	ret0, _ := ret[0].(db.Permission)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPermission indicates an expected call of GetPermission.
+func (mr *MockStoreMockRecorder) GetPermission(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPermission", reflect.TypeOf((*MockStore)(nil).GetPermission), arg0, arg1)
+}
+
+// GetPermissionByName mocks base method.
+func (m *MockStore) GetPermissionByName(arg0 context.Context, arg1 string) (db.Permission, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPermissionByName", arg0, arg1)
+	ret0, _ := ret[0].(db.Permission)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPermissionByName indicates an expected call of GetPermissionByName.
+func (mr *MockStoreMockRecorder) GetPermissionByName(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPermissionByName", reflect.TypeOf((*MockStore)(nil).GetPermissionByName), arg0, arg1)
+}
+
+// GetPermissionsForUser mocks base method.
+func (m *MockStore) GetPermissionsForUser(arg0 context.Context, arg1 int32) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPermissionsForUser", arg0, arg1)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPermissionsForUser indicates an expected call of GetPermissionsForUser.
+func (mr *MockStoreMockRecorder) GetPermissionsForUser(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPermissionsForUser", reflect.TypeOf((*MockStore)(nil).GetPermissionsForUser), arg0, arg1)
+}
+
+// GetPurchaseOrder mocks base method.
+func (m *MockStore) GetPurchaseOrder(arg0 context.Context, arg1 int32) (db.PurchaseOrder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPurchaseOrder", arg0, arg1)
+	ret0, _ := ret[0].(db.PurchaseOrder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPurchaseOrder indicates an expected call of GetPurchaseOrder.
+func (mr *MockStoreMockRecorder) GetPurchaseOrder(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPurchaseOrder", reflect.TypeOf((*MockStore)(nil).GetPurchaseOrder), arg0, arg1)
+}
+
+// GetPurchaseOrderForUpdate mocks base method.
+func (m *MockStore) GetPurchaseOrderForUpdate(arg0 context.Context, arg1 int32) (db.PurchaseOrder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPurchaseOrderForUpdate", arg0, arg1)
+	ret0, _ := ret[0].(db.PurchaseOrder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPurchaseOrderForUpdate indicates an expected call of GetPurchaseOrderForUpdate.
+func (mr *MockStoreMockRecorder) GetPurchaseOrderForUpdate(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPurchaseOrderForUpdate", reflect.TypeOf((*MockStore)(nil).GetPurchaseOrderForUpdate), arg0, arg1)
+}
+
+// GetRole mocks base method.
+func (m *MockStore) GetRole(arg0 context.Context, arg1 int32) (db.Role, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRole", arg0, arg1)
+	ret0, _ := ret[0].(db.Role)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRole indicates an expected call of GetRole.
+func (mr *MockStoreMockRecorder) GetRole(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRole", reflect.TypeOf((*MockStore)(nil).GetRole), arg0, arg1)
+}
+
+// GetRolePermission mocks base method.
+func (m *MockStore) GetRolePermission(arg0 context.Context, arg1 db.GetRolePermissionParams) (db.RolePermission, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRolePermission", arg0, arg1)
+	ret0, _ := ret[0].(db.RolePermission)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRolePermission indicates an expected call of GetRolePermission.
+func (mr *MockStoreMockRecorder) GetRolePermission(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRolePermission", reflect.TypeOf((*MockStore)(nil).GetRolePermission), arg0, arg1)
+}
+
+// GetRolePermissionDeny mocks base method.
+func (m *MockStore) GetRolePermissionDeny(arg0 context.Context, arg1 db.GetRolePermissionDenyParams) (db.RolePermissionDeny, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRolePermissionDeny", arg0, arg1)
+	ret0, _ := ret[0].(db.RolePermissionDeny)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRolePermissionDeny indicates an expected call of GetRolePermissionDeny.
+func (mr *MockStoreMockRecorder) GetRolePermissionDeny(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRolePermissionDeny", reflect.TypeOf((*MockStore)(nil).GetRolePermissionDeny), arg0, arg1)
+}
+
+// GetRolesForUser mocks base method.
+func (m *MockStore) GetRolesForUser(arg0 context.Context, arg1 int32) ([]db.Role, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRolesForUser", arg0, arg1)
+	ret0, _ := ret[0].([]db.Role)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRolesForUser indicates an expected call of GetRolesForUser.
+func (mr *MockStoreMockRecorder) GetRolesForUser(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRolesForUser", reflect.TypeOf((*MockStore)(nil).GetRolesForUser), arg0, arg1)
+}
+
+// GetSession mocks base method.
+func (m *MockStore) GetSession(arg0 context.Context, arg1 uuid.UUID) (db.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSession", arg0, arg1)
+	ret0, _ := ret[0].(db.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSession indicates an expected call of GetSession.
+func (mr *MockStoreMockRecorder) GetSession(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSession", reflect.TypeOf((*MockStore)(nil).GetSession), arg0, arg1)
+}
+
+// GetStockAlert mocks base method.
+func (m *MockStore) GetStockAlert(arg0 context.Context, arg1 int64) (db.StockAlert, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStockAlert", arg0, arg1)
+	ret0, _ := ret[0].(db.StockAlert)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStockAlert indicates an expected call of GetStockAlert.
+func (mr *MockStoreMockRecorder) GetStockAlert(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStockAlert", reflect.TypeOf((*MockStore)(nil).GetStockAlert), arg0, arg1)
+}
+
+// GetStockLocation mocks base method.
+func (m *MockStore) GetStockLocation(arg0 context.Context, arg1 int32) (db.StockLocation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStockLocation", arg0, arg1)
+	ret0, _ := ret[0].(db.StockLocation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStockLocation indicates an expected call of GetStockLocation.
+func (mr *MockStoreMockRecorder) GetStockLocation(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStockLocation", reflect.TypeOf((*MockStore)(nil).GetStockLocation), arg0, arg1)
+}
+
+// GetStocktake mocks base method.
+func (m *MockStore) GetStocktake(arg0 context.Context, arg1 int32) (db.Stocktake, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStocktake", arg0, arg1)
+	ret0, _ := ret[0].(db.Stocktake)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStocktake indicates an expected call of GetStocktake.
+func (mr *MockStoreMockRecorder) GetStocktake(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStocktake", reflect.TypeOf((*MockStore)(nil).GetStocktake), arg0, arg1)
+}
+
+// GetStocktakeForUpdate mocks base method.
+func (m *MockStore) GetStocktakeForUpdate(arg0 context.Context, arg1 int32) (db.Stocktake, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStocktakeForUpdate", arg0, arg1)
+	ret0, _ := ret[0].(db.Stocktake)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStocktakeForUpdate indicates an expected call of GetStocktakeForUpdate.
+func (mr *MockStoreMockRecorder) GetStocktakeForUpdate(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStocktakeForUpdate", reflect.TypeOf((*MockStore)(nil).GetStocktakeForUpdate), arg0, arg1)
+}
+
+// GetSupplier mocks base method.
+func (m *MockStore) GetSupplier(arg0 context.Context, arg1 int32) (db.Supplier, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSupplier", arg0, arg1)
+	ret0, _ := ret[0].(db.Supplier)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSupplier indicates an expected call of GetSupplier.
+func (mr *MockStoreMockRecorder) GetSupplier(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSupplier", reflect.TypeOf((*MockStore)(nil).GetSupplier), arg0, arg1)
+}
+
+// GetTransfer mocks base method.
+func (m *MockStore) GetTransfer(arg0 context.Context, arg1 int64) (db.Transfer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTransfer", arg0, arg1)
+	ret0, _ := ret[0].(db.Transfer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTransfer indicates an expected call of GetTransfer.
+func (mr *MockStoreMockRecorder) GetTransfer(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransfer", reflect.TypeOf((*MockStore)(nil).GetTransfer), arg0, arg1)
+}
+
+// GetUser mocks base method.
+func (m *MockStore) GetUser(arg0 context.Context, arg1 string) (db.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUser", arg0, arg1)
+	ret0, _ := ret[0].(db.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUser indicates an expected call of GetUser.
+func (mr *MockStoreMockRecorder) GetUser(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUser", reflect.TypeOf((*MockStore)(nil).GetUser), arg0, arg1)
+}
+
+// GetUserByID mocks base method.
+func (m *MockStore) GetUserByID(arg0 context.Context, arg1 int32) (db.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserByID", arg0, arg1)
+	ret0, _ := ret[0].(db.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserByID indicates an expected call of GetUserByID.
+func (mr *MockStoreMockRecorder) GetUserByID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByID", reflect.TypeOf((*MockStore)(nil).GetUserByID), arg0, arg1)
+}
+
+// GetUserPermissionOverride mocks base method.
+func (m *MockStore) GetUserPermissionOverride(arg0 context.Context, arg1 db.GetUserPermissionOverrideParams) (db.UserPermissionOverride, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserPermissionOverride", arg0, arg1)
+	ret0, _ := ret[0].(db.UserPermissionOverride)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserPermissionOverride indicates an expected call of GetUserPermissionOverride.
+func (mr *MockStoreMockRecorder) GetUserPermissionOverride(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserPermissionOverride", reflect.TypeOf((*MockStore)(nil).GetUserPermissionOverride), arg0, arg1)
+}
+
+// ImportMedicinesTx mocks base method.
+func (m *MockStore) ImportMedicinesTx(arg0 context.Context, arg1 db.ImportMedicinesTxParams) (db.ImportMedicinesTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportMedicinesTx", arg0, arg1)
+	ret0, _ := ret[0].(db.ImportMedicinesTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImportMedicinesTx indicates an expected call of ImportMedicinesTx.
+func (mr *MockStoreMockRecorder) ImportMedicinesTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportMedicinesTx", reflect.TypeOf((*MockStore)(nil).ImportMedicinesTx), arg0, arg1)
+}
+
+// ImportPolicyTx mocks base method.
+func (m *MockStore) ImportPolicyTx(arg0 context.Context, arg1 db.ImportPolicyTxParams) (db.ImportPolicyTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportPolicyTx", arg0, arg1)
+	ret0, _ := ret[0].(db.ImportPolicyTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImportPolicyTx indicates an expected call of ImportPolicyTx.
+func (mr *MockStoreMockRecorder) ImportPolicyTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportPolicyTx", reflect.TypeOf((*MockStore)(nil).ImportPolicyTx), arg0, arg1)
+}
+
+// ListAccounts mocks base method.
+func (m *MockStore) ListAccounts(arg0 context.Context, arg1 db.ListAccountsParams) ([]db.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAccounts", arg0, arg1)
+	ret0, _ := ret[0].([]db.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAccounts indicates an expected call of ListAccounts.
+func (mr *MockStoreMockRecorder) ListAccounts(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAccounts", reflect.TypeOf((*MockStore)(nil).ListAccounts), arg0, arg1)
+}
+
+// ListActiveBreakGlassGrantsForUser mocks base method.
+func (m *MockStore) ListActiveBreakGlassGrantsForUser(arg0 context.Context, arg1 int32) ([]db.ListActiveBreakGlassGrantsForUserRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListActiveBreakGlassGrantsForUser", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListActiveBreakGlassGrantsForUserRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListActiveBreakGlassGrantsForUser indicates an expected call of ListActiveBreakGlassGrantsForUser.
+func (mr *MockStoreMockRecorder) ListActiveBreakGlassGrantsForUser(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListActiveBreakGlassGrantsForUser", reflect.TypeOf((*MockStore)(nil).ListActiveBreakGlassGrantsForUser), arg0, arg1)
+}
+
+// ListActiveIngredients mocks base method.
+func (m *MockStore) ListActiveIngredients(arg0 context.Context) ([]db.ActiveIngredient, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListActiveIngredients", arg0)
+	ret0, _ := ret[0].([]db.ActiveIngredient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListActiveIngredients indicates an expected call of ListActiveIngredients.
+func (mr *MockStoreMockRecorder) ListActiveIngredients(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListActiveIngredients", reflect.TypeOf((*MockStore)(nil).ListActiveIngredients), arg0)
+}
+
+// ListAllMedicines mocks base method.
+func (m *MockStore) ListAllMedicines(arg0 context.Context) ([]db.Medicine, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAllMedicines", arg0)
+	ret0, _ := ret[0].([]db.Medicine)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAllMedicines indicates an expected call of ListAllMedicines.
+func (mr *MockStoreMockRecorder) ListAllMedicines(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAllMedicines", reflect.TypeOf((*MockStore)(nil).ListAllMedicines), arg0)
+}
+
+// ListAllPermissions mocks base method.
+func (m *MockStore) ListAllPermissions(arg0 context.Context) ([]db.Permission, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAllPermissions", arg0)
+	ret0, _ := ret[0].([]db.Permission)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAllPermissions indicates an expected call of ListAllPermissions.
+func (mr *MockStoreMockRecorder) ListAllPermissions(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAllPermissions", reflect.TypeOf((*MockStore)(nil).ListAllPermissions), arg0)
+}
+
+// ListAllRolePermissionDenies mocks base method.
+func (m *MockStore) ListAllRolePermissionDenies(arg0 context.Context) ([]db.RolePermissionDeny, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAllRolePermissionDenies", arg0)
+	ret0, _ := ret[0].([]db.RolePermissionDeny)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAllRolePermissionDenies indicates an expected call of ListAllRolePermissionDenies.
+func (mr *MockStoreMockRecorder) ListAllRolePermissionDenies(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAllRolePermissionDenies", reflect.TypeOf((*MockStore)(nil).ListAllRolePermissionDenies), arg0)
+}
+
+// ListAllRolePermissions mocks base method.
+func (m *MockStore) ListAllRolePermissions(arg0 context.Context) ([]db.RolePermission, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAllRolePermissions", arg0)
+	ret0, _ := ret[0].([]db.RolePermission)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAllRolePermissions indicates an expected call of ListAllRolePermissions.
+func (mr *MockStoreMockRecorder) ListAllRolePermissions(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAllRolePermissions", reflect.TypeOf((*MockStore)(nil).ListAllRolePermissions), arg0)
+}
+
+// ListAllRoles mocks base method.
+func (m *MockStore) ListAllRoles(arg0 context.Context) ([]db.Role, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAllRoles", arg0)
+	ret0, _ := ret[0].([]db.Role)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAllRoles indicates an expected call of ListAllRoles.
+func (mr *MockStoreMockRecorder) ListAllRoles(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAllRoles", reflect.TypeOf((*MockStore)(nil).ListAllRoles), arg0)
+}
+
+// ListAllUserPermissionOverrides mocks base method.
+func (m *MockStore) ListAllUserPermissionOverrides(arg0 context.Context) ([]db.ListAllUserPermissionOverridesRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAllUserPermissionOverrides", arg0)
+	ret0, _ := ret[0].([]db.ListAllUserPermissionOverridesRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAllUserPermissionOverrides indicates an expected call of ListAllUserPermissionOverrides.
+func (mr *MockStoreMockRecorder) ListAllUserPermissionOverrides(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAllUserPermissionOverrides", reflect.TypeOf((*MockStore)(nil).ListAllUserPermissionOverrides), arg0)
+}
+
+// ListAllUserRoles mocks base method.
+func (m *MockStore) ListAllUserRoles(arg0 context.Context) ([]db.ListAllUserRolesRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAllUserRoles", arg0)
+	ret0, _ := ret[0].([]db.ListAllUserRolesRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAllUserRoles indicates an expected call of ListAllUserRoles.
+func (mr *MockStoreMockRecorder) ListAllUserRoles(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAllUserRoles", reflect.TypeOf((*MockStore)(nil).ListAllUserRoles), arg0)
+}
+
+// ListBatchLocations mocks base method.
+func (m *MockStore) ListBatchLocations(arg0 context.Context, arg1 int64) ([]db.BatchLocation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListBatchLocations", arg0, arg1)
+	ret0, _ := ret[0].([]db.BatchLocation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListBatchLocations indicates an expected call of ListBatchLocations.
+func (mr *MockStoreMockRecorder) ListBatchLocations(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBatchLocations", reflect.TypeOf((*MockStore)(nil).ListBatchLocations), arg0, arg1)
+}
+
+// ListCategories mocks base method.
+func (m *MockStore) ListCategories(arg0 context.Context) ([]db.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCategories", arg0)
+	ret0, _ := ret[0].([]db.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCategories indicates an expected call of ListCategories.
+func (mr *MockStoreMockRecorder) ListCategories(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCategories", reflect.TypeOf((*MockStore)(nil).ListCategories), arg0)
+}
+
+// ListControlledRegisterBalances mocks base method.
+func (m *MockStore) ListControlledRegisterBalances(arg0 context.Context, arg1 db.ListControlledRegisterBalancesParams) ([]db.ListControlledRegisterBalancesRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListControlledRegisterBalances", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListControlledRegisterBalancesRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListControlledRegisterBalances indicates an expected call of ListControlledRegisterBalances.
+func (mr *MockStoreMockRecorder) ListControlledRegisterBalances(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListControlledRegisterBalances", reflect.TypeOf((*MockStore)(nil).ListControlledRegisterBalances), arg0, arg1)
+}
+
+// ListControlledRegisterEntries mocks base method.
+func (m *MockStore) ListControlledRegisterEntries(arg0 context.Context, arg1 db.ListControlledRegisterEntriesParams) ([]db.ListControlledRegisterEntriesRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListControlledRegisterEntries", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListControlledRegisterEntriesRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListControlledRegisterEntries indicates an expected call of ListControlledRegisterEntries.
+func (mr *MockStoreMockRecorder) ListControlledRegisterEntries(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListControlledRegisterEntries", reflect.TypeOf((*MockStore)(nil).ListControlledRegisterEntries), arg0, arg1)
+}
+
+// ListDispensableLocationBatches mocks base method.
+func (m *MockStore) ListDispensableLocationBatches(arg0 context.Context, arg1 db.ListDispensableLocationBatchesParams) ([]db.ListDispensableLocationBatchesRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDispensableLocationBatches", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListDispensableLocationBatchesRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDispensableLocationBatches indicates an expected call of ListDispensableLocationBatches.
+func (mr *MockStoreMockRecorder) ListDispensableLocationBatches(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDispensableLocationBatches", reflect.TypeOf((*MockStore)(nil).ListDispensableLocationBatches), arg0, arg1)
+}
+
+// ListDispensableMedicineBatches mocks base method.
+func (m *MockStore) ListDispensableMedicineBatches(arg0 context.Context, arg1 int32) ([]db.MedicineBatch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDispensableMedicineBatches", arg0, arg1)
+	ret0, _ := ret[0].([]db.MedicineBatch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDispensableMedicineBatches indicates an expected call of ListDispensableMedicineBatches.
+func (mr *MockStoreMockRecorder) ListDispensableMedicineBatches(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDispensableMedicineBatches", reflect.TypeOf((*MockStore)(nil).ListDispensableMedicineBatches), arg0, arg1)
+}
+
+// ListEntries mocks base method.
+func (m *MockStore) ListEntries(arg0 context.Context, arg1 db.ListEntriesParams) ([]db.Entry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListEntries", arg0, arg1)
+	ret0, _ := ret[0].([]db.Entry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListEntries indicates an expected call of ListEntries.
+func (mr *MockStoreMockRecorder) ListEntries(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEntries", reflect.TypeOf((*MockStore)(nil).ListEntries), arg0, arg1)
+}
+
+// ListExpiringMedicineBatches mocks base method.
+func (m *MockStore) ListExpiringMedicineBatches(arg0 context.Context, arg1 sql.NullTime) ([]db.ListExpiringMedicineBatchesRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListExpiringMedicineBatches", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListExpiringMedicineBatchesRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListExpiringMedicineBatches indicates an expected call of ListExpiringMedicineBatches.
+func (mr *MockStoreMockRecorder) ListExpiringMedicineBatches(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListExpiringMedicineBatches", reflect.TypeOf((*MockStore)(nil).ListExpiringMedicineBatches), arg0, arg1)
+}
+
+// ListExpiringUserRoles mocks base method.
+func (m *MockStore) ListExpiringUserRoles(arg0 context.Context, arg1 time.Time) ([]db.ListExpiringUserRolesRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListExpiringUserRoles", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListExpiringUserRolesRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListExpiringUserRoles indicates an expected call of ListExpiringUserRoles.
+func (mr *MockStoreMockRecorder) ListExpiringUserRoles(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListExpiringUserRoles", reflect.TypeOf((*MockStore)(nil).ListExpiringUserRoles), arg0, arg1)
+}
+
+// ListGoodsReceiptItems mocks base method.
+func (m *MockStore) ListGoodsReceiptItems(arg0 context.Context, arg1 []int32) ([]db.ListGoodsReceiptItemsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListGoodsReceiptItems", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListGoodsReceiptItemsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListGoodsReceiptItems indicates an expected call of ListGoodsReceiptItems.
+func (mr *MockStoreMockRecorder) ListGoodsReceiptItems(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListGoodsReceiptItems", reflect.TypeOf((*MockStore)(nil).ListGoodsReceiptItems), arg0, arg1)
+}
+
+// ListGoodsReceipts mocks base method.
+func (m *MockStore) ListGoodsReceipts(arg0 context.Context, arg1 int32) ([]db.GoodsReceipt, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListGoodsReceipts", arg0, arg1)
+	ret0, _ := ret[0].([]db.GoodsReceipt)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListGoodsReceipts indicates an expected call of ListGoodsReceipts.
+func (mr *MockStoreMockRecorder) ListGoodsReceipts(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListGoodsReceipts", reflect.TypeOf((*MockStore)(nil).ListGoodsReceipts), arg0, arg1)
+}
+
+// ListInteractionsAmongIngredients mocks base method.
+func (m *MockStore) ListInteractionsAmongIngredients(arg0 context.Context, arg1 []int32) ([]db.ListInteractionsAmongIngredientsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListInteractionsAmongIngredients", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListInteractionsAmongIngredientsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListInteractionsAmongIngredients indicates an expected call of ListInteractionsAmongIngredients.
+func (mr *MockStoreMockRecorder) ListInteractionsAmongIngredients(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListInteractionsAmongIngredients", reflect.TypeOf((*MockStore)(nil).ListInteractionsAmongIngredients), arg0, arg1)
+}
+
+// ListInteractionsForIngredient mocks base method.
+func (m *MockStore) ListInteractionsForIngredient(arg0 context.Context, arg1 int32) ([]db.ListInteractionsForIngredientRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListInteractionsForIngredient", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListInteractionsForIngredientRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListInteractionsForIngredient indicates an expected call of ListInteractionsForIngredient.
+func (mr *MockStoreMockRecorder) ListInteractionsForIngredient(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListInteractionsForIngredient", reflect.TypeOf((*MockStore)(nil).ListInteractionsForIngredient), arg0, arg1)
+}
+
+// ListLocationStock mocks base method.
+func (m *MockStore) ListLocationStock(arg0 context.Context, arg1 int32) ([]db.ListLocationStockRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListLocationStock", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListLocationStockRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListLocationStock indicates an expected call of ListLocationStock.
+func (mr *MockStoreMockRecorder) ListLocationStock(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLocationStock", reflect.TypeOf((*MockStore)(nil).ListLocationStock), arg0, arg1)
+}
+
+// ListLowStockMedicines mocks base method.
+func (m *MockStore) ListLowStockMedicines(arg0 context.Context) ([]db.Medicine, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListLowStockMedicines", arg0)
+	ret0, _ := ret[0].([]db.Medicine)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListLowStockMedicines indicates an expected call of ListLowStockMedicines.
+func (mr *MockStoreMockRecorder) ListLowStockMedicines(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLowStockMedicines", reflect.TypeOf((*MockStore)(nil).ListLowStockMedicines), arg0)
+}
+
+// ListMedicineBarcodes mocks base method.
+func (m *MockStore) ListMedicineBarcodes(arg0 context.Context, arg1 int32) ([]db.MedicineBarcode, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListMedicineBarcodes", arg0, arg1)
+	ret0, _ := ret[0].([]db.MedicineBarcode)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListMedicineBarcodes indicates an expected call of ListMedicineBarcodes.
+func (mr *MockStoreMockRecorder) ListMedicineBarcodes(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMedicineBarcodes", reflect.TypeOf((*MockStore)(nil).ListMedicineBarcodes), arg0, arg1)
+}
+
+// ListMedicineBatches mocks base method.
+func (m *MockStore) ListMedicineBatches(arg0 context.Context, arg1 int32) ([]db.MedicineBatch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListMedicineBatches", arg0, arg1)
+	ret0, _ := ret[0].([]db.MedicineBatch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListMedicineBatches indicates an expected call of ListMedicineBatches.
+func (mr *MockStoreMockRecorder) ListMedicineBatches(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMedicineBatches", reflect.TypeOf((*MockStore)(nil).ListMedicineBatches), arg0, arg1)
+}
+
+// ListMedicineCategories mocks base method.
+func (m *MockStore) ListMedicineCategories(arg0 context.Context, arg1 int32) ([]db.Category, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListMedicineCategories", arg0, arg1)
+	ret0, _ := ret[0].([]db.Category)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListMedicineCategories indicates an expected call of ListMedicineCategories.
+func (mr *MockStoreMockRecorder) ListMedicineCategories(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMedicineCategories", reflect.TypeOf((*MockStore)(nil).ListMedicineCategories), arg0, arg1)
+}
+
+// ListMedicineConsumption mocks base method.
+func (m *MockStore) ListMedicineConsumption(arg0 context.Context, arg1 time.Time) ([]db.ListMedicineConsumptionRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListMedicineConsumption", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListMedicineConsumptionRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListMedicineConsumption indicates an expected call of ListMedicineConsumption.
+func (mr *MockStoreMockRecorder) ListMedicineConsumption(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMedicineConsumption", reflect.TypeOf((*MockStore)(nil).ListMedicineConsumption), arg0, arg1)
+}
+
+// ListMedicineIngredients mocks base method.
+func (m *MockStore) ListMedicineIngredients(arg0 context.Context, arg1 []int32) ([]db.ListMedicineIngredientsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListMedicineIngredients", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListMedicineIngredientsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListMedicineIngredients indicates an expected call of ListMedicineIngredients.
+func (mr *MockStoreMockRecorder) ListMedicineIngredients(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMedicineIngredients", reflect.TypeOf((*MockStore)(nil).ListMedicineIngredients), arg0, arg1)
+}
+
+// ListMedicineLocationStock mocks base method.
+func (m *MockStore) ListMedicineLocationStock(arg0 context.Context, arg1 int32) ([]db.ListMedicineLocationStockRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListMedicineLocationStock", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListMedicineLocationStockRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListMedicineLocationStock indicates an expected call of ListMedicineLocationStock.
+func (mr *MockStoreMockRecorder) ListMedicineLocationStock(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMedicineLocationStock", reflect.TypeOf((*MockStore)(nil).ListMedicineLocationStock), arg0, arg1)
+}
+
+// ListMedicinePrices mocks base method.
+func (m *MockStore) ListMedicinePrices(arg0 context.Context, arg1 db.ListMedicinePricesParams) ([]db.MedicinePrice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListMedicinePrices", arg0, arg1)
+	ret0, _ := ret[0].([]db.MedicinePrice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListMedicinePrices indicates an expected call of ListMedicinePrices.
+func (mr *MockStoreMockRecorder) ListMedicinePrices(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMedicinePrices", reflect.TypeOf((*MockStore)(nil).ListMedicinePrices), arg0, arg1)
+}
+
+// ListMedicineUnitConversions mocks base method.
+func (m *MockStore) ListMedicineUnitConversions(arg0 context.Context, arg1 int32) ([]db.MedicineUnitConversion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListMedicineUnitConversions", arg0, arg1)
+	ret0, _ := ret[0].([]db.MedicineUnitConversion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListMedicineUnitConversions indicates an expected call of ListMedicineUnitConversions.
+func (mr *MockStoreMockRecorder) ListMedicineUnitConversions(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMedicineUnitConversions", reflect.TypeOf((*MockStore)(nil).ListMedicineUnitConversions), arg0, arg1)
+}
+
+// ListMedicines mocks base method.
+func (m *MockStore) ListMedicines(arg0 context.Context, arg1 db.ListMedicinesParams) ([]db.Medicine, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListMedicines", arg0, arg1)
+	ret0, _ := ret[0].([]db.Medicine)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListMedicines indicates an expected call of ListMedicines.
+func (mr *MockStoreMockRecorder) ListMedicines(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMedicines", reflect.TypeOf((*MockStore)(nil).ListMedicines), arg0, arg1)
+}
+
+// ListMedicinesByIDs mocks base method.
+func (m *MockStore) ListMedicinesByIDs(arg0 context.Context, arg1 []int32) ([]db.Medicine, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListMedicinesByIDs", arg0, arg1)
+	ret0, _ := ret[0].([]db.Medicine)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListMedicinesByIDs indicates an expected call of ListMedicinesByIDs.
+func (mr *MockStoreMockRecorder) ListMedicinesByIDs(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMedicinesByIDs", reflect.TypeOf((*MockStore)(nil).ListMedicinesByIDs), arg0, arg1)
+}
+
+// ListMedicinesForImport mocks base method.
+func (m *MockStore) ListMedicinesForImport(arg0 context.Context, arg1 db.ListMedicinesForImportParams) ([]db.Medicine, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListMedicinesForImport", arg0, arg1)
+	ret0, _ := ret[0].([]db.Medicine)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListMedicinesForImport indicates an expected call of ListMedicinesForImport.
+func (mr *MockStoreMockRecorder) ListMedicinesForImport(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMedicinesForImport", reflect.TypeOf((*MockStore)(nil).ListMedicinesForImport), arg0, arg1)
+}
+
+// ListMedicinesForUpdate mocks base method.
+func (m *MockStore) ListMedicinesForUpdate(arg0 context.Context, arg1 db.ListMedicinesForUpdateParams) ([]db.Medicine, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListMedicinesForUpdate", arg0, arg1)
+	ret0, _ := ret[0].([]db.Medicine)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListMedicinesForUpdate indicates an expected call of ListMedicinesForUpdate.
+func (mr *MockStoreMockRecorder) ListMedicinesForUpdate(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMedicinesForUpdate", reflect.TypeOf((*MockStore)(nil).ListMedicinesForUpdate), arg0, arg1)
+}
+
+// ListPendingBreakGlassGrants mocks base method.
+func (m *MockStore) ListPendingBreakGlassGrants(arg0 context.Context, arg1 db.ListPendingBreakGlassGrantsParams) ([]db.ListPendingBreakGlassGrantsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPendingBreakGlassGrants", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListPendingBreakGlassGrantsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPendingBreakGlassGrants indicates an expected call of ListPendingBreakGlassGrants.
+func (mr *MockStoreMockRecorder) ListPendingBreakGlassGrants(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPendingBreakGlassGrants", reflect.TypeOf((*MockStore)(nil).ListPendingBreakGlassGrants), arg0, arg1)
+}
+
+// ListPermissions mocks base method.
+func (m *MockStore) ListPermissions(arg0 context.Context, arg1 db.ListPermissionsParams) ([]db.Permission, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPermissions", arg0, arg1)
+	ret0, _ := ret[0].([]db.Permission)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPermissions indicates an expected call of ListPermissions.
+func (mr *MockStoreMockRecorder) ListPermissions(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPermissions", reflect.TypeOf((*MockStore)(nil).ListPermissions), arg0, arg1)
+}
+
+// ListPermissionsForRole mocks base method.
+func (m *MockStore) ListPermissionsForRole(arg0 context.Context, arg1 db.ListPermissionsForRoleParams) ([]db.ListPermissionsForRoleRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPermissionsForRole", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListPermissionsForRoleRow)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// AddAccountBalance indicates an expected call of AddAccountBalance.
-func (mr *MockStoreMockRecorder) AddAccountBalance(arg0, arg1 interface{}) *gomock.Call {
+// ListPermissionsForRole indicates an expected call of ListPermissionsForRole.
+func (mr *MockStoreMockRecorder) ListPermissionsForRole(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddAccountBalance", reflect.TypeOf((*MockStore)(nil).AddAccountBalance), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPermissionsForRole", reflect.TypeOf((*MockStore)(nil).ListPermissionsForRole), arg0, arg1)
 }
 
-// AddRoleForUser mocks base method.
-func (m *MockStore) AddRoleForUser(arg0 context.Context, arg1 db.AddRoleForUserParams) (db.UserRole, error) {
+// ListPurchaseOrderItems mocks base method.
+func (m *MockStore) ListPurchaseOrderItems(arg0 context.Context, arg1 int32) ([]db.ListPurchaseOrderItemsRow, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AddRoleForUser", arg0, arg1)
-	ret0, _ := ret[0].(db.UserRole)
+	ret := m.ctrl.Call(m, "ListPurchaseOrderItems", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListPurchaseOrderItemsRow)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// AddRoleForUser indicates an expected call of AddRoleForUser.
-func (mr *MockStoreMockRecorder) AddRoleForUser(arg0, arg1 interface{}) *gomock.Call {
+// ListPurchaseOrderItems indicates an expected call of ListPurchaseOrderItems.
+func (mr *MockStoreMockRecorder) ListPurchaseOrderItems(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddRoleForUser", reflect.TypeOf((*MockStore)(nil).AddRoleForUser), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPurchaseOrderItems", reflect.TypeOf((*MockStore)(nil).ListPurchaseOrderItems), arg0, arg1)
 }
 
-// CountMedicines mocks base method.
-func (m *MockStore) CountMedicines(arg0 context.Context) (int64, error) {
+// ListPurchaseOrders mocks base method.
+func (m *MockStore) ListPurchaseOrders(arg0 context.Context, arg1 db.ListPurchaseOrdersParams) ([]db.PurchaseOrder, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CountMedicines", arg0)
-	ret0, _ := ret[0].(int64)
+	ret := m.ctrl.Call(m, "ListPurchaseOrders", arg0, arg1)
+	ret0, _ := ret[0].([]db.PurchaseOrder)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CountMedicines indicates an expected call of CountMedicines.
-func (mr *MockStoreMockRecorder) CountMedicines(arg0 interface{}) *gomock.Call {
+// ListPurchaseOrders indicates an expected call of ListPurchaseOrders.
+func (mr *MockStoreMockRecorder) ListPurchaseOrders(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountMedicines", reflect.TypeOf((*MockStore)(nil).CountMedicines), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPurchaseOrders", reflect.TypeOf((*MockStore)(nil).ListPurchaseOrders), arg0, arg1)
 }
 
-// CountPermissions mocks base method.
-func (m *MockStore) CountPermissions(arg0 context.Context) (int64, error) {
+// ListRolePermissionDenies mocks base method.
+func (m *MockStore) ListRolePermissionDenies(arg0 context.Context, arg1 db.ListRolePermissionDeniesParams) ([]db.ListRolePermissionDeniesRow, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CountPermissions", arg0)
-	ret0, _ := ret[0].(int64)
+	ret := m.ctrl.Call(m, "ListRolePermissionDenies", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListRolePermissionDeniesRow)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CountPermissions indicates an expected call of CountPermissions.
-func (mr *MockStoreMockRecorder) CountPermissions(arg0 interface{}) *gomock.Call {
+// ListRolePermissionDenies indicates an expected call of ListRolePermissionDenies.
+func (mr *MockStoreMockRecorder) ListRolePermissionDenies(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountPermissions", reflect.TypeOf((*MockStore)(nil).CountPermissions), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRolePermissionDenies", reflect.TypeOf((*MockStore)(nil).ListRolePermissionDenies), arg0, arg1)
 }
 
-// CountRolePermissions mocks base method.
-func (m *MockStore) CountRolePermissions(arg0 context.Context) (int64, error) {
+// ListRolePermissions mocks base method.
+func (m *MockStore) ListRolePermissions(arg0 context.Context, arg1 db.ListRolePermissionsParams) ([]db.RolePermission, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CountRolePermissions", arg0)
-	ret0, _ := ret[0].(int64)
+	ret := m.ctrl.Call(m, "ListRolePermissions", arg0, arg1)
+	ret0, _ := ret[0].([]db.RolePermission)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CountRolePermissions indicates an expected call of CountRolePermissions.
-func (mr *MockStoreMockRecorder) CountRolePermissions(arg0 interface{}) *gomock.Call {
+// ListRolePermissions indicates an expected call of ListRolePermissions.
+func (mr *MockStoreMockRecorder) ListRolePermissions(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountRolePermissions", reflect.TypeOf((*MockStore)(nil).CountRolePermissions), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRolePermissions", reflect.TypeOf((*MockStore)(nil).ListRolePermissions), arg0, arg1)
 }
 
-// CountRoles mocks base method.
-func (m *MockStore) CountRoles(arg0 context.Context) (int64, error) {
+// ListRoles mocks base method.
+func (m *MockStore) ListRoles(arg0 context.Context, arg1 db.ListRolesParams) ([]db.ListRolesRow, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CountRoles", arg0)
-	ret0, _ := ret[0].(int64)
+	ret := m.ctrl.Call(m, "ListRoles", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListRolesRow)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CountRoles indicates an expected call of CountRoles.
-func (mr *MockStoreMockRecorder) CountRoles(arg0 interface{}) *gomock.Call {
+// ListRoles indicates an expected call of ListRoles.
+func (mr *MockStoreMockRecorder) ListRoles(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountRoles", reflect.TypeOf((*MockStore)(nil).CountRoles), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRoles", reflect.TypeOf((*MockStore)(nil).ListRoles), arg0, arg1)
 }
 
-// CountUserRoles mocks base method.
-func (m *MockStore) CountUserRoles(arg0 context.Context) (int64, error) {
+// ListStockAlerts mocks base method.
+func (m *MockStore) ListStockAlerts(arg0 context.Context, arg1 db.ListStockAlertsParams) ([]db.ListStockAlertsRow, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CountUserRoles", arg0)
-	ret0, _ := ret[0].(int64)
+	ret := m.ctrl.Call(m, "ListStockAlerts", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListStockAlertsRow)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CountUserRoles indicates an expected call of CountUserRoles.
-func (mr *MockStoreMockRecorder) CountUserRoles(arg0 interface{}) *gomock.Call {
+// ListStockAlerts indicates an expected call of ListStockAlerts.
+func (mr *MockStoreMockRecorder) ListStockAlerts(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountUserRoles", reflect.TypeOf((*MockStore)(nil).CountUserRoles), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListStockAlerts", reflect.TypeOf((*MockStore)(nil).ListStockAlerts), arg0, arg1)
 }
 
-// CountUsers mocks base method.
-func (m *MockStore) CountUsers(arg0 context.Context) (int64, error) {
+// ListStockLocations mocks base method.
+func (m *MockStore) ListStockLocations(arg0 context.Context) ([]db.StockLocation, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CountUsers", arg0)
-	ret0, _ := ret[0].(int64)
+	ret := m.ctrl.Call(m, "ListStockLocations", arg0)
+	ret0, _ := ret[0].([]db.StockLocation)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CountUsers indicates an expected call of CountUsers.
-func (mr *MockStoreMockRecorder) CountUsers(arg0 interface{}) *gomock.Call {
+// ListStockLocations indicates an expected call of ListStockLocations.
+func (mr *MockStoreMockRecorder) ListStockLocations(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountUsers", reflect.TypeOf((*MockStore)(nil).CountUsers), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListStockLocations", reflect.TypeOf((*MockStore)(nil).ListStockLocations), arg0)
 }
 
-// CreateAccount mocks base method.
-func (m *MockStore) CreateAccount(arg0 context.Context, arg1 db.CreateAccountParams) (db.Account, error) {
+// ListStockMovements mocks base method.
+func (m *MockStore) ListStockMovements(arg0 context.Context, arg1 db.ListStockMovementsParams) ([]db.StockMovement, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateAccount", arg0, arg1)
-	ret0, _ := ret[0].(db.Account)
+	ret := m.ctrl.Call(m, "ListStockMovements", arg0, arg1)
+	ret0, _ := ret[0].([]db.StockMovement)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CreateAccount indicates an expected call of CreateAccount.
-func (mr *MockStoreMockRecorder) CreateAccount(arg0, arg1 interface{}) *gomock.Call {
+// ListStockMovements indicates an expected call of ListStockMovements.
+func (mr *MockStoreMockRecorder) ListStockMovements(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAccount", reflect.TypeOf((*MockStore)(nil).CreateAccount), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListStockMovements", reflect.TypeOf((*MockStore)(nil).ListStockMovements), arg0, arg1)
 }
 
-// CreateEntry mocks base method.
-func (m *MockStore) CreateEntry(arg0 context.Context, arg1 db.CreateEntryParams) (db.Entry, error) {
+// ListStocktakeCounts mocks base method.
+func (m *MockStore) ListStocktakeCounts(arg0 context.Context, arg1 int32) ([]db.StocktakeCount, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateEntry", arg0, arg1)
-	ret0, _ := ret[0].(db.Entry)
+	ret := m.ctrl.Call(m, "ListStocktakeCounts", arg0, arg1)
+	ret0, _ := ret[0].([]db.StocktakeCount)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CreateEntry indicates an expected call of CreateEntry.
-func (mr *MockStoreMockRecorder) CreateEntry(arg0, arg1 interface{}) *gomock.Call {
+// ListStocktakeCounts indicates an expected call of ListStocktakeCounts.
+func (mr *MockStoreMockRecorder) ListStocktakeCounts(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateEntry", reflect.TypeOf((*MockStore)(nil).CreateEntry), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListStocktakeCounts", reflect.TypeOf((*MockStore)(nil).ListStocktakeCounts), arg0, arg1)
 }
 
-// CreateMedicine mocks base method.
-func (m *MockStore) CreateMedicine(arg0 context.Context, arg1 db.CreateMedicineParams) (db.Medicine, error) {
+// ListStocktakeItems mocks base method.
+func (m *MockStore) ListStocktakeItems(arg0 context.Context, arg1 int32) ([]db.ListStocktakeItemsRow, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateMedicine", arg0, arg1)
-	ret0, _ := ret[0].(db.Medicine)
+	ret := m.ctrl.Call(m, "ListStocktakeItems", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListStocktakeItemsRow)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CreateMedicine indicates an expected call of CreateMedicine.
-func (mr *MockStoreMockRecorder) CreateMedicine(arg0, arg1 interface{}) *gomock.Call {
+// ListStocktakeItems indicates an expected call of ListStocktakeItems.
+func (mr *MockStoreMockRecorder) ListStocktakeItems(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMedicine", reflect.TypeOf((*MockStore)(nil).CreateMedicine), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListStocktakeItems", reflect.TypeOf((*MockStore)(nil).ListStocktakeItems), arg0, arg1)
 }
 
-// CreatePermission mocks base method.
-func (m *MockStore) CreatePermission(arg0 context.Context, arg1 db.CreatePermissionParams) (db.Permission, error) {
+// ListStocktakes mocks base method.
+func (m *MockStore) ListStocktakes(arg0 context.Context, arg1 db.ListStocktakesParams) ([]db.Stocktake, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreatePermission", arg0, arg1)
-	ret0, _ := ret[0].(db.Permission)
+	ret := m.ctrl.Call(m, "ListStocktakes", arg0, arg1)
+	ret0, _ := ret[0].([]db.Stocktake)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CreatePermission indicates an expected call of CreatePermission.
-func (mr *MockStoreMockRecorder) CreatePermission(arg0, arg1 interface{}) *gomock.Call {
+// ListStocktakes indicates an expected call of ListStocktakes.
+func (mr *MockStoreMockRecorder) ListStocktakes(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePermission", reflect.TypeOf((*MockStore)(nil).CreatePermission), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListStocktakes", reflect.TypeOf((*MockStore)(nil).ListStocktakes), arg0, arg1)
 }
 
-// CreateRole mocks base method.
-func (m *MockStore) CreateRole(arg0 context.Context, arg1 db.CreateRoleParams) (db.Role, error) {
+// ListSuppliers mocks base method.
+func (m *MockStore) ListSuppliers(arg0 context.Context) ([]db.Supplier, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateRole", arg0, arg1)
-	ret0, _ := ret[0].(db.Role)
+	ret := m.ctrl.Call(m, "ListSuppliers", arg0)
+	ret0, _ := ret[0].([]db.Supplier)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CreateRole indicates an expected call of CreateRole.
-func (mr *MockStoreMockRecorder) CreateRole(arg0, arg1 interface{}) *gomock.Call {
+// ListSuppliers indicates an expected call of ListSuppliers.
+func (mr *MockStoreMockRecorder) ListSuppliers(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRole", reflect.TypeOf((*MockStore)(nil).CreateRole), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSuppliers", reflect.TypeOf((*MockStore)(nil).ListSuppliers), arg0)
 }
 
-// CreateRolePermission mocks base method.
-func (m *MockStore) CreateRolePermission(arg0 context.Context, arg1 db.CreateRolePermissionParams) (db.RolePermission, error) {
+// ListSystemAdmins mocks base method.
+func (m *MockStore) ListSystemAdmins(arg0 context.Context) ([]db.User, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateRolePermission", arg0, arg1)
-	ret0, _ := ret[0].(db.RolePermission)
+	ret := m.ctrl.Call(m, "ListSystemAdmins", arg0)
+	ret0, _ := ret[0].([]db.User)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CreateRolePermission indicates an expected call of CreateRolePermission.
-func (mr *MockStoreMockRecorder) CreateRolePermission(arg0, arg1 interface{}) *gomock.Call {
+// ListSystemAdmins indicates an expected call of ListSystemAdmins.
+func (mr *MockStoreMockRecorder) ListSystemAdmins(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRolePermission", reflect.TypeOf((*MockStore)(nil).CreateRolePermission), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSystemAdmins", reflect.TypeOf((*MockStore)(nil).ListSystemAdmins), arg0)
 }
 
-// CreateSession mocks base method.
-func (m *MockStore) CreateSession(arg0 context.Context, arg1 db.CreateSessionParams) (db.Session, error) {
+// ListTransfers mocks base method.
+func (m *MockStore) ListTransfers(arg0 context.Context, arg1 db.ListTransfersParams) ([]db.Transfer, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateSession", arg0, arg1)
-	ret0, _ := ret[0].(db.Session)
+	ret := m.ctrl.Call(m, "ListTransfers", arg0, arg1)
+	ret0, _ := ret[0].([]db.Transfer)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CreateSession indicates an expected call of CreateSession.
-func (mr *MockStoreMockRecorder) CreateSession(arg0, arg1 interface{}) *gomock.Call {
+// ListTransfers indicates an expected call of ListTransfers.
+func (mr *MockStoreMockRecorder) ListTransfers(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSession", reflect.TypeOf((*MockStore)(nil).CreateSession), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTransfers", reflect.TypeOf((*MockStore)(nil).ListTransfers), arg0, arg1)
 }
 
-// CreateTransfer mocks base method.
-func (m *MockStore) CreateTransfer(arg0 context.Context, arg1 db.CreateTransferParams) (db.Transfer, error) {
+// ListUnits mocks base method.
+func (m *MockStore) ListUnits(arg0 context.Context) ([]db.Unit, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateTransfer", arg0, arg1)
-	ret0, _ := ret[0].(db.Transfer)
+	ret := m.ctrl.Call(m, "ListUnits", arg0)
+	ret0, _ := ret[0].([]db.Unit)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CreateTransfer indicates an expected call of CreateTransfer.
-func (mr *MockStoreMockRecorder) CreateTransfer(arg0, arg1 interface{}) *gomock.Call {
+// ListUnits indicates an expected call of ListUnits.
+func (mr *MockStoreMockRecorder) ListUnits(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTransfer", reflect.TypeOf((*MockStore)(nil).CreateTransfer), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUnits", reflect.TypeOf((*MockStore)(nil).ListUnits), arg0)
 }
 
-// CreateUser mocks base method.
-func (m *MockStore) CreateUser(arg0 context.Context, arg1 db.CreateUserParams) (db.User, error) {
+// ListUserPermissionOverrides mocks base method.
+func (m *MockStore) ListUserPermissionOverrides(arg0 context.Context, arg1 int32) ([]db.ListUserPermissionOverridesRow, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateUser", arg0, arg1)
-	ret0, _ := ret[0].(db.User)
+	ret := m.ctrl.Call(m, "ListUserPermissionOverrides", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListUserPermissionOverridesRow)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CreateUser indicates an expected call of CreateUser.
-func (mr *MockStoreMockRecorder) CreateUser(arg0, arg1 interface{}) *gomock.Call {
+// ListUserPermissionOverrides indicates an expected call of ListUserPermissionOverrides.
+func (mr *MockStoreMockRecorder) ListUserPermissionOverrides(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*MockStore)(nil).CreateUser), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUserPermissionOverrides", reflect.TypeOf((*MockStore)(nil).ListUserPermissionOverrides), arg0, arg1)
 }
 
-// DeleteAccount mocks base method.
-func (m *MockStore) DeleteAccount(arg0 context.Context, arg1 int64) error {
+// ListUserRoleDenies mocks base method.
+func (m *MockStore) ListUserRoleDenies(arg0 context.Context, arg1 int32) ([]db.ListUserRoleDeniesRow, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteAccount", arg0, arg1)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "ListUserRoleDenies", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListUserRoleDeniesRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// DeleteAccount indicates an expected call of DeleteAccount.
-func (mr *MockStoreMockRecorder) DeleteAccount(arg0, arg1 interface{}) *gomock.Call {
+// ListUserRoleDenies indicates an expected call of ListUserRoleDenies.
+func (mr *MockStoreMockRecorder) ListUserRoleDenies(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAccount", reflect.TypeOf((*MockStore)(nil).DeleteAccount), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUserRoleDenies", reflect.TypeOf((*MockStore)(nil).ListUserRoleDenies), arg0, arg1)
 }
 
-// DeleteEntry mocks base method.
-func (m *MockStore) DeleteEntry(arg0 context.Context, arg1 int64) error {
+// ListUserRoleGrants mocks base method.
+func (m *MockStore) ListUserRoleGrants(arg0 context.Context, arg1 int32) ([]db.ListUserRoleGrantsRow, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteEntry", arg0, arg1)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "ListUserRoleGrants", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListUserRoleGrantsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// DeleteEntry indicates an expected call of DeleteEntry.
-func (mr *MockStoreMockRecorder) DeleteEntry(arg0, arg1 interface{}) *gomock.Call {
+// ListUserRoleGrants indicates an expected call of ListUserRoleGrants.
+func (mr *MockStoreMockRecorder) ListUserRoleGrants(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteEntry", reflect.TypeOf((*MockStore)(nil).DeleteEntry), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUserRoleGrants", reflect.TypeOf((*MockStore)(nil).ListUserRoleGrants), arg0, arg1)
 }
 
-// DeleteMedicine mocks base method.
-func (m *MockStore) DeleteMedicine(arg0 context.Context, arg1 int32) error {
+// ListUserRoles mocks base method.
+func (m *MockStore) ListUserRoles(arg0 context.Context, arg1 db.ListUserRolesParams) ([]db.UserRole, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteMedicine", arg0, arg1)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "ListUserRoles", arg0, arg1)
+	ret0, _ := ret[0].([]db.UserRole)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// DeleteMedicine indicates an expected call of DeleteMedicine.
-func (mr *MockStoreMockRecorder) DeleteMedicine(arg0, arg1 interface{}) *gomock.Call {
+// ListUserRoles indicates an expected call of ListUserRoles.
+func (mr *MockStoreMockRecorder) ListUserRoles(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMedicine", reflect.TypeOf((*MockStore)(nil).DeleteMedicine), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUserRoles", reflect.TypeOf((*MockStore)(nil).ListUserRoles), arg0, arg1)
 }
 
-// DeletePermission mocks base method.
-func (m *MockStore) DeletePermission(arg0 context.Context, arg1 int32) error {
+// ListUsers mocks base method.
+func (m *MockStore) ListUsers(arg0 context.Context, arg1 db.ListUsersParams) ([]db.User, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeletePermission", arg0, arg1)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "ListUsers", arg0, arg1)
+	ret0, _ := ret[0].([]db.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// DeletePermission indicates an expected call of DeletePermission.
-func (mr *MockStoreMockRecorder) DeletePermission(arg0, arg1 interface{}) *gomock.Call {
+// ListUsers indicates an expected call of ListUsers.
+func (mr *MockStoreMockRecorder) ListUsers(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePermission", reflect.TypeOf((*MockStore)(nil).DeletePermission), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUsers", reflect.TypeOf((*MockStore)(nil).ListUsers), arg0, arg1)
 }
 
-// DeleteRole mocks base method.
-func (m *MockStore) DeleteRole(arg0 context.Context, arg1 int32) error {
+// ListUsersForRole mocks base method.
+func (m *MockStore) ListUsersForRole(arg0 context.Context, arg1 db.ListUsersForRoleParams) ([]db.ListUsersForRoleRow, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteRole", arg0, arg1)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "ListUsersForRole", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListUsersForRoleRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// DeleteRole indicates an expected call of DeleteRole.
-func (mr *MockStoreMockRecorder) DeleteRole(arg0, arg1 interface{}) *gomock.Call {
+// ListUsersForRole indicates an expected call of ListUsersForRole.
+func (mr *MockStoreMockRecorder) ListUsersForRole(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRole", reflect.TypeOf((*MockStore)(nil).DeleteRole), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUsersForRole", reflect.TypeOf((*MockStore)(nil).ListUsersForRole), arg0, arg1)
 }
 
-// DeleteRolePermission mocks base method.
-func (m *MockStore) DeleteRolePermission(arg0 context.Context, arg1 db.DeleteRolePermissionParams) error {
+// ListUsersWithPermission mocks base method.
+func (m *MockStore) ListUsersWithPermission(arg0 context.Context, arg1 string) ([]db.User, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteRolePermission", arg0, arg1)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "ListUsersWithPermission", arg0, arg1)
+	ret0, _ := ret[0].([]db.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// DeleteRolePermission indicates an expected call of DeleteRolePermission.
-func (mr *MockStoreMockRecorder) DeleteRolePermission(arg0, arg1 interface{}) *gomock.Call {
+// ListUsersWithPermission indicates an expected call of ListUsersWithPermission.
+func (mr *MockStoreMockRecorder) ListUsersWithPermission(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRolePermission", reflect.TypeOf((*MockStore)(nil).DeleteRolePermission), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUsersWithPermission", reflect.TypeOf((*MockStore)(nil).ListUsersWithPermission), arg0, arg1)
 }
 
-// DeleteTransfer mocks base method.
-func (m *MockStore) DeleteTransfer(arg0 context.Context, arg1 int64) error {
+// LockSystemRoles mocks base method.
+func (m *MockStore) LockSystemRoles(arg0 context.Context) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteTransfer", arg0, arg1)
+	ret := m.ctrl.Call(m, "LockSystemRoles", arg0)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// DeleteTransfer indicates an expected call of DeleteTransfer.
-func (mr *MockStoreMockRecorder) DeleteTransfer(arg0, arg1 interface{}) *gomock.Call {
+// LockSystemRoles indicates an expected call of LockSystemRoles.
+func (mr *MockStoreMockRecorder) LockSystemRoles(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTransfer", reflect.TypeOf((*MockStore)(nil).DeleteTransfer), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockSystemRoles", reflect.TypeOf((*MockStore)(nil).LockSystemRoles), arg0)
 }
 
-// GetAccount mocks base method.
-func (m *MockStore) GetAccount(arg0 context.Context, arg1 int64) (db.Account, error) {
+// MarkUserRoleExpiryNotified mocks base method.
+func (m *MockStore) MarkUserRoleExpiryNotified(arg0 context.Context, arg1 db.MarkUserRoleExpiryNotifiedParams) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAccount", arg0, arg1)
-	ret0, _ := ret[0].(db.Account)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "MarkUserRoleExpiryNotified", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// GetAccount indicates an expected call of GetAccount.
-func (mr *MockStoreMockRecorder) GetAccount(arg0, arg1 interface{}) *gomock.Call {
+// MarkUserRoleExpiryNotified indicates an expected call of MarkUserRoleExpiryNotified.
+func (mr *MockStoreMockRecorder) MarkUserRoleExpiryNotified(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccount", reflect.TypeOf((*MockStore)(nil).GetAccount), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkUserRoleExpiryNotified", reflect.TypeOf((*MockStore)(nil).MarkUserRoleExpiryNotified), arg0, arg1)
 }
 
-// GetAccountForUpdate mocks base method.
-func (m *MockStore) GetAccountForUpdate(arg0 context.Context, arg1 int64) (db.Account, error) {
+// MedicineUnitInUse mocks base method.
+func (m *MockStore) MedicineUnitInUse(arg0 context.Context, arg1 int32) (bool, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAccountForUpdate", arg0, arg1)
-	ret0, _ := ret[0].(db.Account)
+	ret := m.ctrl.Call(m, "MedicineUnitInUse", arg0, arg1)
+	ret0, _ := ret[0].(bool)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetAccountForUpdate indicates an expected call of GetAccountForUpdate.
-func (mr *MockStoreMockRecorder) GetAccountForUpdate(arg0, arg1 interface{}) *gomock.Call {
+// MedicineUnitInUse indicates an expected call of MedicineUnitInUse.
+func (mr *MockStoreMockRecorder) MedicineUnitInUse(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountForUpdate", reflect.TypeOf((*MockStore)(nil).GetAccountForUpdate), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MedicineUnitInUse", reflect.TypeOf((*MockStore)(nil).MedicineUnitInUse), arg0, arg1)
 }
 
-// GetEntry mocks base method.
-func (m *MockStore) GetEntry(arg0 context.Context, arg1 int64) (db.Entry, error) {
+// PlacePurchaseOrderTx mocks base method.
+func (m *MockStore) PlacePurchaseOrderTx(arg0 context.Context, arg1 int32) (db.PurchaseOrder, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetEntry", arg0, arg1)
-	ret0, _ := ret[0].(db.Entry)
+	ret := m.ctrl.Call(m, "PlacePurchaseOrderTx", arg0, arg1)
+	ret0, _ := ret[0].(db.PurchaseOrder)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetEntry indicates an expected call of GetEntry.
-func (mr *MockStoreMockRecorder) GetEntry(arg0, arg1 interface{}) *gomock.Call {
+// PlacePurchaseOrderTx indicates an expected call of PlacePurchaseOrderTx.
+func (mr *MockStoreMockRecorder) PlacePurchaseOrderTx(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEntry", reflect.TypeOf((*MockStore)(nil).GetEntry), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PlacePurchaseOrderTx", reflect.TypeOf((*MockStore)(nil).PlacePurchaseOrderTx), arg0, arg1)
 }
 
-// GetMedicine mocks base method.
-func (m *MockStore) GetMedicine(arg0 context.Context, arg1 int32) (db.Medicine, error) {
+// ReceiveGoodsTx mocks base method.
+func (m *MockStore) ReceiveGoodsTx(arg0 context.Context, arg1 db.ReceiveGoodsTxParams) (db.ReceiveGoodsTxResult, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetMedicine", arg0, arg1)
-	ret0, _ := ret[0].(db.Medicine)
+	ret := m.ctrl.Call(m, "ReceiveGoodsTx", arg0, arg1)
+	ret0, _ := ret[0].(db.ReceiveGoodsTxResult)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetMedicine indicates an expected call of GetMedicine.
-func (mr *MockStoreMockRecorder) GetMedicine(arg0, arg1 interface{}) *gomock.Call {
+// ReceiveGoodsTx indicates an expected call of ReceiveGoodsTx.
+func (mr *MockStoreMockRecorder) ReceiveGoodsTx(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMedicine", reflect.TypeOf((*MockStore)(nil).GetMedicine), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReceiveGoodsTx", reflect.TypeOf((*MockStore)(nil).ReceiveGoodsTx), arg0, arg1)
 }
 
-// GetPermission mocks base method.
-func (m *MockStore) GetPermission(arg0 context.Context, arg1 int32) (db.Permission, error) {
+// RecordStocktakeCountsTx mocks base method.
+func (m *MockStore) RecordStocktakeCountsTx(arg0 context.Context, arg1 db.RecordStocktakeCountsTxParams) (db.StocktakeTxResult, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetPermission", arg0, arg1)
-	ret0, _ := ret[0].(db.Permission)
+	ret := m.ctrl.Call(m, "RecordStocktakeCountsTx", arg0, arg1)
+	ret0, _ := ret[0].(db.StocktakeTxResult)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetPermission indicates an expected call of GetPermission.
-func (mr *MockStoreMockRecorder) GetPermission(arg0, arg1 interface{}) *gomock.Call {
+// RecordStocktakeCountsTx indicates an expected call of RecordStocktakeCountsTx.
+func (mr *MockStoreMockRecorder) RecordStocktakeCountsTx(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPermission", reflect.TypeOf((*MockStore)(nil).GetPermission), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordStocktakeCountsTx", reflect.TypeOf((*MockStore)(nil).RecordStocktakeCountsTx), arg0, arg1)
 }
 
-// GetPermissionsForUser mocks base method.
-func (m *MockStore) GetPermissionsForUser(arg0 context.Context, arg1 int32) ([]string, error) {
+// RemoveRoleForUser mocks base method.
+func (m *MockStore) RemoveRoleForUser(arg0 context.Context, arg1 db.RemoveRoleForUserParams) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetPermissionsForUser", arg0, arg1)
-	ret0, _ := ret[0].([]string)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "RemoveRoleForUser", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// GetPermissionsForUser indicates an expected call of GetPermissionsForUser.
-func (mr *MockStoreMockRecorder) GetPermissionsForUser(arg0, arg1 interface{}) *gomock.Call {
+// RemoveRoleForUser indicates an expected call of RemoveRoleForUser.
+func (mr *MockStoreMockRecorder) RemoveRoleForUser(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPermissionsForUser", reflect.TypeOf((*MockStore)(nil).GetPermissionsForUser), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveRoleForUser", reflect.TypeOf((*MockStore)(nil).RemoveRoleForUser), arg0, arg1)
 }
 
-// GetRole mocks base method.
-func (m *MockStore) GetRole(arg0 context.Context, arg1 int32) (db.Role, error) {
+// RemoveRoleForUserTx mocks base method.
+func (m *MockStore) RemoveRoleForUserTx(arg0 context.Context, arg1 db.RemoveRoleForUserParams) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetRole", arg0, arg1)
-	ret0, _ := ret[0].(db.Role)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "RemoveRoleForUserTx", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// GetRole indicates an expected call of GetRole.
-func (mr *MockStoreMockRecorder) GetRole(arg0, arg1 interface{}) *gomock.Call {
+// RemoveRoleForUserTx indicates an expected call of RemoveRoleForUserTx.
+func (mr *MockStoreMockRecorder) RemoveRoleForUserTx(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRole", reflect.TypeOf((*MockStore)(nil).GetRole), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveRoleForUserTx", reflect.TypeOf((*MockStore)(nil).RemoveRoleForUserTx), arg0, arg1)
 }
 
-// GetRolePermission mocks base method.
-func (m *MockStore) GetRolePermission(arg0 context.Context, arg1 db.GetRolePermissionParams) (db.RolePermission, error) {
+// ReorderSuggestions mocks base method.
+func (m *MockStore) ReorderSuggestions(arg0 context.Context, arg1 db.ReorderSuggestionsParams) ([]db.ReorderSuggestion, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetRolePermission", arg0, arg1)
-	ret0, _ := ret[0].(db.RolePermission)
+	ret := m.ctrl.Call(m, "ReorderSuggestions", arg0, arg1)
+	ret0, _ := ret[0].([]db.ReorderSuggestion)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetRolePermission indicates an expected call of GetRolePermission.
-func (mr *MockStoreMockRecorder) GetRolePermission(arg0, arg1 interface{}) *gomock.Call {
+// ReorderSuggestions indicates an expected call of ReorderSuggestions.
+func (mr *MockStoreMockRecorder) ReorderSuggestions(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRolePermission", reflect.TypeOf((*MockStore)(nil).GetRolePermission), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReorderSuggestions", reflect.TypeOf((*MockStore)(nil).ReorderSuggestions), arg0, arg1)
 }
 
-// GetRolesForUser mocks base method.
-func (m *MockStore) GetRolesForUser(arg0 context.Context, arg1 int32) ([]db.Role, error) {
+// ResolveLowStockAlerts mocks base method.
+func (m *MockStore) ResolveLowStockAlerts(arg0 context.Context) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetRolesForUser", arg0, arg1)
-	ret0, _ := ret[0].([]db.Role)
+	ret := m.ctrl.Call(m, "ResolveLowStockAlerts", arg0)
+	ret0, _ := ret[0].(int64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetRolesForUser indicates an expected call of GetRolesForUser.
-func (mr *MockStoreMockRecorder) GetRolesForUser(arg0, arg1 interface{}) *gomock.Call {
+// ResolveLowStockAlerts indicates an expected call of ResolveLowStockAlerts.
+func (mr *MockStoreMockRecorder) ResolveLowStockAlerts(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRolesForUser", reflect.TypeOf((*MockStore)(nil).GetRolesForUser), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveLowStockAlerts", reflect.TypeOf((*MockStore)(nil).ResolveLowStockAlerts), arg0)
 }
 
-// GetSession mocks base method.
-func (m *MockStore) GetSession(arg0 context.Context, arg1 uuid.UUID) (db.Session, error) {
+// ResolveNearExpiryAlerts mocks base method.
+func (m *MockStore) ResolveNearExpiryAlerts(arg0 context.Context) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetSession", arg0, arg1)
-	ret0, _ := ret[0].(db.Session)
+	ret := m.ctrl.Call(m, "ResolveNearExpiryAlerts", arg0)
+	ret0, _ := ret[0].(int64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetSession indicates an expected call of GetSession.
-func (mr *MockStoreMockRecorder) GetSession(arg0, arg1 interface{}) *gomock.Call {
+// ResolveNearExpiryAlerts indicates an expected call of ResolveNearExpiryAlerts.
+func (mr *MockStoreMockRecorder) ResolveNearExpiryAlerts(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSession", reflect.TypeOf((*MockStore)(nil).GetSession), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveNearExpiryAlerts", reflect.TypeOf((*MockStore)(nil).ResolveNearExpiryAlerts), arg0)
 }
 
-// GetTransfer mocks base method.
-func (m *MockStore) GetTransfer(arg0 context.Context, arg1 int64) (db.Transfer, error) {
+// RestoreMedicine mocks base method.
+func (m *MockStore) RestoreMedicine(arg0 context.Context, arg1 int32) (db.Medicine, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetTransfer", arg0, arg1)
-	ret0, _ := ret[0].(db.Transfer)
+	ret := m.ctrl.Call(m, "RestoreMedicine", arg0, arg1)
+	ret0, _ := ret[0].(db.Medicine)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetTransfer indicates an expected call of GetTransfer.
-func (mr *MockStoreMockRecorder) GetTransfer(arg0, arg1 interface{}) *gomock.Call {
+// RestoreMedicine indicates an expected call of RestoreMedicine.
+func (mr *MockStoreMockRecorder) RestoreMedicine(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransfer", reflect.TypeOf((*MockStore)(nil).GetTransfer), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreMedicine", reflect.TypeOf((*MockStore)(nil).RestoreMedicine), arg0, arg1)
 }
 
-// GetUser mocks base method.
-func (m *MockStore) GetUser(arg0 context.Context, arg1 string) (db.User, error) {
+// ReviewBreakGlassGrant mocks base method.
+func (m *MockStore) ReviewBreakGlassGrant(arg0 context.Context, arg1 db.ReviewBreakGlassGrantParams) (db.BreakGlassGrant, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetUser", arg0, arg1)
-	ret0, _ := ret[0].(db.User)
+	ret := m.ctrl.Call(m, "ReviewBreakGlassGrant", arg0, arg1)
+	ret0, _ := ret[0].(db.BreakGlassGrant)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetUser indicates an expected call of GetUser.
-func (mr *MockStoreMockRecorder) GetUser(arg0, arg1 interface{}) *gomock.Call {
+// ReviewBreakGlassGrant indicates an expected call of ReviewBreakGlassGrant.
+func (mr *MockStoreMockRecorder) ReviewBreakGlassGrant(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUser", reflect.TypeOf((*MockStore)(nil).GetUser), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReviewBreakGlassGrant", reflect.TypeOf((*MockStore)(nil).ReviewBreakGlassGrant), arg0, arg1)
 }
 
-// ListAccounts mocks base method.
-func (m *MockStore) ListAccounts(arg0 context.Context, arg1 db.ListAccountsParams) ([]db.Account, error) {
+// SetMedicineCategoriesTx mocks base method.
+func (m *MockStore) SetMedicineCategoriesTx(arg0 context.Context, arg1 db.SetMedicineCategoriesTxParams) ([]db.Category, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListAccounts", arg0, arg1)
-	ret0, _ := ret[0].([]db.Account)
+	ret := m.ctrl.Call(m, "SetMedicineCategoriesTx", arg0, arg1)
+	ret0, _ := ret[0].([]db.Category)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListAccounts indicates an expected call of ListAccounts.
-func (mr *MockStoreMockRecorder) ListAccounts(arg0, arg1 interface{}) *gomock.Call {
+// SetMedicineCategoriesTx indicates an expected call of SetMedicineCategoriesTx.
+func (mr *MockStoreMockRecorder) SetMedicineCategoriesTx(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAccounts", reflect.TypeOf((*MockStore)(nil).ListAccounts), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetMedicineCategoriesTx", reflect.TypeOf((*MockStore)(nil).SetMedicineCategoriesTx), arg0, arg1)
 }
 
-// ListEntries mocks base method.
-func (m *MockStore) ListEntries(arg0 context.Context, arg1 db.ListEntriesParams) ([]db.Entry, error) {
+// SetMedicineControlled mocks base method.
+func (m *MockStore) SetMedicineControlled(arg0 context.Context, arg1 db.SetMedicineControlledParams) (db.Medicine, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListEntries", arg0, arg1)
-	ret0, _ := ret[0].([]db.Entry)
+	ret := m.ctrl.Call(m, "SetMedicineControlled", arg0, arg1)
+	ret0, _ := ret[0].(db.Medicine)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListEntries indicates an expected call of ListEntries.
-func (mr *MockStoreMockRecorder) ListEntries(arg0, arg1 interface{}) *gomock.Call {
+// SetMedicineControlled indicates an expected call of SetMedicineControlled.
+func (mr *MockStoreMockRecorder) SetMedicineControlled(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEntries", reflect.TypeOf((*MockStore)(nil).ListEntries), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetMedicineControlled", reflect.TypeOf((*MockStore)(nil).SetMedicineControlled), arg0, arg1)
 }
 
-// ListMedicines mocks base method.
-func (m *MockStore) ListMedicines(arg0 context.Context, arg1 db.ListMedicinesParams) ([]db.Medicine, error) {
+// SetMedicineControlledTx mocks base method.
+func (m *MockStore) SetMedicineControlledTx(arg0 context.Context, arg1 db.SetMedicineControlledTxParams) (db.Medicine, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListMedicines", arg0, arg1)
-	ret0, _ := ret[0].([]db.Medicine)
+	ret := m.ctrl.Call(m, "SetMedicineControlledTx", arg0, arg1)
+	ret0, _ := ret[0].(db.Medicine)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListMedicines indicates an expected call of ListMedicines.
-func (mr *MockStoreMockRecorder) ListMedicines(arg0, arg1 interface{}) *gomock.Call {
+// SetMedicineControlledTx indicates an expected call of SetMedicineControlledTx.
+func (mr *MockStoreMockRecorder) SetMedicineControlledTx(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMedicines", reflect.TypeOf((*MockStore)(nil).ListMedicines), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetMedicineControlledTx", reflect.TypeOf((*MockStore)(nil).SetMedicineControlledTx), arg0, arg1)
 }
 
-// ListPermissions mocks base method.
-func (m *MockStore) ListPermissions(arg0 context.Context, arg1 db.ListPermissionsParams) ([]db.Permission, error) {
+// SetMedicineIngredientsTx mocks base method.
+func (m *MockStore) SetMedicineIngredientsTx(arg0 context.Context, arg1 db.SetMedicineIngredientsTxParams) ([]db.ListMedicineIngredientsRow, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListPermissions", arg0, arg1)
-	ret0, _ := ret[0].([]db.Permission)
+	ret := m.ctrl.Call(m, "SetMedicineIngredientsTx", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListMedicineIngredientsRow)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListPermissions indicates an expected call of ListPermissions.
-func (mr *MockStoreMockRecorder) ListPermissions(arg0, arg1 interface{}) *gomock.Call {
+// SetMedicineIngredientsTx indicates an expected call of SetMedicineIngredientsTx.
+func (mr *MockStoreMockRecorder) SetMedicineIngredientsTx(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPermissions", reflect.TypeOf((*MockStore)(nil).ListPermissions), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetMedicineIngredientsTx", reflect.TypeOf((*MockStore)(nil).SetMedicineIngredientsTx), arg0, arg1)
 }
 
-// ListRolePermissions mocks base method.
-func (m *MockStore) ListRolePermissions(arg0 context.Context, arg1 db.ListRolePermissionsParams) ([]db.RolePermission, error) {
+// SetPermissionBreakGlass mocks base method.
+func (m *MockStore) SetPermissionBreakGlass(arg0 context.Context, arg1 db.SetPermissionBreakGlassParams) (db.Permission, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListRolePermissions", arg0, arg1)
-	ret0, _ := ret[0].([]db.RolePermission)
+	ret := m.ctrl.Call(m, "SetPermissionBreakGlass", arg0, arg1)
+	ret0, _ := ret[0].(db.Permission)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListRolePermissions indicates an expected call of ListRolePermissions.
-func (mr *MockStoreMockRecorder) ListRolePermissions(arg0, arg1 interface{}) *gomock.Call {
+// SetPermissionBreakGlass indicates an expected call of SetPermissionBreakGlass.
+func (mr *MockStoreMockRecorder) SetPermissionBreakGlass(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRolePermissions", reflect.TypeOf((*MockStore)(nil).ListRolePermissions), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPermissionBreakGlass", reflect.TypeOf((*MockStore)(nil).SetPermissionBreakGlass), arg0, arg1)
 }
 
-// ListRoles mocks base method.
-func (m *MockStore) ListRoles(arg0 context.Context, arg1 db.ListRolesParams) ([]db.Role, error) {
+// SetPermissionDescription mocks base method.
+func (m *MockStore) SetPermissionDescription(arg0 context.Context, arg1 db.SetPermissionDescriptionParams) (db.Permission, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListRoles", arg0, arg1)
-	ret0, _ := ret[0].([]db.Role)
+	ret := m.ctrl.Call(m, "SetPermissionDescription", arg0, arg1)
+	ret0, _ := ret[0].(db.Permission)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListRoles indicates an expected call of ListRoles.
-func (mr *MockStoreMockRecorder) ListRoles(arg0, arg1 interface{}) *gomock.Call {
+// SetPermissionDescription indicates an expected call of SetPermissionDescription.
+func (mr *MockStoreMockRecorder) SetPermissionDescription(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRoles", reflect.TypeOf((*MockStore)(nil).ListRoles), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPermissionDescription", reflect.TypeOf((*MockStore)(nil).SetPermissionDescription), arg0, arg1)
 }
 
-// ListTransfers mocks base method.
-func (m *MockStore) ListTransfers(arg0 context.Context, arg1 db.ListTransfersParams) ([]db.Transfer, error) {
+// SetPurchaseOrderStatus mocks base method.
+func (m *MockStore) SetPurchaseOrderStatus(arg0 context.Context, arg1 db.SetPurchaseOrderStatusParams) (db.PurchaseOrder, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListTransfers", arg0, arg1)
-	ret0, _ := ret[0].([]db.Transfer)
+	ret := m.ctrl.Call(m, "SetPurchaseOrderStatus", arg0, arg1)
+	ret0, _ := ret[0].(db.PurchaseOrder)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListTransfers indicates an expected call of ListTransfers.
-func (mr *MockStoreMockRecorder) ListTransfers(arg0, arg1 interface{}) *gomock.Call {
+// SetPurchaseOrderStatus indicates an expected call of SetPurchaseOrderStatus.
+func (mr *MockStoreMockRecorder) SetPurchaseOrderStatus(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTransfers", reflect.TypeOf((*MockStore)(nil).ListTransfers), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPurchaseOrderStatus", reflect.TypeOf((*MockStore)(nil).SetPurchaseOrderStatus), arg0, arg1)
 }
 
-// ListUserRoles mocks base method.
-func (m *MockStore) ListUserRoles(arg0 context.Context, arg1 db.ListUserRolesParams) ([]db.UserRole, error) {
+// SetRoleDescription mocks base method.
+func (m *MockStore) SetRoleDescription(arg0 context.Context, arg1 db.SetRoleDescriptionParams) (db.Role, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListUserRoles", arg0, arg1)
-	ret0, _ := ret[0].([]db.UserRole)
+	ret := m.ctrl.Call(m, "SetRoleDescription", arg0, arg1)
+	ret0, _ := ret[0].(db.Role)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListUserRoles indicates an expected call of ListUserRoles.
-func (mr *MockStoreMockRecorder) ListUserRoles(arg0, arg1 interface{}) *gomock.Call {
+// SetRoleDescription indicates an expected call of SetRoleDescription.
+func (mr *MockStoreMockRecorder) SetRoleDescription(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUserRoles", reflect.TypeOf((*MockStore)(nil).ListUserRoles), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRoleDescription", reflect.TypeOf((*MockStore)(nil).SetRoleDescription), arg0, arg1)
 }
 
-// ListUsers mocks base method.
-func (m *MockStore) ListUsers(arg0 context.Context, arg1 db.ListUsersParams) ([]db.User, error) {
+// StockMovementTx mocks base method.
+func (m *MockStore) StockMovementTx(arg0 context.Context, arg1 db.StockMovementTxParams) (db.StockMovementTxResult, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListUsers", arg0, arg1)
-	ret0, _ := ret[0].([]db.User)
+	ret := m.ctrl.Call(m, "StockMovementTx", arg0, arg1)
+	ret0, _ := ret[0].(db.StockMovementTxResult)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListUsers indicates an expected call of ListUsers.
-func (mr *MockStoreMockRecorder) ListUsers(arg0, arg1 interface{}) *gomock.Call {
+// StockMovementTx indicates an expected call of StockMovementTx.
+func (mr *MockStoreMockRecorder) StockMovementTx(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUsers", reflect.TypeOf((*MockStore)(nil).ListUsers), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StockMovementTx", reflect.TypeOf((*MockStore)(nil).StockMovementTx), arg0, arg1)
 }
 
-// RemoveRoleForUser mocks base method.
-func (m *MockStore) RemoveRoleForUser(arg0 context.Context, arg1 db.RemoveRoleForUserParams) error {
+// StockTransferTx mocks base method.
+func (m *MockStore) StockTransferTx(arg0 context.Context, arg1 db.StockTransferTxParams) (db.StockTransferTxResult, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "RemoveRoleForUser", arg0, arg1)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "StockTransferTx", arg0, arg1)
+	ret0, _ := ret[0].(db.StockTransferTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// RemoveRoleForUser indicates an expected call of RemoveRoleForUser.
-func (mr *MockStoreMockRecorder) RemoveRoleForUser(arg0, arg1 interface{}) *gomock.Call {
+// StockTransferTx indicates an expected call of StockTransferTx.
+func (mr *MockStoreMockRecorder) StockTransferTx(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveRoleForUser", reflect.TypeOf((*MockStore)(nil).RemoveRoleForUser), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StockTransferTx", reflect.TypeOf((*MockStore)(nil).StockTransferTx), arg0, arg1)
 }
 
 // TransferTx mocks base method.
@@ -778,6 +3198,36 @@ func (mr *MockStoreMockRecorder) UpdateMedicine(arg0, arg1 interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMedicine", reflect.TypeOf((*MockStore)(nil).UpdateMedicine), arg0, arg1)
 }
 
+// UpdateMedicinePrice mocks base method.
+func (m *MockStore) UpdateMedicinePrice(arg0 context.Context, arg1 db.UpdateMedicinePriceParams) (db.Medicine, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateMedicinePrice", arg0, arg1)
+	ret0, _ := ret[0].(db.Medicine)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateMedicinePrice indicates an expected call of UpdateMedicinePrice.
+func (mr *MockStoreMockRecorder) UpdateMedicinePrice(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMedicinePrice", reflect.TypeOf((*MockStore)(nil).UpdateMedicinePrice), arg0, arg1)
+}
+
+// UpdateMedicineTx mocks base method.
+func (m *MockStore) UpdateMedicineTx(arg0 context.Context, arg1 db.UpdateMedicineTxParams) (db.Medicine, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateMedicineTx", arg0, arg1)
+	ret0, _ := ret[0].(db.Medicine)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateMedicineTx indicates an expected call of UpdateMedicineTx.
+func (mr *MockStoreMockRecorder) UpdateMedicineTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMedicineTx", reflect.TypeOf((*MockStore)(nil).UpdateMedicineTx), arg0, arg1)
+}
+
 // UpdatePermission mocks base method.
 func (m *MockStore) UpdatePermission(arg0 context.Context, arg1 db.UpdatePermissionParams) (db.Permission, error) {
 	m.ctrl.T.Helper()
@@ -793,6 +3243,36 @@ func (mr *MockStoreMockRecorder) UpdatePermission(arg0, arg1 interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePermission", reflect.TypeOf((*MockStore)(nil).UpdatePermission), arg0, arg1)
 }
 
+// UpdatePurchaseOrder mocks base method.
+func (m *MockStore) UpdatePurchaseOrder(arg0 context.Context, arg1 db.UpdatePurchaseOrderParams) (db.PurchaseOrder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePurchaseOrder", arg0, arg1)
+	ret0, _ := ret[0].(db.PurchaseOrder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdatePurchaseOrder indicates an expected call of UpdatePurchaseOrder.
+func (mr *MockStoreMockRecorder) UpdatePurchaseOrder(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePurchaseOrder", reflect.TypeOf((*MockStore)(nil).UpdatePurchaseOrder), arg0, arg1)
+}
+
+// UpdatePurchaseOrderTx mocks base method.
+func (m *MockStore) UpdatePurchaseOrderTx(arg0 context.Context, arg1 db.UpdatePurchaseOrderTxParams) (db.PurchaseOrderTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePurchaseOrderTx", arg0, arg1)
+	ret0, _ := ret[0].(db.PurchaseOrderTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdatePurchaseOrderTx indicates an expected call of UpdatePurchaseOrderTx.
+func (mr *MockStoreMockRecorder) UpdatePurchaseOrderTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePurchaseOrderTx", reflect.TypeOf((*MockStore)(nil).UpdatePurchaseOrderTx), arg0, arg1)
+}
+
 // UpdateRole mocks base method.
 func (m *MockStore) UpdateRole(arg0 context.Context, arg1 db.UpdateRoleParams) (db.Role, error) {
 	m.ctrl.T.Helper()
@@ -823,6 +3303,51 @@ func (mr *MockStoreMockRecorder) UpdateRolePermission(arg0, arg1 interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRolePermission", reflect.TypeOf((*MockStore)(nil).UpdateRolePermission), arg0, arg1)
 }
 
+// UpdateRolePermissionTx mocks base method.
+func (m *MockStore) UpdateRolePermissionTx(arg0 context.Context, arg1 db.UpdateRolePermissionParams) (db.RolePermission, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateRolePermissionTx", arg0, arg1)
+	ret0, _ := ret[0].(db.RolePermission)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateRolePermissionTx indicates an expected call of UpdateRolePermissionTx.
+func (mr *MockStoreMockRecorder) UpdateRolePermissionTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRolePermissionTx", reflect.TypeOf((*MockStore)(nil).UpdateRolePermissionTx), arg0, arg1)
+}
+
+// UpdateStockLocation mocks base method.
+func (m *MockStore) UpdateStockLocation(arg0 context.Context, arg1 db.UpdateStockLocationParams) (db.StockLocation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateStockLocation", arg0, arg1)
+	ret0, _ := ret[0].(db.StockLocation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateStockLocation indicates an expected call of UpdateStockLocation.
+func (mr *MockStoreMockRecorder) UpdateStockLocation(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStockLocation", reflect.TypeOf((*MockStore)(nil).UpdateStockLocation), arg0, arg1)
+}
+
+// UpdateSupplier mocks base method.
+func (m *MockStore) UpdateSupplier(arg0 context.Context, arg1 db.UpdateSupplierParams) (db.Supplier, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSupplier", arg0, arg1)
+	ret0, _ := ret[0].(db.Supplier)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateSupplier indicates an expected call of UpdateSupplier.
+func (mr *MockStoreMockRecorder) UpdateSupplier(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSupplier", reflect.TypeOf((*MockStore)(nil).UpdateSupplier), arg0, arg1)
+}
+
 // UpdateTransfer mocks base method.
 func (m *MockStore) UpdateTransfer(arg0 context.Context, arg1 db.UpdateTransferParams) (db.Transfer, error) {
 	m.ctrl.T.Helper()
@@ -852,3 +3377,108 @@ func (mr *MockStoreMockRecorder) UpdateUserRoleTx(arg0, arg1 interface{}) *gomoc
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserRoleTx", reflect.TypeOf((*MockStore)(nil).UpdateUserRoleTx), arg0, arg1)
 }
+
+// UpdateUserRoleValidity mocks base method.
+func (m *MockStore) UpdateUserRoleValidity(arg0 context.Context, arg1 db.UpdateUserRoleValidityParams) (db.UserRole, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserRoleValidity", arg0, arg1)
+	ret0, _ := ret[0].(db.UserRole)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateUserRoleValidity indicates an expected call of UpdateUserRoleValidity.
+func (mr *MockStoreMockRecorder) UpdateUserRoleValidity(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserRoleValidity", reflect.TypeOf((*MockStore)(nil).UpdateUserRoleValidity), arg0, arg1)
+}
+
+// UpsertIngredientInteraction mocks base method.
+func (m *MockStore) UpsertIngredientInteraction(arg0 context.Context, arg1 db.UpsertIngredientInteractionParams) (db.IngredientInteraction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertIngredientInteraction", arg0, arg1)
+	ret0, _ := ret[0].(db.IngredientInteraction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertIngredientInteraction indicates an expected call of UpsertIngredientInteraction.
+func (mr *MockStoreMockRecorder) UpsertIngredientInteraction(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertIngredientInteraction", reflect.TypeOf((*MockStore)(nil).UpsertIngredientInteraction), arg0, arg1)
+}
+
+// UpsertMedicineBatch mocks base method.
+func (m *MockStore) UpsertMedicineBatch(arg0 context.Context, arg1 db.UpsertMedicineBatchParams) (db.MedicineBatch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertMedicineBatch", arg0, arg1)
+	ret0, _ := ret[0].(db.MedicineBatch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertMedicineBatch indicates an expected call of UpsertMedicineBatch.
+func (mr *MockStoreMockRecorder) UpsertMedicineBatch(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertMedicineBatch", reflect.TypeOf((*MockStore)(nil).UpsertMedicineBatch), arg0, arg1)
+}
+
+// UpsertMedicineUnitConversion mocks base method.
+func (m *MockStore) UpsertMedicineUnitConversion(arg0 context.Context, arg1 db.UpsertMedicineUnitConversionParams) (db.MedicineUnitConversion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertMedicineUnitConversion", arg0, arg1)
+	ret0, _ := ret[0].(db.MedicineUnitConversion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertMedicineUnitConversion indicates an expected call of UpsertMedicineUnitConversion.
+func (mr *MockStoreMockRecorder) UpsertMedicineUnitConversion(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertMedicineUnitConversion", reflect.TypeOf((*MockStore)(nil).UpsertMedicineUnitConversion), arg0, arg1)
+}
+
+// UpsertStocktakeCount mocks base method.
+func (m *MockStore) UpsertStocktakeCount(arg0 context.Context, arg1 db.UpsertStocktakeCountParams) (db.StocktakeCount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertStocktakeCount", arg0, arg1)
+	ret0, _ := ret[0].(db.StocktakeCount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertStocktakeCount indicates an expected call of UpsertStocktakeCount.
+func (mr *MockStoreMockRecorder) UpsertStocktakeCount(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertStocktakeCount", reflect.TypeOf((*MockStore)(nil).UpsertStocktakeCount), arg0, arg1)
+}
+
+// UpsertUserPermissionOverride mocks base method.
+func (m *MockStore) UpsertUserPermissionOverride(arg0 context.Context, arg1 db.UpsertUserPermissionOverrideParams) (db.UserPermissionOverride, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertUserPermissionOverride", arg0, arg1)
+	ret0, _ := ret[0].(db.UserPermissionOverride)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertUserPermissionOverride indicates an expected call of UpsertUserPermissionOverride.
+func (mr *MockStoreMockRecorder) UpsertUserPermissionOverride(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertUserPermissionOverride", reflect.TypeOf((*MockStore)(nil).UpsertUserPermissionOverride), arg0, arg1)
+}
+
+// UpsertUserPermissionOverrideTx mocks base method.
+func (m *MockStore) UpsertUserPermissionOverrideTx(arg0 context.Context, arg1 db.UpsertUserPermissionOverrideParams) (db.UserPermissionOverride, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertUserPermissionOverrideTx", arg0, arg1)
+	ret0, _ := ret[0].(db.UserPermissionOverride)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertUserPermissionOverrideTx indicates an expected call of UpsertUserPermissionOverrideTx.
+func (mr *MockStoreMockRecorder) UpsertUserPermissionOverrideTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertUserPermissionOverrideTx", reflect.TypeOf((*MockStore)(nil).UpsertUserPermissionOverrideTx), arg0, arg1)
+}
@@ -0,0 +1,40 @@
+-- name: CreateActiveIngredient :one
+INSERT INTO active_ingredients (
+  name
+) VALUES (
+  $1
+) RETURNING *;
+
+-- name: ListActiveIngredients :many
+SELECT * FROM active_ingredients
+ORDER BY name;
+
+-- name: DeleteActiveIngredient :execrows
+DELETE FROM active_ingredients
+WHERE id = $1;
+
+-- name: CreateMedicineIngredient :one
+INSERT INTO medicine_ingredients (
+  medicine_id,
+  ingredient_id,
+  strength,
+  strength_unit
+) VALUES (
+  $1, $2, $3, $4
+) RETURNING *;
+
+-- name: ListMedicineIngredients :many
+SELECT
+  mi.medicine_id,
+  mi.ingredient_id,
+  ai.name,
+  mi.strength,
+  mi.strength_unit
+FROM medicine_ingredients mi
+JOIN active_ingredients ai ON ai.id = mi.ingredient_id
+WHERE mi.medicine_id = ANY(sqlc.arg(medicine_ids)::int[])
+ORDER BY mi.medicine_id, ai.name;
+
+-- name: DeleteMedicineIngredients :exec
+DELETE FROM medicine_ingredients
+WHERE medicine_id = $1;
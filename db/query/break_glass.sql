@@ -0,0 +1,57 @@
+-- name: CreateBreakGlassGrant :one
+INSERT INTO break_glass_grants (
+  user_id,
+  permission_id,
+  justification,
+  expires_at
+) VALUES (
+  $1, $2, $3, $4
+) RETURNING *;
+
+-- name: GetBreakGlassGrant :one
+SELECT g.*, u.username FROM break_glass_grants g
+JOIN users u ON u.id = g.user_id
+WHERE g.id = $1 LIMIT 1;
+
+-- name: ListPendingBreakGlassGrants :many
+SELECT
+  g.id,
+  g.user_id,
+  u.username,
+  g.permission_id,
+  p.name AS permission_name,
+  g.justification,
+  g.expires_at,
+  g.created_at
+FROM break_glass_grants g
+JOIN users u ON u.id = g.user_id
+JOIN permissions p ON p.id = g.permission_id
+WHERE g.reviewed_at IS NULL
+ORDER BY g.created_at
+LIMIT $1
+OFFSET $2;
+
+-- name: CountPendingBreakGlassGrants :one
+SELECT count(*) FROM break_glass_grants
+WHERE reviewed_at IS NULL;
+
+-- name: ReviewBreakGlassGrant :one
+UPDATE break_glass_grants
+SET
+  reviewed_by = sqlc.arg(reviewed_by),
+  reviewed_at = now(),
+  review_note = sqlc.arg(review_note),
+  expires_at = CASE WHEN sqlc.arg(revoke)::boolean THEN LEAST(expires_at, now()) ELSE expires_at END
+WHERE id = sqlc.arg(id)
+RETURNING *;
+
+-- name: ListActiveBreakGlassGrantsForUser :many
+SELECT
+  g.id,
+  p.name AS permission_name,
+  g.justification,
+  g.expires_at
+FROM break_glass_grants g
+JOIN permissions p ON p.id = g.permission_id
+WHERE g.user_id = $1 AND g.expires_at > now()
+ORDER BY g.expires_at;
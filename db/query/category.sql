@@ -0,0 +1,29 @@
+-- name: CreateCategory :one
+INSERT INTO categories (
+  name,
+  description
+) VALUES (
+  $1, $2
+) RETURNING *;
+
+-- name: ListCategories :many
+SELECT * FROM categories
+ORDER BY name;
+
+-- name: DeleteCategory :execrows
+DELETE FROM categories
+WHERE id = $1;
+
+-- name: ListMedicineCategories :many
+SELECT categories.* FROM categories
+JOIN medicine_categories ON medicine_categories.category_id = categories.id
+WHERE medicine_categories.medicine_id = $1
+ORDER BY categories.name;
+
+-- name: AddMedicineCategories :exec
+INSERT INTO medicine_categories (medicine_id, category_id)
+SELECT sqlc.arg(medicine_id)::int, unnest(sqlc.arg(category_ids)::int[]);
+
+-- name: DeleteMedicineCategories :exec
+DELETE FROM medicine_categories
+WHERE medicine_id = $1;
@@ -0,0 +1,53 @@
+-- name: CreateControlledRegisterEntry :one
+INSERT INTO controlled_register (
+  medicine_id,
+  stock_movement_id,
+  movement_type,
+  quantity,
+  balance,
+  batch_id,
+  location_id,
+  note,
+  recorded_by,
+  witnessed_by
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7, $8, $9, $10
+) RETURNING *;
+
+-- name: ListControlledRegisterEntries :many
+SELECT
+  r.*,
+  b.lot_number,
+  l.name AS location_name
+FROM controlled_register r
+LEFT JOIN medicine_batches b ON b.id = r.batch_id
+LEFT JOIN stock_locations l ON l.id = r.location_id
+WHERE r.created_at >= sqlc.arg(period_start) AND r.created_at < sqlc.arg(period_end)
+  AND (sqlc.narg(medicine_id)::int IS NULL OR r.medicine_id = sqlc.narg(medicine_id)::int)
+ORDER BY r.medicine_id, r.id;
+
+-- name: ListControlledRegisterBalances :many
+-- The stock of each controlled medicine, and of each medicine that has register
+-- entries, before and at the end of a period.
+SELECT
+  m.id,
+  m.name,
+  m.unit,
+  COALESCE((
+    SELECT balance FROM stock_movements
+    WHERE medicine_id = m.id AND created_at < sqlc.arg(period_start)
+    ORDER BY id DESC
+    LIMIT 1
+  ), 0)::int AS opening_balance,
+  COALESCE((
+    SELECT balance FROM stock_movements
+    WHERE medicine_id = m.id AND created_at < sqlc.arg(period_end)
+    ORDER BY id DESC
+    LIMIT 1
+  ), 0)::int AS closing_balance
+FROM medicines m
+WHERE (m.controlled OR EXISTS (
+    SELECT 1 FROM controlled_register WHERE medicine_id = m.id
+  ))
+  AND (sqlc.narg(medicine_id)::int IS NULL OR m.id = sqlc.narg(medicine_id)::int)
+ORDER BY m.name, m.id;
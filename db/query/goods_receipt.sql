@@ -0,0 +1,33 @@
+-- name: CreateGoodsReceipt :one
+INSERT INTO goods_receipts (
+  purchase_order_id,
+  note,
+  received_by
+) VALUES (
+  $1, $2, $3
+) RETURNING *;
+
+-- name: CreateGoodsReceiptItem :one
+INSERT INTO goods_receipt_items (
+  goods_receipt_id,
+  purchase_order_item_id,
+  batch_id,
+  quantity,
+  unit_cost,
+  currency
+) VALUES (
+  $1, $2, $3, $4, $5, $6
+) RETURNING *;
+
+-- name: ListGoodsReceipts :many
+SELECT * FROM goods_receipts
+WHERE purchase_order_id = $1
+ORDER BY id;
+
+-- name: ListGoodsReceiptItems :many
+SELECT r.*, i.medicine_id, b.lot_number, b.expiry_date
+FROM goods_receipt_items r
+JOIN purchase_order_items i ON i.id = r.purchase_order_item_id
+JOIN medicine_batches b ON b.id = r.batch_id
+WHERE r.goods_receipt_id = ANY(sqlc.arg(goods_receipt_ids)::int[])
+ORDER BY r.id;
@@ -0,0 +1,50 @@
+-- name: UpsertIngredientInteraction :one
+INSERT INTO ingredient_interactions (
+  ingredient_a_id,
+  ingredient_b_id,
+  severity,
+  description
+) VALUES (
+  $1, $2, $3, $4
+)
+ON CONFLICT (ingredient_a_id, ingredient_b_id) DO UPDATE
+SET
+  severity = EXCLUDED.severity,
+  description = EXCLUDED.description,
+  updated_at = now()
+RETURNING *;
+
+-- name: DeleteIngredientInteraction :execrows
+DELETE FROM ingredient_interactions
+WHERE id = $1;
+
+-- name: ListInteractionsForIngredient :many
+SELECT
+  ii.id,
+  ii.ingredient_a_id,
+  a.name AS ingredient_a_name,
+  ii.ingredient_b_id,
+  b.name AS ingredient_b_name,
+  ii.severity,
+  ii.description
+FROM ingredient_interactions ii
+JOIN active_ingredients a ON a.id = ii.ingredient_a_id
+JOIN active_ingredients b ON b.id = ii.ingredient_b_id
+WHERE ii.ingredient_a_id = sqlc.arg(ingredient_id) OR ii.ingredient_b_id = sqlc.arg(ingredient_id)
+ORDER BY ii.id;
+
+-- name: ListInteractionsAmongIngredients :many
+SELECT
+  ii.id,
+  ii.ingredient_a_id,
+  a.name AS ingredient_a_name,
+  ii.ingredient_b_id,
+  b.name AS ingredient_b_name,
+  ii.severity,
+  ii.description
+FROM ingredient_interactions ii
+JOIN active_ingredients a ON a.id = ii.ingredient_a_id
+JOIN active_ingredients b ON b.id = ii.ingredient_b_id
+WHERE ii.ingredient_a_id = ANY(sqlc.arg(ingredient_ids)::int[])
+  AND ii.ingredient_b_id = ANY(sqlc.arg(ingredient_ids)::int[])
+ORDER BY ii.id;
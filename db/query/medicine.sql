@@ -3,24 +3,85 @@ INSERT INTO medicines (
   name,
   unit,
   price,
-  stock,
-  description
+  description,
+  reorder_level,
+  currency,
+  sku,
+  dosage_form,
+  manufacturer
 ) VALUES (
-  $1, $2, $3, $4, $5
+  $1, $2, $3, $4, $5, $6, $7, $8, $9
 ) RETURNING *;
 
 -- name: GetMedicine :one
 SELECT * FROM medicines
 WHERE id = $1 LIMIT 1;
 
+-- name: GetMedicineByBarcode :one
+SELECT * FROM medicines
+WHERE id = (
+  SELECT medicine_id FROM medicine_barcodes
+  WHERE barcode = $1
+) LIMIT 1;
+
+-- name: GetMedicineForUpdate :one
+SELECT * FROM medicines
+WHERE id = $1 LIMIT 1
+FOR NO KEY UPDATE;
+
 -- name: ListMedicines :many
 SELECT * FROM medicines
-ORDER BY id
-LIMIT $1
-OFFSET $2;
+WHERE
+  (sqlc.narg(search)::text IS NULL OR unaccent(name) ILIKE '%' || replace(replace(replace(unaccent(sqlc.narg(search)::text), '\', '\\'), '%', '\%'), '_', '\_') || '%' ESCAPE '\')
+  AND (sqlc.narg(unit)::text IS NULL OR unit = sqlc.narg(unit)::text)
+  AND (sqlc.narg(min_price)::numeric IS NULL OR price >= sqlc.narg(min_price)::numeric)
+  AND (sqlc.narg(max_price)::numeric IS NULL OR price <= sqlc.narg(max_price)::numeric)
+  AND (NOT sqlc.arg(low_stock)::boolean OR stock <= reorder_level)
+  AND (sqlc.arg(include_archived)::boolean OR deleted_at IS NULL)
+  AND (sqlc.narg(dosage_form)::text IS NULL OR dosage_form = sqlc.narg(dosage_form)::text)
+  AND (sqlc.narg(manufacturer)::text IS NULL OR unaccent(manufacturer) ILIKE '%' || replace(replace(replace(unaccent(sqlc.narg(manufacturer)::text), '\', '\\'), '%', '\%'), '_', '\_') || '%' ESCAPE '\')
+  AND (sqlc.narg(category_id)::int IS NULL OR EXISTS (
+    SELECT 1 FROM medicine_categories
+    WHERE medicine_id = medicines.id AND category_id = sqlc.narg(category_id)::int
+  ))
+  AND (sqlc.narg(ingredient_id)::int IS NULL OR EXISTS (
+    SELECT 1 FROM medicine_ingredients
+    WHERE medicine_id = medicines.id AND ingredient_id = sqlc.narg(ingredient_id)::int
+  ))
+  AND (sqlc.narg(currency)::text IS NULL OR currency = sqlc.narg(currency)::text)
+ORDER BY
+  CASE WHEN sqlc.arg(sort_by)::text = 'name' AND NOT sqlc.arg(sort_desc)::boolean THEN name END ASC,
+  CASE WHEN sqlc.arg(sort_by)::text = 'name' AND sqlc.arg(sort_desc)::boolean THEN name END DESC,
+  CASE WHEN sqlc.arg(sort_by)::text = 'price' AND NOT sqlc.arg(sort_desc)::boolean THEN price END ASC,
+  CASE WHEN sqlc.arg(sort_by)::text = 'price' AND sqlc.arg(sort_desc)::boolean THEN price END DESC,
+  CASE WHEN sqlc.arg(sort_by)::text = 'stock' AND NOT sqlc.arg(sort_desc)::boolean THEN stock END ASC,
+  CASE WHEN sqlc.arg(sort_by)::text = 'stock' AND sqlc.arg(sort_desc)::boolean THEN stock END DESC,
+  CASE WHEN sqlc.arg(sort_by)::text = 'updated_at' AND NOT sqlc.arg(sort_desc)::boolean THEN updated_at END ASC,
+  CASE WHEN sqlc.arg(sort_by)::text = 'updated_at' AND sqlc.arg(sort_desc)::boolean THEN updated_at END DESC,
+  id
+LIMIT sqlc.arg('limit')
+OFFSET sqlc.arg('offset');
 
 -- name: CountMedicines :one
-SELECT count(*) FROM medicines;
+SELECT count(*) FROM medicines
+WHERE
+  (sqlc.narg(search)::text IS NULL OR unaccent(name) ILIKE '%' || replace(replace(replace(unaccent(sqlc.narg(search)::text), '\', '\\'), '%', '\%'), '_', '\_') || '%' ESCAPE '\')
+  AND (sqlc.narg(unit)::text IS NULL OR unit = sqlc.narg(unit)::text)
+  AND (sqlc.narg(min_price)::numeric IS NULL OR price >= sqlc.narg(min_price)::numeric)
+  AND (sqlc.narg(max_price)::numeric IS NULL OR price <= sqlc.narg(max_price)::numeric)
+  AND (NOT sqlc.arg(low_stock)::boolean OR stock <= reorder_level)
+  AND (sqlc.arg(include_archived)::boolean OR deleted_at IS NULL)
+  AND (sqlc.narg(dosage_form)::text IS NULL OR dosage_form = sqlc.narg(dosage_form)::text)
+  AND (sqlc.narg(manufacturer)::text IS NULL OR unaccent(manufacturer) ILIKE '%' || replace(replace(replace(unaccent(sqlc.narg(manufacturer)::text), '\', '\\'), '%', '\%'), '_', '\_') || '%' ESCAPE '\')
+  AND (sqlc.narg(category_id)::int IS NULL OR EXISTS (
+    SELECT 1 FROM medicine_categories
+    WHERE medicine_id = medicines.id AND category_id = sqlc.narg(category_id)::int
+  ))
+  AND (sqlc.narg(ingredient_id)::int IS NULL OR EXISTS (
+    SELECT 1 FROM medicine_ingredients
+    WHERE medicine_id = medicines.id AND ingredient_id = sqlc.narg(ingredient_id)::int
+  ))
+  AND (sqlc.narg(currency)::text IS NULL OR currency = sqlc.narg(currency)::text);
 
 -- name: UpdateMedicine :one
 UPDATE medicines
@@ -28,8 +89,20 @@ SET
   name = COALESCE(sqlc.narg(name), name),
   unit = COALESCE(sqlc.narg(unit), unit),
   price = COALESCE(sqlc.narg(price), price),
-  stock = COALESCE(sqlc.narg(stock), stock),
   description = COALESCE(sqlc.narg(description), description),
+  reorder_level = COALESCE(sqlc.narg(reorder_level), reorder_level),
+  currency = COALESCE(sqlc.narg(currency), currency),
+  sku = COALESCE(sqlc.narg(sku), sku),
+  dosage_form = COALESCE(sqlc.narg(dosage_form), dosage_form),
+  manufacturer = COALESCE(sqlc.narg(manufacturer), manufacturer),
+  updated_at = now()
+WHERE id = sqlc.arg(id)
+RETURNING *;
+
+-- name: AddMedicineStock :one
+UPDATE medicines
+SET
+  stock = stock + sqlc.arg(amount),
   updated_at = now()
 WHERE id = sqlc.arg(id)
 RETURNING *;
@@ -37,3 +110,83 @@ RETURNING *;
 -- name: DeleteMedicine :exec
 DELETE FROM medicines
 WHERE id = $1;
+
+-- name: ArchiveMedicine :one
+UPDATE medicines
+SET
+  deleted_at = now(),
+  updated_at = now()
+WHERE id = $1
+RETURNING *;
+
+-- name: RestoreMedicine :one
+UPDATE medicines
+SET
+  deleted_at = NULL,
+  updated_at = now()
+WHERE id = $1
+RETURNING *;
+
+-- name: ListLowStockMedicines :many
+SELECT * FROM medicines
+WHERE stock <= reorder_level AND deleted_at IS NULL
+ORDER BY id;
+
+-- name: UpdateMedicinePrice :one
+UPDATE medicines
+SET
+  price = sqlc.arg(price),
+  currency = sqlc.arg(currency),
+  updated_at = now()
+WHERE id = sqlc.arg(id)
+RETURNING *;
+
+-- name: SetMedicineControlled :one
+UPDATE medicines
+SET
+  controlled = sqlc.arg(controlled),
+  updated_at = now()
+WHERE id = sqlc.arg(id)
+RETURNING *;
+
+-- name: ListMedicinesForUpdate :many
+SELECT * FROM medicines
+WHERE
+  (sqlc.narg(ids)::int[] IS NULL OR id = ANY(sqlc.narg(ids)::int[]))
+  AND (sqlc.narg(search)::text IS NULL OR unaccent(name) ILIKE '%' || replace(replace(replace(unaccent(sqlc.narg(search)::text), '\', '\\'), '%', '\%'), '_', '\_') || '%' ESCAPE '\')
+  AND (sqlc.narg(unit)::text IS NULL OR unit = sqlc.narg(unit)::text)
+  AND (sqlc.narg(category_id)::int IS NULL OR EXISTS (
+    SELECT 1 FROM medicine_categories
+    WHERE medicine_id = medicines.id AND category_id = sqlc.narg(category_id)::int
+  ))
+  AND deleted_at IS NULL
+ORDER BY id
+FOR NO KEY UPDATE;
+
+-- name: ListAllMedicines :many
+SELECT * FROM medicines
+WHERE deleted_at IS NULL
+ORDER BY id;
+
+-- name: ListMedicinesForImport :many
+SELECT * FROM medicines
+WHERE sku = ANY(sqlc.arg(skus)::text[])
+  OR (name = ANY(sqlc.arg(names)::text[]) AND deleted_at IS NULL)
+ORDER BY id
+FOR NO KEY UPDATE;
+
+-- name: ListMedicinesByIDs :many
+SELECT * FROM medicines
+WHERE id = ANY(sqlc.arg(ids)::int[])
+ORDER BY id;
+
+-- name: MedicineUnitInUse :one
+-- Whether quantities have been recorded in the unit of the medicine: it has
+-- stock, lots, or conversions from other units.
+SELECT (
+  stock <> 0
+  OR EXISTS (SELECT 1 FROM medicine_batches WHERE medicine_id = medicines.id)
+  OR EXISTS (SELECT 1 FROM medicine_unit_conversions WHERE medicine_id = medicines.id)
+)::boolean AS in_use
+FROM medicines
+WHERE id = $1;
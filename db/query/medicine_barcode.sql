@@ -0,0 +1,17 @@
+-- name: CreateMedicineBarcode :one
+INSERT INTO medicine_barcodes (
+  barcode,
+  medicine_id
+) VALUES (
+  $1, $2
+)
+RETURNING *;
+
+-- name: ListMedicineBarcodes :many
+SELECT * FROM medicine_barcodes
+WHERE medicine_id = $1
+ORDER BY created_at, barcode;
+
+-- name: DeleteMedicineBarcode :execrows
+DELETE FROM medicine_barcodes
+WHERE medicine_id = $1 AND barcode = $2;
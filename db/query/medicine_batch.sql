@@ -0,0 +1,53 @@
+-- name: UpsertMedicineBatch :one
+-- Adds a receipt to its lot. A lot that is received again with a different
+-- expiry date is left alone and no row is returned.
+INSERT INTO medicine_batches (
+  medicine_id,
+  lot_number,
+  expiry_date,
+  received_quantity,
+  remaining_quantity
+) VALUES (
+  sqlc.arg(medicine_id), sqlc.arg(lot_number), sqlc.arg(expiry_date), sqlc.arg(quantity), sqlc.arg(quantity)
+)
+ON CONFLICT (medicine_id, lot_number) DO UPDATE
+SET
+  expiry_date = COALESCE(medicine_batches.expiry_date, EXCLUDED.expiry_date),
+  received_quantity = medicine_batches.received_quantity + EXCLUDED.received_quantity,
+  remaining_quantity = medicine_batches.remaining_quantity + EXCLUDED.remaining_quantity
+WHERE medicine_batches.expiry_date IS NULL
+  OR EXCLUDED.expiry_date IS NULL
+  OR medicine_batches.expiry_date = EXCLUDED.expiry_date
+RETURNING *;
+
+-- name: GetMedicineBatch :one
+SELECT * FROM medicine_batches
+WHERE id = $1 LIMIT 1;
+
+-- name: ListMedicineBatches :many
+SELECT * FROM medicine_batches
+WHERE medicine_id = $1 AND remaining_quantity > 0
+ORDER BY expiry_date NULLS FIRST, id;
+
+-- name: ListDispensableMedicineBatches :many
+-- Earliest expiry first (FEFO). Legacy stock without an expiry date goes first
+-- and expired batches are skipped.
+SELECT * FROM medicine_batches
+WHERE medicine_id = $1
+  AND remaining_quantity > 0
+  AND (expiry_date IS NULL OR expiry_date >= CURRENT_DATE)
+ORDER BY expiry_date NULLS FIRST, id;
+
+-- name: AddMedicineBatchQuantity :one
+UPDATE medicine_batches
+SET remaining_quantity = remaining_quantity + sqlc.arg(amount)
+WHERE id = sqlc.arg(id)
+RETURNING *;
+
+-- name: ListExpiringMedicineBatches :many
+SELECT b.*, m.name AS medicine_name
+FROM medicine_batches b
+JOIN medicines m ON m.id = b.medicine_id
+WHERE b.remaining_quantity > 0 AND b.expiry_date <= sqlc.arg(expiry_before)
+  AND m.deleted_at IS NULL
+ORDER BY b.expiry_date, b.id;
@@ -0,0 +1,45 @@
+-- name: CreateMedicinePrice :one
+INSERT INTO medicine_prices (
+  medicine_id,
+  price,
+  currency,
+  effective_from,
+  reason,
+  created_by
+) VALUES (
+  $1, $2, $3, $4, $5, $6
+) RETURNING *;
+
+-- name: GetMedicinePriceAt :one
+SELECT * FROM medicine_prices
+WHERE medicine_id = $1 AND effective_from <= sqlc.arg(at)
+ORDER BY effective_from DESC
+LIMIT 1;
+
+-- name: ListMedicinePrices :many
+SELECT * FROM medicine_prices
+WHERE medicine_id = $1
+ORDER BY effective_from DESC
+LIMIT $2
+OFFSET $3;
+
+-- name: CountMedicinePrices :one
+SELECT count(*) FROM medicine_prices
+WHERE medicine_id = $1;
+
+-- name: ApplyDueMedicinePrices :execrows
+-- Brings medicines.price up to date with the latest history entry that has
+-- taken effect, for price changes scheduled ahead of time.
+UPDATE medicines m
+SET
+  price = p.price,
+  currency = p.currency,
+  updated_at = now()
+FROM (
+  SELECT DISTINCT ON (medicine_id) medicine_id, price, currency
+  FROM medicine_prices
+  WHERE effective_from <= now()
+  ORDER BY medicine_id, effective_from DESC
+) p
+WHERE m.id = p.medicine_id
+  AND (m.price, m.currency) IS DISTINCT FROM (p.price, p.currency);
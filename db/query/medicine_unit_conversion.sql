@@ -0,0 +1,24 @@
+-- name: UpsertMedicineUnitConversion :one
+INSERT INTO medicine_unit_conversions (
+  medicine_id,
+  unit,
+  factor
+) VALUES (
+  $1, $2, $3
+)
+ON CONFLICT (medicine_id, unit) DO UPDATE
+SET factor = EXCLUDED.factor
+RETURNING *;
+
+-- name: GetMedicineUnitConversion :one
+SELECT * FROM medicine_unit_conversions
+WHERE medicine_id = $1 AND unit = $2 LIMIT 1;
+
+-- name: ListMedicineUnitConversions :many
+SELECT * FROM medicine_unit_conversions
+WHERE medicine_id = $1
+ORDER BY factor, unit;
+
+-- name: DeleteMedicineUnitConversion :execrows
+DELETE FROM medicine_unit_conversions
+WHERE medicine_id = $1 AND unit = $2;
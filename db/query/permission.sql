@@ -10,6 +10,10 @@ INSERT INTO permissions (
 SELECT * FROM permissions
 WHERE id = $1 LIMIT 1;
 
+-- name: GetPermissionByName :one
+SELECT * FROM permissions
+WHERE name = $1 LIMIT 1;
+
 -- name: ListPermissions :many
 SELECT * FROM permissions
 ORDER BY id
@@ -31,3 +35,23 @@ RETURNING *;
 -- name: DeletePermission :exec
 DELETE FROM permissions
 WHERE id = $1;
+
+-- name: ListAllPermissions :many
+SELECT * FROM permissions
+ORDER BY id;
+
+-- name: SetPermissionDescription :one
+UPDATE permissions
+SET
+    description = sqlc.narg(description),
+    updated_at = now()
+WHERE id = sqlc.arg(id)
+RETURNING *;
+
+-- name: SetPermissionBreakGlass :one
+UPDATE permissions
+SET
+    break_glass = sqlc.arg(break_glass),
+    updated_at = now()
+WHERE id = sqlc.arg(id)
+RETURNING *;
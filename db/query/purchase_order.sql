@@ -0,0 +1,84 @@
+-- name: CreatePurchaseOrder :one
+INSERT INTO purchase_orders (
+  supplier_id,
+  note,
+  created_by
+) VALUES (
+  $1, $2, $3
+) RETURNING *;
+
+-- name: GetPurchaseOrder :one
+SELECT * FROM purchase_orders
+WHERE id = $1 LIMIT 1;
+
+-- name: GetPurchaseOrderForUpdate :one
+SELECT * FROM purchase_orders
+WHERE id = $1 LIMIT 1
+FOR NO KEY UPDATE;
+
+-- name: ListPurchaseOrders :many
+SELECT * FROM purchase_orders
+WHERE
+  (sqlc.narg(status)::text IS NULL OR status = sqlc.narg(status)::text)
+  AND (sqlc.narg(supplier_id)::int IS NULL OR supplier_id = sqlc.narg(supplier_id)::int)
+ORDER BY id DESC
+LIMIT sqlc.arg('limit')
+OFFSET sqlc.arg('offset');
+
+-- name: CountPurchaseOrders :one
+SELECT count(*) FROM purchase_orders
+WHERE
+  (sqlc.narg(status)::text IS NULL OR status = sqlc.narg(status)::text)
+  AND (sqlc.narg(supplier_id)::int IS NULL OR supplier_id = sqlc.narg(supplier_id)::int);
+
+-- name: UpdatePurchaseOrder :one
+UPDATE purchase_orders
+SET
+  supplier_id = COALESCE(sqlc.narg(supplier_id), supplier_id),
+  note = COALESCE(sqlc.narg(note), note),
+  updated_at = now()
+WHERE id = sqlc.arg(id)
+RETURNING *;
+
+-- name: SetPurchaseOrderStatus :one
+UPDATE purchase_orders
+SET
+  status = sqlc.arg(status)::text,
+  ordered_at = CASE WHEN sqlc.arg(status)::text = 'ordered' THEN now() ELSE ordered_at END,
+  received_at = CASE WHEN sqlc.arg(status)::text = 'received' THEN now() ELSE received_at END,
+  updated_at = now()
+WHERE id = sqlc.arg(id)
+RETURNING *;
+
+-- name: DeletePurchaseOrder :execrows
+-- Only drafts can be deleted; ordered ones are kept for the record.
+DELETE FROM purchase_orders
+WHERE id = $1 AND status = 'draft';
+
+-- name: CreatePurchaseOrderItem :one
+INSERT INTO purchase_order_items (
+  purchase_order_id,
+  medicine_id,
+  quantity,
+  unit_cost,
+  currency
+) VALUES (
+  $1, $2, $3, $4, $5
+) RETURNING *;
+
+-- name: ListPurchaseOrderItems :many
+SELECT i.*, m.name AS medicine_name, m.unit
+FROM purchase_order_items i
+JOIN medicines m ON m.id = i.medicine_id
+WHERE i.purchase_order_id = $1
+ORDER BY i.id;
+
+-- name: DeletePurchaseOrderItems :exec
+DELETE FROM purchase_order_items
+WHERE purchase_order_id = $1;
+
+-- name: AddPurchaseOrderItemReceived :one
+UPDATE purchase_order_items
+SET received_quantity = received_quantity + sqlc.arg(quantity)
+WHERE id = sqlc.arg(id)
+RETURNING *;
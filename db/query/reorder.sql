@@ -0,0 +1,58 @@
+-- name: ListMedicineConsumption :many
+-- What was dispensed of each medicine since a point in time, with what is
+-- needed to plan an order: the unexpired stock, what is still on order, the
+-- supplier of the latest placed purchase order for it, and the unit cost paid
+-- on its latest delivery.
+SELECT
+  m.id,
+  m.name,
+  m.unit,
+  m.currency,
+  COALESCE(b.usable_stock, 0)::int AS usable_stock,
+  COALESCE(c.consumed, 0)::int AS consumed,
+  COALESCE(o.on_order, 0)::int AS on_order,
+  last.supplier_id,
+  s.name AS supplier_name,
+  s.lead_time_days,
+  paid.unit_cost,
+  paid.currency AS unit_cost_currency
+FROM medicines m
+LEFT JOIN (
+  SELECT medicine_id, sum(remaining_quantity) AS usable_stock
+  FROM medicine_batches
+  WHERE remaining_quantity > 0 AND (expiry_date IS NULL OR expiry_date >= CURRENT_DATE)
+  GROUP BY medicine_id
+) b ON b.medicine_id = m.id
+LEFT JOIN (
+  SELECT medicine_id, -sum(quantity) AS consumed
+  FROM stock_movements
+  WHERE movement_type = 'dispense' AND created_at >= sqlc.arg(since)
+  GROUP BY medicine_id
+) c ON c.medicine_id = m.id
+LEFT JOIN (
+  SELECT i.medicine_id, sum(i.quantity - i.received_quantity) AS on_order
+  FROM purchase_order_items i
+  JOIN purchase_orders po ON po.id = i.purchase_order_id
+  WHERE po.status IN ('ordered', 'partially_received')
+  GROUP BY i.medicine_id
+) o ON o.medicine_id = m.id
+LEFT JOIN LATERAL (
+  SELECT po.supplier_id
+  FROM purchase_order_items i
+  JOIN purchase_orders po ON po.id = i.purchase_order_id
+  WHERE i.medicine_id = m.id AND po.status <> 'draft'
+  ORDER BY po.ordered_at DESC, po.id DESC
+  LIMIT 1
+) last ON true
+LEFT JOIN suppliers s ON s.id = last.supplier_id
+LEFT JOIN LATERAL (
+  SELECT ri.unit_cost, ri.currency
+  FROM goods_receipt_items ri
+  JOIN goods_receipts r ON r.id = ri.goods_receipt_id
+  JOIN purchase_order_items i ON i.id = ri.purchase_order_item_id
+  WHERE i.medicine_id = m.id
+  ORDER BY r.received_at DESC, ri.id DESC
+  LIMIT 1
+) paid ON true
+WHERE m.deleted_at IS NULL
+ORDER BY m.name, m.id;
@@ -11,7 +11,11 @@ SELECT * FROM roles
 WHERE id = $1 LIMIT 1;
 
 -- name: ListRoles :many
-SELECT * FROM roles
+SELECT
+  roles.*,
+  (SELECT count(*) FROM user_roles ur WHERE ur.role_id = roles.id)::bigint AS member_count,
+  (SELECT count(*) FROM role_permissions rp WHERE rp.role_id = roles.id)::bigint AS permission_count
+FROM roles
 ORDER BY id
 LIMIT $1
 OFFSET $2;
@@ -31,3 +35,20 @@ WHERE id = $1;
 
 -- name: CountRoles :one
 SELECT count(*) FROM roles;
+
+-- name: LockSystemRoles :exec
+SELECT id FROM roles
+WHERE is_system
+FOR UPDATE;
+
+-- name: ListAllRoles :many
+SELECT * FROM roles
+ORDER BY id;
+
+-- name: SetRoleDescription :one
+UPDATE roles
+SET
+    description = sqlc.narg(description),
+    updated_at = now()
+WHERE id = sqlc.arg(id)
+RETURNING *;
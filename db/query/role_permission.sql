@@ -31,3 +31,22 @@ SET
   updated_at = now()
 WHERE role_id = $1 AND permission_id = $2
 RETURNING *;
+
+-- name: ListAllRolePermissions :many
+SELECT * FROM role_permissions
+ORDER BY role_id, permission_id;
+
+-- name: ListPermissionsForRole :many
+SELECT
+  p.*,
+  rp.created_at AS granted_at
+FROM role_permissions rp
+JOIN permissions p ON p.id = rp.permission_id
+WHERE rp.role_id = $1
+ORDER BY p.id
+LIMIT $2
+OFFSET $3;
+
+-- name: CountPermissionsForRole :one
+SELECT count(*) FROM role_permissions
+WHERE role_id = $1;
@@ -0,0 +1,49 @@
+-- name: CreateRolePermissionDeny :one
+INSERT INTO role_permission_denies (
+  role_id,
+  permission_id
+) VALUES (
+  $1, $2
+) RETURNING *;
+
+-- name: GetRolePermissionDeny :one
+SELECT * FROM role_permission_denies
+WHERE role_id = $1 AND permission_id = $2
+LIMIT 1;
+
+-- name: DeleteRolePermissionDeny :exec
+DELETE FROM role_permission_denies
+WHERE role_id = $1 AND permission_id = $2;
+
+-- name: ListRolePermissionDenies :many
+SELECT
+  d.role_id,
+  r.name AS role_name,
+  d.permission_id,
+  p.name AS permission_name,
+  d.created_at
+FROM role_permission_denies d
+JOIN roles r ON r.id = d.role_id
+JOIN permissions p ON p.id = d.permission_id
+ORDER BY d.role_id, d.permission_id
+LIMIT $1
+OFFSET $2;
+
+-- name: CountRolePermissionDenies :one
+SELECT count(*) FROM role_permission_denies;
+
+-- name: ListUserRoleDenies :many
+SELECT
+  r.id AS role_id,
+  r.name AS role_name,
+  p.name AS permission_name
+FROM user_roles ur
+JOIN role_permission_denies d ON d.role_id = ur.role_id
+JOIN roles r ON r.id = d.role_id
+JOIN permissions p ON p.id = d.permission_id
+WHERE ur.user_id = $1
+ORDER BY r.id, p.id;
+
+-- name: ListAllRolePermissionDenies :many
+SELECT * FROM role_permission_denies
+ORDER BY role_id, permission_id;
@@ -0,0 +1,57 @@
+-- name: CreateStockAlert :one
+INSERT INTO stock_alerts (
+  alert_type,
+  medicine_id,
+  batch_id,
+  message
+) VALUES (
+  $1, $2, $3, $4
+)
+ON CONFLICT (alert_type, medicine_id, COALESCE(batch_id, 0)) WHERE resolved_at IS NULL DO NOTHING
+RETURNING *;
+
+-- name: GetStockAlert :one
+SELECT * FROM stock_alerts
+WHERE id = $1 LIMIT 1;
+
+-- name: ListStockAlerts :many
+SELECT sa.*, m.name AS medicine_name, b.lot_number, b.expiry_date
+FROM stock_alerts sa
+JOIN medicines m ON m.id = sa.medicine_id
+LEFT JOIN medicine_batches b ON b.id = sa.batch_id
+WHERE sa.resolved_at IS NULL
+  AND (sqlc.narg(acknowledged)::boolean IS NULL OR (sa.acknowledged_at IS NOT NULL) = sqlc.narg(acknowledged)::boolean)
+ORDER BY sa.id DESC
+LIMIT sqlc.arg('limit')
+OFFSET sqlc.arg('offset');
+
+-- name: CountStockAlerts :one
+SELECT count(*) FROM stock_alerts
+WHERE resolved_at IS NULL
+  AND (sqlc.narg(acknowledged)::boolean IS NULL OR (acknowledged_at IS NOT NULL) = sqlc.narg(acknowledged)::boolean);
+
+-- name: AcknowledgeStockAlert :one
+UPDATE stock_alerts
+SET
+  acknowledged_by = $2,
+  acknowledged_at = now()
+WHERE id = $1
+RETURNING *;
+
+-- name: ResolveLowStockAlerts :execrows
+UPDATE stock_alerts sa
+SET resolved_at = now()
+FROM medicines m
+WHERE m.id = sa.medicine_id
+  AND sa.alert_type = 'low_stock'
+  AND sa.resolved_at IS NULL
+  AND (m.stock > m.reorder_level OR m.deleted_at IS NOT NULL);
+
+-- name: ResolveNearExpiryAlerts :execrows
+UPDATE stock_alerts sa
+SET resolved_at = now()
+FROM medicine_batches b
+WHERE b.id = sa.batch_id
+  AND sa.alert_type = 'near_expiry'
+  AND sa.resolved_at IS NULL
+  AND b.remaining_quantity = 0;
@@ -0,0 +1,79 @@
+-- name: CreateStockLocation :one
+INSERT INTO stock_locations (
+  name
+) VALUES (
+  $1
+) RETURNING *;
+
+-- name: GetStockLocation :one
+SELECT * FROM stock_locations
+WHERE id = $1 LIMIT 1;
+
+-- name: GetDefaultStockLocation :one
+SELECT * FROM stock_locations
+WHERE is_default LIMIT 1;
+
+-- name: ListStockLocations :many
+SELECT * FROM stock_locations
+ORDER BY id;
+
+-- name: UpdateStockLocation :one
+UPDATE stock_locations
+SET name = $2
+WHERE id = $1
+RETURNING *;
+
+-- name: AddBatchLocationQuantity :one
+INSERT INTO batch_locations (
+  batch_id,
+  location_id,
+  quantity
+) VALUES (
+  sqlc.arg(batch_id), sqlc.arg(location_id), sqlc.arg(amount)
+)
+ON CONFLICT (batch_id, location_id) DO UPDATE
+SET quantity = batch_locations.quantity + EXCLUDED.quantity
+RETURNING *;
+
+-- name: ListBatchLocations :many
+SELECT * FROM batch_locations
+WHERE batch_id = $1 AND quantity > 0
+ORDER BY location_id;
+
+-- name: ListDispensableLocationBatches :many
+-- Like ListDispensableMedicineBatches, limited to the stock at one location.
+SELECT b.id, b.lot_number, b.expiry_date, bl.quantity
+FROM batch_locations bl
+JOIN medicine_batches b ON b.id = bl.batch_id
+WHERE b.medicine_id = sqlc.arg(medicine_id)
+  AND bl.location_id = sqlc.arg(location_id)
+  AND bl.quantity > 0
+  AND (b.expiry_date IS NULL OR b.expiry_date >= CURRENT_DATE)
+ORDER BY b.expiry_date NULLS FIRST, b.id;
+
+-- name: ListLocationStock :many
+SELECT
+  m.id AS medicine_id,
+  m.name AS medicine_name,
+  m.unit,
+  sum(bl.quantity)::int AS quantity
+FROM batch_locations bl
+JOIN medicine_batches b ON b.id = bl.batch_id
+JOIN medicines m ON m.id = b.medicine_id
+WHERE bl.location_id = $1 AND bl.quantity > 0
+GROUP BY m.id
+ORDER BY m.name;
+
+-- name: ListMedicineLocationStock :many
+SELECT
+  l.id AS location_id,
+  l.name AS location_name,
+  b.id AS batch_id,
+  b.lot_number,
+  b.expiry_date,
+  bl.quantity
+FROM batch_locations bl
+JOIN medicine_batches b ON b.id = bl.batch_id
+JOIN stock_locations l ON l.id = bl.location_id
+WHERE b.medicine_id = $1 AND bl.quantity > 0
+ORDER BY l.id, b.expiry_date NULLS FIRST, b.id;
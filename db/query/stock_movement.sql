@@ -0,0 +1,24 @@
+-- name: CreateStockMovement :one
+INSERT INTO stock_movements (
+  medicine_id,
+  movement_type,
+  quantity,
+  balance,
+  note,
+  created_by,
+  batch_id,
+  location_id
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7, $8
+) RETURNING *;
+
+-- name: ListStockMovements :many
+SELECT * FROM stock_movements
+WHERE medicine_id = $1
+ORDER BY id DESC
+LIMIT $2
+OFFSET $3;
+
+-- name: CountStockMovements :one
+SELECT count(*) FROM stock_movements
+WHERE medicine_id = $1;
@@ -0,0 +1,11 @@
+-- name: CreateStockTransfer :one
+INSERT INTO stock_transfers (
+  medicine_id,
+  from_location_id,
+  to_location_id,
+  quantity,
+  note,
+  created_by
+) VALUES (
+  $1, $2, $3, $4, $5, $6
+) RETURNING *;
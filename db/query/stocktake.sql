@@ -0,0 +1,113 @@
+-- name: CreateStocktake :one
+INSERT INTO stocktakes (
+  note,
+  created_by
+) VALUES (
+  $1, $2
+) RETURNING *;
+
+-- name: GetStocktake :one
+SELECT * FROM stocktakes
+WHERE id = $1 LIMIT 1;
+
+-- name: GetStocktakeForUpdate :one
+SELECT * FROM stocktakes
+WHERE id = $1 LIMIT 1
+FOR NO KEY UPDATE;
+
+-- name: ListStocktakes :many
+SELECT * FROM stocktakes
+ORDER BY id DESC
+LIMIT $1
+OFFSET $2;
+
+-- name: CountStocktakes :one
+SELECT count(*) FROM stocktakes;
+
+-- name: ApproveStocktake :one
+UPDATE stocktakes
+SET
+  status = 'approved',
+  approved_by = sqlc.arg(approved_by),
+  approved_at = now()
+WHERE id = sqlc.arg(id)
+RETURNING *;
+
+-- name: DeleteStocktake :execrows
+-- Approved stocktakes are kept with the adjustments they posted.
+DELETE FROM stocktakes
+WHERE id = $1 AND status = 'open';
+
+-- name: CreateStocktakeItems :execrows
+-- Snapshots the batches in stock at every location of the given medicines, or
+-- of every medicine that is not archived when none are given.
+INSERT INTO stocktake_items (stocktake_id, medicine_id, batch_id, location_id, expected_quantity)
+SELECT sqlc.arg(stocktake_id)::int, b.medicine_id, b.id, bl.location_id, bl.quantity
+FROM batch_locations bl
+JOIN medicine_batches b ON b.id = bl.batch_id
+JOIN medicines m ON m.id = b.medicine_id
+WHERE bl.quantity > 0
+  AND m.deleted_at IS NULL
+  AND (cardinality(sqlc.arg(medicine_ids)::int[]) = 0 OR b.medicine_id = ANY(sqlc.arg(medicine_ids)::int[]));
+
+-- name: ListStocktakeItems :many
+-- Every counter counts the whole item, so counted_quantity is what they found
+-- and disputed is set when they disagree. moved_quantity is the net stock
+-- movement of the batch at the location between the snapshot and the last
+-- count, which the counters already saw on the shelf.
+SELECT
+  i.*,
+  m.name AS medicine_name,
+  m.unit,
+  b.lot_number,
+  b.expiry_date,
+  l.name AS location_name,
+  c.counters,
+  c.counted_quantity,
+  c.disputed,
+  c.counted_at,
+  COALESCE(mv.quantity, 0)::int AS moved_quantity
+FROM stocktake_items i
+JOIN stocktakes s ON s.id = i.stocktake_id
+JOIN medicines m ON m.id = i.medicine_id
+JOIN medicine_batches b ON b.id = i.batch_id
+JOIN stock_locations l ON l.id = i.location_id
+CROSS JOIN LATERAL (
+  SELECT
+    count(*) AS counters,
+    COALESCE(max(quantity), 0)::int AS counted_quantity,
+    count(DISTINCT quantity) > 1 AS disputed,
+    max(counted_at) AS counted_at
+  FROM stocktake_counts
+  WHERE stocktake_item_id = i.id
+) c
+LEFT JOIN LATERAL (
+  SELECT sum(quantity) AS quantity
+  FROM stock_movements
+  WHERE batch_id = i.batch_id
+    AND location_id = i.location_id
+    AND created_at > s.created_at
+    AND created_at <= c.counted_at
+) mv ON true
+WHERE i.stocktake_id = $1
+ORDER BY m.name, b.expiry_date NULLS FIRST, l.id, i.id;
+
+-- name: UpsertStocktakeCount :one
+INSERT INTO stocktake_counts (
+  stocktake_item_id,
+  counted_by,
+  quantity
+) VALUES (
+  $1, $2, $3
+)
+ON CONFLICT (stocktake_item_id, counted_by) DO UPDATE
+SET
+  quantity = EXCLUDED.quantity,
+  counted_at = now()
+RETURNING *;
+
+-- name: ListStocktakeCounts :many
+SELECT c.* FROM stocktake_counts c
+JOIN stocktake_items i ON i.id = c.stocktake_item_id
+WHERE i.stocktake_id = $1
+ORDER BY c.stocktake_item_id, c.counted_at;
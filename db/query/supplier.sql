@@ -0,0 +1,36 @@
+-- name: CreateSupplier :one
+INSERT INTO suppliers (
+  name,
+  contact_name,
+  phone,
+  email,
+  address,
+  lead_time_days
+) VALUES (
+  $1, $2, $3, $4, $5, $6
+) RETURNING *;
+
+-- name: GetSupplier :one
+SELECT * FROM suppliers
+WHERE id = $1 LIMIT 1;
+
+-- name: ListSuppliers :many
+SELECT * FROM suppliers
+ORDER BY name;
+
+-- name: UpdateSupplier :one
+UPDATE suppliers
+SET
+  name = COALESCE(sqlc.narg(name), name),
+  contact_name = COALESCE(sqlc.narg(contact_name), contact_name),
+  phone = COALESCE(sqlc.narg(phone), phone),
+  email = COALESCE(sqlc.narg(email), email),
+  address = COALESCE(sqlc.narg(address), address),
+  lead_time_days = COALESCE(sqlc.narg(lead_time_days), lead_time_days),
+  updated_at = now()
+WHERE id = sqlc.arg(id)
+RETURNING *;
+
+-- name: DeleteSupplier :execrows
+DELETE FROM suppliers
+WHERE id = $1;
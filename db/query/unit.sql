@@ -0,0 +1,15 @@
+-- name: CreateUnit :one
+INSERT INTO units (
+  name,
+  description
+) VALUES (
+  $1, $2
+) RETURNING *;
+
+-- name: ListUnits :many
+SELECT * FROM units
+ORDER BY name;
+
+-- name: DeleteUnit :execrows
+DELETE FROM units
+WHERE name = $1;
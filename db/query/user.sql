@@ -7,6 +7,10 @@ RETURNING *;
 SELECT * FROM users
 WHERE username = $1 LIMIT 1;
 
+-- name: GetUserByID :one
+SELECT * FROM users
+WHERE id = $1 LIMIT 1;
+
 -- name: ListUsers :many
 SELECT * FROM users
 ORDER BY username
@@ -16,8 +20,56 @@ LIMIT $1 OFFSET $2;
 SELECT count(*) FROM users;
 
 -- name: GetPermissionsForUser :many
-SELECT DISTINCT p.name
+SELECT p.name
 FROM permissions p
-JOIN role_permissions rp ON p.id = rp.permission_id
-JOIN user_roles ur ON rp.role_id = ur.role_id
-WHERE ur.user_id = $1;
\ No newline at end of file
+JOIN user_effective_permissions e ON e.permission_id = p.id
+WHERE e.user_id = $1;
+
+-- name: CountSystemAdmins :one
+-- CountSystemAdmins counts the users holding every system permission without
+-- an end date: each one must come from an active role assignment without
+-- valid_until or from an allow override. Assignments about to lapse do not
+-- keep the system administrable.
+SELECT count(*) FROM users u
+WHERE NOT EXISTS (
+  SELECT 1 FROM permissions p
+  WHERE p.is_system
+  AND NOT (
+    EXISTS (
+      SELECT 1 FROM user_effective_permissions e
+      WHERE e.user_id = u.id AND e.permission_id = p.id
+    )
+    AND (
+      EXISTS (
+        SELECT 1 FROM user_roles ur
+        JOIN role_permissions rp ON rp.role_id = ur.role_id
+        WHERE ur.user_id = u.id AND rp.permission_id = p.id
+        AND (ur.valid_from IS NULL OR ur.valid_from <= now())
+        AND ur.valid_until IS NULL
+      )
+      OR EXISTS (
+        SELECT 1 FROM user_permission_overrides o
+        WHERE o.user_id = u.id AND o.permission_id = p.id AND o.effect = 'allow'
+      )
+    )
+  )
+);
+
+-- name: ListSystemAdmins :many
+SELECT u.* FROM users u
+WHERE NOT EXISTS (
+  SELECT 1 FROM permissions p
+  WHERE p.is_system
+  AND NOT EXISTS (
+    SELECT 1 FROM user_effective_permissions e
+    WHERE e.user_id = u.id AND e.permission_id = p.id
+  )
+)
+ORDER BY u.username;
+
+-- name: ListUsersWithPermission :many
+SELECT u.* FROM users u
+JOIN user_effective_permissions e ON e.user_id = u.id
+JOIN permissions p ON p.id = e.permission_id
+WHERE p.name = $1
+ORDER BY u.username;
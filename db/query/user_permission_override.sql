@@ -0,0 +1,47 @@
+-- name: UpsertUserPermissionOverride :one
+INSERT INTO user_permission_overrides (
+  user_id,
+  permission_id,
+  effect,
+  reason
+) VALUES (
+  $1, $2, $3, $4
+)
+ON CONFLICT (user_id, permission_id) DO UPDATE
+SET effect = EXCLUDED.effect,
+    reason = EXCLUDED.reason
+RETURNING *;
+
+-- name: GetUserPermissionOverride :one
+SELECT * FROM user_permission_overrides
+WHERE user_id = $1 AND permission_id = $2
+LIMIT 1;
+
+-- name: DeleteUserPermissionOverride :exec
+DELETE FROM user_permission_overrides
+WHERE user_id = $1 AND permission_id = $2;
+
+-- name: ListUserPermissionOverrides :many
+SELECT
+  o.user_id,
+  o.permission_id,
+  p.name AS permission_name,
+  o.effect,
+  o.reason,
+  o.created_at,
+  o.updated_at
+FROM user_permission_overrides o
+JOIN permissions p ON p.id = o.permission_id
+WHERE o.user_id = $1
+ORDER BY p.name;
+
+-- name: ListAllUserPermissionOverrides :many
+SELECT
+  o.user_id,
+  u.username,
+  o.permission_id,
+  o.effect,
+  o.reason
+FROM user_permission_overrides o
+JOIN users u ON u.id = o.user_id
+ORDER BY u.username, o.permission_id;
@@ -1,14 +1,18 @@
 -- name: GetRolesForUser :many
 SELECT roles.* FROM roles
 JOIN user_roles ON roles.id = user_roles.role_id
-WHERE user_roles.user_id = $1;
+WHERE user_roles.user_id = $1
+AND (user_roles.valid_from IS NULL OR user_roles.valid_from <= now())
+AND (user_roles.valid_until IS NULL OR user_roles.valid_until > now());
 
 -- name: AddRoleForUser :one
 INSERT INTO user_roles (
   user_id,
-  role_id
+  role_id,
+  valid_from,
+  valid_until
 ) VALUES (
-  $1, $2
+  $1, $2, $3, $4
 ) RETURNING *;
 
 -- name: RemoveRoleForUser :exec
@@ -23,3 +27,89 @@ OFFSET $2;
 
 -- name: CountUserRoles :one
 SELECT count(*) FROM user_roles;
+
+-- name: ListUserRoleGrants :many
+SELECT
+  r.id AS role_id,
+  r.name AS role_name,
+  ur.valid_from,
+  ur.valid_until,
+  ((ur.valid_from IS NULL OR ur.valid_from <= now())
+    AND (ur.valid_until IS NULL OR ur.valid_until > now()))::boolean AS active,
+  p.id AS permission_id,
+  p.name AS permission_name
+FROM user_roles ur
+JOIN roles r ON r.id = ur.role_id
+LEFT JOIN role_permissions rp ON rp.role_id = ur.role_id
+LEFT JOIN permissions p ON p.id = rp.permission_id
+WHERE ur.user_id = $1
+ORDER BY r.id, p.id;
+
+-- name: ListExpiringUserRoles :many
+SELECT
+  ur.user_id,
+  ur.role_id,
+  ur.valid_until,
+  u.username,
+  u.full_name,
+  r.name AS role_name
+FROM user_roles ur
+JOIN users u ON u.id = ur.user_id
+JOIN roles r ON r.id = ur.role_id
+WHERE ur.valid_until > now()
+AND ur.valid_until <= sqlc.arg(notify_before)
+AND ur.expiry_notified_at IS NULL
+ORDER BY ur.valid_until;
+
+-- name: MarkUserRoleExpiryNotified :exec
+UPDATE user_roles
+SET expiry_notified_at = now()
+WHERE user_id = $1 AND role_id = $2;
+
+-- name: DeleteExpiredUserRoles :execrows
+DELETE FROM user_roles
+WHERE valid_until <= now();
+
+-- name: ListUsersForRole :many
+SELECT
+  u.id,
+  u.username,
+  u.full_name,
+  u.email,
+  u.phone,
+  ur.valid_from,
+  ur.valid_until,
+  ((ur.valid_from IS NULL OR ur.valid_from <= now())
+    AND (ur.valid_until IS NULL OR ur.valid_until > now()))::boolean AS active,
+  ur.created_at AS assigned_at
+FROM user_roles ur
+JOIN users u ON u.id = ur.user_id
+WHERE ur.role_id = $1
+ORDER BY u.id
+LIMIT $2
+OFFSET $3;
+
+-- name: CountUsersForRole :one
+SELECT count(*) FROM user_roles
+WHERE role_id = $1;
+
+-- name: ListAllUserRoles :many
+SELECT
+  ur.user_id,
+  u.username,
+  ur.role_id,
+  ur.valid_from,
+  ur.valid_until
+FROM user_roles ur
+JOIN users u ON u.id = ur.user_id
+ORDER BY u.username, ur.role_id;
+
+-- name: UpdateUserRoleValidity :one
+UPDATE user_roles
+SET
+  valid_from = $3,
+  valid_until = $4,
+  expiry_notified_at = NULL,
+  updated_at = now()
+WHERE user_id = $1 AND role_id = $2
+RETURNING *;
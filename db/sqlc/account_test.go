@@ -92,7 +92,7 @@ func TestListAccount(t *testing.T) {
 	}
 
 	arg := ListAccountsParams{
-		Owner:  lastAccount.Owner,
+		Owner:  sql.NullString{String: lastAccount.Owner, Valid: true},
 		Limit:  5,
 		Offset: 0,
 	}
@@ -105,4 +105,11 @@ func TestListAccount(t *testing.T) {
 		require.NotEmpty(t, account)
 		require.Equal(t, lastAccount.Owner, account.Owner)
 	}
+
+	// without an owner every account is listed
+	arg.Owner = sql.NullString{}
+	arg.Limit = 10
+	accounts, err = testQueries.ListAccounts(context.Background(), arg)
+	require.NoError(t, err)
+	require.Len(t, accounts, 10)
 }
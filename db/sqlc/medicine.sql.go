@@ -8,14 +8,130 @@ package db
 import (
 	"context"
 	"database/sql"
+
+	"github.com/lib/pq"
+	"github.com/toannguyen3105/nht-bsihuyen.com-api/utils"
 )
 
+const addMedicineStock = `-- name: AddMedicineStock :one
+UPDATE medicines
+SET
+  stock = stock + $1,
+  updated_at = now()
+WHERE id = $2
+RETURNING id, name, unit, price, stock, description, created_at, updated_at, reorder_level, currency, sku, deleted_at, dosage_form, manufacturer, controlled
+`
+
+type AddMedicineStockParams struct {
+	Amount int32 `json:"amount"`
+	ID     int32 `json:"id"`
+}
+
+func (q *Queries) AddMedicineStock(ctx context.Context, arg AddMedicineStockParams) (Medicine, error) {
+	row := q.db.QueryRowContext(ctx, addMedicineStock, arg.Amount, arg.ID)
+	var i Medicine
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Unit,
+		&i.Price,
+		&i.Stock,
+		&i.Description,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.ReorderLevel,
+		&i.Currency,
+		&i.Sku,
+		&i.DeletedAt,
+		&i.DosageForm,
+		&i.Manufacturer,
+		&i.Controlled,
+	)
+	return i, err
+}
+
+const archiveMedicine = `-- name: ArchiveMedicine :one
+UPDATE medicines
+SET
+  deleted_at = now(),
+  updated_at = now()
+WHERE id = $1
+RETURNING id, name, unit, price, stock, description, created_at, updated_at, reorder_level, currency, sku, deleted_at, dosage_form, manufacturer, controlled
+`
+
+func (q *Queries) ArchiveMedicine(ctx context.Context, id int32) (Medicine, error) {
+	row := q.db.QueryRowContext(ctx, archiveMedicine, id)
+	var i Medicine
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Unit,
+		&i.Price,
+		&i.Stock,
+		&i.Description,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.ReorderLevel,
+		&i.Currency,
+		&i.Sku,
+		&i.DeletedAt,
+		&i.DosageForm,
+		&i.Manufacturer,
+		&i.Controlled,
+	)
+	return i, err
+}
+
 const countMedicines = `-- name: CountMedicines :one
 SELECT count(*) FROM medicines
+WHERE
+  ($1::text IS NULL OR unaccent(name) ILIKE '%' || replace(replace(replace(unaccent($1::text), '\', '\\'), '%', '\%'), '_', '\_') || '%' ESCAPE '\')
+  AND ($2::text IS NULL OR unit = $2::text)
+  AND ($3::numeric IS NULL OR price >= $3::numeric)
+  AND ($4::numeric IS NULL OR price <= $4::numeric)
+  AND (NOT $5::boolean OR stock <= reorder_level)
+  AND ($6::boolean OR deleted_at IS NULL)
+  AND ($7::text IS NULL OR dosage_form = $7::text)
+  AND ($8::text IS NULL OR unaccent(manufacturer) ILIKE '%' || replace(replace(replace(unaccent($8::text), '\', '\\'), '%', '\%'), '_', '\_') || '%' ESCAPE '\')
+  AND ($9::int IS NULL OR EXISTS (
+    SELECT 1 FROM medicine_categories
+    WHERE medicine_id = medicines.id AND category_id = $9::int
+  ))
+  AND ($10::int IS NULL OR EXISTS (
+    SELECT 1 FROM medicine_ingredients
+    WHERE medicine_id = medicines.id AND ingredient_id = $10::int
+  ))
+  AND ($11::text IS NULL OR currency = $11::text)
 `
 
-func (q *Queries) CountMedicines(ctx context.Context) (int64, error) {
-	row := q.db.QueryRowContext(ctx, countMedicines)
+type CountMedicinesParams struct {
+	Search          sql.NullString    `json:"search"`
+	Unit            sql.NullString    `json:"unit"`
+	MinPrice        utils.NullDecimal `json:"min_price"`
+	MaxPrice        utils.NullDecimal `json:"max_price"`
+	LowStock        bool              `json:"low_stock"`
+	IncludeArchived bool              `json:"include_archived"`
+	DosageForm      sql.NullString    `json:"dosage_form"`
+	Manufacturer    sql.NullString    `json:"manufacturer"`
+	CategoryID      sql.NullInt32     `json:"category_id"`
+	IngredientID    sql.NullInt32     `json:"ingredient_id"`
+	Currency        sql.NullString    `json:"currency"`
+}
+
+func (q *Queries) CountMedicines(ctx context.Context, arg CountMedicinesParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countMedicines,
+		arg.Search,
+		arg.Unit,
+		arg.MinPrice,
+		arg.MaxPrice,
+		arg.LowStock,
+		arg.IncludeArchived,
+		arg.DosageForm,
+		arg.Manufacturer,
+		arg.CategoryID,
+		arg.IngredientID,
+		arg.Currency,
+	)
 	var count int64
 	err := row.Scan(&count)
 	return count, err
@@ -26,19 +142,27 @@ INSERT INTO medicines (
   name,
   unit,
   price,
-  stock,
-  description
+  description,
+  reorder_level,
+  currency,
+  sku,
+  dosage_form,
+  manufacturer
 ) VALUES (
-  $1, $2, $3, $4, $5
-) RETURNING id, name, unit, price, stock, description, created_at, updated_at
+  $1, $2, $3, $4, $5, $6, $7, $8, $9
+) RETURNING id, name, unit, price, stock, description, created_at, updated_at, reorder_level, currency, sku, deleted_at, dosage_form, manufacturer, controlled
 `
 
 type CreateMedicineParams struct {
-	Name        string         `json:"name"`
-	Unit        interface{}    `json:"unit"`
-	Price       string         `json:"price"`
-	Stock       int32          `json:"stock"`
-	Description sql.NullString `json:"description"`
+	Name         string         `json:"name"`
+	Unit         string         `json:"unit"`
+	Price        utils.Decimal  `json:"price"`
+	Description  sql.NullString `json:"description"`
+	ReorderLevel int32          `json:"reorder_level"`
+	Currency     string         `json:"currency"`
+	Sku          sql.NullString `json:"sku"`
+	DosageForm   sql.NullString `json:"dosage_form"`
+	Manufacturer sql.NullString `json:"manufacturer"`
 }
 
 func (q *Queries) CreateMedicine(ctx context.Context, arg CreateMedicineParams) (Medicine, error) {
@@ -46,8 +170,12 @@ func (q *Queries) CreateMedicine(ctx context.Context, arg CreateMedicineParams)
 		arg.Name,
 		arg.Unit,
 		arg.Price,
-		arg.Stock,
 		arg.Description,
+		arg.ReorderLevel,
+		arg.Currency,
+		arg.Sku,
+		arg.DosageForm,
+		arg.Manufacturer,
 	)
 	var i Medicine
 	err := row.Scan(
@@ -59,6 +187,13 @@ func (q *Queries) CreateMedicine(ctx context.Context, arg CreateMedicineParams)
 		&i.Description,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.ReorderLevel,
+		&i.Currency,
+		&i.Sku,
+		&i.DeletedAt,
+		&i.DosageForm,
+		&i.Manufacturer,
+		&i.Controlled,
 	)
 	return i, err
 }
@@ -74,7 +209,7 @@ func (q *Queries) DeleteMedicine(ctx context.Context, id int32) error {
 }
 
 const getMedicine = `-- name: GetMedicine :one
-SELECT id, name, unit, price, stock, description, created_at, updated_at FROM medicines
+SELECT id, name, unit, price, stock, description, created_at, updated_at, reorder_level, currency, sku, deleted_at, dosage_form, manufacturer, controlled FROM medicines
 WHERE id = $1 LIMIT 1
 `
 
@@ -90,24 +225,400 @@ func (q *Queries) GetMedicine(ctx context.Context, id int32) (Medicine, error) {
 		&i.Description,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.ReorderLevel,
+		&i.Currency,
+		&i.Sku,
+		&i.DeletedAt,
+		&i.DosageForm,
+		&i.Manufacturer,
+		&i.Controlled,
 	)
 	return i, err
 }
 
-const listMedicines = `-- name: ListMedicines :many
-SELECT id, name, unit, price, stock, description, created_at, updated_at FROM medicines
+const getMedicineByBarcode = `-- name: GetMedicineByBarcode :one
+SELECT id, name, unit, price, stock, description, created_at, updated_at, reorder_level, currency, sku, deleted_at, dosage_form, manufacturer, controlled FROM medicines
+WHERE id = (
+  SELECT medicine_id FROM medicine_barcodes
+  WHERE barcode = $1
+) LIMIT 1
+`
+
+func (q *Queries) GetMedicineByBarcode(ctx context.Context, barcode string) (Medicine, error) {
+	row := q.db.QueryRowContext(ctx, getMedicineByBarcode, barcode)
+	var i Medicine
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Unit,
+		&i.Price,
+		&i.Stock,
+		&i.Description,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.ReorderLevel,
+		&i.Currency,
+		&i.Sku,
+		&i.DeletedAt,
+		&i.DosageForm,
+		&i.Manufacturer,
+		&i.Controlled,
+	)
+	return i, err
+}
+
+const getMedicineForUpdate = `-- name: GetMedicineForUpdate :one
+SELECT id, name, unit, price, stock, description, created_at, updated_at, reorder_level, currency, sku, deleted_at, dosage_form, manufacturer, controlled FROM medicines
+WHERE id = $1 LIMIT 1
+FOR NO KEY UPDATE
+`
+
+func (q *Queries) GetMedicineForUpdate(ctx context.Context, id int32) (Medicine, error) {
+	row := q.db.QueryRowContext(ctx, getMedicineForUpdate, id)
+	var i Medicine
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Unit,
+		&i.Price,
+		&i.Stock,
+		&i.Description,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.ReorderLevel,
+		&i.Currency,
+		&i.Sku,
+		&i.DeletedAt,
+		&i.DosageForm,
+		&i.Manufacturer,
+		&i.Controlled,
+	)
+	return i, err
+}
+
+const listAllMedicines = `-- name: ListAllMedicines :many
+SELECT id, name, unit, price, stock, description, created_at, updated_at, reorder_level, currency, sku, deleted_at, dosage_form, manufacturer, controlled FROM medicines
+WHERE deleted_at IS NULL
 ORDER BY id
-LIMIT $1
-OFFSET $2
+`
+
+func (q *Queries) ListAllMedicines(ctx context.Context) ([]Medicine, error) {
+	rows, err := q.db.QueryContext(ctx, listAllMedicines)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Medicine{}
+	for rows.Next() {
+		var i Medicine
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Unit,
+			&i.Price,
+			&i.Stock,
+			&i.Description,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.ReorderLevel,
+			&i.Currency,
+			&i.Sku,
+			&i.DeletedAt,
+			&i.DosageForm,
+			&i.Manufacturer,
+			&i.Controlled,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listLowStockMedicines = `-- name: ListLowStockMedicines :many
+SELECT id, name, unit, price, stock, description, created_at, updated_at, reorder_level, currency, sku, deleted_at, dosage_form, manufacturer, controlled FROM medicines
+WHERE stock <= reorder_level AND deleted_at IS NULL
+ORDER BY id
+`
+
+func (q *Queries) ListLowStockMedicines(ctx context.Context) ([]Medicine, error) {
+	rows, err := q.db.QueryContext(ctx, listLowStockMedicines)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Medicine{}
+	for rows.Next() {
+		var i Medicine
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Unit,
+			&i.Price,
+			&i.Stock,
+			&i.Description,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.ReorderLevel,
+			&i.Currency,
+			&i.Sku,
+			&i.DeletedAt,
+			&i.DosageForm,
+			&i.Manufacturer,
+			&i.Controlled,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listMedicines = `-- name: ListMedicines :many
+SELECT id, name, unit, price, stock, description, created_at, updated_at, reorder_level, currency, sku, deleted_at, dosage_form, manufacturer, controlled FROM medicines
+WHERE
+  ($1::text IS NULL OR unaccent(name) ILIKE '%' || replace(replace(replace(unaccent($1::text), '\', '\\'), '%', '\%'), '_', '\_') || '%' ESCAPE '\')
+  AND ($2::text IS NULL OR unit = $2::text)
+  AND ($3::numeric IS NULL OR price >= $3::numeric)
+  AND ($4::numeric IS NULL OR price <= $4::numeric)
+  AND (NOT $5::boolean OR stock <= reorder_level)
+  AND ($6::boolean OR deleted_at IS NULL)
+  AND ($7::text IS NULL OR dosage_form = $7::text)
+  AND ($8::text IS NULL OR unaccent(manufacturer) ILIKE '%' || replace(replace(replace(unaccent($8::text), '\', '\\'), '%', '\%'), '_', '\_') || '%' ESCAPE '\')
+  AND ($9::int IS NULL OR EXISTS (
+    SELECT 1 FROM medicine_categories
+    WHERE medicine_id = medicines.id AND category_id = $9::int
+  ))
+  AND ($10::int IS NULL OR EXISTS (
+    SELECT 1 FROM medicine_ingredients
+    WHERE medicine_id = medicines.id AND ingredient_id = $10::int
+  ))
+  AND ($11::text IS NULL OR currency = $11::text)
+ORDER BY
+  CASE WHEN $12::text = 'name' AND NOT $13::boolean THEN name END ASC,
+  CASE WHEN $12::text = 'name' AND $13::boolean THEN name END DESC,
+  CASE WHEN $12::text = 'price' AND NOT $13::boolean THEN price END ASC,
+  CASE WHEN $12::text = 'price' AND $13::boolean THEN price END DESC,
+  CASE WHEN $12::text = 'stock' AND NOT $13::boolean THEN stock END ASC,
+  CASE WHEN $12::text = 'stock' AND $13::boolean THEN stock END DESC,
+  CASE WHEN $12::text = 'updated_at' AND NOT $13::boolean THEN updated_at END ASC,
+  CASE WHEN $12::text = 'updated_at' AND $13::boolean THEN updated_at END DESC,
+  id
+LIMIT $14
+OFFSET $15
 `
 
 type ListMedicinesParams struct {
-	Limit  int32 `json:"limit"`
-	Offset int32 `json:"offset"`
+	Search          sql.NullString    `json:"search"`
+	Unit            sql.NullString    `json:"unit"`
+	MinPrice        utils.NullDecimal `json:"min_price"`
+	MaxPrice        utils.NullDecimal `json:"max_price"`
+	LowStock        bool              `json:"low_stock"`
+	IncludeArchived bool              `json:"include_archived"`
+	DosageForm      sql.NullString    `json:"dosage_form"`
+	Manufacturer    sql.NullString    `json:"manufacturer"`
+	CategoryID      sql.NullInt32     `json:"category_id"`
+	IngredientID    sql.NullInt32     `json:"ingredient_id"`
+	Currency        sql.NullString    `json:"currency"`
+	SortBy          string            `json:"sort_by"`
+	SortDesc        bool              `json:"sort_desc"`
+	Limit           int32             `json:"limit"`
+	Offset          int32             `json:"offset"`
 }
 
 func (q *Queries) ListMedicines(ctx context.Context, arg ListMedicinesParams) ([]Medicine, error) {
-	rows, err := q.db.QueryContext(ctx, listMedicines, arg.Limit, arg.Offset)
+	rows, err := q.db.QueryContext(ctx, listMedicines,
+		arg.Search,
+		arg.Unit,
+		arg.MinPrice,
+		arg.MaxPrice,
+		arg.LowStock,
+		arg.IncludeArchived,
+		arg.DosageForm,
+		arg.Manufacturer,
+		arg.CategoryID,
+		arg.IngredientID,
+		arg.Currency,
+		arg.SortBy,
+		arg.SortDesc,
+		arg.Limit,
+		arg.Offset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Medicine{}
+	for rows.Next() {
+		var i Medicine
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Unit,
+			&i.Price,
+			&i.Stock,
+			&i.Description,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.ReorderLevel,
+			&i.Currency,
+			&i.Sku,
+			&i.DeletedAt,
+			&i.DosageForm,
+			&i.Manufacturer,
+			&i.Controlled,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listMedicinesByIDs = `-- name: ListMedicinesByIDs :many
+SELECT id, name, unit, price, stock, description, created_at, updated_at, reorder_level, currency, sku, deleted_at, dosage_form, manufacturer, controlled FROM medicines
+WHERE id = ANY($1::int[])
+ORDER BY id
+`
+
+func (q *Queries) ListMedicinesByIDs(ctx context.Context, ids []int32) ([]Medicine, error) {
+	rows, err := q.db.QueryContext(ctx, listMedicinesByIDs, pq.Array(ids))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Medicine{}
+	for rows.Next() {
+		var i Medicine
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Unit,
+			&i.Price,
+			&i.Stock,
+			&i.Description,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.ReorderLevel,
+			&i.Currency,
+			&i.Sku,
+			&i.DeletedAt,
+			&i.DosageForm,
+			&i.Manufacturer,
+			&i.Controlled,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listMedicinesForImport = `-- name: ListMedicinesForImport :many
+SELECT id, name, unit, price, stock, description, created_at, updated_at, reorder_level, currency, sku, deleted_at, dosage_form, manufacturer, controlled FROM medicines
+WHERE sku = ANY($1::text[])
+  OR (name = ANY($2::text[]) AND deleted_at IS NULL)
+ORDER BY id
+FOR NO KEY UPDATE
+`
+
+type ListMedicinesForImportParams struct {
+	Skus  []string `json:"skus"`
+	Names []string `json:"names"`
+}
+
+func (q *Queries) ListMedicinesForImport(ctx context.Context, arg ListMedicinesForImportParams) ([]Medicine, error) {
+	rows, err := q.db.QueryContext(ctx, listMedicinesForImport, pq.Array(arg.Skus), pq.Array(arg.Names))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Medicine{}
+	for rows.Next() {
+		var i Medicine
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Unit,
+			&i.Price,
+			&i.Stock,
+			&i.Description,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.ReorderLevel,
+			&i.Currency,
+			&i.Sku,
+			&i.DeletedAt,
+			&i.DosageForm,
+			&i.Manufacturer,
+			&i.Controlled,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listMedicinesForUpdate = `-- name: ListMedicinesForUpdate :many
+SELECT id, name, unit, price, stock, description, created_at, updated_at, reorder_level, currency, sku, deleted_at, dosage_form, manufacturer, controlled FROM medicines
+WHERE
+  ($1::int[] IS NULL OR id = ANY($1::int[]))
+  AND ($2::text IS NULL OR unaccent(name) ILIKE '%' || replace(replace(replace(unaccent($2::text), '\', '\\'), '%', '\%'), '_', '\_') || '%' ESCAPE '\')
+  AND ($3::text IS NULL OR unit = $3::text)
+  AND ($4::int IS NULL OR EXISTS (
+    SELECT 1 FROM medicine_categories
+    WHERE medicine_id = medicines.id AND category_id = $4::int
+  ))
+  AND deleted_at IS NULL
+ORDER BY id
+FOR NO KEY UPDATE
+`
+
+type ListMedicinesForUpdateParams struct {
+	Ids        []int32        `json:"ids"`
+	Search     sql.NullString `json:"search"`
+	Unit       sql.NullString `json:"unit"`
+	CategoryID sql.NullInt32  `json:"category_id"`
+}
+
+func (q *Queries) ListMedicinesForUpdate(ctx context.Context, arg ListMedicinesForUpdateParams) ([]Medicine, error) {
+	rows, err := q.db.QueryContext(ctx, listMedicinesForUpdate,
+		pq.Array(arg.Ids),
+		arg.Search,
+		arg.Unit,
+		arg.CategoryID,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -124,6 +635,13 @@ func (q *Queries) ListMedicines(ctx context.Context, arg ListMedicinesParams) ([
 			&i.Description,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.ReorderLevel,
+			&i.Currency,
+			&i.Sku,
+			&i.DeletedAt,
+			&i.DosageForm,
+			&i.Manufacturer,
+			&i.Controlled,
 		); err != nil {
 			return nil, err
 		}
@@ -138,26 +656,122 @@ func (q *Queries) ListMedicines(ctx context.Context, arg ListMedicinesParams) ([
 	return items, nil
 }
 
+const medicineUnitInUse = `-- name: MedicineUnitInUse :one
+SELECT (
+  stock <> 0
+  OR EXISTS (SELECT 1 FROM medicine_batches WHERE medicine_id = medicines.id)
+  OR EXISTS (SELECT 1 FROM medicine_unit_conversions WHERE medicine_id = medicines.id)
+)::boolean AS in_use
+FROM medicines
+WHERE id = $1
+`
+
+// Whether quantities have been recorded in the unit of the medicine: it has
+// stock, lots, or conversions from other units.
+func (q *Queries) MedicineUnitInUse(ctx context.Context, id int32) (bool, error) {
+	row := q.db.QueryRowContext(ctx, medicineUnitInUse, id)
+	var in_use bool
+	err := row.Scan(&in_use)
+	return in_use, err
+}
+
+const restoreMedicine = `-- name: RestoreMedicine :one
+UPDATE medicines
+SET
+  deleted_at = NULL,
+  updated_at = now()
+WHERE id = $1
+RETURNING id, name, unit, price, stock, description, created_at, updated_at, reorder_level, currency, sku, deleted_at, dosage_form, manufacturer, controlled
+`
+
+func (q *Queries) RestoreMedicine(ctx context.Context, id int32) (Medicine, error) {
+	row := q.db.QueryRowContext(ctx, restoreMedicine, id)
+	var i Medicine
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Unit,
+		&i.Price,
+		&i.Stock,
+		&i.Description,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.ReorderLevel,
+		&i.Currency,
+		&i.Sku,
+		&i.DeletedAt,
+		&i.DosageForm,
+		&i.Manufacturer,
+		&i.Controlled,
+	)
+	return i, err
+}
+
+const setMedicineControlled = `-- name: SetMedicineControlled :one
+UPDATE medicines
+SET
+  controlled = $1,
+  updated_at = now()
+WHERE id = $2
+RETURNING id, name, unit, price, stock, description, created_at, updated_at, reorder_level, currency, sku, deleted_at, dosage_form, manufacturer, controlled
+`
+
+type SetMedicineControlledParams struct {
+	Controlled bool  `json:"controlled"`
+	ID         int32 `json:"id"`
+}
+
+func (q *Queries) SetMedicineControlled(ctx context.Context, arg SetMedicineControlledParams) (Medicine, error) {
+	row := q.db.QueryRowContext(ctx, setMedicineControlled, arg.Controlled, arg.ID)
+	var i Medicine
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Unit,
+		&i.Price,
+		&i.Stock,
+		&i.Description,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.ReorderLevel,
+		&i.Currency,
+		&i.Sku,
+		&i.DeletedAt,
+		&i.DosageForm,
+		&i.Manufacturer,
+		&i.Controlled,
+	)
+	return i, err
+}
+
 const updateMedicine = `-- name: UpdateMedicine :one
 UPDATE medicines
 SET
   name = COALESCE($1, name),
   unit = COALESCE($2, unit),
   price = COALESCE($3, price),
-  stock = COALESCE($4, stock),
-  description = COALESCE($5, description),
+  description = COALESCE($4, description),
+  reorder_level = COALESCE($5, reorder_level),
+  currency = COALESCE($6, currency),
+  sku = COALESCE($7, sku),
+  dosage_form = COALESCE($8, dosage_form),
+  manufacturer = COALESCE($9, manufacturer),
   updated_at = now()
-WHERE id = $6
-RETURNING id, name, unit, price, stock, description, created_at, updated_at
+WHERE id = $10
+RETURNING id, name, unit, price, stock, description, created_at, updated_at, reorder_level, currency, sku, deleted_at, dosage_form, manufacturer, controlled
 `
 
 type UpdateMedicineParams struct {
-	Name        sql.NullString `json:"name"`
-	Unit        interface{}    `json:"unit"`
-	Price       sql.NullString `json:"price"`
-	Stock       sql.NullInt32  `json:"stock"`
-	Description sql.NullString `json:"description"`
-	ID          int32          `json:"id"`
+	Name         sql.NullString    `json:"name"`
+	Unit         sql.NullString    `json:"unit"`
+	Price        utils.NullDecimal `json:"price"`
+	Description  sql.NullString    `json:"description"`
+	ReorderLevel sql.NullInt32     `json:"reorder_level"`
+	Currency     sql.NullString    `json:"currency"`
+	Sku          sql.NullString    `json:"sku"`
+	DosageForm   sql.NullString    `json:"dosage_form"`
+	Manufacturer sql.NullString    `json:"manufacturer"`
+	ID           int32             `json:"id"`
 }
 
 func (q *Queries) UpdateMedicine(ctx context.Context, arg UpdateMedicineParams) (Medicine, error) {
@@ -165,8 +779,12 @@ func (q *Queries) UpdateMedicine(ctx context.Context, arg UpdateMedicineParams)
 		arg.Name,
 		arg.Unit,
 		arg.Price,
-		arg.Stock,
 		arg.Description,
+		arg.ReorderLevel,
+		arg.Currency,
+		arg.Sku,
+		arg.DosageForm,
+		arg.Manufacturer,
 		arg.ID,
 	)
 	var i Medicine
@@ -179,6 +797,52 @@ func (q *Queries) UpdateMedicine(ctx context.Context, arg UpdateMedicineParams)
 		&i.Description,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.ReorderLevel,
+		&i.Currency,
+		&i.Sku,
+		&i.DeletedAt,
+		&i.DosageForm,
+		&i.Manufacturer,
+		&i.Controlled,
+	)
+	return i, err
+}
+
+const updateMedicinePrice = `-- name: UpdateMedicinePrice :one
+UPDATE medicines
+SET
+  price = $1,
+  currency = $2,
+  updated_at = now()
+WHERE id = $3
+RETURNING id, name, unit, price, stock, description, created_at, updated_at, reorder_level, currency, sku, deleted_at, dosage_form, manufacturer, controlled
+`
+
+type UpdateMedicinePriceParams struct {
+	Price    utils.Decimal `json:"price"`
+	Currency string        `json:"currency"`
+	ID       int32         `json:"id"`
+}
+
+func (q *Queries) UpdateMedicinePrice(ctx context.Context, arg UpdateMedicinePriceParams) (Medicine, error) {
+	row := q.db.QueryRowContext(ctx, updateMedicinePrice, arg.Price, arg.Currency, arg.ID)
+	var i Medicine
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Unit,
+		&i.Price,
+		&i.Stock,
+		&i.Description,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.ReorderLevel,
+		&i.Currency,
+		&i.Sku,
+		&i.DeletedAt,
+		&i.DosageForm,
+		&i.Manufacturer,
+		&i.Controlled,
 	)
 	return i, err
 }
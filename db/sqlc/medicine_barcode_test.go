@@ -0,0 +1,47 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"testing"
+
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+	"github.com/toannguyen3105/nht-bsihuyen.com-api/utils"
+)
+
+func TestMedicineBarcode(t *testing.T) {
+	medicine := createRandomMedicine(t, 0)
+	barcode := fmt.Sprintf("%013d", utils.RandomInt(0, 999999999999))
+
+	created, err := testQueries.CreateMedicineBarcode(context.Background(), CreateMedicineBarcodeParams{
+		Barcode:    barcode,
+		MedicineID: medicine.ID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, barcode, created.Barcode)
+
+	found, err := testQueries.GetMedicineByBarcode(context.Background(), barcode)
+	require.NoError(t, err)
+	require.Equal(t, medicine.ID, found.ID)
+
+	// a barcode belongs to one medicine only
+	other := createRandomMedicine(t, 0)
+	_, err = testQueries.CreateMedicineBarcode(context.Background(), CreateMedicineBarcodeParams{
+		Barcode:    barcode,
+		MedicineID: other.ID,
+	})
+	require.Error(t, err)
+	require.Equal(t, "unique_violation", err.(*pq.Error).Code.Name())
+
+	deleted, err := testQueries.DeleteMedicineBarcode(context.Background(), DeleteMedicineBarcodeParams{
+		MedicineID: medicine.ID,
+		Barcode:    barcode,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), deleted)
+
+	_, err = testQueries.GetMedicineByBarcode(context.Background(), barcode)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
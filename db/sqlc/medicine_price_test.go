@@ -0,0 +1,85 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/toannguyen3105/nht-bsihuyen.com-api/utils"
+)
+
+func TestChangeMedicinePriceTx(t *testing.T) {
+	store := NewStore(testDB)
+	medicine := createRandomMedicine(t, 0)
+
+	result, err := store.ChangeMedicinePriceTx(context.Background(), ChangeMedicinePriceTxParams{
+		MedicineID: medicine.ID,
+		Price:      utils.NewMoney(utils.MustParseDecimal("150"), ""),
+		Reason:     "Supplier price increase",
+	})
+	require.NoError(t, err)
+	require.Equal(t, utils.MustParseDecimal("150"), result.Medicine.Price)
+	require.Equal(t, medicine.Currency, result.Price.Currency)
+
+	// a scheduled price leaves the current one alone until it is due
+	effectiveFrom := time.Now().Add(time.Hour)
+	result, err = store.ChangeMedicinePriceTx(context.Background(), ChangeMedicinePriceTxParams{
+		MedicineID:    medicine.ID,
+		Price:         utils.NewMoney(utils.MustParseDecimal("200"), utils.VND),
+		EffectiveFrom: effectiveFrom,
+		Reason:        "New price list",
+	})
+	require.NoError(t, err)
+	require.Equal(t, utils.MustParseDecimal("150"), result.Medicine.Price)
+
+	price, err := store.GetMedicinePriceAt(context.Background(), GetMedicinePriceAtParams{
+		MedicineID: medicine.ID,
+		At:         effectiveFrom.Add(time.Minute),
+	})
+	require.NoError(t, err)
+	require.Equal(t, utils.MustParseDecimal("200"), price.Price)
+
+	count, err := store.CountMedicinePrices(context.Background(), medicine.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(3), count)
+
+	_, err = store.ChangeMedicinePriceTx(context.Background(), ChangeMedicinePriceTxParams{
+		MedicineID:    medicine.ID,
+		Price:         utils.NewMoney(utils.MustParseDecimal("100"), utils.VND),
+		EffectiveFrom: time.Now().Add(-time.Hour),
+		Reason:        "Backdated",
+	})
+	require.ErrorIs(t, err, ErrPastPriceChange)
+}
+
+func TestBulkAdjustPricesTx(t *testing.T) {
+	store := NewStore(testDB)
+	medicine1 := createRandomMedicine(t, 0)
+	medicine2 := createRandomMedicine(t, 0)
+
+	arg := BulkAdjustPricesTxParams{
+		Filter:  ListMedicinesForUpdateParams{Ids: []int32{medicine2.ID, medicine1.ID}},
+		Percent: utils.MustParseDecimal("10"),
+		Reason:  "Annual increase",
+		DryRun:  true,
+	}
+
+	result, err := store.BulkAdjustPricesTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Len(t, result.Adjustments, 2)
+	require.Equal(t, medicine1.ID, result.Adjustments[0].MedicineID)
+	require.Equal(t, utils.MustParseDecimal("110"), result.Adjustments[0].NewPrice.Amount)
+
+	medicine, err := store.GetMedicine(context.Background(), medicine1.ID)
+	require.NoError(t, err)
+	require.Equal(t, medicine1.Price, medicine.Price)
+
+	arg.DryRun = false
+	_, err = store.BulkAdjustPricesTx(context.Background(), arg)
+	require.NoError(t, err)
+
+	medicine, err = store.GetMedicine(context.Background(), medicine1.ID)
+	require.NoError(t, err)
+	require.Equal(t, utils.MustParseDecimal("110"), medicine.Price)
+}
@@ -0,0 +1,208 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/toannguyen3105/nht-bsihuyen.com-api/utils"
+)
+
+func createRandomMedicine(t *testing.T, stock int32) Medicine {
+	store := NewStore(testDB)
+
+	arg := CreateMedicineTxParams{
+		Medicine: CreateMedicineParams{
+			Name:        utils.RandomString(8),
+			Unit:        "tablet",
+			Price:       utils.MustParseDecimal("100.00"),
+			Currency:    utils.VND,
+			Description: sql.NullString{String: utils.RandomString(20), Valid: true},
+		},
+		Stock:      stock,
+		LotNumber:  utils.RandomString(10),
+		ExpiryDate: time.Now().AddDate(1, 0, 0),
+	}
+
+	medicine, err := store.CreateMedicineTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotEmpty(t, medicine)
+
+	require.Equal(t, arg.Medicine.Name, medicine.Name)
+	require.Equal(t, arg.Medicine.Price, medicine.Price)
+	require.Equal(t, stock, medicine.Stock)
+
+	require.NotZero(t, medicine.ID)
+	require.NotZero(t, medicine.CreatedAt)
+
+	return medicine
+}
+
+func TestCreateMedicineTx(t *testing.T) {
+	medicine := createRandomMedicine(t, 20)
+
+	movements, err := testQueries.ListStockMovements(context.Background(), ListStockMovementsParams{
+		MedicineID: medicine.ID,
+		Limit:      5,
+		Offset:     0,
+	})
+	require.NoError(t, err)
+	require.Len(t, movements, 1)
+	require.Equal(t, MovementReceipt, movements[0].MovementType)
+	require.Equal(t, int32(20), movements[0].Quantity)
+	require.Equal(t, int32(20), movements[0].Balance)
+
+	batches, err := testQueries.ListMedicineBatches(context.Background(), medicine.ID)
+	require.NoError(t, err)
+	require.Len(t, batches, 1)
+	require.Equal(t, int32(20), batches[0].RemainingQuantity)
+	require.Equal(t, batches[0].ID, movements[0].BatchID.Int64)
+}
+
+func TestArchiveMedicine(t *testing.T) {
+	store := NewStore(testDB)
+	medicine := createRandomMedicine(t, 10)
+
+	archived, err := store.ArchiveMedicine(context.Background(), medicine.ID)
+	require.NoError(t, err)
+	require.True(t, archived.DeletedAt.Valid)
+
+	arg := ListMedicinesParams{
+		Search: sql.NullString{String: medicine.Name, Valid: true},
+		Limit:  5,
+	}
+	medicines, err := store.ListMedicines(context.Background(), arg)
+	require.NoError(t, err)
+	require.Empty(t, medicines)
+
+	arg.IncludeArchived = true
+	medicines, err = store.ListMedicines(context.Background(), arg)
+	require.NoError(t, err)
+	require.Len(t, medicines, 1)
+
+	// archived medicines cannot be dispensed
+	_, err = store.StockMovementTx(context.Background(), StockMovementTxParams{
+		MedicineID:   medicine.ID,
+		MovementType: MovementDispense,
+		Quantity:     -1,
+	})
+	require.ErrorIs(t, err, ErrMedicineArchived)
+
+	// nor raise expiry alerts
+	expiring, err := store.ListExpiringMedicineBatches(context.Background(), sql.NullTime{Time: time.Now().AddDate(2, 0, 0), Valid: true})
+	require.NoError(t, err)
+	for _, batch := range expiring {
+		require.NotEqual(t, medicine.ID, batch.MedicineID)
+	}
+
+	restored, err := store.RestoreMedicine(context.Background(), medicine.ID)
+	require.NoError(t, err)
+	require.False(t, restored.DeletedAt.Valid)
+}
+
+func TestListMedicinesByCurrency(t *testing.T) {
+	prefix := utils.RandomString(8)
+	vnd := createRandomMedicine(t, 0)
+	_, err := testQueries.UpdateMedicine(context.Background(), UpdateMedicineParams{
+		ID:   vnd.ID,
+		Name: sql.NullString{String: prefix + "vnd", Valid: true},
+	})
+	require.NoError(t, err)
+
+	usd := createRandomMedicine(t, 0)
+	_, err = testQueries.UpdateMedicine(context.Background(), UpdateMedicineParams{
+		ID:       usd.ID,
+		Name:     sql.NullString{String: prefix + "usd", Valid: true},
+		Price:    utils.NullDecimal{Decimal: utils.MustParseDecimal("500.00"), Valid: true},
+		Currency: sql.NullString{String: utils.USD, Valid: true},
+	})
+	require.NoError(t, err)
+
+	// 500 USD is above the minimum too, but it is not a VND price
+	filter := CountMedicinesParams{
+		Search:   sql.NullString{String: prefix, Valid: true},
+		MinPrice: utils.NullDecimal{Decimal: utils.MustParseDecimal("50.00"), Valid: true},
+		Currency: sql.NullString{String: utils.VND, Valid: true},
+	}
+	count, err := testQueries.CountMedicines(context.Background(), filter)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), count)
+
+	medicines, err := testQueries.ListMedicines(context.Background(), ListMedicinesParams{
+		Search:   filter.Search,
+		MinPrice: filter.MinPrice,
+		Currency: filter.Currency,
+		SortBy:   "price",
+		Limit:    5,
+	})
+	require.NoError(t, err)
+	require.Len(t, medicines, 1)
+	require.Equal(t, vnd.ID, medicines[0].ID)
+}
+
+func TestListMedicinesSearchIsLiteral(t *testing.T) {
+	prefix := utils.RandomString(8)
+	names := []string{prefix + "%off", prefix + "xoff", prefix + `\off`}
+	for _, name := range names {
+		medicine := createRandomMedicine(t, 0)
+		_, err := testQueries.UpdateMedicine(context.Background(), UpdateMedicineParams{
+			ID:   medicine.ID,
+			Name: sql.NullString{String: name, Valid: true},
+		})
+		require.NoError(t, err)
+	}
+
+	// %, _ and \ in the search text match themselves, not any character
+	for _, search := range []string{prefix + "%off", prefix + `\off`} {
+		medicines, err := testQueries.ListMedicines(context.Background(), ListMedicinesParams{
+			Search: sql.NullString{String: search, Valid: true},
+			Limit:  5,
+		})
+		require.NoError(t, err)
+		require.Len(t, medicines, 1)
+		require.Equal(t, search, medicines[0].Name)
+	}
+
+	medicines, err := testQueries.ListMedicines(context.Background(), ListMedicinesParams{
+		Search: sql.NullString{String: prefix + "_off", Valid: true},
+		Limit:  5,
+	})
+	require.NoError(t, err)
+	require.Empty(t, medicines)
+}
+
+func TestUpdateMedicineTxUnit(t *testing.T) {
+	store := NewStore(testDB)
+
+	// a medicine with nothing counted in its unit may change it
+	medicine := createRandomMedicine(t, 0)
+	updated, err := store.UpdateMedicineTx(context.Background(), UpdateMedicineTxParams{
+		Medicine: UpdateMedicineParams{
+			ID:   medicine.ID,
+			Unit: sql.NullString{String: "box", Valid: true},
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "box", updated.Unit)
+
+	// once stock is recorded the unit is fixed
+	stocked := createRandomMedicine(t, 10)
+	_, err = store.UpdateMedicineTx(context.Background(), UpdateMedicineTxParams{
+		Medicine: UpdateMedicineParams{
+			ID:   stocked.ID,
+			Unit: sql.NullString{String: "box", Valid: true},
+		},
+	})
+	require.ErrorIs(t, err, ErrUnitInUse)
+
+	// setting the same unit again is not a change
+	_, err = store.UpdateMedicineTx(context.Background(), UpdateMedicineTxParams{
+		Medicine: UpdateMedicineParams{
+			ID:   stocked.ID,
+			Unit: sql.NullString{String: stocked.Unit, Valid: true},
+		},
+	})
+	require.NoError(t, err)
+}
@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/toannguyen3105/nht-bsihuyen.com-api/utils"
 )
 
 type Account struct {
@@ -19,6 +20,55 @@ type Account struct {
 	CreatedAt time.Time `json:"created_at"`
 }
 
+type ActiveIngredient struct {
+	ID        int32     `json:"id"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type BatchLocation struct {
+	BatchID    int64     `json:"batch_id"`
+	LocationID int32     `json:"location_id"`
+	Quantity   int32     `json:"quantity"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+type BreakGlassGrant struct {
+	ID            int64          `json:"id"`
+	UserID        int32          `json:"user_id"`
+	PermissionID  int32          `json:"permission_id"`
+	Justification string         `json:"justification"`
+	ExpiresAt     time.Time      `json:"expires_at"`
+	ReviewedBy    sql.NullString `json:"reviewed_by"`
+	ReviewedAt    sql.NullTime   `json:"reviewed_at"`
+	ReviewNote    sql.NullString `json:"review_note"`
+	CreatedAt     time.Time      `json:"created_at"`
+}
+
+type Category struct {
+	ID          int32          `json:"id"`
+	Name        string         `json:"name"`
+	Description sql.NullString `json:"description"`
+	CreatedAt   time.Time      `json:"created_at"`
+}
+
+type ControlledRegister struct {
+	ID         int64 `json:"id"`
+	MedicineID int32 `json:"medicine_id"`
+	// NULL on the entries that open and close the register of a medicine
+	StockMovementID sql.NullInt64 `json:"stock_movement_id"`
+	MovementType    string        `json:"movement_type"`
+	Quantity        int32         `json:"quantity"`
+	// stock of the medicine after the movement
+	Balance     int32          `json:"balance"`
+	BatchID     sql.NullInt64  `json:"batch_id"`
+	LocationID  sql.NullInt32  `json:"location_id"`
+	Note        sql.NullString `json:"note"`
+	RecordedBy  string         `json:"recorded_by"`
+	WitnessedBy string         `json:"witnessed_by"`
+	CreatedAt   time.Time      `json:"created_at"`
+}
+
 type Entry struct {
 	ID        int64 `json:"id"`
 	AccountID int64 `json:"account_id"`
@@ -27,15 +77,107 @@ type Entry struct {
 	CreatedAt time.Time `json:"created_at"`
 }
 
+type GoodsReceipt struct {
+	ID              int32          `json:"id"`
+	PurchaseOrderID int32          `json:"purchase_order_id"`
+	Note            sql.NullString `json:"note"`
+	ReceivedBy      sql.NullString `json:"received_by"`
+	ReceivedAt      time.Time      `json:"received_at"`
+}
+
+type GoodsReceiptItem struct {
+	ID                  int64 `json:"id"`
+	GoodsReceiptID      int32 `json:"goods_receipt_id"`
+	PurchaseOrderItemID int64 `json:"purchase_order_item_id"`
+	BatchID             int64 `json:"batch_id"`
+	Quantity            int32 `json:"quantity"`
+	// the cost price paid per unit of the medicine for this delivery
+	UnitCost utils.Decimal `json:"unit_cost"`
+	Currency string        `json:"currency"`
+}
+
+type IngredientInteraction struct {
+	ID int32 `json:"id"`
+	// the lower ingredient ID of the pair, so each pair is stored once
+	IngredientAID int32     `json:"ingredient_a_id"`
+	IngredientBID int32     `json:"ingredient_b_id"`
+	Severity      string    `json:"severity"`
+	Description   string    `json:"description"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
 type Medicine struct {
-	ID          int32          `json:"id"`
-	Name        string         `json:"name"`
-	Unit        interface{}    `json:"unit"`
-	Price       string         `json:"price"`
-	Stock       int32          `json:"stock"`
-	Description sql.NullString `json:"description"`
-	CreatedAt   time.Time      `json:"created_at"`
-	UpdatedAt   time.Time      `json:"updated_at"`
+	ID           int32          `json:"id"`
+	Name         string         `json:"name"`
+	Unit         string         `json:"unit"`
+	Price        utils.Decimal  `json:"price"`
+	Stock        int32          `json:"stock"`
+	Description  sql.NullString `json:"description"`
+	CreatedAt    time.Time      `json:"created_at"`
+	UpdatedAt    time.Time      `json:"updated_at"`
+	ReorderLevel int32          `json:"reorder_level"`
+	Currency     string         `json:"currency"`
+	Sku          sql.NullString `json:"sku"`
+	// set when the medicine is archived; archived medicines are hidden but keep their history
+	DeletedAt sql.NullTime `json:"deleted_at"`
+	// how the medicine is given, such as tablet, syrup or injection
+	DosageForm   sql.NullString `json:"dosage_form"`
+	Manufacturer sql.NullString `json:"manufacturer"`
+	// psychotropic or narcotic; every movement is witnessed and entered in the controlled register
+	Controlled bool `json:"controlled"`
+}
+
+type MedicineBarcode struct {
+	// an EAN-13 code; a medicine sold in several packs has one per pack
+	Barcode    string    `json:"barcode"`
+	MedicineID int32     `json:"medicine_id"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+type MedicineBatch struct {
+	ID         int64  `json:"id"`
+	MedicineID int32  `json:"medicine_id"`
+	LotNumber  string `json:"lot_number"`
+	// NULL only for stock recorded before batches were tracked
+	ExpiryDate        sql.NullTime `json:"expiry_date"`
+	ReceivedQuantity  int32        `json:"received_quantity"`
+	RemainingQuantity int32        `json:"remaining_quantity"`
+	ReceivedAt        time.Time    `json:"received_at"`
+	UpdatedAt         time.Time    `json:"updated_at"`
+}
+
+type MedicineCategory struct {
+	MedicineID int32 `json:"medicine_id"`
+	CategoryID int32 `json:"category_id"`
+}
+
+type MedicineIngredient struct {
+	MedicineID   int32 `json:"medicine_id"`
+	IngredientID int32 `json:"ingredient_id"`
+	// the amount of the ingredient per unit of the medicine, in strength_unit
+	Strength     utils.Decimal `json:"strength"`
+	StrengthUnit string        `json:"strength_unit"`
+}
+
+type MedicinePrice struct {
+	ID         int64         `json:"id"`
+	MedicineID int32         `json:"medicine_id"`
+	Price      utils.Decimal `json:"price"`
+	Currency   string        `json:"currency"`
+	// the price applies from this time until the next entry of the medicine
+	EffectiveFrom time.Time      `json:"effective_from"`
+	Reason        string         `json:"reason"`
+	CreatedBy     sql.NullString `json:"created_by"`
+	CreatedAt     time.Time      `json:"created_at"`
+}
+
+type MedicineUnitConversion struct {
+	MedicineID int32  `json:"medicine_id"`
+	Unit       string `json:"unit"`
+	// how many of the medicine's own unit one of this unit holds
+	Factor    int32     `json:"factor"`
+	CreatedAt time.Time `json:"created_at"`
 }
 
 type Permission struct {
@@ -44,6 +186,32 @@ type Permission struct {
 	Description sql.NullString `json:"description"`
 	CreatedAt   time.Time      `json:"created_at"`
 	UpdatedAt   time.Time      `json:"updated_at"`
+	IsSystem    bool           `json:"is_system"`
+	// can be obtained through break-glass access
+	BreakGlass bool `json:"break_glass"`
+}
+
+type PurchaseOrder struct {
+	ID         int32          `json:"id"`
+	SupplierID int32          `json:"supplier_id"`
+	Status     string         `json:"status"`
+	Note       sql.NullString `json:"note"`
+	CreatedBy  sql.NullString `json:"created_by"`
+	OrderedAt  sql.NullTime   `json:"ordered_at"`
+	ReceivedAt sql.NullTime   `json:"received_at"`
+	CreatedAt  time.Time      `json:"created_at"`
+	UpdatedAt  time.Time      `json:"updated_at"`
+}
+
+type PurchaseOrderItem struct {
+	ID              int64 `json:"id"`
+	PurchaseOrderID int32 `json:"purchase_order_id"`
+	MedicineID      int32 `json:"medicine_id"`
+	// in the unit of the medicine
+	Quantity         int32         `json:"quantity"`
+	ReceivedQuantity int32         `json:"received_quantity"`
+	UnitCost         utils.Decimal `json:"unit_cost"`
+	Currency         string        `json:"currency"`
 }
 
 type Role struct {
@@ -52,6 +220,7 @@ type Role struct {
 	Description sql.NullString `json:"description"`
 	CreatedAt   time.Time      `json:"created_at"`
 	UpdatedAt   time.Time      `json:"updated_at"`
+	IsSystem    bool           `json:"is_system"`
 }
 
 type RolePermission struct {
@@ -61,6 +230,12 @@ type RolePermission struct {
 	UpdatedAt    time.Time `json:"updated_at"`
 }
 
+type RolePermissionDeny struct {
+	RoleID       int32     `json:"role_id"`
+	PermissionID int32     `json:"permission_id"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
 type Session struct {
 	ID           uuid.UUID `json:"id"`
 	Username     string    `json:"username"`
@@ -72,6 +247,94 @@ type Session struct {
 	CreatedAt    time.Time `json:"created_at"`
 }
 
+type StockAlert struct {
+	ID             int64          `json:"id"`
+	AlertType      string         `json:"alert_type"`
+	MedicineID     int32          `json:"medicine_id"`
+	BatchID        sql.NullInt64  `json:"batch_id"`
+	Message        string         `json:"message"`
+	AcknowledgedBy sql.NullString `json:"acknowledged_by"`
+	AcknowledgedAt sql.NullTime   `json:"acknowledged_at"`
+	// set once stock is back above the reorder level or the batch is empty
+	ResolvedAt sql.NullTime `json:"resolved_at"`
+	CreatedAt  time.Time    `json:"created_at"`
+}
+
+type StockLocation struct {
+	ID   int32  `json:"id"`
+	Name string `json:"name"`
+	// where stock goes and is taken from first when no location is given
+	IsDefault bool      `json:"is_default"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type StockMovement struct {
+	ID           int64  `json:"id"`
+	MedicineID   int32  `json:"medicine_id"`
+	MovementType string `json:"movement_type"`
+	// positive adds stock, negative removes it
+	Quantity int32 `json:"quantity"`
+	// stock of the medicine after the movement
+	Balance   int32          `json:"balance"`
+	Note      sql.NullString `json:"note"`
+	CreatedBy sql.NullString `json:"created_by"`
+	CreatedAt time.Time      `json:"created_at"`
+	BatchID   sql.NullInt64  `json:"batch_id"`
+	// NULL for movements recorded before stock had locations
+	LocationID sql.NullInt32 `json:"location_id"`
+}
+
+type StockTransfer struct {
+	ID             int64          `json:"id"`
+	MedicineID     int32          `json:"medicine_id"`
+	FromLocationID int32          `json:"from_location_id"`
+	ToLocationID   int32          `json:"to_location_id"`
+	Quantity       int32          `json:"quantity"`
+	Note           sql.NullString `json:"note"`
+	CreatedBy      sql.NullString `json:"created_by"`
+	CreatedAt      time.Time      `json:"created_at"`
+}
+
+type Stocktake struct {
+	ID         int32          `json:"id"`
+	Status     string         `json:"status"`
+	Note       sql.NullString `json:"note"`
+	CreatedBy  sql.NullString `json:"created_by"`
+	CreatedAt  time.Time      `json:"created_at"`
+	ApprovedBy sql.NullString `json:"approved_by"`
+	ApprovedAt sql.NullTime   `json:"approved_at"`
+}
+
+type StocktakeCount struct {
+	StocktakeItemID int64     `json:"stocktake_item_id"`
+	CountedBy       string    `json:"counted_by"`
+	Quantity        int32     `json:"quantity"`
+	CountedAt       time.Time `json:"counted_at"`
+}
+
+type StocktakeItem struct {
+	ID          int64 `json:"id"`
+	StocktakeID int32 `json:"stocktake_id"`
+	MedicineID  int32 `json:"medicine_id"`
+	BatchID     int64 `json:"batch_id"`
+	// quantity of the batch at the location when the stocktake started
+	ExpectedQuantity int32 `json:"expected_quantity"`
+	LocationID       int32 `json:"location_id"`
+}
+
+type Supplier struct {
+	ID          int32          `json:"id"`
+	Name        string         `json:"name"`
+	ContactName sql.NullString `json:"contact_name"`
+	Phone       sql.NullString `json:"phone"`
+	Email       sql.NullString `json:"email"`
+	Address     sql.NullString `json:"address"`
+	// days between placing an order and receiving it
+	LeadTimeDays int32     `json:"lead_time_days"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
 type Transfer struct {
 	ID            int64 `json:"id"`
 	FromAccountID int64 `json:"from_account_id"`
@@ -81,6 +344,13 @@ type Transfer struct {
 	CreatedAt time.Time `json:"created_at"`
 }
 
+type Unit struct {
+	ID          int32          `json:"id"`
+	Name        string         `json:"name"`
+	Description sql.NullString `json:"description"`
+	CreatedAt   time.Time      `json:"created_at"`
+}
+
 type User struct {
 	ID                int32          `json:"id"`
 	Username          string         `json:"username"`
@@ -93,9 +363,26 @@ type User struct {
 	UpdatedAt         time.Time      `json:"updated_at"`
 }
 
+type UserEffectivePermission struct {
+	UserID       int32 `json:"user_id"`
+	PermissionID int32 `json:"permission_id"`
+}
+
+type UserPermissionOverride struct {
+	UserID       int32     `json:"user_id"`
+	PermissionID int32     `json:"permission_id"`
+	Effect       string    `json:"effect"`
+	Reason       string    `json:"reason"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
 type UserRole struct {
-	UserID    int32     `json:"user_id"`
-	RoleID    int32     `json:"role_id"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	UserID           int32        `json:"user_id"`
+	RoleID           int32        `json:"role_id"`
+	CreatedAt        time.Time    `json:"created_at"`
+	UpdatedAt        time.Time    `json:"updated_at"`
+	ValidFrom        sql.NullTime `json:"valid_from"`
+	ValidUntil       sql.NullTime `json:"valid_until"`
+	ExpiryNotifiedAt sql.NullTime `json:"expiry_notified_at"`
 }
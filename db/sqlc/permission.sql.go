@@ -27,7 +27,7 @@ INSERT INTO permissions (
   description
 ) VALUES (
   $1, $2
-) RETURNING id, name, description, created_at, updated_at
+) RETURNING id, name, description, created_at, updated_at, is_system, break_glass
 `
 
 type CreatePermissionParams struct {
@@ -44,6 +44,8 @@ func (q *Queries) CreatePermission(ctx context.Context, arg CreatePermissionPara
 		&i.Description,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.IsSystem,
+		&i.BreakGlass,
 	)
 	return i, err
 }
@@ -59,7 +61,7 @@ func (q *Queries) DeletePermission(ctx context.Context, id int32) error {
 }
 
 const getPermission = `-- name: GetPermission :one
-SELECT id, name, description, created_at, updated_at FROM permissions
+SELECT id, name, description, created_at, updated_at, is_system, break_glass FROM permissions
 WHERE id = $1 LIMIT 1
 `
 
@@ -72,12 +74,70 @@ func (q *Queries) GetPermission(ctx context.Context, id int32) (Permission, erro
 		&i.Description,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.IsSystem,
+		&i.BreakGlass,
 	)
 	return i, err
 }
 
+const getPermissionByName = `-- name: GetPermissionByName :one
+SELECT id, name, description, created_at, updated_at, is_system, break_glass FROM permissions
+WHERE name = $1 LIMIT 1
+`
+
+func (q *Queries) GetPermissionByName(ctx context.Context, name string) (Permission, error) {
+	row := q.db.QueryRowContext(ctx, getPermissionByName, name)
+	var i Permission
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Description,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.IsSystem,
+		&i.BreakGlass,
+	)
+	return i, err
+}
+
+const listAllPermissions = `-- name: ListAllPermissions :many
+SELECT id, name, description, created_at, updated_at, is_system, break_glass FROM permissions
+ORDER BY id
+`
+
+func (q *Queries) ListAllPermissions(ctx context.Context) ([]Permission, error) {
+	rows, err := q.db.QueryContext(ctx, listAllPermissions)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Permission{}
+	for rows.Next() {
+		var i Permission
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Description,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.IsSystem,
+			&i.BreakGlass,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listPermissions = `-- name: ListPermissions :many
-SELECT id, name, description, created_at, updated_at FROM permissions
+SELECT id, name, description, created_at, updated_at, is_system, break_glass FROM permissions
 ORDER BY id
 LIMIT $1
 OFFSET $2
@@ -103,6 +163,8 @@ func (q *Queries) ListPermissions(ctx context.Context, arg ListPermissionsParams
 			&i.Description,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.IsSystem,
+			&i.BreakGlass,
 		); err != nil {
 			return nil, err
 		}
@@ -117,6 +179,64 @@ func (q *Queries) ListPermissions(ctx context.Context, arg ListPermissionsParams
 	return items, nil
 }
 
+const setPermissionBreakGlass = `-- name: SetPermissionBreakGlass :one
+UPDATE permissions
+SET
+    break_glass = $1,
+    updated_at = now()
+WHERE id = $2
+RETURNING id, name, description, created_at, updated_at, is_system, break_glass
+`
+
+type SetPermissionBreakGlassParams struct {
+	BreakGlass bool  `json:"break_glass"`
+	ID         int32 `json:"id"`
+}
+
+func (q *Queries) SetPermissionBreakGlass(ctx context.Context, arg SetPermissionBreakGlassParams) (Permission, error) {
+	row := q.db.QueryRowContext(ctx, setPermissionBreakGlass, arg.BreakGlass, arg.ID)
+	var i Permission
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Description,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.IsSystem,
+		&i.BreakGlass,
+	)
+	return i, err
+}
+
+const setPermissionDescription = `-- name: SetPermissionDescription :one
+UPDATE permissions
+SET
+    description = $1,
+    updated_at = now()
+WHERE id = $2
+RETURNING id, name, description, created_at, updated_at, is_system, break_glass
+`
+
+type SetPermissionDescriptionParams struct {
+	Description sql.NullString `json:"description"`
+	ID          int32          `json:"id"`
+}
+
+func (q *Queries) SetPermissionDescription(ctx context.Context, arg SetPermissionDescriptionParams) (Permission, error) {
+	row := q.db.QueryRowContext(ctx, setPermissionDescription, arg.Description, arg.ID)
+	var i Permission
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Description,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.IsSystem,
+		&i.BreakGlass,
+	)
+	return i, err
+}
+
 const updatePermission = `-- name: UpdatePermission :one
 UPDATE permissions
 SET 
@@ -124,7 +244,7 @@ SET
     description = COALESCE($2, description),
     updated_at = now()
 WHERE id = $3
-RETURNING id, name, description, created_at, updated_at
+RETURNING id, name, description, created_at, updated_at, is_system, break_glass
 `
 
 type UpdatePermissionParams struct {
@@ -142,6 +262,8 @@ func (q *Queries) UpdatePermission(ctx context.Context, arg UpdatePermissionPara
 		&i.Description,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.IsSystem,
+		&i.BreakGlass,
 	)
 	return i, err
 }
@@ -6,64 +6,240 @@ package db
 
 import (
 	"context"
+	"database/sql"
+	"time"
 
 	"github.com/google/uuid"
 )
 
 type Querier interface {
+	AcknowledgeStockAlert(ctx context.Context, arg AcknowledgeStockAlertParams) (StockAlert, error)
 	AddAccountBalance(ctx context.Context, arg AddAccountBalanceParams) (Account, error)
+	AddBatchLocationQuantity(ctx context.Context, arg AddBatchLocationQuantityParams) (BatchLocation, error)
+	AddMedicineBatchQuantity(ctx context.Context, arg AddMedicineBatchQuantityParams) (MedicineBatch, error)
+	AddMedicineCategories(ctx context.Context, arg AddMedicineCategoriesParams) error
+	AddMedicineStock(ctx context.Context, arg AddMedicineStockParams) (Medicine, error)
+	AddPurchaseOrderItemReceived(ctx context.Context, arg AddPurchaseOrderItemReceivedParams) (PurchaseOrderItem, error)
 	AddRoleForUser(ctx context.Context, arg AddRoleForUserParams) (UserRole, error)
-	CountMedicines(ctx context.Context) (int64, error)
+	// Brings medicines.price up to date with the latest history entry that has
+	// taken effect, for price changes scheduled ahead of time.
+	ApplyDueMedicinePrices(ctx context.Context) (int64, error)
+	ApproveStocktake(ctx context.Context, arg ApproveStocktakeParams) (Stocktake, error)
+	ArchiveMedicine(ctx context.Context, id int32) (Medicine, error)
+	CountMedicinePrices(ctx context.Context, medicineID int32) (int64, error)
+	CountMedicines(ctx context.Context, arg CountMedicinesParams) (int64, error)
+	CountPendingBreakGlassGrants(ctx context.Context) (int64, error)
 	CountPermissions(ctx context.Context) (int64, error)
+	CountPermissionsForRole(ctx context.Context, roleID int32) (int64, error)
+	CountPurchaseOrders(ctx context.Context, arg CountPurchaseOrdersParams) (int64, error)
+	CountRolePermissionDenies(ctx context.Context) (int64, error)
 	CountRolePermissions(ctx context.Context) (int64, error)
 	CountRoles(ctx context.Context) (int64, error)
+	CountStockAlerts(ctx context.Context, acknowledged sql.NullBool) (int64, error)
+	CountStockMovements(ctx context.Context, medicineID int32) (int64, error)
+	CountStocktakes(ctx context.Context) (int64, error)
+	// CountSystemAdmins counts the users holding every system permission without
+	// an end date: each one must come from an active role assignment without
+	// valid_until or from an allow override. Assignments about to lapse do not
+	// keep the system administrable.
+	CountSystemAdmins(ctx context.Context) (int64, error)
 	CountUserRoles(ctx context.Context) (int64, error)
 	CountUsers(ctx context.Context) (int64, error)
+	CountUsersForRole(ctx context.Context, roleID int32) (int64, error)
 	CreateAccount(ctx context.Context, arg CreateAccountParams) (Account, error)
+	CreateActiveIngredient(ctx context.Context, name string) (ActiveIngredient, error)
+	CreateBreakGlassGrant(ctx context.Context, arg CreateBreakGlassGrantParams) (BreakGlassGrant, error)
+	CreateCategory(ctx context.Context, arg CreateCategoryParams) (Category, error)
+	CreateControlledRegisterEntry(ctx context.Context, arg CreateControlledRegisterEntryParams) (ControlledRegister, error)
 	CreateEntry(ctx context.Context, arg CreateEntryParams) (Entry, error)
+	CreateGoodsReceipt(ctx context.Context, arg CreateGoodsReceiptParams) (GoodsReceipt, error)
+	CreateGoodsReceiptItem(ctx context.Context, arg CreateGoodsReceiptItemParams) (GoodsReceiptItem, error)
 	CreateMedicine(ctx context.Context, arg CreateMedicineParams) (Medicine, error)
+	CreateMedicineBarcode(ctx context.Context, arg CreateMedicineBarcodeParams) (MedicineBarcode, error)
+	CreateMedicineIngredient(ctx context.Context, arg CreateMedicineIngredientParams) (MedicineIngredient, error)
+	CreateMedicinePrice(ctx context.Context, arg CreateMedicinePriceParams) (MedicinePrice, error)
 	CreatePermission(ctx context.Context, arg CreatePermissionParams) (Permission, error)
+	CreatePurchaseOrder(ctx context.Context, arg CreatePurchaseOrderParams) (PurchaseOrder, error)
+	CreatePurchaseOrderItem(ctx context.Context, arg CreatePurchaseOrderItemParams) (PurchaseOrderItem, error)
 	CreateRole(ctx context.Context, arg CreateRoleParams) (Role, error)
 	CreateRolePermission(ctx context.Context, arg CreateRolePermissionParams) (RolePermission, error)
+	CreateRolePermissionDeny(ctx context.Context, arg CreateRolePermissionDenyParams) (RolePermissionDeny, error)
 	CreateSession(ctx context.Context, arg CreateSessionParams) (Session, error)
+	CreateStockAlert(ctx context.Context, arg CreateStockAlertParams) (StockAlert, error)
+	CreateStockLocation(ctx context.Context, name string) (StockLocation, error)
+	CreateStockMovement(ctx context.Context, arg CreateStockMovementParams) (StockMovement, error)
+	CreateStockTransfer(ctx context.Context, arg CreateStockTransferParams) (StockTransfer, error)
+	CreateStocktake(ctx context.Context, arg CreateStocktakeParams) (Stocktake, error)
+	// Snapshots the batches in stock at every location of the given medicines, or
+	// of every medicine that is not archived when none are given.
+	CreateStocktakeItems(ctx context.Context, arg CreateStocktakeItemsParams) (int64, error)
+	CreateSupplier(ctx context.Context, arg CreateSupplierParams) (Supplier, error)
 	CreateTransfer(ctx context.Context, arg CreateTransferParams) (Transfer, error)
+	CreateUnit(ctx context.Context, arg CreateUnitParams) (Unit, error)
 	CreateUser(ctx context.Context, arg CreateUserParams) (User, error)
 	DeleteAccount(ctx context.Context, id int64) error
+	DeleteActiveIngredient(ctx context.Context, id int32) (int64, error)
+	DeleteCategory(ctx context.Context, id int32) (int64, error)
 	DeleteEntry(ctx context.Context, id int64) error
+	DeleteExpiredUserRoles(ctx context.Context) (int64, error)
+	DeleteIngredientInteraction(ctx context.Context, id int32) (int64, error)
 	DeleteMedicine(ctx context.Context, id int32) error
+	DeleteMedicineBarcode(ctx context.Context, arg DeleteMedicineBarcodeParams) (int64, error)
+	DeleteMedicineCategories(ctx context.Context, medicineID int32) error
+	DeleteMedicineIngredients(ctx context.Context, medicineID int32) error
+	DeleteMedicineUnitConversion(ctx context.Context, arg DeleteMedicineUnitConversionParams) (int64, error)
 	DeletePermission(ctx context.Context, id int32) error
+	// Only drafts can be deleted; ordered ones are kept for the record.
+	DeletePurchaseOrder(ctx context.Context, id int32) (int64, error)
+	DeletePurchaseOrderItems(ctx context.Context, purchaseOrderID int32) error
 	DeleteRole(ctx context.Context, id int32) error
 	DeleteRolePermission(ctx context.Context, arg DeleteRolePermissionParams) error
+	DeleteRolePermissionDeny(ctx context.Context, arg DeleteRolePermissionDenyParams) error
+	// Approved stocktakes are kept with the adjustments they posted.
+	DeleteStocktake(ctx context.Context, id int32) (int64, error)
+	DeleteSupplier(ctx context.Context, id int32) (int64, error)
 	DeleteTransfer(ctx context.Context, id int64) error
+	DeleteUnit(ctx context.Context, name string) (int64, error)
+	DeleteUserPermissionOverride(ctx context.Context, arg DeleteUserPermissionOverrideParams) error
 	GetAccount(ctx context.Context, id int64) (Account, error)
 	GetAccountForUpdate(ctx context.Context, id int64) (Account, error)
+	GetBreakGlassGrant(ctx context.Context, id int64) (GetBreakGlassGrantRow, error)
+	GetDefaultStockLocation(ctx context.Context) (StockLocation, error)
 	GetEntry(ctx context.Context, id int64) (Entry, error)
 	GetMedicine(ctx context.Context, id int32) (Medicine, error)
+	GetMedicineBatch(ctx context.Context, id int64) (MedicineBatch, error)
+	GetMedicineByBarcode(ctx context.Context, barcode string) (Medicine, error)
+	GetMedicineForUpdate(ctx context.Context, id int32) (Medicine, error)
+	GetMedicinePriceAt(ctx context.Context, arg GetMedicinePriceAtParams) (MedicinePrice, error)
+	GetMedicineUnitConversion(ctx context.Context, arg GetMedicineUnitConversionParams) (MedicineUnitConversion, error)
 	GetPermission(ctx context.Context, id int32) (Permission, error)
+	GetPermissionByName(ctx context.Context, name string) (Permission, error)
 	GetPermissionsForUser(ctx context.Context, userID int32) ([]string, error)
+	GetPurchaseOrder(ctx context.Context, id int32) (PurchaseOrder, error)
+	GetPurchaseOrderForUpdate(ctx context.Context, id int32) (PurchaseOrder, error)
 	GetRole(ctx context.Context, id int32) (Role, error)
 	GetRolePermission(ctx context.Context, arg GetRolePermissionParams) (RolePermission, error)
+	GetRolePermissionDeny(ctx context.Context, arg GetRolePermissionDenyParams) (RolePermissionDeny, error)
 	GetRolesForUser(ctx context.Context, userID int32) ([]Role, error)
 	GetSession(ctx context.Context, id uuid.UUID) (Session, error)
+	GetStockAlert(ctx context.Context, id int64) (StockAlert, error)
+	GetStockLocation(ctx context.Context, id int32) (StockLocation, error)
+	GetStocktake(ctx context.Context, id int32) (Stocktake, error)
+	GetStocktakeForUpdate(ctx context.Context, id int32) (Stocktake, error)
+	GetSupplier(ctx context.Context, id int32) (Supplier, error)
 	GetTransfer(ctx context.Context, id int64) (Transfer, error)
 	GetUser(ctx context.Context, username string) (User, error)
+	GetUserByID(ctx context.Context, id int32) (User, error)
+	GetUserPermissionOverride(ctx context.Context, arg GetUserPermissionOverrideParams) (UserPermissionOverride, error)
 	ListAccounts(ctx context.Context, arg ListAccountsParams) ([]Account, error)
+	ListActiveBreakGlassGrantsForUser(ctx context.Context, userID int32) ([]ListActiveBreakGlassGrantsForUserRow, error)
+	ListActiveIngredients(ctx context.Context) ([]ActiveIngredient, error)
+	ListAllMedicines(ctx context.Context) ([]Medicine, error)
+	ListAllPermissions(ctx context.Context) ([]Permission, error)
+	ListAllRolePermissionDenies(ctx context.Context) ([]RolePermissionDeny, error)
+	ListAllRolePermissions(ctx context.Context) ([]RolePermission, error)
+	ListAllRoles(ctx context.Context) ([]Role, error)
+	ListAllUserPermissionOverrides(ctx context.Context) ([]ListAllUserPermissionOverridesRow, error)
+	ListAllUserRoles(ctx context.Context) ([]ListAllUserRolesRow, error)
+	ListBatchLocations(ctx context.Context, batchID int64) ([]BatchLocation, error)
+	ListCategories(ctx context.Context) ([]Category, error)
+	// The stock of each controlled medicine, and of each medicine that has register
+	// entries, before and at the end of a period.
+	ListControlledRegisterBalances(ctx context.Context, arg ListControlledRegisterBalancesParams) ([]ListControlledRegisterBalancesRow, error)
+	ListControlledRegisterEntries(ctx context.Context, arg ListControlledRegisterEntriesParams) ([]ListControlledRegisterEntriesRow, error)
+	// Like ListDispensableMedicineBatches, limited to the stock at one location.
+	ListDispensableLocationBatches(ctx context.Context, arg ListDispensableLocationBatchesParams) ([]ListDispensableLocationBatchesRow, error)
+	// Earliest expiry first (FEFO). Legacy stock without an expiry date goes first
+	// and expired batches are skipped.
+	ListDispensableMedicineBatches(ctx context.Context, medicineID int32) ([]MedicineBatch, error)
 	ListEntries(ctx context.Context, arg ListEntriesParams) ([]Entry, error)
+	ListExpiringMedicineBatches(ctx context.Context, expiryBefore sql.NullTime) ([]ListExpiringMedicineBatchesRow, error)
+	ListExpiringUserRoles(ctx context.Context, notifyBefore time.Time) ([]ListExpiringUserRolesRow, error)
+	ListGoodsReceiptItems(ctx context.Context, goodsReceiptIds []int32) ([]ListGoodsReceiptItemsRow, error)
+	ListGoodsReceipts(ctx context.Context, purchaseOrderID int32) ([]GoodsReceipt, error)
+	ListInteractionsAmongIngredients(ctx context.Context, ingredientIds []int32) ([]ListInteractionsAmongIngredientsRow, error)
+	ListInteractionsForIngredient(ctx context.Context, ingredientID int32) ([]ListInteractionsForIngredientRow, error)
+	ListLocationStock(ctx context.Context, locationID int32) ([]ListLocationStockRow, error)
+	ListLowStockMedicines(ctx context.Context) ([]Medicine, error)
+	ListMedicineBarcodes(ctx context.Context, medicineID int32) ([]MedicineBarcode, error)
+	ListMedicineBatches(ctx context.Context, medicineID int32) ([]MedicineBatch, error)
+	ListMedicineCategories(ctx context.Context, medicineID int32) ([]Category, error)
+	// What was dispensed of each medicine since a point in time, with what is
+	// needed to plan an order: the unexpired stock, what is still on order, the
+	// supplier of the latest placed purchase order for it, and the unit cost paid
+	// on its latest delivery.
+	ListMedicineConsumption(ctx context.Context, since time.Time) ([]ListMedicineConsumptionRow, error)
+	ListMedicineIngredients(ctx context.Context, medicineIds []int32) ([]ListMedicineIngredientsRow, error)
+	ListMedicineLocationStock(ctx context.Context, medicineID int32) ([]ListMedicineLocationStockRow, error)
+	ListMedicinePrices(ctx context.Context, arg ListMedicinePricesParams) ([]MedicinePrice, error)
+	ListMedicineUnitConversions(ctx context.Context, medicineID int32) ([]MedicineUnitConversion, error)
 	ListMedicines(ctx context.Context, arg ListMedicinesParams) ([]Medicine, error)
+	ListMedicinesByIDs(ctx context.Context, ids []int32) ([]Medicine, error)
+	ListMedicinesForImport(ctx context.Context, arg ListMedicinesForImportParams) ([]Medicine, error)
+	ListMedicinesForUpdate(ctx context.Context, arg ListMedicinesForUpdateParams) ([]Medicine, error)
+	ListPendingBreakGlassGrants(ctx context.Context, arg ListPendingBreakGlassGrantsParams) ([]ListPendingBreakGlassGrantsRow, error)
 	ListPermissions(ctx context.Context, arg ListPermissionsParams) ([]Permission, error)
+	ListPermissionsForRole(ctx context.Context, arg ListPermissionsForRoleParams) ([]ListPermissionsForRoleRow, error)
+	ListPurchaseOrderItems(ctx context.Context, purchaseOrderID int32) ([]ListPurchaseOrderItemsRow, error)
+	ListPurchaseOrders(ctx context.Context, arg ListPurchaseOrdersParams) ([]PurchaseOrder, error)
+	ListRolePermissionDenies(ctx context.Context, arg ListRolePermissionDeniesParams) ([]ListRolePermissionDeniesRow, error)
 	ListRolePermissions(ctx context.Context, arg ListRolePermissionsParams) ([]RolePermission, error)
-	ListRoles(ctx context.Context, arg ListRolesParams) ([]Role, error)
+	ListRoles(ctx context.Context, arg ListRolesParams) ([]ListRolesRow, error)
+	ListStockAlerts(ctx context.Context, arg ListStockAlertsParams) ([]ListStockAlertsRow, error)
+	ListStockLocations(ctx context.Context) ([]StockLocation, error)
+	ListStockMovements(ctx context.Context, arg ListStockMovementsParams) ([]StockMovement, error)
+	ListStocktakeCounts(ctx context.Context, stocktakeID int32) ([]StocktakeCount, error)
+	// Every counter counts the whole item, so counted_quantity is what they found
+	// and disputed is set when they disagree. moved_quantity is the net stock
+	// movement of the batch at the location between the snapshot and the last
+	// count, which the counters already saw on the shelf.
+	ListStocktakeItems(ctx context.Context, stocktakeID int32) ([]ListStocktakeItemsRow, error)
+	ListStocktakes(ctx context.Context, arg ListStocktakesParams) ([]Stocktake, error)
+	ListSuppliers(ctx context.Context) ([]Supplier, error)
+	ListSystemAdmins(ctx context.Context) ([]User, error)
 	ListTransfers(ctx context.Context, arg ListTransfersParams) ([]Transfer, error)
+	ListUnits(ctx context.Context) ([]Unit, error)
+	ListUserPermissionOverrides(ctx context.Context, userID int32) ([]ListUserPermissionOverridesRow, error)
+	ListUserRoleDenies(ctx context.Context, userID int32) ([]ListUserRoleDeniesRow, error)
+	ListUserRoleGrants(ctx context.Context, userID int32) ([]ListUserRoleGrantsRow, error)
 	ListUserRoles(ctx context.Context, arg ListUserRolesParams) ([]UserRole, error)
 	ListUsers(ctx context.Context, arg ListUsersParams) ([]User, error)
+	ListUsersForRole(ctx context.Context, arg ListUsersForRoleParams) ([]ListUsersForRoleRow, error)
+	ListUsersWithPermission(ctx context.Context, name string) ([]User, error)
+	LockSystemRoles(ctx context.Context) error
+	MarkUserRoleExpiryNotified(ctx context.Context, arg MarkUserRoleExpiryNotifiedParams) error
+	// Whether quantities have been recorded in the unit of the medicine: it has
+	// stock, lots, or conversions from other units.
+	MedicineUnitInUse(ctx context.Context, id int32) (bool, error)
 	RemoveRoleForUser(ctx context.Context, arg RemoveRoleForUserParams) error
+	ResolveLowStockAlerts(ctx context.Context) (int64, error)
+	ResolveNearExpiryAlerts(ctx context.Context) (int64, error)
+	RestoreMedicine(ctx context.Context, id int32) (Medicine, error)
+	ReviewBreakGlassGrant(ctx context.Context, arg ReviewBreakGlassGrantParams) (BreakGlassGrant, error)
+	SetMedicineControlled(ctx context.Context, arg SetMedicineControlledParams) (Medicine, error)
+	SetPermissionBreakGlass(ctx context.Context, arg SetPermissionBreakGlassParams) (Permission, error)
+	SetPermissionDescription(ctx context.Context, arg SetPermissionDescriptionParams) (Permission, error)
+	SetPurchaseOrderStatus(ctx context.Context, arg SetPurchaseOrderStatusParams) (PurchaseOrder, error)
+	SetRoleDescription(ctx context.Context, arg SetRoleDescriptionParams) (Role, error)
 	UpdateAccount(ctx context.Context, arg UpdateAccountParams) (Account, error)
 	UpdateEntry(ctx context.Context, arg UpdateEntryParams) (Entry, error)
 	UpdateMedicine(ctx context.Context, arg UpdateMedicineParams) (Medicine, error)
+	UpdateMedicinePrice(ctx context.Context, arg UpdateMedicinePriceParams) (Medicine, error)
 	UpdatePermission(ctx context.Context, arg UpdatePermissionParams) (Permission, error)
+	UpdatePurchaseOrder(ctx context.Context, arg UpdatePurchaseOrderParams) (PurchaseOrder, error)
 	UpdateRole(ctx context.Context, arg UpdateRoleParams) (Role, error)
 	UpdateRolePermission(ctx context.Context, arg UpdateRolePermissionParams) (RolePermission, error)
+	UpdateStockLocation(ctx context.Context, arg UpdateStockLocationParams) (StockLocation, error)
+	UpdateSupplier(ctx context.Context, arg UpdateSupplierParams) (Supplier, error)
 	UpdateTransfer(ctx context.Context, arg UpdateTransferParams) (Transfer, error)
+	UpdateUserRoleValidity(ctx context.Context, arg UpdateUserRoleValidityParams) (UserRole, error)
+	UpsertIngredientInteraction(ctx context.Context, arg UpsertIngredientInteractionParams) (IngredientInteraction, error)
+	// Adds a receipt to its lot. A lot that is received again with a different
+	// expiry date is left alone and no row is returned.
+	UpsertMedicineBatch(ctx context.Context, arg UpsertMedicineBatchParams) (MedicineBatch, error)
+	UpsertMedicineUnitConversion(ctx context.Context, arg UpsertMedicineUnitConversionParams) (MedicineUnitConversion, error)
+	UpsertStocktakeCount(ctx context.Context, arg UpsertStocktakeCountParams) (StocktakeCount, error)
+	UpsertUserPermissionOverride(ctx context.Context, arg UpsertUserPermissionOverrideParams) (UserPermissionOverride, error)
 }
 
 var _ Querier = (*Queries)(nil)
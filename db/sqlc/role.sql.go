@@ -8,6 +8,7 @@ package db
 import (
 	"context"
 	"database/sql"
+	"time"
 )
 
 const countRoles = `-- name: CountRoles :one
@@ -27,7 +28,7 @@ INSERT INTO roles (
   description
 ) VALUES (
   $1, $2
-) RETURNING id, name, description, created_at, updated_at
+) RETURNING id, name, description, created_at, updated_at, is_system
 `
 
 type CreateRoleParams struct {
@@ -44,6 +45,7 @@ func (q *Queries) CreateRole(ctx context.Context, arg CreateRoleParams) (Role, e
 		&i.Description,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.IsSystem,
 	)
 	return i, err
 }
@@ -59,7 +61,7 @@ func (q *Queries) DeleteRole(ctx context.Context, id int32) error {
 }
 
 const getRole = `-- name: GetRole :one
-SELECT id, name, description, created_at, updated_at FROM roles
+SELECT id, name, description, created_at, updated_at, is_system FROM roles
 WHERE id = $1 LIMIT 1
 `
 
@@ -72,12 +74,52 @@ func (q *Queries) GetRole(ctx context.Context, id int32) (Role, error) {
 		&i.Description,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.IsSystem,
 	)
 	return i, err
 }
 
+const listAllRoles = `-- name: ListAllRoles :many
+SELECT id, name, description, created_at, updated_at, is_system FROM roles
+ORDER BY id
+`
+
+func (q *Queries) ListAllRoles(ctx context.Context) ([]Role, error) {
+	rows, err := q.db.QueryContext(ctx, listAllRoles)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Role{}
+	for rows.Next() {
+		var i Role
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Description,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.IsSystem,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listRoles = `-- name: ListRoles :many
-SELECT id, name, description, created_at, updated_at FROM roles
+SELECT
+  roles.id, roles.name, roles.description, roles.created_at, roles.updated_at, roles.is_system,
+  (SELECT count(*) FROM user_roles ur WHERE ur.role_id = roles.id)::bigint AS member_count,
+  (SELECT count(*) FROM role_permissions rp WHERE rp.role_id = roles.id)::bigint AS permission_count
+FROM roles
 ORDER BY id
 LIMIT $1
 OFFSET $2
@@ -88,21 +130,35 @@ type ListRolesParams struct {
 	Offset int32 `json:"offset"`
 }
 
-func (q *Queries) ListRoles(ctx context.Context, arg ListRolesParams) ([]Role, error) {
+type ListRolesRow struct {
+	ID              int32          `json:"id"`
+	Name            string         `json:"name"`
+	Description     sql.NullString `json:"description"`
+	CreatedAt       time.Time      `json:"created_at"`
+	UpdatedAt       time.Time      `json:"updated_at"`
+	IsSystem        bool           `json:"is_system"`
+	MemberCount     int64          `json:"member_count"`
+	PermissionCount int64          `json:"permission_count"`
+}
+
+func (q *Queries) ListRoles(ctx context.Context, arg ListRolesParams) ([]ListRolesRow, error) {
 	rows, err := q.db.QueryContext(ctx, listRoles, arg.Limit, arg.Offset)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	items := []Role{}
+	items := []ListRolesRow{}
 	for rows.Next() {
-		var i Role
+		var i ListRolesRow
 		if err := rows.Scan(
 			&i.ID,
 			&i.Name,
 			&i.Description,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.IsSystem,
+			&i.MemberCount,
+			&i.PermissionCount,
 		); err != nil {
 			return nil, err
 		}
@@ -117,6 +173,45 @@ func (q *Queries) ListRoles(ctx context.Context, arg ListRolesParams) ([]Role, e
 	return items, nil
 }
 
+const lockSystemRoles = `-- name: LockSystemRoles :exec
+SELECT id FROM roles
+WHERE is_system
+FOR UPDATE
+`
+
+func (q *Queries) LockSystemRoles(ctx context.Context) error {
+	_, err := q.db.ExecContext(ctx, lockSystemRoles)
+	return err
+}
+
+const setRoleDescription = `-- name: SetRoleDescription :one
+UPDATE roles
+SET
+    description = $1,
+    updated_at = now()
+WHERE id = $2
+RETURNING id, name, description, created_at, updated_at, is_system
+`
+
+type SetRoleDescriptionParams struct {
+	Description sql.NullString `json:"description"`
+	ID          int32          `json:"id"`
+}
+
+func (q *Queries) SetRoleDescription(ctx context.Context, arg SetRoleDescriptionParams) (Role, error) {
+	row := q.db.QueryRowContext(ctx, setRoleDescription, arg.Description, arg.ID)
+	var i Role
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Description,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.IsSystem,
+	)
+	return i, err
+}
+
 const updateRole = `-- name: UpdateRole :one
 UPDATE roles
 SET 
@@ -124,7 +219,7 @@ SET
     description = COALESCE($2, description),
     updated_at = now()
 WHERE id = $3
-RETURNING id, name, description, created_at, updated_at
+RETURNING id, name, description, created_at, updated_at, is_system
 `
 
 type UpdateRoleParams struct {
@@ -142,6 +237,7 @@ func (q *Queries) UpdateRole(ctx context.Context, arg UpdateRoleParams) (Role, e
 		&i.Description,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.IsSystem,
 	)
 	return i, err
 }
@@ -7,8 +7,22 @@ package db
 
 import (
 	"context"
+	"database/sql"
+	"time"
 )
 
+const countPermissionsForRole = `-- name: CountPermissionsForRole :one
+SELECT count(*) FROM role_permissions
+WHERE role_id = $1
+`
+
+func (q *Queries) CountPermissionsForRole(ctx context.Context, roleID int32) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countPermissionsForRole, roleID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const countRolePermissions = `-- name: CountRolePermissions :one
 SELECT count(*) FROM role_permissions
 `
@@ -84,6 +98,100 @@ func (q *Queries) GetRolePermission(ctx context.Context, arg GetRolePermissionPa
 	return i, err
 }
 
+const listAllRolePermissions = `-- name: ListAllRolePermissions :many
+SELECT role_id, permission_id, created_at, updated_at FROM role_permissions
+ORDER BY role_id, permission_id
+`
+
+func (q *Queries) ListAllRolePermissions(ctx context.Context) ([]RolePermission, error) {
+	rows, err := q.db.QueryContext(ctx, listAllRolePermissions)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []RolePermission{}
+	for rows.Next() {
+		var i RolePermission
+		if err := rows.Scan(
+			&i.RoleID,
+			&i.PermissionID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listPermissionsForRole = `-- name: ListPermissionsForRole :many
+SELECT
+  p.id, p.name, p.description, p.created_at, p.updated_at, p.is_system, p.break_glass,
+  rp.created_at AS granted_at
+FROM role_permissions rp
+JOIN permissions p ON p.id = rp.permission_id
+WHERE rp.role_id = $1
+ORDER BY p.id
+LIMIT $2
+OFFSET $3
+`
+
+type ListPermissionsForRoleParams struct {
+	RoleID int32 `json:"role_id"`
+	Limit  int32 `json:"limit"`
+	Offset int32 `json:"offset"`
+}
+
+type ListPermissionsForRoleRow struct {
+	ID          int32          `json:"id"`
+	Name        string         `json:"name"`
+	Description sql.NullString `json:"description"`
+	CreatedAt   time.Time      `json:"created_at"`
+	UpdatedAt   time.Time      `json:"updated_at"`
+	IsSystem    bool           `json:"is_system"`
+	BreakGlass  bool           `json:"break_glass"`
+	GrantedAt   time.Time      `json:"granted_at"`
+}
+
+func (q *Queries) ListPermissionsForRole(ctx context.Context, arg ListPermissionsForRoleParams) ([]ListPermissionsForRoleRow, error) {
+	rows, err := q.db.QueryContext(ctx, listPermissionsForRole, arg.RoleID, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListPermissionsForRoleRow{}
+	for rows.Next() {
+		var i ListPermissionsForRoleRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Description,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.IsSystem,
+			&i.BreakGlass,
+			&i.GrantedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listRolePermissions = `-- name: ListRolePermissions :many
 SELECT role_id, permission_id, created_at, updated_at FROM role_permissions
 ORDER BY role_id, permission_id
@@ -3,13 +3,49 @@ package db
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 )
 
+var (
+	ErrProtectedRole       = errors.New("system role cannot be deleted or renamed")
+	ErrProtectedPermission = errors.New("system permission cannot be deleted, renamed or removed from a system role")
+	ErrLastAdmin           = errors.New("at least one user must keep the administrator permissions")
+)
+
 type Store interface {
 	Querier
 	TransferTx(ctx context.Context, arg TransferTxParams) (TransferTxResult, error)
 	UpdateUserRoleTx(ctx context.Context, arg UpdateUserRoleTxParams) (UpdateUserRoleTxResult, error)
+	RemoveRoleForUserTx(ctx context.Context, arg RemoveRoleForUserParams) error
+	DeleteRoleTx(ctx context.Context, id int32) error
+	DeleteRolePermissionTx(ctx context.Context, arg DeleteRolePermissionParams) error
+	UpdateRolePermissionTx(ctx context.Context, arg UpdateRolePermissionParams) (RolePermission, error)
+	CreateRolePermissionDenyTx(ctx context.Context, arg CreateRolePermissionDenyParams) (RolePermissionDeny, error)
+	UpsertUserPermissionOverrideTx(ctx context.Context, arg UpsertUserPermissionOverrideParams) (UserPermissionOverride, error)
+	DeleteUserPermissionOverrideTx(ctx context.Context, arg DeleteUserPermissionOverrideParams) error
+	ExportPolicy(ctx context.Context) (PolicyDocument, error)
+	ImportPolicyTx(ctx context.Context, arg ImportPolicyTxParams) (ImportPolicyTxResult, error)
+	CreateMedicineTx(ctx context.Context, arg CreateMedicineTxParams) (Medicine, error)
+	StockMovementTx(ctx context.Context, arg StockMovementTxParams) (StockMovementTxResult, error)
+	UpdateMedicineTx(ctx context.Context, arg UpdateMedicineTxParams) (Medicine, error)
+	ChangeMedicinePriceTx(ctx context.Context, arg ChangeMedicinePriceTxParams) (ChangeMedicinePriceTxResult, error)
+	BulkAdjustPricesTx(ctx context.Context, arg BulkAdjustPricesTxParams) (BulkAdjustPricesTxResult, error)
+	ImportMedicinesTx(ctx context.Context, arg ImportMedicinesTxParams) (ImportMedicinesTxResult, error)
+	SetMedicineCategoriesTx(ctx context.Context, arg SetMedicineCategoriesTxParams) ([]Category, error)
+	SetMedicineIngredientsTx(ctx context.Context, arg SetMedicineIngredientsTxParams) ([]ListMedicineIngredientsRow, error)
+	CheckMedicineWarnings(ctx context.Context, arg CheckMedicineWarningsParams) (CheckMedicineWarningsResult, error)
+	CreatePurchaseOrderTx(ctx context.Context, arg CreatePurchaseOrderTxParams) (PurchaseOrderTxResult, error)
+	UpdatePurchaseOrderTx(ctx context.Context, arg UpdatePurchaseOrderTxParams) (PurchaseOrderTxResult, error)
+	PlacePurchaseOrderTx(ctx context.Context, id int32) (PurchaseOrder, error)
+	ReceiveGoodsTx(ctx context.Context, arg ReceiveGoodsTxParams) (ReceiveGoodsTxResult, error)
+	CreateStocktakeTx(ctx context.Context, arg CreateStocktakeTxParams) (StocktakeTxResult, error)
+	RecordStocktakeCountsTx(ctx context.Context, arg RecordStocktakeCountsTxParams) (StocktakeTxResult, error)
+	ApproveStocktakeTx(ctx context.Context, arg ApproveStocktakeTxParams) (ApproveStocktakeTxResult, error)
+	StockTransferTx(ctx context.Context, arg StockTransferTxParams) (StockTransferTxResult, error)
+	ReorderSuggestions(ctx context.Context, arg ReorderSuggestionsParams) ([]ReorderSuggestion, error)
+	ControlledRegisterReport(ctx context.Context, arg ControlledRegisterReportParams) ([]ControlledRegisterMedicine, error)
+	SetMedicineControlledTx(ctx context.Context, arg SetMedicineControlledTxParams) (Medicine, error)
 }
 
 type SQLStore struct {
@@ -114,9 +150,11 @@ func addMoney(ctx context.Context, q *Queries, accountID1 int64, amount1 int64,
 }
 
 type UpdateUserRoleTxParams struct {
-	UserID    int32 `json:"user_id"`
-	OldRoleID int32 `json:"old_role_id"`
-	NewRoleID int32 `json:"new_role_id"`
+	UserID     int32        `json:"user_id"`
+	OldRoleID  int32        `json:"old_role_id"`
+	NewRoleID  int32        `json:"new_role_id"`
+	ValidFrom  sql.NullTime `json:"valid_from"`
+	ValidUntil sql.NullTime `json:"valid_until"`
 }
 
 type UpdateUserRoleTxResult struct {
@@ -127,7 +165,12 @@ func (store *SQLStore) UpdateUserRoleTx(ctx context.Context, arg UpdateUserRoleT
 	var result UpdateUserRoleTxResult
 
 	err := store.execTx(ctx, func(q *Queries) error {
-		err := q.RemoveRoleForUser(ctx, RemoveRoleForUserParams{
+		err := q.LockSystemRoles(ctx)
+		if err != nil {
+			return err
+		}
+
+		err = q.RemoveRoleForUser(ctx, RemoveRoleForUserParams{
 			UserID: arg.UserID,
 			RoleID: arg.OldRoleID,
 		})
@@ -136,15 +179,223 @@ func (store *SQLStore) UpdateUserRoleTx(ctx context.Context, arg UpdateUserRoleT
 		}
 
 		result.UserRole, err = q.AddRoleForUser(ctx, AddRoleForUserParams{
-			UserID: arg.UserID,
-			RoleID: arg.NewRoleID,
+			UserID:     arg.UserID,
+			RoleID:     arg.NewRoleID,
+			ValidFrom:  arg.ValidFrom,
+			ValidUntil: arg.ValidUntil,
 		})
 		if err != nil {
 			return err
 		}
 
-		return nil
+		return ensureSystemAdmin(ctx, q)
 	})
 
 	return result, err
 }
+
+// ensureSystemAdmin fails when no user holds every system permission without
+// an end date anymore, so the surrounding transaction is rolled back instead
+// of locking everyone out once the remaining assignments lapse.
+// Callers must hold the lock taken by LockSystemRoles.
+func ensureSystemAdmin(ctx context.Context, q *Queries) error {
+	count, err := q.CountSystemAdmins(ctx)
+	if err != nil {
+		return err
+	}
+
+	if count == 0 {
+		return ErrLastAdmin
+	}
+
+	return nil
+}
+
+func (store *SQLStore) RemoveRoleForUserTx(ctx context.Context, arg RemoveRoleForUserParams) error {
+	return store.execTx(ctx, func(q *Queries) error {
+		err := q.LockSystemRoles(ctx)
+		if err != nil {
+			return err
+		}
+
+		err = q.RemoveRoleForUser(ctx, arg)
+		if err != nil {
+			return err
+		}
+
+		return ensureSystemAdmin(ctx, q)
+	})
+}
+
+func (store *SQLStore) DeleteRoleTx(ctx context.Context, id int32) error {
+	return store.execTx(ctx, func(q *Queries) error {
+		err := q.LockSystemRoles(ctx)
+		if err != nil {
+			return err
+		}
+
+		role, err := q.GetRole(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if role.IsSystem {
+			return ErrProtectedRole
+		}
+
+		err = q.DeleteRole(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		return ensureSystemAdmin(ctx, q)
+	})
+}
+
+func (store *SQLStore) DeleteRolePermissionTx(ctx context.Context, arg DeleteRolePermissionParams) error {
+	return store.execTx(ctx, func(q *Queries) error {
+		err := q.LockSystemRoles(ctx)
+		if err != nil {
+			return err
+		}
+
+		err = checkSystemGrant(ctx, q, arg.RoleID, arg.PermissionID)
+		if err != nil {
+			return err
+		}
+
+		err = q.DeleteRolePermission(ctx, arg)
+		if err != nil {
+			return err
+		}
+
+		return ensureSystemAdmin(ctx, q)
+	})
+}
+
+func (store *SQLStore) UpdateRolePermissionTx(ctx context.Context, arg UpdateRolePermissionParams) (RolePermission, error) {
+	var result RolePermission
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		err := q.LockSystemRoles(ctx)
+		if err != nil {
+			return err
+		}
+
+		err = checkSystemGrant(ctx, q, arg.RoleID, arg.PermissionID)
+		if err != nil {
+			return err
+		}
+
+		result, err = q.UpdateRolePermission(ctx, arg)
+		if err != nil {
+			return err
+		}
+
+		return ensureSystemAdmin(ctx, q)
+	})
+
+	return result, err
+}
+
+func (store *SQLStore) CreateRolePermissionDenyTx(ctx context.Context, arg CreateRolePermissionDenyParams) (RolePermissionDeny, error) {
+	var result RolePermissionDeny
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		err := q.LockSystemRoles(ctx)
+		if err != nil {
+			return err
+		}
+
+		role, err := q.GetRole(ctx, arg.RoleID)
+		if err != nil {
+			return err
+		}
+
+		permission, err := q.GetPermission(ctx, arg.PermissionID)
+		if err != nil {
+			return err
+		}
+
+		if role.IsSystem && permission.IsSystem {
+			return ErrProtectedPermission
+		}
+
+		result, err = q.CreateRolePermissionDeny(ctx, arg)
+		if err != nil {
+			return err
+		}
+
+		return ensureSystemAdmin(ctx, q)
+	})
+
+	return result, err
+}
+
+func (store *SQLStore) UpsertUserPermissionOverrideTx(ctx context.Context, arg UpsertUserPermissionOverrideParams) (UserPermissionOverride, error) {
+	var result UserPermissionOverride
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		err := q.LockSystemRoles(ctx)
+		if err != nil {
+			return err
+		}
+
+		result, err = q.UpsertUserPermissionOverride(ctx, arg)
+		if err != nil {
+			return err
+		}
+
+		return ensureSystemAdmin(ctx, q)
+	})
+
+	return result, err
+}
+
+func (store *SQLStore) DeleteUserPermissionOverrideTx(ctx context.Context, arg DeleteUserPermissionOverrideParams) error {
+	return store.execTx(ctx, func(q *Queries) error {
+		err := q.LockSystemRoles(ctx)
+		if err != nil {
+			return err
+		}
+
+		_, err = q.GetUserPermissionOverride(ctx, GetUserPermissionOverrideParams(arg))
+		if err != nil {
+			return err
+		}
+
+		err = q.DeleteUserPermissionOverride(ctx, arg)
+		if err != nil {
+			return err
+		}
+
+		return ensureSystemAdmin(ctx, q)
+	})
+}
+
+// checkSystemGrant refuses to touch a system permission granted to a system role.
+func checkSystemGrant(ctx context.Context, q *Queries, roleID int32, permissionID int32) error {
+	_, err := q.GetRolePermission(ctx, GetRolePermissionParams{
+		RoleID:       roleID,
+		PermissionID: permissionID,
+	})
+	if err != nil {
+		return err
+	}
+
+	role, err := q.GetRole(ctx, roleID)
+	if err != nil {
+		return err
+	}
+
+	permission, err := q.GetPermission(ctx, permissionID)
+	if err != nil {
+		return err
+	}
+
+	if role.IsSystem && permission.IsSystem {
+		return ErrProtectedPermission
+	}
+
+	return nil
+}
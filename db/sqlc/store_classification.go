@@ -0,0 +1,89 @@
+package db
+
+import (
+	"context"
+
+	"github.com/toannguyen3105/nht-bsihuyen.com-api/utils"
+)
+
+type SetMedicineCategoriesTxParams struct {
+	MedicineID  int32   `json:"medicine_id"`
+	CategoryIDs []int32 `json:"category_ids"`
+}
+
+// SetMedicineCategoriesTx replaces the categories of a medicine and returns
+// the new ones. A medicine that does not exist returns sql.ErrNoRows.
+func (store *SQLStore) SetMedicineCategoriesTx(ctx context.Context, arg SetMedicineCategoriesTxParams) ([]Category, error) {
+	var categories []Category
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		_, err := q.GetMedicineForUpdate(ctx, arg.MedicineID)
+		if err != nil {
+			return err
+		}
+
+		err = q.DeleteMedicineCategories(ctx, arg.MedicineID)
+		if err != nil {
+			return err
+		}
+
+		err = q.AddMedicineCategories(ctx, AddMedicineCategoriesParams{
+			MedicineID:  arg.MedicineID,
+			CategoryIds: arg.CategoryIDs,
+		})
+		if err != nil {
+			return err
+		}
+
+		categories, err = q.ListMedicineCategories(ctx, arg.MedicineID)
+		return err
+	})
+
+	return categories, err
+}
+
+type MedicineIngredientParams struct {
+	IngredientID int32         `json:"ingredient_id"`
+	Strength     utils.Decimal `json:"strength"`
+	StrengthUnit string        `json:"strength_unit"`
+}
+
+type SetMedicineIngredientsTxParams struct {
+	MedicineID  int32                      `json:"medicine_id"`
+	Ingredients []MedicineIngredientParams `json:"ingredients"`
+}
+
+// SetMedicineIngredientsTx replaces the active ingredients of a medicine and
+// returns the new ones. A medicine that does not exist returns sql.ErrNoRows.
+func (store *SQLStore) SetMedicineIngredientsTx(ctx context.Context, arg SetMedicineIngredientsTxParams) ([]ListMedicineIngredientsRow, error) {
+	var ingredients []ListMedicineIngredientsRow
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		_, err := q.GetMedicineForUpdate(ctx, arg.MedicineID)
+		if err != nil {
+			return err
+		}
+
+		err = q.DeleteMedicineIngredients(ctx, arg.MedicineID)
+		if err != nil {
+			return err
+		}
+
+		for _, ingredient := range arg.Ingredients {
+			_, err = q.CreateMedicineIngredient(ctx, CreateMedicineIngredientParams{
+				MedicineID:   arg.MedicineID,
+				IngredientID: ingredient.IngredientID,
+				Strength:     ingredient.Strength,
+				StrengthUnit: ingredient.StrengthUnit,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		ingredients, err = q.ListMedicineIngredients(ctx, []int32{arg.MedicineID})
+		return err
+	})
+
+	return ingredients, err
+}
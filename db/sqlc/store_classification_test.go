@@ -0,0 +1,74 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/toannguyen3105/nht-bsihuyen.com-api/utils"
+)
+
+func TestSetMedicineCategoriesTx(t *testing.T) {
+	store := NewStore(testDB)
+	medicine := createRandomMedicine(t, 0)
+
+	category, err := store.CreateCategory(context.Background(), CreateCategoryParams{Name: utils.RandomString(10)})
+	require.NoError(t, err)
+
+	categories, err := store.SetMedicineCategoriesTx(context.Background(), SetMedicineCategoriesTxParams{
+		MedicineID:  medicine.ID,
+		CategoryIDs: []int32{category.ID},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []Category{category}, categories)
+
+	medicines, err := store.ListMedicines(context.Background(), ListMedicinesParams{
+		CategoryID: sql.NullInt32{Int32: category.ID, Valid: true},
+		Limit:      5,
+	})
+	require.NoError(t, err)
+	require.Len(t, medicines, 1)
+	require.Equal(t, medicine.ID, medicines[0].ID)
+
+	// a category in use cannot be deleted
+	_, err = store.DeleteCategory(context.Background(), category.ID)
+	require.Error(t, err)
+
+	categories, err = store.SetMedicineCategoriesTx(context.Background(), SetMedicineCategoriesTxParams{
+		MedicineID:  medicine.ID,
+		CategoryIDs: []int32{},
+	})
+	require.NoError(t, err)
+	require.Empty(t, categories)
+}
+
+func TestSetMedicineIngredientsTx(t *testing.T) {
+	store := NewStore(testDB)
+	medicine := createRandomMedicine(t, 0)
+
+	ingredient, err := store.CreateActiveIngredient(context.Background(), utils.RandomString(10))
+	require.NoError(t, err)
+
+	ingredients, err := store.SetMedicineIngredientsTx(context.Background(), SetMedicineIngredientsTxParams{
+		MedicineID: medicine.ID,
+		Ingredients: []MedicineIngredientParams{{
+			IngredientID: ingredient.ID,
+			Strength:     utils.MustParseDecimal("500"),
+			StrengthUnit: "mg",
+		}},
+	})
+	require.NoError(t, err)
+	require.Len(t, ingredients, 1)
+	require.Equal(t, ingredient.Name, ingredients[0].Name)
+
+	medicines, err := store.ListMedicines(context.Background(), ListMedicinesParams{
+		IngredientID: sql.NullInt32{Int32: ingredient.ID, Valid: true},
+		Limit:        5,
+	})
+	require.NoError(t, err)
+	require.Len(t, medicines, 1)
+
+	_, err = store.SetMedicineIngredientsTx(context.Background(), SetMedicineIngredientsTxParams{MedicineID: -1})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
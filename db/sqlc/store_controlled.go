@@ -0,0 +1,194 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+var ErrWitnessRequired = errors.New("movements of controlled medicines need a second person as witness")
+
+// The register entries made when a medicine is put on or taken off the
+// register. They carry the balance at that moment and no stock movement.
+const (
+	RegisterOpened = "register_opened"
+	RegisterClosed = "register_closed"
+)
+
+// checkWitness makes sure a movement of a controlled medicine is made by a
+// known user and witnessed by another one. Other medicines need no witness.
+func checkWitness(medicine Medicine, createdBy, witnessedBy sql.NullString) error {
+	if !medicine.Controlled {
+		return nil
+	}
+
+	if !createdBy.Valid || !witnessedBy.Valid || createdBy.String == witnessedBy.String {
+		return fmt.Errorf("%w: %s", ErrWitnessRequired, medicine.Name)
+	}
+
+	return nil
+}
+
+// recordControlledMovement enters a movement of a controlled medicine in the
+// controlled register. Movements of other medicines are not entered.
+func recordControlledMovement(ctx context.Context, q *Queries, medicine Medicine, movement StockMovement, witnessedBy sql.NullString) error {
+	if !medicine.Controlled {
+		return nil
+	}
+
+	_, err := q.CreateControlledRegisterEntry(ctx, CreateControlledRegisterEntryParams{
+		MedicineID:      movement.MedicineID,
+		StockMovementID: sql.NullInt64{Int64: movement.ID, Valid: true},
+		MovementType:    movement.MovementType,
+		Quantity:        movement.Quantity,
+		Balance:         movement.Balance,
+		BatchID:         movement.BatchID,
+		LocationID:      movement.LocationID,
+		Note:            movement.Note,
+		RecordedBy:      movement.CreatedBy.String,
+		WitnessedBy:     witnessedBy.String,
+	})
+	return err
+}
+
+type SetMedicineControlledTxParams struct {
+	MedicineID  int32          `json:"medicine_id"`
+	Controlled  bool           `json:"controlled"`
+	Note        sql.NullString `json:"note"`
+	RecordedBy  sql.NullString `json:"recorded_by"`
+	WitnessedBy sql.NullString `json:"witnessed_by"`
+}
+
+// SetMedicineControlledTx puts a medicine on the controlled register or takes
+// it off, witnessed like a movement. The register gets an entry with the
+// stock at that moment, so its balances follow on from the movements before
+// and after. Setting the flag it already has changes nothing.
+func (store *SQLStore) SetMedicineControlledTx(ctx context.Context, arg SetMedicineControlledTxParams) (Medicine, error) {
+	var result Medicine
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		medicine, err := q.GetMedicineForUpdate(ctx, arg.MedicineID)
+		if err != nil {
+			return err
+		}
+
+		result = medicine
+		if medicine.Controlled == arg.Controlled {
+			return nil
+		}
+
+		// taking a medicine off needs a witness as much as putting it on
+		if !arg.RecordedBy.Valid || !arg.WitnessedBy.Valid || arg.RecordedBy.String == arg.WitnessedBy.String {
+			return fmt.Errorf("%w: %s", ErrWitnessRequired, medicine.Name)
+		}
+
+		result, err = q.SetMedicineControlled(ctx, SetMedicineControlledParams{
+			ID:         medicine.ID,
+			Controlled: arg.Controlled,
+		})
+		if err != nil {
+			return err
+		}
+
+		entryType := RegisterOpened
+		if !arg.Controlled {
+			entryType = RegisterClosed
+		}
+
+		_, err = q.CreateControlledRegisterEntry(ctx, CreateControlledRegisterEntryParams{
+			MedicineID:   medicine.ID,
+			MovementType: entryType,
+			Balance:      medicine.Stock,
+			Note:         arg.Note,
+			RecordedBy:   arg.RecordedBy.String,
+			WitnessedBy:  arg.WitnessedBy.String,
+		})
+		return err
+	})
+
+	return result, err
+}
+
+type ControlledRegisterReportParams struct {
+	// PeriodStart and PeriodEnd bound the period; the end is exclusive.
+	PeriodStart time.Time     `json:"period_start"`
+	PeriodEnd   time.Time     `json:"period_end"`
+	MedicineID  sql.NullInt32 `json:"medicine_id"`
+}
+
+type ControlledRegisterMedicine struct {
+	MedicineID     int32  `json:"medicine_id"`
+	MedicineName   string `json:"medicine_name"`
+	Unit           string `json:"unit"`
+	OpeningBalance int32  `json:"opening_balance"`
+	// Received and Issued total the entries that added and removed stock;
+	// transfers between locations count as neither.
+	Received       int32                              `json:"received"`
+	Issued         int32                              `json:"issued"`
+	ClosingBalance int32                              `json:"closing_balance"`
+	Entries        []ListControlledRegisterEntriesRow `json:"entries"`
+}
+
+// ControlledRegisterReport returns the register of each controlled medicine
+// over a period: the stock it opened and closed with and the entries in
+// between. A medicine that does not exist returns sql.ErrNoRows.
+func (store *SQLStore) ControlledRegisterReport(ctx context.Context, arg ControlledRegisterReportParams) ([]ControlledRegisterMedicine, error) {
+	if arg.MedicineID.Valid {
+		_, err := store.GetMedicine(ctx, arg.MedicineID.Int32)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	balances, err := store.ListControlledRegisterBalances(ctx, ListControlledRegisterBalancesParams{
+		PeriodStart: arg.PeriodStart,
+		PeriodEnd:   arg.PeriodEnd,
+		MedicineID:  arg.MedicineID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := store.ListControlledRegisterEntries(ctx, ListControlledRegisterEntriesParams{
+		PeriodStart: arg.PeriodStart,
+		PeriodEnd:   arg.PeriodEnd,
+		MedicineID:  arg.MedicineID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	byMedicine := make(map[int32][]ListControlledRegisterEntriesRow)
+	for _, entry := range entries {
+		byMedicine[entry.MedicineID] = append(byMedicine[entry.MedicineID], entry)
+	}
+
+	report := make([]ControlledRegisterMedicine, len(balances))
+	for i, balance := range balances {
+		medicine := ControlledRegisterMedicine{
+			MedicineID:     balance.ID,
+			MedicineName:   balance.Name,
+			Unit:           balance.Unit,
+			OpeningBalance: balance.OpeningBalance,
+			ClosingBalance: balance.ClosingBalance,
+			Entries:        byMedicine[balance.ID],
+		}
+		if medicine.Entries == nil {
+			medicine.Entries = []ListControlledRegisterEntriesRow{}
+		}
+		for _, entry := range medicine.Entries {
+			switch {
+			case entry.MovementType == MovementTransfer, entry.Quantity == 0:
+			case entry.Quantity > 0:
+				medicine.Received += entry.Quantity
+			default:
+				medicine.Issued -= entry.Quantity
+			}
+		}
+		report[i] = medicine
+	}
+
+	return report, nil
+}
@@ -0,0 +1,137 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/toannguyen3105/nht-bsihuyen.com-api/utils"
+)
+
+func TestControlledRegister(t *testing.T) {
+	store := NewStore(testDB)
+	pharmacist := createRandomUser(t)
+	witness := createRandomUser(t)
+	start := time.Now().Add(-time.Hour)
+
+	medicine := createRandomMedicine(t, 20)
+	control := SetMedicineControlledTxParams{
+		MedicineID: medicine.ID,
+		Controlled: true,
+		RecordedBy: sql.NullString{String: pharmacist.Username, Valid: true},
+	}
+	_, err := store.SetMedicineControlledTx(context.Background(), control)
+	require.ErrorIs(t, err, ErrWitnessRequired)
+
+	control.WitnessedBy = sql.NullString{String: witness.Username, Valid: true}
+	medicine, err = store.SetMedicineControlledTx(context.Background(), control)
+	require.NoError(t, err)
+	require.True(t, medicine.Controlled)
+
+	dispense := StockMovementTxParams{
+		MedicineID:   medicine.ID,
+		MovementType: MovementDispense,
+		Quantity:     -4,
+		CreatedBy:    sql.NullString{String: pharmacist.Username, Valid: true},
+	}
+	_, err = store.StockMovementTx(context.Background(), dispense)
+	require.ErrorIs(t, err, ErrWitnessRequired)
+
+	// nobody witnesses their own movement
+	dispense.WitnessedBy = dispense.CreatedBy
+	_, err = store.StockMovementTx(context.Background(), dispense)
+	require.ErrorIs(t, err, ErrWitnessRequired)
+
+	dispense.WitnessedBy = sql.NullString{String: witness.Username, Valid: true}
+	dispensed, err := store.StockMovementTx(context.Background(), dispense)
+	require.NoError(t, err)
+	require.Equal(t, int32(16), dispensed.Medicine.Stock)
+
+	cabinet, err := store.CreateStockLocation(context.Background(), utils.RandomString(12))
+	require.NoError(t, err)
+	pharmacy, err := store.GetDefaultStockLocation(context.Background())
+	require.NoError(t, err)
+
+	transfer := StockTransferTxParams{
+		MedicineID:     medicine.ID,
+		FromLocationID: pharmacy.ID,
+		ToLocationID:   cabinet.ID,
+		Quantity:       6,
+		CreatedBy:      sql.NullString{String: pharmacist.Username, Valid: true},
+	}
+	_, err = store.StockTransferTx(context.Background(), transfer)
+	require.ErrorIs(t, err, ErrWitnessRequired)
+
+	transfer.WitnessedBy = sql.NullString{String: witness.Username, Valid: true}
+	_, err = store.StockTransferTx(context.Background(), transfer)
+	require.NoError(t, err)
+
+	report, err := store.ControlledRegisterReport(context.Background(), ControlledRegisterReportParams{
+		PeriodStart: start,
+		PeriodEnd:   time.Now().Add(time.Hour),
+		MedicineID:  sql.NullInt32{Int32: medicine.ID, Valid: true},
+	})
+	require.NoError(t, err)
+	require.Len(t, report, 1)
+
+	// the initial stock came in before the medicine was controlled; the
+	// register opens with it, then has the dispense and both legs of the
+	// transfer
+	register := report[0]
+	require.Zero(t, register.OpeningBalance)
+	require.Equal(t, int32(16), register.ClosingBalance)
+	require.Zero(t, register.Received)
+	require.Equal(t, int32(4), register.Issued)
+	require.Len(t, register.Entries, 4)
+	require.Equal(t, RegisterOpened, register.Entries[0].MovementType)
+	require.Equal(t, int32(20), register.Entries[0].Balance)
+	require.False(t, register.Entries[0].StockMovementID.Valid)
+	require.Equal(t, MovementDispense, register.Entries[1].MovementType)
+	require.Equal(t, int32(16), register.Entries[1].Balance)
+	require.Equal(t, pharmacist.Username, register.Entries[1].RecordedBy)
+	require.Equal(t, witness.Username, register.Entries[1].WitnessedBy)
+	require.Equal(t, cabinet.Name, register.Entries[3].LocationName.String)
+
+	// the register is append-only
+	_, err = testDB.ExecContext(context.Background(), "UPDATE controlled_register SET quantity = 0 WHERE id = $1", register.Entries[0].ID)
+	require.Error(t, err)
+	_, err = testDB.ExecContext(context.Background(), "DELETE FROM controlled_register WHERE id = $1", register.Entries[0].ID)
+	require.Error(t, err)
+
+	// taking it off the register closes it with the balance, and the medicine
+	// stays in the report for the period
+	control.Controlled = false
+	medicine, err = store.SetMedicineControlledTx(context.Background(), control)
+	require.NoError(t, err)
+	require.False(t, medicine.Controlled)
+
+	report, err = store.ControlledRegisterReport(context.Background(), ControlledRegisterReportParams{
+		PeriodStart: start,
+		PeriodEnd:   time.Now().Add(time.Hour),
+		MedicineID:  sql.NullInt32{Int32: medicine.ID, Valid: true},
+	})
+	require.NoError(t, err)
+	require.Len(t, report, 1)
+	require.Len(t, report[0].Entries, 5)
+	require.Equal(t, RegisterClosed, report[0].Entries[4].MovementType)
+	require.Equal(t, int32(16), report[0].Entries[4].Balance)
+
+	// other medicines need no witness and stay out of the register
+	other := createRandomMedicine(t, 5)
+	_, err = store.StockMovementTx(context.Background(), StockMovementTxParams{
+		MedicineID:   other.ID,
+		MovementType: MovementDispense,
+		Quantity:     -1,
+	})
+	require.NoError(t, err)
+
+	report, err = store.ControlledRegisterReport(context.Background(), ControlledRegisterReportParams{
+		PeriodStart: start,
+		PeriodEnd:   time.Now().Add(time.Hour),
+		MedicineID:  sql.NullInt32{Int32: other.ID, Valid: true},
+	})
+	require.NoError(t, err)
+	require.Empty(t, report)
+}
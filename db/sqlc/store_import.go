@@ -0,0 +1,324 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/toannguyen3105/nht-bsihuyen.com-api/utils"
+)
+
+var ErrInvalidImport = errors.New("import has invalid rows")
+
+const (
+	ImportCreated   = "created"
+	ImportUpdated   = "updated"
+	ImportUnchanged = "unchanged"
+)
+
+// MedicineImportRow is one medicine read from an imported catalog. A Price
+// without a currency keeps the currency of the medicine it updates, and new
+// medicines are priced in đồng.
+type MedicineImportRow struct {
+	// Row is the line of the file the medicine was read from.
+	Row          int            `json:"row"`
+	Sku          sql.NullString `json:"sku"`
+	Name         string         `json:"name"`
+	Unit         string         `json:"unit"`
+	Price        utils.Money    `json:"price"`
+	Description  sql.NullString `json:"description"`
+	ReorderLevel sql.NullInt32  `json:"reorder_level"`
+}
+
+type MedicineImportError struct {
+	Row     int    `json:"row"`
+	Message string `json:"message"`
+}
+
+type MedicineImportResult struct {
+	Row        int    `json:"row"`
+	Action     string `json:"action"`
+	MedicineID int32  `json:"medicine_id"`
+	Name       string `json:"name"`
+}
+
+type ImportMedicinesTxParams struct {
+	Rows []MedicineImportRow `json:"rows"`
+	// DefaultReorderLevel is used for new medicines whose row has none.
+	DefaultReorderLevel int32          `json:"default_reorder_level"`
+	CreatedBy           sql.NullString `json:"created_by"`
+	DryRun              bool           `json:"dry_run"`
+}
+
+type ImportMedicinesTxResult struct {
+	DryRun    bool                   `json:"dry_run"`
+	Created   int                    `json:"created"`
+	Updated   int                    `json:"updated"`
+	Unchanged int                    `json:"unchanged"`
+	Rows      []MedicineImportResult `json:"rows"`
+	Errors    []MedicineImportError  `json:"errors"`
+}
+
+// ImportMedicinesTx creates or updates a medicine for each row, all or
+// nothing. A row with a SKU updates the medicine with that SKU; otherwise, or
+// when no medicine has it yet, the row updates the medicine with the same
+// name, and a new medicine is created when there is none. Problems are
+// reported per row in the result together with ErrInvalidImport, and nothing
+// is saved.
+func (store *SQLStore) ImportMedicinesTx(ctx context.Context, arg ImportMedicinesTxParams) (ImportMedicinesTxResult, error) {
+	result := ImportMedicinesTxResult{
+		DryRun: arg.DryRun,
+		Rows:   []MedicineImportResult{},
+		Errors: []MedicineImportError{},
+	}
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		targets, err := matchImportRows(ctx, q, arg.Rows, &result)
+		if err != nil {
+			return err
+		}
+		if len(result.Errors) > 0 {
+			return ErrInvalidImport
+		}
+
+		for i, row := range arg.Rows {
+			rowResult, err := importMedicine(ctx, q, row, targets[i], arg)
+			if err != nil {
+				return fmt.Errorf("row %d: %w", row.Row, err)
+			}
+
+			switch rowResult.Action {
+			case ImportCreated:
+				result.Created++
+			case ImportUpdated:
+				result.Updated++
+			default:
+				result.Unchanged++
+			}
+			result.Rows = append(result.Rows, rowResult)
+		}
+
+		if arg.DryRun {
+			return errDryRun
+		}
+		return nil
+	})
+	if errors.Is(err, errDryRun) {
+		err = nil
+	}
+
+	return result, err
+}
+
+// matchImportRows finds the medicine each row updates, nil for new ones, and
+// locks them in ascending ID order. Rows that cannot be imported are added to
+// the errors of the result.
+func matchImportRows(ctx context.Context, q *Queries, rows []MedicineImportRow, result *ImportMedicinesTxResult) ([]*Medicine, error) {
+	units, err := q.ListUnits(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	knownUnits := make(map[string]bool, len(units))
+	for _, unit := range units {
+		knownUnits[unit.Name] = true
+	}
+
+	arg := ListMedicinesForImportParams{
+		Skus:  []string{},
+		Names: []string{},
+	}
+	for _, row := range rows {
+		if row.Sku.Valid {
+			arg.Skus = append(arg.Skus, row.Sku.String)
+		}
+		arg.Names = append(arg.Names, row.Name)
+	}
+
+	medicines, err := q.ListMedicinesForImport(ctx, arg)
+	if err != nil {
+		return nil, err
+	}
+
+	bySku := make(map[string]*Medicine)
+	byName := make(map[string][]*Medicine)
+	for i := range medicines {
+		medicine := &medicines[i]
+		if medicine.Sku.Valid {
+			bySku[medicine.Sku.String] = medicine
+		}
+		if !medicine.DeletedAt.Valid {
+			byName[medicine.Name] = append(byName[medicine.Name], medicine)
+		}
+	}
+
+	addError := func(row int, format string, a ...any) {
+		result.Errors = append(result.Errors, MedicineImportError{
+			Row:     row,
+			Message: fmt.Sprintf(format, a...),
+		})
+	}
+
+	targets := make([]*Medicine, len(rows))
+	skuRows := make(map[string]int)
+	nameRows := make(map[string]int)
+	medicineRows := make(map[int32]int)
+	for i, row := range rows {
+		if !knownUnits[row.Unit] {
+			addError(row.Row, "unknown unit %q", row.Unit)
+		}
+
+		if row.Sku.Valid {
+			if other, ok := skuRows[row.Sku.String]; ok {
+				addError(row.Row, "SKU %q is also on row %d", row.Sku.String, other)
+				continue
+			}
+			skuRows[row.Sku.String] = row.Row
+
+			if medicine, ok := bySku[row.Sku.String]; ok {
+				if medicine.DeletedAt.Valid {
+					addError(row.Row, "SKU %q belongs to an archived medicine; restore it first", row.Sku.String)
+					continue
+				}
+				targets[i] = medicine
+			}
+		} else {
+			if other, ok := nameRows[row.Name]; ok {
+				addError(row.Row, "%q is also on row %d; add a SKU to tell them apart", row.Name, other)
+				continue
+			}
+			nameRows[row.Name] = row.Row
+		}
+
+		if targets[i] == nil {
+			// a row with a new SKU only takes over a medicine that has none
+			// and that no other row updates; otherwise it is a new medicine
+			var candidates []*Medicine
+			for _, medicine := range byName[row.Name] {
+				_, claimed := medicineRows[medicine.ID]
+				if !row.Sku.Valid || !medicine.Sku.Valid && !claimed {
+					candidates = append(candidates, medicine)
+				}
+			}
+
+			switch {
+			case len(candidates) == 1:
+				targets[i] = candidates[0]
+			case len(candidates) > 1 && !row.Sku.Valid:
+				addError(row.Row, "%d medicines are named %q; add a SKU to choose one", len(candidates), row.Name)
+				continue
+			}
+		}
+
+		if targets[i] != nil {
+			if other, ok := medicineRows[targets[i].ID]; ok {
+				addError(row.Row, "updates the same medicine as row %d", other)
+				continue
+			}
+			medicineRows[targets[i].ID] = row.Row
+		}
+
+		if err := importPrice(row, targets[i]).Validate(); err != nil {
+			addError(row.Row, "%s", err)
+		}
+
+		if targets[i] != nil && row.Unit != targets[i].Unit {
+			inUse, err := q.MedicineUnitInUse(ctx, targets[i].ID)
+			if err != nil {
+				return nil, err
+			}
+			if inUse {
+				addError(row.Row, "unit cannot change from %q to %q while the medicine has stock, lots or conversions", targets[i].Unit, row.Unit)
+			}
+		}
+	}
+
+	return targets, nil
+}
+
+// importPrice is the price of the row in the currency it names, or else in
+// the currency of the medicine it updates, or đồng for a new medicine.
+func importPrice(row MedicineImportRow, target *Medicine) utils.Money {
+	price := row.Price
+	if price.Currency == "" {
+		price.Currency = utils.VND
+		if target != nil {
+			price.Currency = target.Currency
+		}
+	}
+	return price
+}
+
+func importMedicine(ctx context.Context, q *Queries, row MedicineImportRow, target *Medicine, arg ImportMedicinesTxParams) (MedicineImportResult, error) {
+	result := MedicineImportResult{
+		Row:  row.Row,
+		Name: row.Name,
+	}
+
+	price := importPrice(row, target)
+	if target == nil {
+		reorderLevel := arg.DefaultReorderLevel
+		if row.ReorderLevel.Valid {
+			reorderLevel = row.ReorderLevel.Int32
+		}
+
+		medicine, err := createMedicineWithPrice(ctx, q, CreateMedicineTxParams{
+			Medicine: CreateMedicineParams{
+				Name:         row.Name,
+				Unit:         row.Unit,
+				Price:        price.Amount,
+				Currency:     price.Currency,
+				Description:  row.Description,
+				ReorderLevel: reorderLevel,
+				Sku:          row.Sku,
+			},
+			CreatedBy: arg.CreatedBy,
+		})
+		result.Action = ImportCreated
+		result.MedicineID = medicine.ID
+		return result, err
+	}
+
+	result.MedicineID = target.ID
+	update := UpdateMedicineParams{ID: target.ID}
+	changed := false
+	if row.Name != target.Name {
+		update.Name = sql.NullString{String: row.Name, Valid: true}
+		changed = true
+	}
+	if row.Unit != target.Unit {
+		update.Unit = sql.NullString{String: row.Unit, Valid: true}
+		changed = true
+	}
+	if price != utils.NewMoney(target.Price, target.Currency) {
+		update.Price = utils.NullDecimal{Decimal: price.Amount, Valid: true}
+		update.Currency = sql.NullString{String: price.Currency, Valid: true}
+		changed = true
+	}
+	if row.Description.Valid && row.Description != target.Description {
+		update.Description = row.Description
+		changed = true
+	}
+	if row.ReorderLevel.Valid && row.ReorderLevel.Int32 != target.ReorderLevel {
+		update.ReorderLevel = row.ReorderLevel
+		changed = true
+	}
+	if row.Sku.Valid && row.Sku != target.Sku {
+		update.Sku = row.Sku
+		changed = true
+	}
+
+	if !changed {
+		result.Action = ImportUnchanged
+		return result, nil
+	}
+
+	_, err := updateMedicineWithPrice(ctx, q, UpdateMedicineTxParams{
+		Medicine:    update,
+		PriceReason: "Catalog import",
+		CreatedBy:   arg.CreatedBy,
+	})
+	result.Action = ImportUpdated
+	return result, err
+}
@@ -0,0 +1,132 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/toannguyen3105/nht-bsihuyen.com-api/utils"
+)
+
+func TestImportMedicinesTx(t *testing.T) {
+	store := NewStore(testDB)
+	existing := createRandomMedicine(t, 0)
+	sku := utils.RandomString(12)
+
+	arg := ImportMedicinesTxParams{
+		Rows: []MedicineImportRow{
+			{
+				Row:   2,
+				Sku:   sql.NullString{String: sku, Valid: true},
+				Name:  existing.Name,
+				Unit:  existing.Unit,
+				Price: utils.NewMoney(utils.MustParseDecimal("300"), existing.Currency),
+			},
+			{
+				Row:   3,
+				Name:  utils.RandomString(10),
+				Unit:  "box",
+				Price: utils.NewMoney(utils.MustParseDecimal("1000"), utils.VND),
+			},
+		},
+		DefaultReorderLevel: 10,
+		DryRun:              true,
+	}
+
+	result, err := store.ImportMedicinesTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, 1, result.Created)
+	require.Equal(t, 1, result.Updated)
+
+	medicine, err := store.GetMedicine(context.Background(), existing.ID)
+	require.NoError(t, err)
+	require.Equal(t, existing.Price, medicine.Price)
+
+	arg.DryRun = false
+	result, err = store.ImportMedicinesTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, existing.ID, result.Rows[0].MedicineID)
+
+	// the existing medicine took the SKU, so it is found by SKU from now on
+	medicine, err = store.GetMedicine(context.Background(), existing.ID)
+	require.NoError(t, err)
+	require.Equal(t, sku, medicine.Sku.String)
+	require.Equal(t, utils.MustParseDecimal("300"), medicine.Price)
+
+	arg.Rows[0].Name = utils.RandomString(10)
+	arg.Rows[1].Unit = "sachet"
+	result, err = store.ImportMedicinesTx(context.Background(), arg)
+	require.ErrorIs(t, err, ErrInvalidImport)
+	require.Len(t, result.Errors, 1)
+	require.Equal(t, 3, result.Errors[0].Row)
+}
+
+func TestImportMedicinesTxKeepsCurrency(t *testing.T) {
+	store := NewStore(testDB)
+
+	existing, err := store.CreateMedicineTx(context.Background(), CreateMedicineTxParams{
+		Medicine: CreateMedicineParams{
+			Name:     utils.RandomString(10),
+			Unit:     "bottle",
+			Price:    utils.MustParseDecimal("4.50"),
+			Currency: utils.USD,
+		},
+	})
+	require.NoError(t, err)
+
+	// a blank currency cell keeps the medicine's currency
+	_, err = store.ImportMedicinesTx(context.Background(), ImportMedicinesTxParams{
+		Rows: []MedicineImportRow{
+			{
+				Row:   2,
+				Name:  existing.Name,
+				Unit:  existing.Unit,
+				Price: utils.NewMoney(utils.MustParseDecimal("4.75"), ""),
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	medicine, err := store.GetMedicine(context.Background(), existing.ID)
+	require.NoError(t, err)
+	require.Equal(t, utils.USD, medicine.Currency)
+	require.Equal(t, utils.MustParseDecimal("4.75"), medicine.Price)
+
+	// new medicines fall back to đồng, which has no minor unit
+	result, err := store.ImportMedicinesTx(context.Background(), ImportMedicinesTxParams{
+		Rows: []MedicineImportRow{
+			{
+				Row:   2,
+				Name:  utils.RandomString(10),
+				Unit:  "box",
+				Price: utils.NewMoney(utils.MustParseDecimal("4.75"), ""),
+			},
+		},
+	})
+	require.ErrorIs(t, err, ErrInvalidImport)
+	require.Len(t, result.Errors, 1)
+}
+
+func TestImportMedicinesTxUnitInUse(t *testing.T) {
+	store := NewStore(testDB)
+	stocked := createRandomMedicine(t, 10)
+
+	result, err := store.ImportMedicinesTx(context.Background(), ImportMedicinesTxParams{
+		Rows: []MedicineImportRow{
+			{
+				Row:   2,
+				Name:  stocked.Name,
+				Unit:  "box",
+				Price: utils.NewMoney(stocked.Price, stocked.Currency),
+			},
+		},
+	})
+	require.ErrorIs(t, err, ErrInvalidImport)
+	require.Len(t, result.Errors, 1)
+	require.Equal(t, 2, result.Errors[0].Row)
+
+	medicine, err := store.GetMedicine(context.Background(), stocked.ID)
+	require.NoError(t, err)
+	require.Equal(t, stocked.Unit, medicine.Unit)
+}
@@ -0,0 +1,144 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+)
+
+const (
+	SeverityMinor           = "minor"
+	SeverityModerate        = "moderate"
+	SeverityMajor           = "major"
+	SeverityContraindicated = "contraindicated"
+)
+
+// severityRank orders severities from the least to the most serious.
+var severityRank = map[string]int{
+	SeverityMinor:           1,
+	SeverityModerate:        2,
+	SeverityMajor:           3,
+	SeverityContraindicated: 4,
+}
+
+const (
+	WarningInteraction = "interaction"
+	WarningAllergy     = "allergy"
+)
+
+type MedicineWarning struct {
+	Type        string   `json:"type"`
+	Severity    string   `json:"severity"`
+	MedicineIDs []int32  `json:"medicine_ids"`
+	Ingredients []string `json:"ingredients"`
+	Description string   `json:"description"`
+}
+
+type CheckMedicineWarningsParams struct {
+	MedicineIDs []int32 `json:"medicine_ids"`
+	// AllergyIngredientIDs are the active ingredients the patient is
+	// allergic to.
+	AllergyIngredientIDs []int32 `json:"allergy_ingredient_ids"`
+}
+
+type CheckMedicineWarningsResult struct {
+	Warnings []MedicineWarning `json:"warnings"`
+	// UncheckedMedicineIDs are the medicines without recorded ingredients,
+	// which no warning can be given for.
+	UncheckedMedicineIDs []int32 `json:"unchecked_medicine_ids"`
+}
+
+// CheckMedicineWarnings looks for known interactions between the ingredients
+// of different medicines in the list and for ingredients the patient is
+// allergic to. Warnings come most serious first. A medicine that does not
+// exist returns sql.ErrNoRows.
+func (store *SQLStore) CheckMedicineWarnings(ctx context.Context, arg CheckMedicineWarningsParams) (CheckMedicineWarningsResult, error) {
+	result := CheckMedicineWarningsResult{
+		Warnings:             []MedicineWarning{},
+		UncheckedMedicineIDs: []int32{},
+	}
+
+	medicines, err := store.ListMedicinesByIDs(ctx, arg.MedicineIDs)
+	if err != nil {
+		return result, err
+	}
+	if len(medicines) != len(arg.MedicineIDs) {
+		return result, sql.ErrNoRows
+	}
+
+	ingredients, err := store.ListMedicineIngredients(ctx, arg.MedicineIDs)
+	if err != nil {
+		return result, err
+	}
+
+	// the medicines each ingredient is in, in ascending ID order
+	medicinesByIngredient := make(map[int32][]int32)
+	names := make(map[int32]string)
+	checked := make(map[int32]bool)
+	for _, ingredient := range ingredients {
+		medicinesByIngredient[ingredient.IngredientID] = append(medicinesByIngredient[ingredient.IngredientID], ingredient.MedicineID)
+		names[ingredient.IngredientID] = ingredient.Name
+		checked[ingredient.MedicineID] = true
+	}
+	for _, medicine := range medicines {
+		if !checked[medicine.ID] {
+			result.UncheckedMedicineIDs = append(result.UncheckedMedicineIDs, medicine.ID)
+		}
+	}
+
+	ingredientIDs := make([]int32, 0, len(medicinesByIngredient))
+	for id := range medicinesByIngredient {
+		ingredientIDs = append(ingredientIDs, id)
+	}
+
+	interactions, err := store.ListInteractionsAmongIngredients(ctx, ingredientIDs)
+	if err != nil {
+		return result, err
+	}
+
+	for _, interaction := range interactions {
+		// ingredients combined in one medicine are meant to be taken together
+		for _, medicineA := range medicinesByIngredient[interaction.IngredientAID] {
+			for _, medicineB := range medicinesByIngredient[interaction.IngredientBID] {
+				if medicineA == medicineB {
+					continue
+				}
+
+				medicineIDs := []int32{medicineA, medicineB}
+				if medicineB < medicineA {
+					medicineIDs = []int32{medicineB, medicineA}
+				}
+
+				result.Warnings = append(result.Warnings, MedicineWarning{
+					Type:        WarningInteraction,
+					Severity:    interaction.Severity,
+					MedicineIDs: medicineIDs,
+					Ingredients: []string{interaction.IngredientAName, interaction.IngredientBName},
+					Description: interaction.Description,
+				})
+			}
+		}
+	}
+
+	for _, allergy := range arg.AllergyIngredientIDs {
+		medicineIDs, ok := medicinesByIngredient[allergy]
+		if !ok {
+			continue
+		}
+
+		result.Warnings = append(result.Warnings, MedicineWarning{
+			Type:        WarningAllergy,
+			Severity:    SeverityContraindicated,
+			MedicineIDs: medicineIDs,
+			Ingredients: []string{names[allergy]},
+			Description: fmt.Sprintf("the patient is allergic to %s", names[allergy]),
+		})
+	}
+
+	sort.SliceStable(result.Warnings, func(i, j int) bool {
+		return severityRank[result.Warnings[i].Severity] > severityRank[result.Warnings[j].Severity]
+	})
+
+	return result, nil
+}
@@ -0,0 +1,74 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/toannguyen3105/nht-bsihuyen.com-api/utils"
+)
+
+func createRandomIngredientMedicine(t *testing.T, ingredients ...ActiveIngredient) Medicine {
+	store := NewStore(testDB)
+	medicine := createRandomMedicine(t, 0)
+
+	arg := SetMedicineIngredientsTxParams{MedicineID: medicine.ID}
+	for _, ingredient := range ingredients {
+		arg.Ingredients = append(arg.Ingredients, MedicineIngredientParams{
+			IngredientID: ingredient.ID,
+			Strength:     utils.MustParseDecimal("100"),
+			StrengthUnit: "mg",
+		})
+	}
+
+	_, err := store.SetMedicineIngredientsTx(context.Background(), arg)
+	require.NoError(t, err)
+	return medicine
+}
+
+func TestCheckMedicineWarnings(t *testing.T) {
+	store := NewStore(testDB)
+
+	aspirin, err := store.CreateActiveIngredient(context.Background(), utils.RandomString(10))
+	require.NoError(t, err)
+	warfarin, err := store.CreateActiveIngredient(context.Background(), utils.RandomString(10))
+	require.NoError(t, err)
+
+	_, err = store.UpsertIngredientInteraction(context.Background(), UpsertIngredientInteractionParams{
+		IngredientAID: aspirin.ID,
+		IngredientBID: warfarin.ID,
+		Severity:      SeverityMajor,
+		Description:   "Increased risk of bleeding",
+	})
+	require.NoError(t, err)
+
+	medicine1 := createRandomIngredientMedicine(t, aspirin)
+	medicine2 := createRandomIngredientMedicine(t, warfarin)
+	// a combination product does not warn about itself
+	combined := createRandomIngredientMedicine(t, aspirin, warfarin)
+	unknown := createRandomMedicine(t, 0)
+
+	result, err := store.CheckMedicineWarnings(context.Background(), CheckMedicineWarningsParams{
+		MedicineIDs:          []int32{medicine1.ID, medicine2.ID, unknown.ID},
+		AllergyIngredientIDs: []int32{warfarin.ID},
+	})
+	require.NoError(t, err)
+	require.Len(t, result.Warnings, 2)
+	require.Equal(t, WarningAllergy, result.Warnings[0].Type)
+	require.Equal(t, []int32{medicine2.ID}, result.Warnings[0].MedicineIDs)
+	require.Equal(t, WarningInteraction, result.Warnings[1].Type)
+	require.Equal(t, []int32{medicine1.ID, medicine2.ID}, result.Warnings[1].MedicineIDs)
+	require.Equal(t, []int32{unknown.ID}, result.UncheckedMedicineIDs)
+
+	result, err = store.CheckMedicineWarnings(context.Background(), CheckMedicineWarningsParams{
+		MedicineIDs: []int32{combined.ID},
+	})
+	require.NoError(t, err)
+	require.Empty(t, result.Warnings)
+
+	_, err = store.CheckMedicineWarnings(context.Background(), CheckMedicineWarningsParams{
+		MedicineIDs: []int32{medicine1.ID, -1},
+	})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
@@ -0,0 +1,164 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+var ErrInvalidStockTransfer = errors.New("invalid stock transfer")
+
+type StockTransferTxParams struct {
+	MedicineID     int32 `json:"medicine_id"`
+	FromLocationID int32 `json:"from_location_id"`
+	ToLocationID   int32 `json:"to_location_id"`
+	Quantity       int32 `json:"quantity"`
+	// Unit is the unit Quantity is given in, as for StockMovementTxParams.
+	Unit string `json:"unit"`
+	// BatchID is the batch to move. Without one the unexpired stock at the
+	// source location moves, earliest expiry first.
+	BatchID   sql.NullInt64  `json:"batch_id"`
+	Note      sql.NullString `json:"note"`
+	CreatedBy sql.NullString `json:"created_by"`
+	// WitnessedBy is needed to move a controlled medicine.
+	WitnessedBy sql.NullString `json:"witnessed_by"`
+}
+
+type StockTransferTxResult struct {
+	Transfer      StockTransfer   `json:"transfer"`
+	Medicine      Medicine        `json:"medicine"`
+	FromMovements []StockMovement `json:"from_movements"`
+	ToMovements   []StockMovement `json:"to_movements"`
+}
+
+// StockTransferTx moves stock of a medicine from one location to another in
+// one transaction, the way TransferTx moves money between accounts: a transfer
+// record plus a movement out of and into each location per batch. The stock of
+// the medicine stays the same. Like every other movement it runs under the
+// medicine's row lock.
+func (store *SQLStore) StockTransferTx(ctx context.Context, arg StockTransferTxParams) (StockTransferTxResult, error) {
+	var result StockTransferTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		if arg.Quantity <= 0 || arg.FromLocationID == arg.ToLocationID {
+			return fmt.Errorf("%w: %d from location %d to %d", ErrInvalidStockTransfer, arg.Quantity, arg.FromLocationID, arg.ToLocationID)
+		}
+
+		var err error
+		result.Medicine, err = q.GetMedicineForUpdate(ctx, arg.MedicineID)
+		if err != nil {
+			return err
+		}
+
+		err = checkWitness(result.Medicine, arg.CreatedBy, arg.WitnessedBy)
+		if err != nil {
+			return err
+		}
+
+		// what is left of an archived medicine stays where it is until it is
+		// returned, adjusted or written off
+		if result.Medicine.DeletedAt.Valid {
+			return fmt.Errorf("%w: %s", ErrMedicineArchived, result.Medicine.Name)
+		}
+
+		from, err := q.GetStockLocation(ctx, arg.FromLocationID)
+		if err != nil {
+			return err
+		}
+
+		to, err := q.GetStockLocation(ctx, arg.ToLocationID)
+		if err != nil {
+			return err
+		}
+
+		quantity, err := toMedicineUnit(ctx, q, result.Medicine, arg.Unit, arg.Quantity)
+		if err != nil {
+			return err
+		}
+
+		var moves []batchMovement
+		if arg.BatchID.Valid {
+			batch, err := q.GetMedicineBatch(ctx, arg.BatchID.Int64)
+			if err != nil {
+				return err
+			}
+			if batch.MedicineID != arg.MedicineID {
+				return sql.ErrNoRows
+			}
+			moves = []batchMovement{{batchID: batch.ID, quantity: -quantity}}
+		} else {
+			moves, err = allocateFEFO(ctx, q, result.Medicine, quantity, sql.NullInt32{Int32: from.ID, Valid: true})
+			if err != nil {
+				return err
+			}
+		}
+
+		moves, err = placeBatchMovements(ctx, q, result.Medicine, moves, from, true)
+		if err != nil {
+			return err
+		}
+
+		result.Transfer, err = q.CreateStockTransfer(ctx, CreateStockTransferParams{
+			MedicineID:     arg.MedicineID,
+			FromLocationID: from.ID,
+			ToLocationID:   to.ID,
+			Quantity:       quantity,
+			Note:           arg.Note,
+			CreatedBy:      arg.CreatedBy,
+		})
+		if err != nil {
+			return err
+		}
+
+		note := sql.NullString{String: fmt.Sprintf("Transfer #%d from %s to %s", result.Transfer.ID, from.Name, to.Name), Valid: true}
+		for _, move := range moves {
+			fromMovement, err := moveBatchLocation(ctx, q, result.Medicine, move, note, arg.CreatedBy, arg.WitnessedBy)
+			if err != nil {
+				return err
+			}
+			result.FromMovements = append(result.FromMovements, fromMovement)
+
+			move.quantity = -move.quantity
+			move.locationID = to.ID
+			toMovement, err := moveBatchLocation(ctx, q, result.Medicine, move, note, arg.CreatedBy, arg.WitnessedBy)
+			if err != nil {
+				return err
+			}
+			result.ToMovements = append(result.ToMovements, toMovement)
+		}
+
+		return nil
+	})
+
+	return result, err
+}
+
+// moveBatchLocation changes the quantity of a batch at a location and records
+// it as a transfer movement. The batch and the medicine keep their stock.
+func moveBatchLocation(ctx context.Context, q *Queries, medicine Medicine, move batchMovement, note sql.NullString, createdBy, witnessedBy sql.NullString) (StockMovement, error) {
+	_, err := q.AddBatchLocationQuantity(ctx, AddBatchLocationQuantityParams{
+		BatchID:    move.batchID,
+		LocationID: move.locationID,
+		Amount:     move.quantity,
+	})
+	if err != nil {
+		return StockMovement{}, err
+	}
+
+	movement, err := q.CreateStockMovement(ctx, CreateStockMovementParams{
+		MedicineID:   medicine.ID,
+		MovementType: MovementTransfer,
+		Quantity:     move.quantity,
+		Balance:      medicine.Stock,
+		Note:         note,
+		CreatedBy:    createdBy,
+		BatchID:      sql.NullInt64{Int64: move.batchID, Valid: true},
+		LocationID:   sql.NullInt32{Int32: move.locationID, Valid: true},
+	})
+	if err != nil {
+		return movement, err
+	}
+
+	return movement, recordControlledMovement(ctx, q, medicine, movement, witnessedBy)
+}
@@ -0,0 +1,92 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/toannguyen3105/nht-bsihuyen.com-api/utils"
+)
+
+func TestStockTransferTx(t *testing.T) {
+	store := NewStore(testDB)
+	medicine := createRandomMedicine(t, 30)
+
+	pharmacy, err := store.GetDefaultStockLocation(context.Background())
+	require.NoError(t, err)
+	cabinet, err := store.CreateStockLocation(context.Background(), utils.RandomString(12))
+	require.NoError(t, err)
+	require.False(t, cabinet.IsDefault)
+
+	result, err := store.StockTransferTx(context.Background(), StockTransferTxParams{
+		MedicineID:     medicine.ID,
+		FromLocationID: pharmacy.ID,
+		ToLocationID:   cabinet.ID,
+		Quantity:       12,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int32(12), result.Transfer.Quantity)
+	require.Equal(t, int32(30), result.Medicine.Stock)
+	require.Len(t, result.FromMovements, 1)
+	require.Len(t, result.ToMovements, 1)
+	require.Equal(t, int32(-12), result.FromMovements[0].Quantity)
+	require.Equal(t, pharmacy.ID, result.FromMovements[0].LocationID.Int32)
+	require.Equal(t, int32(12), result.ToMovements[0].Quantity)
+	require.Equal(t, cabinet.ID, result.ToMovements[0].LocationID.Int32)
+
+	locations, err := store.ListMedicineLocationStock(context.Background(), medicine.ID)
+	require.NoError(t, err)
+	require.Len(t, locations, 2)
+	require.Equal(t, int32(18), locations[0].Quantity)
+	require.Equal(t, int32(12), locations[1].Quantity)
+
+	// a dispense from the cabinet only takes what is kept there
+	dispense := StockMovementTxParams{
+		MedicineID:   medicine.ID,
+		MovementType: MovementDispense,
+		Quantity:     -13,
+		LocationID:   sql.NullInt32{Int32: cabinet.ID, Valid: true},
+	}
+	_, err = store.StockMovementTx(context.Background(), dispense)
+	require.ErrorIs(t, err, ErrInsufficientStock)
+
+	dispense.Quantity = -5
+	dispensed, err := store.StockMovementTx(context.Background(), dispense)
+	require.NoError(t, err)
+	require.Equal(t, cabinet.ID, dispensed.Movements[0].LocationID.Int32)
+
+	_, err = store.StockTransferTx(context.Background(), StockTransferTxParams{
+		MedicineID:     medicine.ID,
+		FromLocationID: cabinet.ID,
+		ToLocationID:   pharmacy.ID,
+		Quantity:       8,
+	})
+	require.ErrorIs(t, err, ErrInsufficientStock)
+
+	// without a location the main pharmacy goes first
+	dispensed, err = store.StockMovementTx(context.Background(), StockMovementTxParams{
+		MedicineID:   medicine.ID,
+		MovementType: MovementDispense,
+		Quantity:     -20,
+	})
+	require.NoError(t, err)
+	require.Len(t, dispensed.Movements, 2)
+	require.Equal(t, int32(-18), dispensed.Movements[0].Quantity)
+	require.Equal(t, pharmacy.ID, dispensed.Movements[0].LocationID.Int32)
+	require.Equal(t, int32(-2), dispensed.Movements[1].Quantity)
+	require.Equal(t, cabinet.ID, dispensed.Movements[1].LocationID.Int32)
+	require.Equal(t, int32(5), dispensed.Medicine.Stock)
+
+	// what is left of an archived medicine stays where it is
+	_, err = store.ArchiveMedicine(context.Background(), medicine.ID)
+	require.NoError(t, err)
+
+	_, err = store.StockTransferTx(context.Background(), StockTransferTxParams{
+		MedicineID:     medicine.ID,
+		FromLocationID: cabinet.ID,
+		ToLocationID:   pharmacy.ID,
+		Quantity:       1,
+	})
+	require.ErrorIs(t, err, ErrMedicineArchived)
+}
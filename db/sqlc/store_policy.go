@@ -0,0 +1,864 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+)
+
+var (
+	ErrInvalidPolicy = errors.New("invalid policy document")
+	errDryRun        = errors.New("dry run")
+)
+
+// Effects of a user permission override.
+const (
+	overrideAllow = "allow"
+	overrideDeny  = "deny"
+)
+
+// PolicyDocument is the portable form of the RBAC setup: every permission,
+// every role together with the names of the permissions it grants and denies,
+// and every user's role assignments and permission overrides. Users are not
+// created by an import; they must already exist. IsSystem is exported for
+// reference; an import cannot change it.
+type PolicyDocument struct {
+	Permissions []PolicyPermission `json:"permissions"`
+	Roles       []PolicyRole       `json:"roles"`
+	Users       []PolicyUser       `json:"users"`
+}
+
+type PolicyPermission struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	IsSystem    bool   `json:"is_system" yaml:"is_system"`
+	// BreakGlass lets users obtain the permission through break-glass access.
+	BreakGlass bool `json:"break_glass" yaml:"break_glass"`
+}
+
+type PolicyRole struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	IsSystem    bool     `json:"is_system" yaml:"is_system"`
+	Permissions []string `json:"permissions"`
+	Denies      []string `json:"denies"`
+}
+
+type PolicyUser struct {
+	Username  string           `json:"username"`
+	Roles     []PolicyUserRole `json:"roles"`
+	Overrides []PolicyOverride `json:"overrides"`
+}
+
+// PolicyUserRole assigns a role to a user, optionally only within a validity
+// window.
+type PolicyUserRole struct {
+	Role       string     `json:"role"`
+	ValidFrom  *time.Time `json:"valid_from,omitempty" yaml:"valid_from,omitempty"`
+	ValidUntil *time.Time `json:"valid_until,omitempty" yaml:"valid_until,omitempty"`
+}
+
+type PolicyOverride struct {
+	Permission string `json:"permission"`
+	Effect     string `json:"effect"`
+	Reason     string `json:"reason"`
+}
+
+type PolicyGrant struct {
+	Role       string `json:"role"`
+	Permission string `json:"permission"`
+}
+
+type PolicyAssignment struct {
+	User string `json:"user"`
+	Role string `json:"role"`
+}
+
+type PolicyUserPermission struct {
+	User       string `json:"user"`
+	Permission string `json:"permission"`
+}
+
+type PolicyDiff struct {
+	CreatedPermissions []string      `json:"created_permissions"`
+	UpdatedPermissions []string      `json:"updated_permissions"`
+	DeletedPermissions []string      `json:"deleted_permissions"`
+	CreatedRoles       []string      `json:"created_roles"`
+	UpdatedRoles       []string      `json:"updated_roles"`
+	DeletedRoles       []string      `json:"deleted_roles"`
+	Granted            []PolicyGrant `json:"granted"`
+	Revoked            []PolicyGrant `json:"revoked"`
+	Denied             []PolicyGrant `json:"denied"`
+	Undenied           []PolicyGrant `json:"undenied"`
+
+	AssignedRoles      []PolicyAssignment     `json:"assigned_roles"`
+	UpdatedRoleWindows []PolicyAssignment     `json:"updated_role_windows"`
+	UnassignedRoles    []PolicyAssignment     `json:"unassigned_roles"`
+	SetOverrides       []PolicyUserPermission `json:"set_overrides"`
+	RemovedOverrides   []PolicyUserPermission `json:"removed_overrides"`
+}
+
+type ImportPolicyTxParams struct {
+	Document PolicyDocument `json:"document"`
+	DryRun   bool           `json:"dry_run"`
+	// PruneUsers removes the role assignments and overrides of users missing
+	// from the document. Without it only the listed users are touched.
+	PruneUsers bool `json:"prune_users"`
+}
+
+type ImportPolicyTxResult struct {
+	DryRun bool       `json:"dry_run"`
+	Diff   PolicyDiff `json:"diff"`
+}
+
+func (store *SQLStore) ExportPolicy(ctx context.Context) (PolicyDocument, error) {
+	return exportPolicy(ctx, store.Queries)
+}
+
+func exportPolicy(ctx context.Context, q *Queries) (PolicyDocument, error) {
+	doc := PolicyDocument{
+		Permissions: []PolicyPermission{},
+		Roles:       []PolicyRole{},
+		Users:       []PolicyUser{},
+	}
+
+	permissions, err := q.ListAllPermissions(ctx)
+	if err != nil {
+		return doc, err
+	}
+
+	roles, err := q.ListAllRoles(ctx)
+	if err != nil {
+		return doc, err
+	}
+
+	grants, err := q.ListAllRolePermissions(ctx)
+	if err != nil {
+		return doc, err
+	}
+
+	denies, err := q.ListAllRolePermissionDenies(ctx)
+	if err != nil {
+		return doc, err
+	}
+
+	assignments, err := q.ListAllUserRoles(ctx)
+	if err != nil {
+		return doc, err
+	}
+
+	overrides, err := q.ListAllUserPermissionOverrides(ctx)
+	if err != nil {
+		return doc, err
+	}
+
+	permissionNames := make(map[int32]string, len(permissions))
+	for _, permission := range permissions {
+		permissionNames[permission.ID] = permission.Name
+		doc.Permissions = append(doc.Permissions, PolicyPermission{
+			Name:        permission.Name,
+			Description: permission.Description.String,
+			IsSystem:    permission.IsSystem,
+			BreakGlass:  permission.BreakGlass,
+		})
+	}
+
+	roleIndex := make(map[int32]int, len(roles))
+	for _, role := range roles {
+		roleIndex[role.ID] = len(doc.Roles)
+		doc.Roles = append(doc.Roles, PolicyRole{
+			Name:        role.Name,
+			Description: role.Description.String,
+			IsSystem:    role.IsSystem,
+			Permissions: []string{},
+			Denies:      []string{},
+		})
+	}
+
+	for _, grant := range grants {
+		role := &doc.Roles[roleIndex[grant.RoleID]]
+		role.Permissions = append(role.Permissions, permissionNames[grant.PermissionID])
+	}
+
+	for _, deny := range denies {
+		role := &doc.Roles[roleIndex[deny.RoleID]]
+		role.Denies = append(role.Denies, permissionNames[deny.PermissionID])
+	}
+
+	userIndex := make(map[string]int)
+	user := func(username string) *PolicyUser {
+		i, ok := userIndex[username]
+		if !ok {
+			i = len(doc.Users)
+			userIndex[username] = i
+			doc.Users = append(doc.Users, PolicyUser{
+				Username:  username,
+				Roles:     []PolicyUserRole{},
+				Overrides: []PolicyOverride{},
+			})
+		}
+		return &doc.Users[i]
+	}
+
+	for _, assignment := range assignments {
+		u := user(assignment.Username)
+		u.Roles = append(u.Roles, PolicyUserRole{
+			Role:       doc.Roles[roleIndex[assignment.RoleID]].Name,
+			ValidFrom:  policyTime(assignment.ValidFrom),
+			ValidUntil: policyTime(assignment.ValidUntil),
+		})
+	}
+
+	for _, override := range overrides {
+		u := user(override.Username)
+		u.Overrides = append(u.Overrides, PolicyOverride{
+			Permission: permissionNames[override.PermissionID],
+			Effect:     override.Effect,
+			Reason:     override.Reason,
+		})
+	}
+
+	sort.Slice(doc.Users, func(i, j int) bool {
+		return doc.Users[i].Username < doc.Users[j].Username
+	})
+
+	return doc, nil
+}
+
+func policyTime(t sql.NullTime) *time.Time {
+	if !t.Valid {
+		return nil
+	}
+	return &t.Time
+}
+
+func nullPolicyTime(t *time.Time) sql.NullTime {
+	if t == nil {
+		return sql.NullTime{}
+	}
+	return sql.NullTime{Time: *t, Valid: true}
+}
+
+func sameNullTime(a, b sql.NullTime) bool {
+	if a.Valid != b.Valid {
+		return false
+	}
+	return !a.Valid || a.Time.Equal(b.Time)
+}
+
+// validate checks that names are unique, that roles only grant and deny
+// permissions declared in the document, and that users are only assigned
+// declared roles and overridden on declared permissions.
+func (doc PolicyDocument) validate() error {
+	permissions := make(map[string]bool, len(doc.Permissions))
+	for _, permission := range doc.Permissions {
+		if permission.Name == "" {
+			return fmt.Errorf("%w: permission name is required", ErrInvalidPolicy)
+		}
+		if permissions[permission.Name] {
+			return fmt.Errorf("%w: duplicate permission %q", ErrInvalidPolicy, permission.Name)
+		}
+		if permission.IsSystem && permission.BreakGlass {
+			return fmt.Errorf("%w: system permission %q cannot be obtained through break-glass access", ErrInvalidPolicy, permission.Name)
+		}
+		permissions[permission.Name] = true
+	}
+
+	roles := make(map[string]bool, len(doc.Roles))
+	for _, role := range doc.Roles {
+		if role.Name == "" {
+			return fmt.Errorf("%w: role name is required", ErrInvalidPolicy)
+		}
+		if roles[role.Name] {
+			return fmt.Errorf("%w: duplicate role %q", ErrInvalidPolicy, role.Name)
+		}
+		roles[role.Name] = true
+
+		granted := make(map[string]bool, len(role.Permissions))
+		for _, permission := range role.Permissions {
+			if !permissions[permission] {
+				return fmt.Errorf("%w: role %q grants unknown permission %q", ErrInvalidPolicy, role.Name, permission)
+			}
+			if granted[permission] {
+				return fmt.Errorf("%w: role %q grants %q twice", ErrInvalidPolicy, role.Name, permission)
+			}
+			granted[permission] = true
+		}
+
+		denied := make(map[string]bool, len(role.Denies))
+		for _, permission := range role.Denies {
+			if !permissions[permission] {
+				return fmt.Errorf("%w: role %q denies unknown permission %q", ErrInvalidPolicy, role.Name, permission)
+			}
+			if granted[permission] {
+				return fmt.Errorf("%w: role %q both grants and denies %q", ErrInvalidPolicy, role.Name, permission)
+			}
+			if denied[permission] {
+				return fmt.Errorf("%w: role %q denies %q twice", ErrInvalidPolicy, role.Name, permission)
+			}
+			denied[permission] = true
+		}
+	}
+
+	users := make(map[string]bool, len(doc.Users))
+	for _, user := range doc.Users {
+		if user.Username == "" {
+			return fmt.Errorf("%w: username is required", ErrInvalidPolicy)
+		}
+		if users[user.Username] {
+			return fmt.Errorf("%w: duplicate user %q", ErrInvalidPolicy, user.Username)
+		}
+		users[user.Username] = true
+
+		assigned := make(map[string]bool, len(user.Roles))
+		for _, assignment := range user.Roles {
+			if !roles[assignment.Role] {
+				return fmt.Errorf("%w: user %q is assigned unknown role %q", ErrInvalidPolicy, user.Username, assignment.Role)
+			}
+			if assigned[assignment.Role] {
+				return fmt.Errorf("%w: user %q is assigned %q twice", ErrInvalidPolicy, user.Username, assignment.Role)
+			}
+			if assignment.ValidFrom != nil && assignment.ValidUntil != nil && !assignment.ValidFrom.Before(*assignment.ValidUntil) {
+				return fmt.Errorf("%w: role %q of user %q ends before it starts", ErrInvalidPolicy, assignment.Role, user.Username)
+			}
+			assigned[assignment.Role] = true
+		}
+
+		overridden := make(map[string]bool, len(user.Overrides))
+		for _, override := range user.Overrides {
+			if !permissions[override.Permission] {
+				return fmt.Errorf("%w: user %q overrides unknown permission %q", ErrInvalidPolicy, user.Username, override.Permission)
+			}
+			if overridden[override.Permission] {
+				return fmt.Errorf("%w: user %q overrides %q twice", ErrInvalidPolicy, user.Username, override.Permission)
+			}
+			if override.Effect != overrideAllow && override.Effect != overrideDeny {
+				return fmt.Errorf("%w: override of %q for user %q has effect %q", ErrInvalidPolicy, override.Permission, user.Username, override.Effect)
+			}
+			overridden[override.Permission] = true
+		}
+	}
+
+	return nil
+}
+
+// ImportPolicyTx makes the RBAC tables match the document: missing entries are
+// created, descriptions and break-glass flags updated, and roles, permissions,
+// grants and denies absent from the document removed. The role assignments
+// and overrides of the listed users are made to match; those of other users
+// are only removed with PruneUsers. System entries cannot be removed or have their system flag
+// changed, and at least one user must keep the administrator permissions.
+// With DryRun the diff is computed the same way but the transaction is rolled
+// back.
+func (store *SQLStore) ImportPolicyTx(ctx context.Context, arg ImportPolicyTxParams) (ImportPolicyTxResult, error) {
+	result := ImportPolicyTxResult{
+		DryRun: arg.DryRun,
+		Diff: PolicyDiff{
+			CreatedPermissions: []string{},
+			UpdatedPermissions: []string{},
+			DeletedPermissions: []string{},
+			CreatedRoles:       []string{},
+			UpdatedRoles:       []string{},
+			DeletedRoles:       []string{},
+			Granted:            []PolicyGrant{},
+			Revoked:            []PolicyGrant{},
+			Denied:             []PolicyGrant{},
+			Undenied:           []PolicyGrant{},
+			AssignedRoles:      []PolicyAssignment{},
+			UpdatedRoleWindows: []PolicyAssignment{},
+			UnassignedRoles:    []PolicyAssignment{},
+			SetOverrides:       []PolicyUserPermission{},
+			RemovedOverrides:   []PolicyUserPermission{},
+		},
+	}
+
+	err := arg.Document.validate()
+	if err != nil {
+		return result, err
+	}
+
+	err = store.execTx(ctx, func(q *Queries) error {
+		err := q.LockSystemRoles(ctx)
+		if err != nil {
+			return err
+		}
+
+		diff := &result.Diff
+
+		permissionIDs, err := importPermissions(ctx, q, arg.Document.Permissions, diff)
+		if err != nil {
+			return err
+		}
+
+		roleIDs, err := importRoles(ctx, q, arg.Document.Roles, permissionIDs, diff)
+		if err != nil {
+			return err
+		}
+
+		err = importUsers(ctx, q, arg.Document.Users, arg.PruneUsers, roleIDs, permissionIDs, diff)
+		if err != nil {
+			return err
+		}
+
+		err = ensureSystemAdmin(ctx, q)
+		if err != nil {
+			return err
+		}
+
+		if arg.DryRun {
+			return errDryRun
+		}
+		return nil
+	})
+	if errors.Is(err, errDryRun) {
+		err = nil
+	}
+
+	return result, err
+}
+
+func importPermissions(ctx context.Context, q *Queries, desired []PolicyPermission, diff *PolicyDiff) (map[string]int32, error) {
+	existing, err := q.ListAllPermissions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	current := make(map[string]Permission, len(existing))
+	for _, permission := range existing {
+		current[permission.Name] = permission
+	}
+
+	ids := make(map[string]int32, len(desired))
+	for _, want := range desired {
+		description := sql.NullString{String: want.Description, Valid: want.Description != ""}
+
+		permission, ok := current[want.Name]
+		if want.IsSystem != (ok && permission.IsSystem) {
+			return nil, fmt.Errorf("%w: %s cannot be made or unmade a system permission by an import", ErrProtectedPermission, want.Name)
+		}
+		if !ok {
+			permission, err = q.CreatePermission(ctx, CreatePermissionParams{
+				Name:        want.Name,
+				Description: description,
+			})
+			if err != nil {
+				return nil, err
+			}
+			diff.CreatedPermissions = append(diff.CreatedPermissions, want.Name)
+		}
+
+		updated := false
+		if permission.Description != description {
+			_, err = q.SetPermissionDescription(ctx, SetPermissionDescriptionParams{
+				ID:          permission.ID,
+				Description: description,
+			})
+			if err != nil {
+				return nil, err
+			}
+			updated = true
+		}
+		if permission.BreakGlass != want.BreakGlass {
+			_, err = q.SetPermissionBreakGlass(ctx, SetPermissionBreakGlassParams{
+				ID:         permission.ID,
+				BreakGlass: want.BreakGlass,
+			})
+			if err != nil {
+				return nil, err
+			}
+			updated = true
+		}
+		if ok && updated {
+			diff.UpdatedPermissions = append(diff.UpdatedPermissions, want.Name)
+		}
+
+		ids[want.Name] = permission.ID
+	}
+
+	for _, permission := range existing {
+		if _, ok := ids[permission.Name]; ok {
+			continue
+		}
+		if permission.IsSystem {
+			return nil, fmt.Errorf("%w: %s", ErrProtectedPermission, permission.Name)
+		}
+
+		err = q.DeletePermission(ctx, permission.ID)
+		if err != nil {
+			return nil, err
+		}
+		diff.DeletedPermissions = append(diff.DeletedPermissions, permission.Name)
+	}
+
+	return ids, nil
+}
+
+func importRoles(ctx context.Context, q *Queries, desired []PolicyRole, permissionIDs map[string]int32, diff *PolicyDiff) (map[string]int32, error) {
+	existing, err := q.ListAllRoles(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	current := make(map[string]Role, len(existing))
+	for _, role := range existing {
+		current[role.Name] = role
+	}
+
+	// Grants and denies are read after permissions were imported, so those of
+	// deleted permissions are already gone.
+	grants, err := q.ListAllRolePermissions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	denies, err := q.ListAllRolePermissionDenies(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	granted := make(map[int32]map[int32]bool)
+	for _, grant := range grants {
+		if granted[grant.RoleID] == nil {
+			granted[grant.RoleID] = make(map[int32]bool)
+		}
+		granted[grant.RoleID][grant.PermissionID] = true
+	}
+
+	permissionNames := make(map[int32]string, len(permissionIDs))
+	for name, id := range permissionIDs {
+		permissionNames[id] = name
+	}
+
+	ids := make(map[string]int32, len(desired))
+	for _, want := range desired {
+		description := sql.NullString{String: want.Description, Valid: want.Description != ""}
+
+		role, ok := current[want.Name]
+		if want.IsSystem != (ok && role.IsSystem) {
+			return nil, fmt.Errorf("%w: %s cannot be made or unmade a system role by an import", ErrProtectedRole, want.Name)
+		}
+		if !ok {
+			role, err = q.CreateRole(ctx, CreateRoleParams{
+				Name:        want.Name,
+				Description: description,
+			})
+			if err != nil {
+				return nil, err
+			}
+			diff.CreatedRoles = append(diff.CreatedRoles, want.Name)
+		} else if role.Description != description {
+			_, err = q.SetRoleDescription(ctx, SetRoleDescriptionParams{
+				ID:          role.ID,
+				Description: description,
+			})
+			if err != nil {
+				return nil, err
+			}
+			diff.UpdatedRoles = append(diff.UpdatedRoles, want.Name)
+		}
+		ids[want.Name] = role.ID
+
+		wanted := make(map[int32]bool, len(want.Permissions))
+		for _, name := range want.Permissions {
+			permissionID := permissionIDs[name]
+			wanted[permissionID] = true
+			if granted[role.ID][permissionID] {
+				continue
+			}
+
+			_, err = q.CreateRolePermission(ctx, CreateRolePermissionParams{
+				RoleID:       role.ID,
+				PermissionID: permissionID,
+			})
+			if err != nil {
+				return nil, err
+			}
+			diff.Granted = append(diff.Granted, PolicyGrant{Role: want.Name, Permission: name})
+		}
+
+		for _, grant := range grants {
+			if grant.RoleID != role.ID || wanted[grant.PermissionID] {
+				continue
+			}
+
+			err = checkSystemGrant(ctx, q, grant.RoleID, grant.PermissionID)
+			if err != nil {
+				return nil, err
+			}
+
+			err = q.DeleteRolePermission(ctx, DeleteRolePermissionParams{
+				RoleID:       grant.RoleID,
+				PermissionID: grant.PermissionID,
+			})
+			if err != nil {
+				return nil, err
+			}
+			diff.Revoked = append(diff.Revoked, PolicyGrant{Role: want.Name, Permission: permissionNames[grant.PermissionID]})
+		}
+
+		err = importRoleDenies(ctx, q, role, want.Denies, permissionIDs, denies, diff)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for _, role := range existing {
+		if _, ok := ids[role.Name]; ok {
+			continue
+		}
+		if role.IsSystem {
+			return nil, fmt.Errorf("%w: %s", ErrProtectedRole, role.Name)
+		}
+
+		err = q.DeleteRole(ctx, role.ID)
+		if err != nil {
+			return nil, err
+		}
+		diff.DeletedRoles = append(diff.DeletedRoles, role.Name)
+	}
+
+	return ids, nil
+}
+
+func importRoleDenies(ctx context.Context, q *Queries, role Role, desired []string, permissionIDs map[string]int32, denies []RolePermissionDeny, diff *PolicyDiff) error {
+	denied := make(map[int32]bool)
+	for _, deny := range denies {
+		if deny.RoleID == role.ID {
+			denied[deny.PermissionID] = true
+		}
+	}
+
+	wanted := make(map[int32]bool, len(desired))
+	for _, name := range desired {
+		permissionID := permissionIDs[name]
+		wanted[permissionID] = true
+		if denied[permissionID] {
+			continue
+		}
+
+		permission, err := q.GetPermission(ctx, permissionID)
+		if err != nil {
+			return err
+		}
+		if role.IsSystem && permission.IsSystem {
+			return fmt.Errorf("%w: %s denied on %s", ErrProtectedPermission, permission.Name, role.Name)
+		}
+
+		_, err = q.CreateRolePermissionDeny(ctx, CreateRolePermissionDenyParams{
+			RoleID:       role.ID,
+			PermissionID: permissionID,
+		})
+		if err != nil {
+			return err
+		}
+		diff.Denied = append(diff.Denied, PolicyGrant{Role: role.Name, Permission: name})
+	}
+
+	for _, deny := range denies {
+		if deny.RoleID != role.ID || wanted[deny.PermissionID] {
+			continue
+		}
+
+		permission, err := q.GetPermission(ctx, deny.PermissionID)
+		if err != nil {
+			return err
+		}
+
+		err = q.DeleteRolePermissionDeny(ctx, DeleteRolePermissionDenyParams{
+			RoleID:       role.ID,
+			PermissionID: deny.PermissionID,
+		})
+		if err != nil {
+			return err
+		}
+		diff.Undenied = append(diff.Undenied, PolicyGrant{Role: role.Name, Permission: permission.Name})
+	}
+
+	return nil
+}
+
+// importUsers makes the role assignments and permission overrides of the users
+// in the document match it. With prune, users missing from the document lose
+// theirs; otherwise they are left alone.
+func importUsers(ctx context.Context, q *Queries, desired []PolicyUser, prune bool, roleIDs map[string]int32, permissionIDs map[string]int32, diff *PolicyDiff) error {
+	// Read after roles and permissions were imported, so assignments and
+	// overrides of deleted ones are already gone.
+	assignments, err := q.ListAllUserRoles(ctx)
+	if err != nil {
+		return err
+	}
+
+	overrides, err := q.ListAllUserPermissionOverrides(ctx)
+	if err != nil {
+		return err
+	}
+
+	roleNames := make(map[int32]string, len(roleIDs))
+	for name, id := range roleIDs {
+		roleNames[id] = name
+	}
+
+	permissionNames := make(map[int32]string, len(permissionIDs))
+	for name, id := range permissionIDs {
+		permissionNames[id] = name
+	}
+
+	currentRoles := make(map[string]map[int32]ListAllUserRolesRow)
+	for _, assignment := range assignments {
+		if currentRoles[assignment.Username] == nil {
+			currentRoles[assignment.Username] = make(map[int32]ListAllUserRolesRow)
+		}
+		currentRoles[assignment.Username][assignment.RoleID] = assignment
+	}
+
+	currentOverrides := make(map[string]map[int32]ListAllUserPermissionOverridesRow)
+	for _, override := range overrides {
+		if currentOverrides[override.Username] == nil {
+			currentOverrides[override.Username] = make(map[int32]ListAllUserPermissionOverridesRow)
+		}
+		currentOverrides[override.Username][override.PermissionID] = override
+	}
+
+	kept := make(map[string]bool, len(desired))
+	for _, want := range desired {
+		user, err := q.GetUser(ctx, want.Username)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return fmt.Errorf("%w: unknown user %q", ErrInvalidPolicy, want.Username)
+			}
+			return err
+		}
+		kept[want.Username] = true
+
+		wantedRoles := make(map[int32]bool, len(want.Roles))
+		for _, assignment := range want.Roles {
+			roleID := roleIDs[assignment.Role]
+			wantedRoles[roleID] = true
+			validFrom := nullPolicyTime(assignment.ValidFrom)
+			validUntil := nullPolicyTime(assignment.ValidUntil)
+
+			current, ok := currentRoles[want.Username][roleID]
+			switch {
+			case !ok:
+				_, err = q.AddRoleForUser(ctx, AddRoleForUserParams{
+					UserID:     user.ID,
+					RoleID:     roleID,
+					ValidFrom:  validFrom,
+					ValidUntil: validUntil,
+				})
+				if err != nil {
+					return err
+				}
+				diff.AssignedRoles = append(diff.AssignedRoles, PolicyAssignment{User: want.Username, Role: assignment.Role})
+			case !sameNullTime(current.ValidFrom, validFrom) || !sameNullTime(current.ValidUntil, validUntil):
+				_, err = q.UpdateUserRoleValidity(ctx, UpdateUserRoleValidityParams{
+					UserID:     user.ID,
+					RoleID:     roleID,
+					ValidFrom:  validFrom,
+					ValidUntil: validUntil,
+				})
+				if err != nil {
+					return err
+				}
+				diff.UpdatedRoleWindows = append(diff.UpdatedRoleWindows, PolicyAssignment{User: want.Username, Role: assignment.Role})
+			}
+		}
+
+		for _, assignment := range assignments {
+			if assignment.Username != want.Username || wantedRoles[assignment.RoleID] {
+				continue
+			}
+			err = unassignPolicyRole(ctx, q, assignment, roleNames, diff)
+			if err != nil {
+				return err
+			}
+		}
+
+		wantedOverrides := make(map[int32]bool, len(want.Overrides))
+		for _, override := range want.Overrides {
+			permissionID := permissionIDs[override.Permission]
+			wantedOverrides[permissionID] = true
+
+			current, ok := currentOverrides[want.Username][permissionID]
+			if ok && current.Effect == override.Effect && current.Reason == override.Reason {
+				continue
+			}
+
+			_, err = q.UpsertUserPermissionOverride(ctx, UpsertUserPermissionOverrideParams{
+				UserID:       user.ID,
+				PermissionID: permissionID,
+				Effect:       override.Effect,
+				Reason:       override.Reason,
+			})
+			if err != nil {
+				return err
+			}
+			diff.SetOverrides = append(diff.SetOverrides, PolicyUserPermission{User: want.Username, Permission: override.Permission})
+		}
+
+		for _, override := range overrides {
+			if override.Username != want.Username || wantedOverrides[override.PermissionID] {
+				continue
+			}
+			err = removePolicyOverride(ctx, q, override, permissionNames, diff)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	if !prune {
+		return nil
+	}
+
+	for _, assignment := range assignments {
+		if kept[assignment.Username] {
+			continue
+		}
+		err = unassignPolicyRole(ctx, q, assignment, roleNames, diff)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, override := range overrides {
+		if kept[override.Username] {
+			continue
+		}
+		err = removePolicyOverride(ctx, q, override, permissionNames, diff)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func unassignPolicyRole(ctx context.Context, q *Queries, assignment ListAllUserRolesRow, roleNames map[int32]string, diff *PolicyDiff) error {
+	err := q.RemoveRoleForUser(ctx, RemoveRoleForUserParams{
+		UserID: assignment.UserID,
+		RoleID: assignment.RoleID,
+	})
+	if err != nil {
+		return err
+	}
+
+	diff.UnassignedRoles = append(diff.UnassignedRoles, PolicyAssignment{User: assignment.Username, Role: roleNames[assignment.RoleID]})
+	return nil
+}
+
+func removePolicyOverride(ctx context.Context, q *Queries, override ListAllUserPermissionOverridesRow, permissionNames map[int32]string, diff *PolicyDiff) error {
+	err := q.DeleteUserPermissionOverride(ctx, DeleteUserPermissionOverrideParams{
+		UserID:       override.UserID,
+		PermissionID: override.PermissionID,
+	})
+	if err != nil {
+		return err
+	}
+
+	diff.RemovedOverrides = append(diff.RemovedOverrides, PolicyUserPermission{User: override.Username, Permission: permissionNames[override.PermissionID]})
+	return nil
+}
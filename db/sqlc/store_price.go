@@ -0,0 +1,245 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/toannguyen3105/nht-bsihuyen.com-api/utils"
+)
+
+var ErrPastPriceChange = errors.New("price changes cannot take effect in the past")
+
+type ChangeMedicinePriceTxParams struct {
+	MedicineID int32 `json:"medicine_id"`
+	// Price without a currency is in the medicine's current currency.
+	Price utils.Money `json:"price"`
+	// EffectiveFrom is when the price applies; zero means right away.
+	EffectiveFrom time.Time      `json:"effective_from"`
+	Reason        string         `json:"reason"`
+	CreatedBy     sql.NullString `json:"created_by"`
+}
+
+type ChangeMedicinePriceTxResult struct {
+	Medicine Medicine      `json:"medicine"`
+	Price    MedicinePrice `json:"price"`
+}
+
+// ChangeMedicinePriceTx records a price in the history of a medicine. A price
+// that applies right away also becomes the medicine's price; a later one is
+// applied by ApplyDueMedicinePrices when its time comes.
+func (store *SQLStore) ChangeMedicinePriceTx(ctx context.Context, arg ChangeMedicinePriceTxParams) (ChangeMedicinePriceTxResult, error) {
+	var result ChangeMedicinePriceTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		medicine, err := q.GetMedicineForUpdate(ctx, arg.MedicineID)
+		if err != nil {
+			return err
+		}
+
+		result, err = changeMedicinePrice(ctx, q, medicine, arg)
+		return err
+	})
+
+	return result, err
+}
+
+func changeMedicinePrice(ctx context.Context, q *Queries, medicine Medicine, arg ChangeMedicinePriceTxParams) (ChangeMedicinePriceTxResult, error) {
+	result := ChangeMedicinePriceTxResult{Medicine: medicine}
+
+	if arg.Price.Currency == "" {
+		arg.Price.Currency = medicine.Currency
+	}
+
+	err := arg.Price.Validate()
+	if err != nil {
+		return result, err
+	}
+
+	now := time.Now()
+	effectiveFrom := arg.EffectiveFrom
+	if effectiveFrom.IsZero() {
+		effectiveFrom = now
+	}
+	if effectiveFrom.Before(now) {
+		return result, fmt.Errorf("%w: %s", ErrPastPriceChange, effectiveFrom.Format(time.RFC3339))
+	}
+
+	result.Price, err = q.CreateMedicinePrice(ctx, CreateMedicinePriceParams{
+		MedicineID:    medicine.ID,
+		Price:         arg.Price.Amount,
+		Currency:      arg.Price.Currency,
+		EffectiveFrom: effectiveFrom,
+		Reason:        arg.Reason,
+		CreatedBy:     arg.CreatedBy,
+	})
+	if err != nil {
+		return result, err
+	}
+
+	if !effectiveFrom.Equal(now) {
+		return result, nil
+	}
+
+	result.Medicine, err = q.UpdateMedicinePrice(ctx, UpdateMedicinePriceParams{
+		ID:       medicine.ID,
+		Price:    arg.Price.Amount,
+		Currency: arg.Price.Currency,
+	})
+	return result, err
+}
+
+type UpdateMedicineTxParams struct {
+	Medicine UpdateMedicineParams `json:"medicine"`
+	// PriceReason goes into the price history when the price or currency
+	// changes.
+	PriceReason string         `json:"price_reason"`
+	CreatedBy   sql.NullString `json:"created_by"`
+}
+
+// UpdateMedicineTx updates a medicine and records a new price in its history
+// when the price or currency is part of the update.
+func (store *SQLStore) UpdateMedicineTx(ctx context.Context, arg UpdateMedicineTxParams) (Medicine, error) {
+	var result Medicine
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+		result, err = updateMedicineWithPrice(ctx, q, arg)
+		return err
+	})
+
+	return result, err
+}
+
+func updateMedicineWithPrice(ctx context.Context, q *Queries, arg UpdateMedicineTxParams) (Medicine, error) {
+	if arg.Medicine.Unit.Valid {
+		err := checkUnitChange(ctx, q, arg.Medicine.ID, arg.Medicine.Unit.String)
+		if err != nil {
+			return Medicine{}, err
+		}
+	}
+
+	medicine, err := q.UpdateMedicine(ctx, arg.Medicine)
+	if err != nil {
+		return medicine, err
+	}
+
+	if !arg.Medicine.Price.Valid && !arg.Medicine.Currency.Valid {
+		return medicine, nil
+	}
+
+	_, err = q.CreateMedicinePrice(ctx, CreateMedicinePriceParams{
+		MedicineID:    medicine.ID,
+		Price:         medicine.Price,
+		Currency:      medicine.Currency,
+		EffectiveFrom: time.Now(),
+		Reason:        arg.PriceReason,
+		CreatedBy:     arg.CreatedBy,
+	})
+	return medicine, err
+}
+
+// checkUnitChange refuses to change the unit of a medicine whose stock, lots
+// or conversions are counted in it, since those quantities would silently
+// change meaning. The medicine stays locked until the transaction ends.
+func checkUnitChange(ctx context.Context, q *Queries, id int32, unit string) error {
+	medicine, err := q.GetMedicineForUpdate(ctx, id)
+	if err != nil {
+		return err
+	}
+	if medicine.Unit == unit {
+		return nil
+	}
+
+	inUse, err := q.MedicineUnitInUse(ctx, id)
+	if err != nil {
+		return err
+	}
+	if inUse {
+		return fmt.Errorf("%w: %s is counted in %s", ErrUnitInUse, medicine.Name, medicine.Unit)
+	}
+	return nil
+}
+
+type BulkAdjustPricesTxParams struct {
+	Filter ListMedicinesForUpdateParams `json:"filter"`
+	// Percent raises prices by that many per cent, or lowers them when
+	// negative.
+	Percent       utils.Decimal  `json:"percent"`
+	EffectiveFrom time.Time      `json:"effective_from"`
+	Reason        string         `json:"reason"`
+	CreatedBy     sql.NullString `json:"created_by"`
+	DryRun        bool           `json:"dry_run"`
+}
+
+type PriceAdjustment struct {
+	MedicineID int32       `json:"medicine_id"`
+	Name       string      `json:"name"`
+	OldPrice   utils.Money `json:"old_price"`
+	NewPrice   utils.Money `json:"new_price"`
+}
+
+type BulkAdjustPricesTxResult struct {
+	DryRun      bool              `json:"dry_run"`
+	Adjustments []PriceAdjustment `json:"adjustments"`
+}
+
+// BulkAdjustPricesTx changes the price of every medicine matching the filter
+// by the same percentage, rounded to the currency's minor unit. Medicines
+// whose price would not change are left out. With DryRun the adjustments are
+// computed the same way but the transaction is rolled back.
+func (store *SQLStore) BulkAdjustPricesTx(ctx context.Context, arg BulkAdjustPricesTxParams) (BulkAdjustPricesTxResult, error) {
+	result := BulkAdjustPricesTxResult{
+		DryRun:      arg.DryRun,
+		Adjustments: []PriceAdjustment{},
+	}
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		// locked in ascending ID order, like applyStockMovements
+		medicines, err := q.ListMedicinesForUpdate(ctx, arg.Filter)
+		if err != nil {
+			return err
+		}
+
+		for _, medicine := range medicines {
+			oldPrice := utils.NewMoney(medicine.Price, medicine.Currency)
+			newPrice, err := oldPrice.AddPercent(arg.Percent)
+			if err != nil {
+				return err
+			}
+			if newPrice == oldPrice {
+				continue
+			}
+
+			_, err = changeMedicinePrice(ctx, q, medicine, ChangeMedicinePriceTxParams{
+				MedicineID:    medicine.ID,
+				Price:         newPrice,
+				EffectiveFrom: arg.EffectiveFrom,
+				Reason:        arg.Reason,
+				CreatedBy:     arg.CreatedBy,
+			})
+			if err != nil {
+				return err
+			}
+
+			result.Adjustments = append(result.Adjustments, PriceAdjustment{
+				MedicineID: medicine.ID,
+				Name:       medicine.Name,
+				OldPrice:   oldPrice,
+				NewPrice:   newPrice,
+			})
+		}
+
+		if arg.DryRun {
+			return errDryRun
+		}
+		return nil
+	})
+	if errors.Is(err, errDryRun) {
+		err = nil
+	}
+
+	return result, err
+}
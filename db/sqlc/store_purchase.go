@@ -0,0 +1,331 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/toannguyen3105/nht-bsihuyen.com-api/utils"
+)
+
+var (
+	ErrInvalidPurchaseOrder = errors.New("invalid purchase order")
+	ErrPurchaseOrderStatus  = errors.New("purchase order status does not allow this")
+	ErrOverReceipt          = errors.New("more received than ordered")
+)
+
+// A purchase order goes from draft to ordered, and from there to partially
+// received and received as goods come in.
+const (
+	PurchaseOrderDraft             = "draft"
+	PurchaseOrderOrdered           = "ordered"
+	PurchaseOrderPartiallyReceived = "partially_received"
+	PurchaseOrderReceived          = "received"
+)
+
+type PurchaseOrderItemParams struct {
+	MedicineID int32       `json:"medicine_id"`
+	Quantity   int32       `json:"quantity"`
+	UnitCost   utils.Money `json:"unit_cost"`
+}
+
+type CreatePurchaseOrderTxParams struct {
+	SupplierID int32                     `json:"supplier_id"`
+	Note       sql.NullString            `json:"note"`
+	Items      []PurchaseOrderItemParams `json:"items"`
+	CreatedBy  sql.NullString            `json:"created_by"`
+}
+
+type PurchaseOrderTxResult struct {
+	PurchaseOrder PurchaseOrder               `json:"purchase_order"`
+	Items         []ListPurchaseOrderItemsRow `json:"items"`
+}
+
+// CreatePurchaseOrderTx creates a draft purchase order with its items. A
+// medicine that does not exist returns sql.ErrNoRows.
+func (store *SQLStore) CreatePurchaseOrderTx(ctx context.Context, arg CreatePurchaseOrderTxParams) (PurchaseOrderTxResult, error) {
+	var result PurchaseOrderTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+		result.PurchaseOrder, err = q.CreatePurchaseOrder(ctx, CreatePurchaseOrderParams{
+			SupplierID: arg.SupplierID,
+			Note:       arg.Note,
+			CreatedBy:  arg.CreatedBy,
+		})
+		if err != nil {
+			return err
+		}
+
+		result.Items, err = createPurchaseOrderItems(ctx, q, result.PurchaseOrder.ID, arg.Items)
+		return err
+	})
+
+	return result, err
+}
+
+type UpdatePurchaseOrderTxParams struct {
+	ID         int32          `json:"id"`
+	SupplierID sql.NullInt32  `json:"supplier_id"`
+	Note       sql.NullString `json:"note"`
+	// Items replace the items of the order unless nil.
+	Items []PurchaseOrderItemParams `json:"items"`
+}
+
+// UpdatePurchaseOrderTx changes a draft purchase order. Orders that have been
+// placed return ErrPurchaseOrderStatus.
+func (store *SQLStore) UpdatePurchaseOrderTx(ctx context.Context, arg UpdatePurchaseOrderTxParams) (PurchaseOrderTxResult, error) {
+	var result PurchaseOrderTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		order, err := q.GetPurchaseOrderForUpdate(ctx, arg.ID)
+		if err != nil {
+			return err
+		}
+
+		if order.Status != PurchaseOrderDraft {
+			return fmt.Errorf("%w: order #%d is %s", ErrPurchaseOrderStatus, order.ID, order.Status)
+		}
+
+		result.PurchaseOrder, err = q.UpdatePurchaseOrder(ctx, UpdatePurchaseOrderParams{
+			SupplierID: arg.SupplierID,
+			Note:       arg.Note,
+			ID:         arg.ID,
+		})
+		if err != nil {
+			return err
+		}
+
+		if arg.Items == nil {
+			result.Items, err = q.ListPurchaseOrderItems(ctx, arg.ID)
+			return err
+		}
+
+		err = q.DeletePurchaseOrderItems(ctx, arg.ID)
+		if err != nil {
+			return err
+		}
+
+		result.Items, err = createPurchaseOrderItems(ctx, q, arg.ID, arg.Items)
+		return err
+	})
+
+	return result, err
+}
+
+func createPurchaseOrderItems(ctx context.Context, q *Queries, orderID int32, items []PurchaseOrderItemParams) ([]ListPurchaseOrderItemsRow, error) {
+	ids := make([]int32, len(items))
+	seen := make(map[int32]bool, len(items))
+	for i, item := range items {
+		if seen[item.MedicineID] {
+			return nil, fmt.Errorf("%w: medicine %d is on the order twice", ErrInvalidPurchaseOrder, item.MedicineID)
+		}
+		seen[item.MedicineID] = true
+		ids[i] = item.MedicineID
+	}
+
+	medicines, err := q.ListMedicinesByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	if len(medicines) != len(ids) {
+		return nil, sql.ErrNoRows
+	}
+	for _, medicine := range medicines {
+		if medicine.DeletedAt.Valid {
+			return nil, fmt.Errorf("%w: %s", ErrMedicineArchived, medicine.Name)
+		}
+	}
+
+	for _, item := range items {
+		_, err = q.CreatePurchaseOrderItem(ctx, CreatePurchaseOrderItemParams{
+			PurchaseOrderID: orderID,
+			MedicineID:      item.MedicineID,
+			Quantity:        item.Quantity,
+			UnitCost:        item.UnitCost.Amount,
+			Currency:        item.UnitCost.Currency,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return q.ListPurchaseOrderItems(ctx, orderID)
+}
+
+// PlacePurchaseOrderTx marks a draft purchase order as sent to the supplier.
+// After that its items can no longer change, only be received.
+func (store *SQLStore) PlacePurchaseOrderTx(ctx context.Context, id int32) (PurchaseOrder, error) {
+	var result PurchaseOrder
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		order, err := q.GetPurchaseOrderForUpdate(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if order.Status != PurchaseOrderDraft {
+			return fmt.Errorf("%w: order #%d is %s", ErrPurchaseOrderStatus, order.ID, order.Status)
+		}
+
+		items, err := q.ListPurchaseOrderItems(ctx, id)
+		if err != nil {
+			return err
+		}
+		if len(items) == 0 {
+			return fmt.Errorf("%w: order #%d has no items", ErrInvalidPurchaseOrder, order.ID)
+		}
+
+		result, err = q.SetPurchaseOrderStatus(ctx, SetPurchaseOrderStatusParams{
+			Status: PurchaseOrderOrdered,
+			ID:     id,
+		})
+		return err
+	})
+
+	return result, err
+}
+
+type ReceiveGoodsItemParams struct {
+	PurchaseOrderItemID int64     `json:"purchase_order_item_id"`
+	Quantity            int32     `json:"quantity"`
+	LotNumber           string    `json:"lot_number"`
+	ExpiryDate          time.Time `json:"expiry_date"`
+	// UnitCost is the cost price paid, in the currency of the order item.
+	// The ordered cost is used when it is not set.
+	UnitCost utils.NullDecimal `json:"unit_cost"`
+}
+
+type ReceiveGoodsTxParams struct {
+	PurchaseOrderID int32                    `json:"purchase_order_id"`
+	Note            sql.NullString           `json:"note"`
+	Items           []ReceiveGoodsItemParams `json:"items"`
+	ReceivedBy      sql.NullString           `json:"received_by"`
+	// WitnessedBy is needed when the delivery has controlled medicines.
+	WitnessedBy sql.NullString `json:"witnessed_by"`
+}
+
+type ReceiveGoodsTxResult struct {
+	PurchaseOrder PurchaseOrder           `json:"purchase_order"`
+	Receipt       GoodsReceipt            `json:"receipt"`
+	Items         []GoodsReceiptItem      `json:"items"`
+	Movements     []StockMovementTxResult `json:"movements"`
+}
+
+// ReceiveGoodsTx records a delivery against an ordered purchase order. Each
+// line is received into its lot as a stock movement, with its expiry date and
+// cost price, and the order becomes partially received or received. Receiving
+// more of an item than is still outstanding returns ErrOverReceipt.
+func (store *SQLStore) ReceiveGoodsTx(ctx context.Context, arg ReceiveGoodsTxParams) (ReceiveGoodsTxResult, error) {
+	var result ReceiveGoodsTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		order, err := q.GetPurchaseOrderForUpdate(ctx, arg.PurchaseOrderID)
+		if err != nil {
+			return err
+		}
+
+		if order.Status != PurchaseOrderOrdered && order.Status != PurchaseOrderPartiallyReceived {
+			return fmt.Errorf("%w: order #%d is %s", ErrPurchaseOrderStatus, order.ID, order.Status)
+		}
+
+		items, err := q.ListPurchaseOrderItems(ctx, order.ID)
+		if err != nil {
+			return err
+		}
+
+		byID := make(map[int64]ListPurchaseOrderItemsRow, len(items))
+		for _, item := range items {
+			byID[item.ID] = item
+		}
+
+		received := make(map[int64]int32)
+		for _, line := range arg.Items {
+			item, ok := byID[line.PurchaseOrderItemID]
+			if !ok {
+				return fmt.Errorf("%w: item %d is not on order #%d", ErrInvalidPurchaseOrder, line.PurchaseOrderItemID, order.ID)
+			}
+
+			received[item.ID] += line.Quantity
+			if left := item.Quantity - item.ReceivedQuantity; received[item.ID] > left {
+				return fmt.Errorf("%w: %d of %s still to receive", ErrOverReceipt, left, item.MedicineName)
+			}
+		}
+
+		result.Receipt, err = q.CreateGoodsReceipt(ctx, CreateGoodsReceiptParams{
+			PurchaseOrderID: order.ID,
+			Note:            arg.Note,
+			ReceivedBy:      arg.ReceivedBy,
+		})
+		if err != nil {
+			return err
+		}
+
+		note := fmt.Sprintf("Goods receipt #%d for purchase order #%d", result.Receipt.ID, order.ID)
+		movements := make([]StockMovementTxParams, len(arg.Items))
+		for i, line := range arg.Items {
+			movements[i] = StockMovementTxParams{
+				MedicineID:   byID[line.PurchaseOrderItemID].MedicineID,
+				MovementType: MovementReceipt,
+				Quantity:     line.Quantity,
+				LotNumber:    line.LotNumber,
+				ExpiryDate:   line.ExpiryDate,
+				Note:         sql.NullString{String: note, Valid: true},
+				CreatedBy:    arg.ReceivedBy,
+				WitnessedBy:  arg.WitnessedBy,
+			}
+		}
+
+		result.Movements, err = applyStockMovements(ctx, q, movements)
+		if err != nil {
+			return err
+		}
+
+		result.Items = make([]GoodsReceiptItem, len(arg.Items))
+		for i, line := range arg.Items {
+			item := byID[line.PurchaseOrderItemID]
+			unitCost := item.UnitCost
+			if line.UnitCost.Valid {
+				unitCost = line.UnitCost.Decimal
+			}
+
+			result.Items[i], err = q.CreateGoodsReceiptItem(ctx, CreateGoodsReceiptItemParams{
+				GoodsReceiptID:      result.Receipt.ID,
+				PurchaseOrderItemID: item.ID,
+				BatchID:             result.Movements[i].Movements[0].BatchID.Int64,
+				Quantity:            line.Quantity,
+				UnitCost:            unitCost,
+				Currency:            item.Currency,
+			})
+			if err != nil {
+				return err
+			}
+
+			_, err = q.AddPurchaseOrderItemReceived(ctx, AddPurchaseOrderItemReceivedParams{
+				Quantity: line.Quantity,
+				ID:       item.ID,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		status := PurchaseOrderReceived
+		for _, item := range items {
+			if item.ReceivedQuantity+received[item.ID] < item.Quantity {
+				status = PurchaseOrderPartiallyReceived
+				break
+			}
+		}
+
+		result.PurchaseOrder, err = q.SetPurchaseOrderStatus(ctx, SetPurchaseOrderStatusParams{
+			Status: status,
+			ID:     order.ID,
+		})
+		return err
+	})
+
+	return result, err
+}
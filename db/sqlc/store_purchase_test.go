@@ -0,0 +1,88 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/toannguyen3105/nht-bsihuyen.com-api/utils"
+)
+
+func createRandomSupplier(t *testing.T) Supplier {
+	supplier, err := testQueries.CreateSupplier(context.Background(), CreateSupplierParams{
+		Name:         utils.RandomString(12),
+		LeadTimeDays: 5,
+	})
+	require.NoError(t, err)
+	return supplier
+}
+
+func TestReceiveGoodsTx(t *testing.T) {
+	store := NewStore(testDB)
+	supplier := createRandomSupplier(t)
+	medicine := createRandomMedicine(t, 0)
+
+	created, err := store.CreatePurchaseOrderTx(context.Background(), CreatePurchaseOrderTxParams{
+		SupplierID: supplier.ID,
+		Items: []PurchaseOrderItemParams{{
+			MedicineID: medicine.ID,
+			Quantity:   100,
+			UnitCost:   utils.NewMoney(utils.MustParseDecimal("1500"), utils.VND),
+		}},
+	})
+	require.NoError(t, err)
+	require.Equal(t, PurchaseOrderDraft, created.PurchaseOrder.Status)
+	require.Len(t, created.Items, 1)
+	item := created.Items[0]
+
+	// nothing can be received before the order is placed
+	receive := ReceiveGoodsTxParams{
+		PurchaseOrderID: created.PurchaseOrder.ID,
+		Items: []ReceiveGoodsItemParams{{
+			PurchaseOrderItemID: item.ID,
+			Quantity:            40,
+			LotNumber:           utils.RandomString(8),
+			ExpiryDate:          time.Now().AddDate(1, 0, 0),
+			UnitCost:            utils.NullDecimal{Decimal: utils.MustParseDecimal("1400"), Valid: true},
+		}},
+	}
+	_, err = store.ReceiveGoodsTx(context.Background(), receive)
+	require.ErrorIs(t, err, ErrPurchaseOrderStatus)
+
+	order, err := store.PlacePurchaseOrderTx(context.Background(), created.PurchaseOrder.ID)
+	require.NoError(t, err)
+	require.Equal(t, PurchaseOrderOrdered, order.Status)
+	require.True(t, order.OrderedAt.Valid)
+
+	result, err := store.ReceiveGoodsTx(context.Background(), receive)
+	require.NoError(t, err)
+	require.Equal(t, PurchaseOrderPartiallyReceived, result.PurchaseOrder.Status)
+	require.Equal(t, int32(40), result.Movements[0].Medicine.Stock)
+	require.Equal(t, utils.MustParseDecimal("1400"), result.Items[0].UnitCost)
+
+	batch, err := store.GetMedicineBatch(context.Background(), result.Items[0].BatchID)
+	require.NoError(t, err)
+	require.Equal(t, receive.Items[0].LotNumber, batch.LotNumber)
+
+	receive.Items[0].Quantity = 61
+	_, err = store.ReceiveGoodsTx(context.Background(), receive)
+	require.ErrorIs(t, err, ErrOverReceipt)
+
+	receive.Items[0].Quantity = 60
+	receive.Items[0].UnitCost = utils.NullDecimal{}
+	result, err = store.ReceiveGoodsTx(context.Background(), receive)
+	require.NoError(t, err)
+	require.Equal(t, PurchaseOrderReceived, result.PurchaseOrder.Status)
+	require.True(t, result.PurchaseOrder.ReceivedAt.Valid)
+	require.Equal(t, utils.MustParseDecimal("1500"), result.Items[0].UnitCost)
+
+	medicine, err = store.GetMedicine(context.Background(), medicine.ID)
+	require.NoError(t, err)
+	require.Equal(t, int32(100), medicine.Stock)
+
+	// a placed order is kept
+	deleted, err := store.DeletePurchaseOrder(context.Background(), order.ID)
+	require.NoError(t, err)
+	require.Zero(t, deleted)
+}
@@ -0,0 +1,158 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/toannguyen3105/nht-bsihuyen.com-api/utils"
+)
+
+// DefaultLeadTimeDays is the lead time of suppliers created without one, and
+// the one assumed for medicines that were never ordered.
+const DefaultLeadTimeDays = 7
+
+type ReorderSuggestionsParams struct {
+	// WindowDays is how many days of dispensing the average is taken over.
+	WindowDays int32 `json:"window_days"`
+	// SafetyDays is how many days of consumption are kept as safety stock.
+	SafetyDays int32 `json:"safety_days"`
+	// CoverDays is how many days of consumption an order should last beyond
+	// its lead time.
+	CoverDays int32 `json:"cover_days"`
+	// SupplierID limits the suggestions to the medicines last ordered from
+	// this supplier, unless MedicineIDs are given, and plans them with its
+	// lead time.
+	SupplierID  sql.NullInt32 `json:"supplier_id"`
+	MedicineIDs []int32       `json:"medicine_ids"`
+	// All includes the medicines that need no order yet.
+	All bool `json:"all"`
+}
+
+type ReorderSuggestion struct {
+	MedicineID   int32  `json:"medicine_id"`
+	MedicineName string `json:"medicine_name"`
+	Unit         string `json:"unit"`
+	// Stock is the unexpired stock; expired lots cannot be dispensed.
+	Stock    int32 `json:"stock"`
+	OnOrder  int32 `json:"on_order"`
+	Consumed int32 `json:"consumed"`
+	// AverageDailyConsumption is Consumed spread over the window.
+	AverageDailyConsumption float64 `json:"average_daily_consumption"`
+	// DaysLeft is how long Stock lasts at that rate; NULL without consumption.
+	DaysLeft          sql.NullInt32  `json:"days_left"`
+	SupplierID        sql.NullInt32  `json:"supplier_id"`
+	SupplierName      sql.NullString `json:"supplier_name"`
+	LeadTimeDays      int32          `json:"lead_time_days"`
+	SafetyStock       int32          `json:"safety_stock"`
+	ReorderPoint      int32          `json:"reorder_point"`
+	SuggestedQuantity int32          `json:"suggested_quantity"`
+	// UnitCost is what was paid on the latest delivery, or zero for a medicine
+	// never received from a supplier.
+	UnitCost utils.Money `json:"unit_cost"`
+}
+
+// ReorderSuggestions works out what to order from the dispensing history.
+// Safety stock is SafetyDays of average consumption and the reorder point is
+// the consumption over the lead time plus the safety stock. Once the stock and
+// what is on order drop to the reorder point, the suggestion tops them up to
+// the reorder point plus CoverDays of consumption. Suggestions come most
+// urgent first. A supplier that does not exist returns sql.ErrNoRows.
+func (store *SQLStore) ReorderSuggestions(ctx context.Context, arg ReorderSuggestionsParams) ([]ReorderSuggestion, error) {
+	var supplier Supplier
+	if arg.SupplierID.Valid {
+		var err error
+		supplier, err = store.GetSupplier(ctx, arg.SupplierID.Int32)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	since := time.Now().AddDate(0, 0, -int(arg.WindowDays))
+	rows, err := store.ListMedicineConsumption(ctx, since)
+	if err != nil {
+		return nil, err
+	}
+
+	wanted := make(map[int32]bool, len(arg.MedicineIDs))
+	for _, id := range arg.MedicineIDs {
+		wanted[id] = true
+	}
+
+	suggestions := []ReorderSuggestion{}
+	for _, row := range rows {
+		switch {
+		case len(wanted) > 0:
+			if !wanted[row.ID] {
+				continue
+			}
+		case arg.SupplierID.Valid:
+			if row.SupplierID.Int32 != supplier.ID {
+				continue
+			}
+		}
+
+		suggestion := ReorderSuggestion{
+			MedicineID:   row.ID,
+			MedicineName: row.Name,
+			Unit:         row.Unit,
+			Stock:        row.UsableStock,
+			OnOrder:      row.OnOrder,
+			Consumed:     row.Consumed,
+			SupplierID:   row.SupplierID,
+			SupplierName: row.SupplierName,
+			LeadTimeDays: DefaultLeadTimeDays,
+			UnitCost:     utils.NewMoney(0, row.Currency),
+		}
+		if row.LeadTimeDays.Valid {
+			suggestion.LeadTimeDays = row.LeadTimeDays.Int32
+		}
+		if arg.SupplierID.Valid {
+			suggestion.SupplierID = sql.NullInt32{Int32: supplier.ID, Valid: true}
+			suggestion.SupplierName = sql.NullString{String: supplier.Name, Valid: true}
+			suggestion.LeadTimeDays = supplier.LeadTimeDays
+		}
+		if row.UnitCost.Valid {
+			suggestion.UnitCost = utils.NewMoney(row.UnitCost.Decimal, row.UnitCostCurrency.String)
+		}
+
+		suggestion.plan(arg)
+		if suggestion.SuggestedQuantity == 0 && !arg.All {
+			continue
+		}
+		suggestions = append(suggestions, suggestion)
+	}
+
+	sort.SliceStable(suggestions, func(a, b int) bool {
+		left, right := suggestions[a].DaysLeft, suggestions[b].DaysLeft
+		if left.Valid != right.Valid {
+			return left.Valid
+		}
+		return left.Int32 < right.Int32
+	})
+
+	return suggestions, nil
+}
+
+// plan fills in the consumption rate and the order it calls for.
+func (s *ReorderSuggestion) plan(arg ReorderSuggestionsParams) {
+	if arg.WindowDays <= 0 || s.Consumed <= 0 {
+		return
+	}
+
+	daily := float64(s.Consumed) / float64(arg.WindowDays)
+	s.AverageDailyConsumption = math.Round(daily*100) / 100
+	s.DaysLeft = sql.NullInt32{Int32: int32(float64(max(s.Stock, 0)) / daily), Valid: true}
+	s.SafetyStock = int32(math.Ceil(daily * float64(arg.SafetyDays)))
+	s.ReorderPoint = int32(math.Ceil(daily*float64(s.LeadTimeDays))) + s.SafetyStock
+
+	available := s.Stock + s.OnOrder
+	if available > s.ReorderPoint {
+		return
+	}
+
+	target := s.ReorderPoint + int32(math.Ceil(daily*float64(arg.CoverDays)))
+	s.SuggestedQuantity = max(target-available, 0)
+}
@@ -0,0 +1,107 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/toannguyen3105/nht-bsihuyen.com-api/utils"
+)
+
+func TestReorderSuggestions(t *testing.T) {
+	store := NewStore(testDB)
+	medicine := createRandomMedicine(t, 100)
+	supplier := createRandomSupplier(t)
+
+	_, err := store.StockMovementTx(context.Background(), StockMovementTxParams{
+		MedicineID:   medicine.ID,
+		MovementType: MovementDispense,
+		Quantity:     -60,
+	})
+	require.NoError(t, err)
+
+	// 2 a day leaves 40 for 20 days; never ordered, so the default lead time
+	// of 7 days and 5 days of safety stock put the reorder point at 24
+	arg := ReorderSuggestionsParams{
+		WindowDays:  30,
+		SafetyDays:  5,
+		CoverDays:   30,
+		MedicineIDs: []int32{medicine.ID},
+		All:         true,
+	}
+	suggestions, err := store.ReorderSuggestions(context.Background(), arg)
+	require.NoError(t, err)
+	require.Len(t, suggestions, 1)
+	suggestion := suggestions[0]
+	require.Equal(t, int32(60), suggestion.Consumed)
+	require.Equal(t, 2.0, suggestion.AverageDailyConsumption)
+	require.Equal(t, int32(20), suggestion.DaysLeft.Int32)
+	require.Equal(t, int32(DefaultLeadTimeDays), suggestion.LeadTimeDays)
+	require.Equal(t, int32(24), suggestion.ReorderPoint)
+	require.Zero(t, suggestion.SuggestedQuantity)
+	require.True(t, suggestion.UnitCost.Amount.IsZero())
+
+	// with the supplier's 5 days and 15 days of safety stock it is due
+	arg.SupplierID.Int32, arg.SupplierID.Valid = supplier.ID, true
+	arg.SafetyDays = 15
+	suggestions, err = store.ReorderSuggestions(context.Background(), arg)
+	require.NoError(t, err)
+	suggestion = suggestions[0]
+	require.Equal(t, supplier.LeadTimeDays, suggestion.LeadTimeDays)
+	require.Equal(t, int32(40), suggestion.ReorderPoint)
+	require.Equal(t, int32(60), suggestion.SuggestedQuantity)
+
+	created, err := store.CreatePurchaseOrderTx(context.Background(), CreatePurchaseOrderTxParams{
+		SupplierID: supplier.ID,
+		Items: []PurchaseOrderItemParams{{
+			MedicineID: medicine.ID,
+			Quantity:   suggestion.SuggestedQuantity,
+			UnitCost:   utils.NewMoney(utils.MustParseDecimal("1500"), utils.VND),
+		}},
+	})
+	require.NoError(t, err)
+	_, err = store.PlacePurchaseOrderTx(context.Background(), created.PurchaseOrder.ID)
+	require.NoError(t, err)
+
+	// what is on order covers it, and the medicine is now the supplier's
+	arg.MedicineIDs = nil
+	arg.All = true
+	suggestions, err = store.ReorderSuggestions(context.Background(), arg)
+	require.NoError(t, err)
+	require.Len(t, suggestions, 1)
+	require.Equal(t, int32(60), suggestions[0].OnOrder)
+	require.Zero(t, suggestions[0].SuggestedQuantity)
+	// nothing was paid yet; the cost comes from what is received
+	require.True(t, suggestions[0].UnitCost.Amount.IsZero())
+
+	_, err = store.ReceiveGoodsTx(context.Background(), ReceiveGoodsTxParams{
+		PurchaseOrderID: created.PurchaseOrder.ID,
+		Items: []ReceiveGoodsItemParams{{
+			PurchaseOrderItemID: created.Items[0].ID,
+			Quantity:            60,
+			LotNumber:           utils.RandomString(8),
+			ExpiryDate:          time.Now().AddDate(1, 0, 0),
+			UnitCost:            utils.NullDecimal{Decimal: utils.MustParseDecimal("1400"), Valid: true},
+		}},
+	})
+	require.NoError(t, err)
+
+	// a draft with another supplier neither takes the medicine over nor
+	// changes the cost
+	_, err = store.CreatePurchaseOrderTx(context.Background(), CreatePurchaseOrderTxParams{
+		SupplierID: createRandomSupplier(t).ID,
+		Items: []PurchaseOrderItemParams{{
+			MedicineID: medicine.ID,
+			Quantity:   10,
+			UnitCost:   utils.NewMoney(utils.MustParseDecimal("9000"), utils.VND),
+		}},
+	})
+	require.NoError(t, err)
+
+	suggestions, err = store.ReorderSuggestions(context.Background(), arg)
+	require.NoError(t, err)
+	require.Len(t, suggestions, 1)
+	require.Equal(t, medicine.ID, suggestions[0].MedicineID)
+	require.Equal(t, utils.MustParseDecimal("1400"), suggestions[0].UnitCost.Amount)
+}
@@ -0,0 +1,471 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+var (
+	ErrInsufficientStock    = errors.New("insufficient stock")
+	ErrInvalidStockMovement = errors.New("invalid stock movement")
+	ErrBatchExpired         = errors.New("batch has expired")
+	ErrUnknownUnit          = errors.New("unit has no conversion for this medicine")
+	ErrMedicineArchived     = errors.New("medicine is archived")
+	ErrBatchExpiryMismatch  = errors.New("lot is already recorded with a different expiry date")
+	ErrUnitInUse            = errors.New("unit cannot change once stock, lots or conversions are recorded in it")
+)
+
+const (
+	MovementReceipt    = "receipt"
+	MovementDispense   = "dispense"
+	MovementAdjustment = "adjustment"
+	MovementReturn     = "return"
+	MovementWriteOff   = "write_off"
+	MovementTransfer   = "transfer"
+)
+
+type StockMovementTxParams struct {
+	MedicineID   int32  `json:"medicine_id"`
+	MovementType string `json:"movement_type"`
+	Quantity     int32  `json:"quantity"`
+	// Unit is the unit Quantity is given in. Empty means the medicine's own
+	// unit; any other unit needs a conversion for the medicine.
+	Unit string `json:"unit"`
+	// BatchID is the batch to move. Dispenses without one take stock from the
+	// earliest-expiring batches.
+	BatchID sql.NullInt64 `json:"batch_id"`
+	// LotNumber and ExpiryDate identify the batch a receipt goes into.
+	LotNumber  string    `json:"lot_number"`
+	ExpiryDate time.Time `json:"expiry_date"`
+	// LocationID is where stock is added or taken. Without one, stock goes to
+	// the default location and is taken from there first, then from the rest.
+	LocationID sql.NullInt32  `json:"location_id"`
+	Note       sql.NullString `json:"note"`
+	CreatedBy  sql.NullString `json:"created_by"`
+	// WitnessedBy is the second person a movement of a controlled medicine
+	// needs; it must not be CreatedBy.
+	WitnessedBy sql.NullString `json:"witnessed_by"`
+}
+
+type StockMovementTxResult struct {
+	Medicine  Medicine        `json:"medicine"`
+	Movements []StockMovement `json:"movements"`
+}
+
+// validate checks the sign of the quantity against the movement type: receipts
+// and returns add stock, dispenses and write-offs remove it, adjustments go
+// either way. Receipts need a lot number and expiry date; everything but a
+// dispense needs a batch.
+func (arg StockMovementTxParams) validate() error {
+	switch arg.MovementType {
+	case MovementReceipt:
+		if arg.LotNumber == "" || arg.ExpiryDate.IsZero() {
+			return fmt.Errorf("%w: receipt needs a lot number and an expiry date", ErrInvalidStockMovement)
+		}
+		if arg.Quantity > 0 {
+			return nil
+		}
+	case MovementDispense:
+		if arg.Quantity < 0 {
+			return nil
+		}
+	case MovementReturn, MovementWriteOff, MovementAdjustment:
+		if !arg.BatchID.Valid {
+			return fmt.Errorf("%w: %s needs a batch", ErrInvalidStockMovement, arg.MovementType)
+		}
+		if arg.MovementType == MovementReturn && arg.Quantity > 0 ||
+			arg.MovementType == MovementWriteOff && arg.Quantity < 0 ||
+			arg.MovementType == MovementAdjustment && arg.Quantity != 0 {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%w: %s of %d", ErrInvalidStockMovement, arg.MovementType, arg.Quantity)
+}
+
+// StockMovementTx records a movement and applies it to the batches and the
+// medicine stock in one transaction. Stock never goes below zero.
+func (store *SQLStore) StockMovementTx(ctx context.Context, arg StockMovementTxParams) (StockMovementTxResult, error) {
+	var result StockMovementTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		results, err := applyStockMovements(ctx, q, []StockMovementTxParams{arg})
+		if err != nil {
+			return err
+		}
+
+		result = results[0]
+		return nil
+	})
+
+	return result, err
+}
+
+type CreateMedicineTxParams struct {
+	Medicine   CreateMedicineParams `json:"medicine"`
+	Stock      int32                `json:"stock"`
+	LotNumber  string               `json:"lot_number"`
+	ExpiryDate time.Time            `json:"expiry_date"`
+	CreatedBy  sql.NullString       `json:"created_by"`
+}
+
+// CreateMedicineTx creates a medicine, starts its price history and records
+// its initial stock as a receipt into the given lot.
+func (store *SQLStore) CreateMedicineTx(ctx context.Context, arg CreateMedicineTxParams) (Medicine, error) {
+	var result Medicine
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+		result, err = createMedicineWithPrice(ctx, q, arg)
+		return err
+	})
+
+	return result, err
+}
+
+func createMedicineWithPrice(ctx context.Context, q *Queries, arg CreateMedicineTxParams) (Medicine, error) {
+	medicine, err := q.CreateMedicine(ctx, arg.Medicine)
+	if err != nil {
+		return medicine, err
+	}
+
+	_, err = q.CreateMedicinePrice(ctx, CreateMedicinePriceParams{
+		MedicineID:    medicine.ID,
+		Price:         medicine.Price,
+		Currency:      medicine.Currency,
+		EffectiveFrom: medicine.CreatedAt,
+		Reason:        "Initial price",
+		CreatedBy:     arg.CreatedBy,
+	})
+	if err != nil {
+		return medicine, err
+	}
+
+	if arg.Stock == 0 {
+		return medicine, nil
+	}
+
+	results, err := applyStockMovements(ctx, q, []StockMovementTxParams{{
+		MedicineID:   medicine.ID,
+		MovementType: MovementReceipt,
+		Quantity:     arg.Stock,
+		LotNumber:    arg.LotNumber,
+		ExpiryDate:   arg.ExpiryDate,
+		Note:         sql.NullString{String: "Initial stock", Valid: true},
+		CreatedBy:    arg.CreatedBy,
+	}})
+	if err != nil {
+		return medicine, err
+	}
+
+	return results[0].Medicine, nil
+}
+
+// applyStockMovements locks the medicines in ascending ID order, the same
+// ordering TransferTx uses for accounts, so transactions moving stock of the
+// same medicines cannot deadlock. Results are in the order of movements.
+func applyStockMovements(ctx context.Context, q *Queries, movements []StockMovementTxParams) ([]StockMovementTxResult, error) {
+	order := make([]int, len(movements))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return movements[order[a]].MedicineID < movements[order[b]].MedicineID
+	})
+
+	results := make([]StockMovementTxResult, len(movements))
+	for _, i := range order {
+		var err error
+		results[i], err = applyStockMovement(ctx, q, movements[i])
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return results, nil
+}
+
+// batchMovement is the part of a movement applied to a single batch, and once
+// placed, to a single location of it.
+type batchMovement struct {
+	batchID    int64
+	quantity   int32
+	locationID int32
+}
+
+// applyStockMovement splits the movement over batches and their locations and
+// records one stock movement per batch and location, entering those of
+// controlled medicines in the controlled register. Batches and their
+// locations are only changed while the medicine row is locked, so they need no
+// locks of their own.
+func applyStockMovement(ctx context.Context, q *Queries, arg StockMovementTxParams) (StockMovementTxResult, error) {
+	var result StockMovementTxResult
+
+	err := arg.validate()
+	if err != nil {
+		return result, err
+	}
+
+	medicine, err := q.GetMedicineForUpdate(ctx, arg.MedicineID)
+	if err != nil {
+		return result, err
+	}
+
+	err = checkWitness(medicine, arg.CreatedBy, arg.WitnessedBy)
+	if err != nil {
+		return result, err
+	}
+
+	// what is left of an archived medicine can still be returned, adjusted
+	// or written off, but no more is received or dispensed
+	if medicine.DeletedAt.Valid && (arg.MovementType == MovementReceipt || arg.MovementType == MovementDispense) {
+		return result, fmt.Errorf("%w: %s", ErrMedicineArchived, medicine.Name)
+	}
+
+	arg.Quantity, err = toMedicineUnit(ctx, q, medicine, arg.Unit, arg.Quantity)
+	if err != nil {
+		return result, err
+	}
+
+	location, err := stockLocationOrDefault(ctx, q, arg.LocationID)
+	if err != nil {
+		return result, err
+	}
+
+	var moves []batchMovement
+	switch {
+	case arg.MovementType == MovementReceipt:
+		batch, err := q.UpsertMedicineBatch(ctx, UpsertMedicineBatchParams{
+			MedicineID: arg.MedicineID,
+			LotNumber:  arg.LotNumber,
+			ExpiryDate: sql.NullTime{Time: arg.ExpiryDate, Valid: true},
+			Quantity:   arg.Quantity,
+		})
+		if err == sql.ErrNoRows {
+			return result, fmt.Errorf("%w: lot %s of %s", ErrBatchExpiryMismatch, arg.LotNumber, medicine.Name)
+		}
+		if err != nil {
+			return result, err
+		}
+		moves = []batchMovement{{batchID: batch.ID, quantity: arg.Quantity}}
+	case arg.BatchID.Valid:
+		batch, err := q.GetMedicineBatch(ctx, arg.BatchID.Int64)
+		if err != nil {
+			return result, err
+		}
+		if batch.MedicineID != arg.MedicineID {
+			return result, sql.ErrNoRows
+		}
+		if arg.MovementType == MovementDispense && isExpired(batch) {
+			return result, fmt.Errorf("%w: lot %s of %s", ErrBatchExpired, batch.LotNumber, medicine.Name)
+		}
+		if batch.RemainingQuantity+arg.Quantity < 0 {
+			return result, fmt.Errorf("%w: lot %s of %s has %d left", ErrInsufficientStock, batch.LotNumber, medicine.Name, batch.RemainingQuantity)
+		}
+		moves = []batchMovement{{batchID: batch.ID, quantity: arg.Quantity}}
+	default:
+		moves, err = allocateFEFO(ctx, q, medicine, -arg.Quantity, arg.LocationID)
+		if err != nil {
+			return result, err
+		}
+	}
+
+	moves, err = placeBatchMovements(ctx, q, medicine, moves, location, arg.LocationID.Valid)
+	if err != nil {
+		return result, err
+	}
+
+	for _, move := range moves {
+		// the upsert already added a receipt to its batch
+		if arg.MovementType != MovementReceipt {
+			_, err = q.AddMedicineBatchQuantity(ctx, AddMedicineBatchQuantityParams{
+				ID:     move.batchID,
+				Amount: move.quantity,
+			})
+			if err != nil {
+				return result, err
+			}
+		}
+
+		_, err = q.AddBatchLocationQuantity(ctx, AddBatchLocationQuantityParams{
+			BatchID:    move.batchID,
+			LocationID: move.locationID,
+			Amount:     move.quantity,
+		})
+		if err != nil {
+			return result, err
+		}
+	}
+
+	result.Movements = make([]StockMovement, len(moves))
+	for i, move := range moves {
+		result.Medicine, err = q.AddMedicineStock(ctx, AddMedicineStockParams{
+			ID:     arg.MedicineID,
+			Amount: move.quantity,
+		})
+		if err != nil {
+			return result, err
+		}
+
+		result.Movements[i], err = q.CreateStockMovement(ctx, CreateStockMovementParams{
+			MedicineID:   arg.MedicineID,
+			MovementType: arg.MovementType,
+			Quantity:     move.quantity,
+			Balance:      result.Medicine.Stock,
+			Note:         arg.Note,
+			CreatedBy:    arg.CreatedBy,
+			BatchID:      sql.NullInt64{Int64: move.batchID, Valid: true},
+			LocationID:   sql.NullInt32{Int32: move.locationID, Valid: true},
+		})
+		if err != nil {
+			return result, err
+		}
+
+		err = recordControlledMovement(ctx, q, medicine, result.Movements[i], arg.WitnessedBy)
+		if err != nil {
+			return result, err
+		}
+	}
+
+	return result, nil
+}
+
+// allocateFEFO takes quantity units from the unexpired batches of the medicine,
+// earliest expiry first. With a location, only the stock kept there is taken
+// and the movements come back placed at it.
+func allocateFEFO(ctx context.Context, q *Queries, medicine Medicine, quantity int32, locationID sql.NullInt32) ([]batchMovement, error) {
+	var available []batchMovement
+	if locationID.Valid {
+		batches, err := q.ListDispensableLocationBatches(ctx, ListDispensableLocationBatchesParams{
+			MedicineID: medicine.ID,
+			LocationID: locationID.Int32,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, batch := range batches {
+			available = append(available, batchMovement{batchID: batch.ID, quantity: batch.Quantity, locationID: locationID.Int32})
+		}
+	} else {
+		batches, err := q.ListDispensableMedicineBatches(ctx, medicine.ID)
+		if err != nil {
+			return nil, err
+		}
+		for _, batch := range batches {
+			available = append(available, batchMovement{batchID: batch.ID, quantity: batch.RemainingQuantity})
+		}
+	}
+
+	var moves []batchMovement
+	left := quantity
+	for _, batch := range available {
+		if left == 0 {
+			break
+		}
+
+		take := min(left, batch.quantity)
+		moves = append(moves, batchMovement{batchID: batch.batchID, quantity: -take, locationID: batch.locationID})
+		left -= take
+	}
+
+	if left > 0 {
+		return nil, fmt.Errorf("%w: %s has %d unexpired left", ErrInsufficientStock, medicine.Name, quantity-left)
+	}
+
+	return moves, nil
+}
+
+// stockLocationOrDefault returns the location with the given ID, or the default
+// location without one.
+func stockLocationOrDefault(ctx context.Context, q *Queries, locationID sql.NullInt32) (StockLocation, error) {
+	if locationID.Valid {
+		return q.GetStockLocation(ctx, locationID.Int32)
+	}
+	return q.GetDefaultStockLocation(ctx)
+}
+
+// placeBatchMovements splits the movements not yet placed over the locations
+// of their batches. Stock is added to location; it is taken from location
+// first and, unless only is set, then from the other locations in ID order.
+func placeBatchMovements(ctx context.Context, q *Queries, medicine Medicine, moves []batchMovement, location StockLocation, only bool) ([]batchMovement, error) {
+	var placed []batchMovement
+	for _, move := range moves {
+		if move.locationID != 0 {
+			placed = append(placed, move)
+			continue
+		}
+
+		if move.quantity > 0 {
+			move.locationID = location.ID
+			placed = append(placed, move)
+			continue
+		}
+
+		locations, err := q.ListBatchLocations(ctx, move.batchID)
+		if err != nil {
+			return nil, err
+		}
+
+		sort.SliceStable(locations, func(a, b int) bool {
+			return locations[a].LocationID == location.ID && locations[b].LocationID != location.ID
+		})
+
+		left := -move.quantity
+		for _, batchLocation := range locations {
+			if left == 0 || only && batchLocation.LocationID != location.ID {
+				break
+			}
+
+			take := min(left, batchLocation.Quantity)
+			placed = append(placed, batchMovement{batchID: move.batchID, quantity: -take, locationID: batchLocation.LocationID})
+			left -= take
+		}
+
+		if left > 0 {
+			return nil, fmt.Errorf("%w: %s has %d of that lot in %s", ErrInsufficientStock, medicine.Name, -move.quantity-left, location.Name)
+		}
+	}
+
+	return placed, nil
+}
+
+// toMedicineUnit converts a quantity given in unit to the unit the medicine's
+// stock is counted in.
+func toMedicineUnit(ctx context.Context, q *Queries, medicine Medicine, unit string, quantity int32) (int32, error) {
+	if unit == "" || unit == medicine.Unit {
+		return quantity, nil
+	}
+
+	conversion, err := q.GetMedicineUnitConversion(ctx, GetMedicineUnitConversionParams{
+		MedicineID: medicine.ID,
+		Unit:       unit,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return 0, fmt.Errorf("%w: %s of %s", ErrUnknownUnit, unit, medicine.Name)
+		}
+		return 0, err
+	}
+
+	converted := int64(quantity) * int64(conversion.Factor)
+	if converted > math.MaxInt32 || converted < math.MinInt32 {
+		return 0, fmt.Errorf("%w: %d %s of %s is too many", ErrInvalidStockMovement, quantity, unit, medicine.Name)
+	}
+
+	return int32(converted), nil
+}
+
+func isExpired(batch MedicineBatch) bool {
+	if !batch.ExpiryDate.Valid {
+		return false
+	}
+
+	year, month, day := time.Now().Date()
+	today := time.Date(year, month, day, 0, 0, 0, 0, batch.ExpiryDate.Time.Location())
+	return batch.ExpiryDate.Time.Before(today)
+}
@@ -0,0 +1,209 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrInvalidStocktake    = errors.New("invalid stocktake")
+	ErrStocktakeStatus     = errors.New("stocktake is already approved")
+	ErrStocktakeIncomplete = errors.New("stocktake has items that were not counted")
+	ErrStocktakeDisputed   = errors.New("counters disagree on an item of the stocktake")
+)
+
+const (
+	StocktakeOpen     = "open"
+	StocktakeApproved = "approved"
+)
+
+type CreateStocktakeTxParams struct {
+	// MedicineIDs limits the stocktake to these medicines; empty means all.
+	MedicineIDs []int32        `json:"medicine_ids"`
+	Note        sql.NullString `json:"note"`
+	CreatedBy   sql.NullString `json:"created_by"`
+}
+
+type StocktakeTxResult struct {
+	Stocktake Stocktake               `json:"stocktake"`
+	Items     []ListStocktakeItemsRow `json:"items"`
+}
+
+// CreateStocktakeTx starts a stocktake with the quantity of every batch at
+// every location it is kept as the expected quantity. Stock keeps moving while
+// the shelves are counted; approval accounts for what moved before each count.
+func (store *SQLStore) CreateStocktakeTx(ctx context.Context, arg CreateStocktakeTxParams) (StocktakeTxResult, error) {
+	var result StocktakeTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+		result.Stocktake, err = q.CreateStocktake(ctx, CreateStocktakeParams{
+			Note:      arg.Note,
+			CreatedBy: arg.CreatedBy,
+		})
+		if err != nil {
+			return err
+		}
+
+		medicineIDs := arg.MedicineIDs
+		if medicineIDs == nil {
+			medicineIDs = []int32{}
+		}
+
+		count, err := q.CreateStocktakeItems(ctx, CreateStocktakeItemsParams{
+			StocktakeID: result.Stocktake.ID,
+			MedicineIds: medicineIDs,
+		})
+		if err != nil {
+			return err
+		}
+		if count == 0 {
+			return fmt.Errorf("%w: no stock to count", ErrInvalidStocktake)
+		}
+
+		result.Items, err = q.ListStocktakeItems(ctx, result.Stocktake.ID)
+		return err
+	})
+
+	return result, err
+}
+
+type StocktakeCountParams struct {
+	ItemID   int64 `json:"item_id"`
+	Quantity int32 `json:"quantity"`
+}
+
+type RecordStocktakeCountsTxParams struct {
+	StocktakeID int32                  `json:"stocktake_id"`
+	CountedBy   string                 `json:"counted_by"`
+	Counts      []StocktakeCountParams `json:"counts"`
+}
+
+// RecordStocktakeCountsTx saves what a counter found. Each counter has one
+// count per item, which a later count of theirs replaces. Counters count the
+// whole item independently; when their counts differ the item is disputed
+// until one of them recounts.
+func (store *SQLStore) RecordStocktakeCountsTx(ctx context.Context, arg RecordStocktakeCountsTxParams) (StocktakeTxResult, error) {
+	var result StocktakeTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var err error
+		result.Stocktake, err = q.GetStocktakeForUpdate(ctx, arg.StocktakeID)
+		if err != nil {
+			return err
+		}
+
+		if result.Stocktake.Status != StocktakeOpen {
+			return fmt.Errorf("%w: stocktake #%d", ErrStocktakeStatus, result.Stocktake.ID)
+		}
+
+		items, err := q.ListStocktakeItems(ctx, arg.StocktakeID)
+		if err != nil {
+			return err
+		}
+
+		onStocktake := make(map[int64]bool, len(items))
+		for _, item := range items {
+			onStocktake[item.ID] = true
+		}
+
+		for _, count := range arg.Counts {
+			if !onStocktake[count.ItemID] {
+				return fmt.Errorf("%w: item %d is not on stocktake #%d", ErrInvalidStocktake, count.ItemID, arg.StocktakeID)
+			}
+
+			_, err = q.UpsertStocktakeCount(ctx, UpsertStocktakeCountParams{
+				StocktakeItemID: count.ItemID,
+				CountedBy:       arg.CountedBy,
+				Quantity:        count.Quantity,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		result.Items, err = q.ListStocktakeItems(ctx, arg.StocktakeID)
+		return err
+	})
+
+	return result, err
+}
+
+type ApproveStocktakeTxParams struct {
+	StocktakeID int32          `json:"stocktake_id"`
+	ApprovedBy  sql.NullString `json:"approved_by"`
+	// WitnessedBy is needed when a controlled medicine has a variance.
+	WitnessedBy sql.NullString `json:"witnessed_by"`
+}
+
+type ApproveStocktakeTxResult struct {
+	Stocktake Stocktake               `json:"stocktake"`
+	Items     []ListStocktakeItemsRow `json:"items"`
+	Movements []StockMovementTxResult `json:"movements"`
+}
+
+// ApproveStocktakeTx posts an adjustment at the item's location for every item
+// whose count differs from what the batch held there when it was counted,
+// that is the snapshot plus the movements up to the last count, and marks the
+// stocktake approved, all or nothing. Every item must have been counted without dispute.
+func (store *SQLStore) ApproveStocktakeTx(ctx context.Context, arg ApproveStocktakeTxParams) (ApproveStocktakeTxResult, error) {
+	var result ApproveStocktakeTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		stocktake, err := q.GetStocktakeForUpdate(ctx, arg.StocktakeID)
+		if err != nil {
+			return err
+		}
+
+		if stocktake.Status != StocktakeOpen {
+			return fmt.Errorf("%w: stocktake #%d", ErrStocktakeStatus, stocktake.ID)
+		}
+
+		result.Items, err = q.ListStocktakeItems(ctx, stocktake.ID)
+		if err != nil {
+			return err
+		}
+
+		note := sql.NullString{String: fmt.Sprintf("Stocktake #%d", stocktake.ID), Valid: true}
+		movements := []StockMovementTxParams{}
+		for _, item := range result.Items {
+			if item.Counters == 0 {
+				return fmt.Errorf("%w: lot %s of %s", ErrStocktakeIncomplete, item.LotNumber, item.MedicineName)
+			}
+			if item.Disputed {
+				return fmt.Errorf("%w: lot %s of %s", ErrStocktakeDisputed, item.LotNumber, item.MedicineName)
+			}
+
+			variance := item.CountedQuantity - item.ExpectedQuantity - item.MovedQuantity
+			if variance == 0 {
+				continue
+			}
+
+			movements = append(movements, StockMovementTxParams{
+				MedicineID:   item.MedicineID,
+				MovementType: MovementAdjustment,
+				Quantity:     variance,
+				BatchID:      sql.NullInt64{Int64: item.BatchID, Valid: true},
+				LocationID:   sql.NullInt32{Int32: item.LocationID, Valid: true},
+				Note:         note,
+				CreatedBy:    arg.ApprovedBy,
+				WitnessedBy:  arg.WitnessedBy,
+			})
+		}
+
+		result.Movements, err = applyStockMovements(ctx, q, movements)
+		if err != nil {
+			return err
+		}
+
+		result.Stocktake, err = q.ApproveStocktake(ctx, ApproveStocktakeParams{
+			ApprovedBy: arg.ApprovedBy,
+			ID:         stocktake.ID,
+		})
+		return err
+	})
+
+	return result, err
+}
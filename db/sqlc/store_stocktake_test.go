@@ -0,0 +1,140 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/toannguyen3105/nht-bsihuyen.com-api/utils"
+)
+
+func TestApproveStocktakeTx(t *testing.T) {
+	store := NewStore(testDB)
+	medicine := createRandomMedicine(t, 50)
+	counter1 := createRandomUser(t)
+	counter2 := createRandomUser(t)
+	approver := createRandomUser(t)
+
+	created, err := store.CreateStocktakeTx(context.Background(), CreateStocktakeTxParams{
+		MedicineIDs: []int32{medicine.ID},
+	})
+	require.NoError(t, err)
+	require.Equal(t, StocktakeOpen, created.Stocktake.Status)
+	require.Len(t, created.Items, 1)
+	item := created.Items[0]
+	require.Equal(t, int32(50), item.ExpectedQuantity)
+	require.Zero(t, item.Counters)
+
+	approve := ApproveStocktakeTxParams{
+		StocktakeID: created.Stocktake.ID,
+		ApprovedBy:  sql.NullString{String: approver.Username, Valid: true},
+	}
+	_, err = store.ApproveStocktakeTx(context.Background(), approve)
+	require.ErrorIs(t, err, ErrStocktakeIncomplete)
+
+	dispense := StockMovementTxParams{
+		MedicineID:   medicine.ID,
+		MovementType: MovementDispense,
+		Quantity:     -5,
+	}
+
+	// stock dispensed before the count is already missing from the shelf
+	_, err = store.StockMovementTx(context.Background(), dispense)
+	require.NoError(t, err)
+
+	// two people count the lot and disagree until the first one recounts
+	for _, count := range []struct {
+		counter  User
+		quantity int32
+	}{{counter1, 40}, {counter2, 42}} {
+		_, err = store.RecordStocktakeCountsTx(context.Background(), RecordStocktakeCountsTxParams{
+			StocktakeID: created.Stocktake.ID,
+			CountedBy:   count.counter.Username,
+			Counts:      []StocktakeCountParams{{ItemID: item.ID, Quantity: count.quantity}},
+		})
+		require.NoError(t, err)
+	}
+
+	_, err = store.ApproveStocktakeTx(context.Background(), approve)
+	require.ErrorIs(t, err, ErrStocktakeDisputed)
+
+	counted, err := store.RecordStocktakeCountsTx(context.Background(), RecordStocktakeCountsTxParams{
+		StocktakeID: created.Stocktake.ID,
+		CountedBy:   counter1.Username,
+		Counts:      []StocktakeCountParams{{ItemID: item.ID, Quantity: 42}},
+	})
+	require.NoError(t, err)
+	require.False(t, counted.Items[0].Disputed)
+	require.Equal(t, int32(-5), counted.Items[0].MovedQuantity)
+
+	// stock dispensed after the count is not part of the variance
+	_, err = store.StockMovementTx(context.Background(), dispense)
+	require.NoError(t, err)
+
+	result, err := store.ApproveStocktakeTx(context.Background(), approve)
+	require.NoError(t, err)
+	require.Equal(t, StocktakeApproved, result.Stocktake.Status)
+	require.Equal(t, approve.ApprovedBy, result.Stocktake.ApprovedBy)
+	require.Equal(t, int32(42), result.Items[0].CountedQuantity)
+	require.Len(t, result.Movements, 1)
+	require.Equal(t, int32(-3), result.Movements[0].Movements[0].Quantity)
+	require.Equal(t, int32(37), result.Movements[0].Medicine.Stock)
+
+	_, err = store.ApproveStocktakeTx(context.Background(), approve)
+	require.ErrorIs(t, err, ErrStocktakeStatus)
+
+	deleted, err := store.DeleteStocktake(context.Background(), created.Stocktake.ID)
+	require.NoError(t, err)
+	require.Zero(t, deleted)
+}
+
+func TestStocktakeByLocation(t *testing.T) {
+	store := NewStore(testDB)
+	medicine := createRandomMedicine(t, 30)
+	counter := createRandomUser(t)
+
+	pharmacy, err := store.GetDefaultStockLocation(context.Background())
+	require.NoError(t, err)
+	cabinet, err := store.CreateStockLocation(context.Background(), utils.RandomString(12))
+	require.NoError(t, err)
+
+	_, err = store.StockTransferTx(context.Background(), StockTransferTxParams{
+		MedicineID:     medicine.ID,
+		FromLocationID: pharmacy.ID,
+		ToLocationID:   cabinet.ID,
+		Quantity:       10,
+	})
+	require.NoError(t, err)
+
+	created, err := store.CreateStocktakeTx(context.Background(), CreateStocktakeTxParams{
+		MedicineIDs: []int32{medicine.ID},
+	})
+	require.NoError(t, err)
+	require.Len(t, created.Items, 2)
+	require.Equal(t, pharmacy.ID, created.Items[0].LocationID)
+	require.Equal(t, int32(20), created.Items[0].ExpectedQuantity)
+	require.Equal(t, cabinet.ID, created.Items[1].LocationID)
+	require.Equal(t, int32(10), created.Items[1].ExpectedQuantity)
+
+	// the shelves are counted apart, not added up
+	_, err = store.RecordStocktakeCountsTx(context.Background(), RecordStocktakeCountsTxParams{
+		StocktakeID: created.Stocktake.ID,
+		CountedBy:   counter.Username,
+		Counts: []StocktakeCountParams{
+			{ItemID: created.Items[0].ID, Quantity: 20},
+			{ItemID: created.Items[1].ID, Quantity: 8},
+		},
+	})
+	require.NoError(t, err)
+
+	result, err := store.ApproveStocktakeTx(context.Background(), ApproveStocktakeTxParams{
+		StocktakeID: created.Stocktake.ID,
+		ApprovedBy:  sql.NullString{String: counter.Username, Valid: true},
+	})
+	require.NoError(t, err)
+	require.Len(t, result.Movements, 1)
+	require.Equal(t, int32(-2), result.Movements[0].Movements[0].Quantity)
+	require.Equal(t, cabinet.ID, result.Movements[0].Movements[0].LocationID.Int32)
+	require.Equal(t, int32(28), result.Movements[0].Medicine.Stock)
+}
@@ -2,9 +2,12 @@ package db
 
 import (
 	"context"
+	"database/sql"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
+	"github.com/toannguyen3105/nht-bsihuyen.com-api/utils"
 )
 
 func TestTransferTx(t *testing.T) {
@@ -153,3 +156,225 @@ func TestTransferTxDeadlock(t *testing.T) {
 	require.Equal(t, account1.Balance, updatedAccount1.Balance)
 	require.Equal(t, account2.Balance, updatedAccount2.Balance)
 }
+
+func TestStockMovementTx(t *testing.T) {
+	store := NewStore(testDB)
+
+	medicine := createRandomMedicine(t, 100)
+
+	// run n concurrent dispenses
+	n := 5
+	quantity := int32(10)
+
+	errs := make(chan error)
+	results := make(chan StockMovementTxResult)
+
+	for i := 0; i < n; i++ {
+		go func() {
+			result, err := store.StockMovementTx(context.Background(), StockMovementTxParams{
+				MedicineID:   medicine.ID,
+				MovementType: MovementDispense,
+				Quantity:     -quantity,
+			})
+
+			errs <- err
+			results <- result
+		}()
+	}
+
+	existed := make(map[int32]bool)
+	for i := 0; i < n; i++ {
+		err := <-errs
+		require.NoError(t, err)
+
+		result := <-results
+		require.Len(t, result.Movements, 1)
+
+		movement := result.Movements[0]
+		require.Equal(t, medicine.ID, movement.MedicineID)
+		require.Equal(t, -quantity, movement.Quantity)
+		require.Equal(t, result.Medicine.Stock, movement.Balance)
+
+		// every movement sees a distinct balance
+		require.False(t, existed[movement.Balance])
+		existed[movement.Balance] = true
+	}
+
+	updatedMedicine, err := store.GetMedicine(context.Background(), medicine.ID)
+	require.NoError(t, err)
+	require.Equal(t, medicine.Stock-int32(n)*quantity, updatedMedicine.Stock)
+}
+
+func TestStockMovementTxInsufficientStock(t *testing.T) {
+	store := NewStore(testDB)
+
+	medicine := createRandomMedicine(t, 5)
+
+	_, err := store.StockMovementTx(context.Background(), StockMovementTxParams{
+		MedicineID:   medicine.ID,
+		MovementType: MovementDispense,
+		Quantity:     -6,
+	})
+	require.ErrorIs(t, err, ErrInsufficientStock)
+
+	_, err = store.StockMovementTx(context.Background(), StockMovementTxParams{
+		MedicineID:   medicine.ID,
+		MovementType: MovementReceipt,
+		Quantity:     -1,
+		LotNumber:    utils.RandomString(10),
+		ExpiryDate:   time.Now().AddDate(1, 0, 0),
+	})
+	require.ErrorIs(t, err, ErrInvalidStockMovement)
+
+	updatedMedicine, err := store.GetMedicine(context.Background(), medicine.ID)
+	require.NoError(t, err)
+	require.Equal(t, medicine.Stock, updatedMedicine.Stock)
+}
+
+func TestStockMovementTxLotExpiry(t *testing.T) {
+	store := NewStore(testDB)
+	medicine := createRandomMedicine(t, 0)
+	expiryDate := time.Now().AddDate(1, 0, 0)
+
+	receipt := StockMovementTxParams{
+		MedicineID:   medicine.ID,
+		MovementType: MovementReceipt,
+		Quantity:     5,
+		LotNumber:    utils.RandomString(10),
+		ExpiryDate:   expiryDate,
+	}
+	_, err := store.StockMovementTx(context.Background(), receipt)
+	require.NoError(t, err)
+
+	// the same lot received again adds to it
+	result, err := store.StockMovementTx(context.Background(), receipt)
+	require.NoError(t, err)
+	require.Equal(t, int32(10), result.Medicine.Stock)
+
+	// a lot number keeps its expiry date
+	receipt.ExpiryDate = expiryDate.AddDate(0, 6, 0)
+	_, err = store.StockMovementTx(context.Background(), receipt)
+	require.ErrorIs(t, err, ErrBatchExpiryMismatch)
+
+	updatedMedicine, err := store.GetMedicine(context.Background(), medicine.ID)
+	require.NoError(t, err)
+	require.Equal(t, int32(10), updatedMedicine.Stock)
+}
+
+func TestStockMovementTxFEFO(t *testing.T) {
+	store := NewStore(testDB)
+
+	// the initial lot expires in a year
+	medicine := createRandomMedicine(t, 5)
+
+	receive := func(expiryDate time.Time, quantity int32) MedicineBatch {
+		result, err := store.StockMovementTx(context.Background(), StockMovementTxParams{
+			MedicineID:   medicine.ID,
+			MovementType: MovementReceipt,
+			Quantity:     quantity,
+			LotNumber:    utils.RandomString(10),
+			ExpiryDate:   expiryDate,
+		})
+		require.NoError(t, err)
+
+		batch, err := store.GetMedicineBatch(context.Background(), result.Movements[0].BatchID.Int64)
+		require.NoError(t, err)
+		return batch
+	}
+
+	expired := receive(time.Now().AddDate(0, 0, -1), 10)
+	soon := receive(time.Now().AddDate(0, 1, 0), 3)
+
+	result, err := store.StockMovementTx(context.Background(), StockMovementTxParams{
+		MedicineID:   medicine.ID,
+		MovementType: MovementDispense,
+		Quantity:     -4,
+	})
+	require.NoError(t, err)
+
+	// the batch expiring next month is used up before the one expiring next year
+	require.Len(t, result.Movements, 2)
+	require.Equal(t, soon.ID, result.Movements[0].BatchID.Int64)
+	require.Equal(t, int32(-3), result.Movements[0].Quantity)
+	require.Equal(t, int32(-1), result.Movements[1].Quantity)
+	require.Equal(t, medicine.Stock+10+3-4, result.Medicine.Stock)
+
+	// expired stock is never dispensed
+	_, err = store.StockMovementTx(context.Background(), StockMovementTxParams{
+		MedicineID:   medicine.ID,
+		MovementType: MovementDispense,
+		Quantity:     -5,
+	})
+	require.ErrorIs(t, err, ErrInsufficientStock)
+
+	_, err = store.StockMovementTx(context.Background(), StockMovementTxParams{
+		MedicineID:   medicine.ID,
+		MovementType: MovementDispense,
+		Quantity:     -1,
+		BatchID:      sql.NullInt64{Int64: expired.ID, Valid: true},
+	})
+	require.ErrorIs(t, err, ErrBatchExpired)
+
+	// but it can be written off
+	result, err = store.StockMovementTx(context.Background(), StockMovementTxParams{
+		MedicineID:   medicine.ID,
+		MovementType: MovementWriteOff,
+		Quantity:     -10,
+		BatchID:      sql.NullInt64{Int64: expired.ID, Valid: true},
+	})
+	require.NoError(t, err)
+	require.Equal(t, int32(4), result.Medicine.Stock)
+}
+
+func TestStockMovementTxUnitConversion(t *testing.T) {
+	store := NewStore(testDB)
+	medicine := createRandomMedicine(t, 0)
+
+	for unit, factor := range map[string]int32{"blister": 10, "box": 100} {
+		_, err := store.UpsertMedicineUnitConversion(context.Background(), UpsertMedicineUnitConversionParams{
+			MedicineID: medicine.ID,
+			Unit:       unit,
+			Factor:     factor,
+		})
+		require.NoError(t, err)
+	}
+
+	result, err := store.StockMovementTx(context.Background(), StockMovementTxParams{
+		MedicineID:   medicine.ID,
+		MovementType: MovementReceipt,
+		Quantity:     2,
+		Unit:         "box",
+		LotNumber:    utils.RandomString(10),
+		ExpiryDate:   time.Now().AddDate(1, 0, 0),
+	})
+	require.NoError(t, err)
+	require.Equal(t, int32(200), result.Movements[0].Quantity)
+	require.Equal(t, int32(200), result.Medicine.Stock)
+
+	result, err = store.StockMovementTx(context.Background(), StockMovementTxParams{
+		MedicineID:   medicine.ID,
+		MovementType: MovementDispense,
+		Quantity:     -3,
+		Unit:         "blister",
+	})
+	require.NoError(t, err)
+	require.Equal(t, int32(170), result.Medicine.Stock)
+
+	// the medicine's own unit needs no conversion
+	result, err = store.StockMovementTx(context.Background(), StockMovementTxParams{
+		MedicineID:   medicine.ID,
+		MovementType: MovementDispense,
+		Quantity:     -5,
+		Unit:         medicine.Unit,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int32(165), result.Medicine.Stock)
+
+	_, err = store.StockMovementTx(context.Background(), StockMovementTxParams{
+		MedicineID:   medicine.ID,
+		MovementType: MovementDispense,
+		Quantity:     -1,
+		Unit:         "bottle",
+	})
+	require.ErrorIs(t, err, ErrUnknownUnit)
+}
@@ -9,6 +9,44 @@ import (
 	"context"
 )
 
+const countSystemAdmins = `-- name: CountSystemAdmins :one
+SELECT count(*) FROM users u
+WHERE NOT EXISTS (
+  SELECT 1 FROM permissions p
+  WHERE p.is_system
+  AND NOT (
+    EXISTS (
+      SELECT 1 FROM user_effective_permissions e
+      WHERE e.user_id = u.id AND e.permission_id = p.id
+    )
+    AND (
+      EXISTS (
+        SELECT 1 FROM user_roles ur
+        JOIN role_permissions rp ON rp.role_id = ur.role_id
+        WHERE ur.user_id = u.id AND rp.permission_id = p.id
+        AND (ur.valid_from IS NULL OR ur.valid_from <= now())
+        AND ur.valid_until IS NULL
+      )
+      OR EXISTS (
+        SELECT 1 FROM user_permission_overrides o
+        WHERE o.user_id = u.id AND o.permission_id = p.id AND o.effect = 'allow'
+      )
+    )
+  )
+)
+`
+
+// CountSystemAdmins counts the users holding every system permission without
+// an end date: each one must come from an active role assignment without
+// valid_until or from an allow override. Assignments about to lapse do not
+// keep the system administrable.
+func (q *Queries) CountSystemAdmins(ctx context.Context) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countSystemAdmins)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const countUsers = `-- name: CountUsers :one
 SELECT count(*) FROM users
 `
@@ -56,11 +94,10 @@ func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (User, e
 }
 
 const getPermissionsForUser = `-- name: GetPermissionsForUser :many
-SELECT DISTINCT p.name
+SELECT p.name
 FROM permissions p
-JOIN role_permissions rp ON p.id = rp.permission_id
-JOIN user_roles ur ON rp.role_id = ur.role_id
-WHERE ur.user_id = $1
+JOIN user_effective_permissions e ON e.permission_id = p.id
+WHERE e.user_id = $1
 `
 
 func (q *Queries) GetPermissionsForUser(ctx context.Context, userID int32) ([]string, error) {
@@ -108,6 +145,74 @@ func (q *Queries) GetUser(ctx context.Context, username string) (User, error) {
 	return i, err
 }
 
+const getUserByID = `-- name: GetUserByID :one
+SELECT id, username, hashed_password, full_name, email, phone, password_changed_at, created_at, updated_at FROM users
+WHERE id = $1 LIMIT 1
+`
+
+func (q *Queries) GetUserByID(ctx context.Context, id int32) (User, error) {
+	row := q.db.QueryRowContext(ctx, getUserByID, id)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Username,
+		&i.HashedPassword,
+		&i.FullName,
+		&i.Email,
+		&i.Phone,
+		&i.PasswordChangedAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const listSystemAdmins = `-- name: ListSystemAdmins :many
+SELECT u.id, u.username, u.hashed_password, u.full_name, u.email, u.phone, u.password_changed_at, u.created_at, u.updated_at FROM users u
+WHERE NOT EXISTS (
+  SELECT 1 FROM permissions p
+  WHERE p.is_system
+  AND NOT EXISTS (
+    SELECT 1 FROM user_effective_permissions e
+    WHERE e.user_id = u.id AND e.permission_id = p.id
+  )
+)
+ORDER BY u.username
+`
+
+func (q *Queries) ListSystemAdmins(ctx context.Context) ([]User, error) {
+	rows, err := q.db.QueryContext(ctx, listSystemAdmins)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []User{}
+	for rows.Next() {
+		var i User
+		if err := rows.Scan(
+			&i.ID,
+			&i.Username,
+			&i.HashedPassword,
+			&i.FullName,
+			&i.Email,
+			&i.Phone,
+			&i.PasswordChangedAt,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listUsers = `-- name: ListUsers :many
 SELECT id, username, hashed_password, full_name, email, phone, password_changed_at, created_at, updated_at FROM users
 ORDER BY username
@@ -151,3 +256,44 @@ func (q *Queries) ListUsers(ctx context.Context, arg ListUsersParams) ([]User, e
 	}
 	return items, nil
 }
+
+const listUsersWithPermission = `-- name: ListUsersWithPermission :many
+SELECT u.id, u.username, u.hashed_password, u.full_name, u.email, u.phone, u.password_changed_at, u.created_at, u.updated_at FROM users u
+JOIN user_effective_permissions e ON e.user_id = u.id
+JOIN permissions p ON p.id = e.permission_id
+WHERE p.name = $1
+ORDER BY u.username
+`
+
+func (q *Queries) ListUsersWithPermission(ctx context.Context, name string) ([]User, error) {
+	rows, err := q.db.QueryContext(ctx, listUsersWithPermission, name)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []User{}
+	for rows.Next() {
+		var i User
+		if err := rows.Scan(
+			&i.ID,
+			&i.Username,
+			&i.HashedPassword,
+			&i.FullName,
+			&i.Email,
+			&i.Phone,
+			&i.PasswordChangedAt,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
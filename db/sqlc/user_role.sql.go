@@ -7,30 +7,44 @@ package db
 
 import (
 	"context"
+	"database/sql"
+	"time"
 )
 
 const addRoleForUser = `-- name: AddRoleForUser :one
 INSERT INTO user_roles (
   user_id,
-  role_id
+  role_id,
+  valid_from,
+  valid_until
 ) VALUES (
-  $1, $2
-) RETURNING user_id, role_id, created_at, updated_at
+  $1, $2, $3, $4
+) RETURNING user_id, role_id, created_at, updated_at, valid_from, valid_until, expiry_notified_at
 `
 
 type AddRoleForUserParams struct {
-	UserID int32 `json:"user_id"`
-	RoleID int32 `json:"role_id"`
+	UserID     int32        `json:"user_id"`
+	RoleID     int32        `json:"role_id"`
+	ValidFrom  sql.NullTime `json:"valid_from"`
+	ValidUntil sql.NullTime `json:"valid_until"`
 }
 
 func (q *Queries) AddRoleForUser(ctx context.Context, arg AddRoleForUserParams) (UserRole, error) {
-	row := q.db.QueryRowContext(ctx, addRoleForUser, arg.UserID, arg.RoleID)
+	row := q.db.QueryRowContext(ctx, addRoleForUser,
+		arg.UserID,
+		arg.RoleID,
+		arg.ValidFrom,
+		arg.ValidUntil,
+	)
 	var i UserRole
 	err := row.Scan(
 		&i.UserID,
 		&i.RoleID,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.ValidFrom,
+		&i.ValidUntil,
+		&i.ExpiryNotifiedAt,
 	)
 	return i, err
 }
@@ -46,10 +60,37 @@ func (q *Queries) CountUserRoles(ctx context.Context) (int64, error) {
 	return count, err
 }
 
+const countUsersForRole = `-- name: CountUsersForRole :one
+SELECT count(*) FROM user_roles
+WHERE role_id = $1
+`
+
+func (q *Queries) CountUsersForRole(ctx context.Context, roleID int32) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countUsersForRole, roleID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const deleteExpiredUserRoles = `-- name: DeleteExpiredUserRoles :execrows
+DELETE FROM user_roles
+WHERE valid_until <= now()
+`
+
+func (q *Queries) DeleteExpiredUserRoles(ctx context.Context) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteExpiredUserRoles)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
 const getRolesForUser = `-- name: GetRolesForUser :many
-SELECT roles.id, roles.name, roles.description, roles.created_at, roles.updated_at FROM roles
+SELECT roles.id, roles.name, roles.description, roles.created_at, roles.updated_at, roles.is_system FROM roles
 JOIN user_roles ON roles.id = user_roles.role_id
 WHERE user_roles.user_id = $1
+AND (user_roles.valid_from IS NULL OR user_roles.valid_from <= now())
+AND (user_roles.valid_until IS NULL OR user_roles.valid_until > now())
 `
 
 func (q *Queries) GetRolesForUser(ctx context.Context, userID int32) ([]Role, error) {
@@ -67,6 +108,171 @@ func (q *Queries) GetRolesForUser(ctx context.Context, userID int32) ([]Role, er
 			&i.Description,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.IsSystem,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listAllUserRoles = `-- name: ListAllUserRoles :many
+SELECT
+  ur.user_id,
+  u.username,
+  ur.role_id,
+  ur.valid_from,
+  ur.valid_until
+FROM user_roles ur
+JOIN users u ON u.id = ur.user_id
+ORDER BY u.username, ur.role_id
+`
+
+type ListAllUserRolesRow struct {
+	UserID     int32        `json:"user_id"`
+	Username   string       `json:"username"`
+	RoleID     int32        `json:"role_id"`
+	ValidFrom  sql.NullTime `json:"valid_from"`
+	ValidUntil sql.NullTime `json:"valid_until"`
+}
+
+func (q *Queries) ListAllUserRoles(ctx context.Context) ([]ListAllUserRolesRow, error) {
+	rows, err := q.db.QueryContext(ctx, listAllUserRoles)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListAllUserRolesRow{}
+	for rows.Next() {
+		var i ListAllUserRolesRow
+		if err := rows.Scan(
+			&i.UserID,
+			&i.Username,
+			&i.RoleID,
+			&i.ValidFrom,
+			&i.ValidUntil,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listExpiringUserRoles = `-- name: ListExpiringUserRoles :many
+SELECT
+  ur.user_id,
+  ur.role_id,
+  ur.valid_until,
+  u.username,
+  u.full_name,
+  r.name AS role_name
+FROM user_roles ur
+JOIN users u ON u.id = ur.user_id
+JOIN roles r ON r.id = ur.role_id
+WHERE ur.valid_until > now()
+AND ur.valid_until <= $1
+AND ur.expiry_notified_at IS NULL
+ORDER BY ur.valid_until
+`
+
+type ListExpiringUserRolesRow struct {
+	UserID     int32        `json:"user_id"`
+	RoleID     int32        `json:"role_id"`
+	ValidUntil sql.NullTime `json:"valid_until"`
+	Username   string       `json:"username"`
+	FullName   string       `json:"full_name"`
+	RoleName   string       `json:"role_name"`
+}
+
+func (q *Queries) ListExpiringUserRoles(ctx context.Context, notifyBefore time.Time) ([]ListExpiringUserRolesRow, error) {
+	rows, err := q.db.QueryContext(ctx, listExpiringUserRoles, notifyBefore)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListExpiringUserRolesRow{}
+	for rows.Next() {
+		var i ListExpiringUserRolesRow
+		if err := rows.Scan(
+			&i.UserID,
+			&i.RoleID,
+			&i.ValidUntil,
+			&i.Username,
+			&i.FullName,
+			&i.RoleName,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listUserRoleGrants = `-- name: ListUserRoleGrants :many
+SELECT
+  r.id AS role_id,
+  r.name AS role_name,
+  ur.valid_from,
+  ur.valid_until,
+  ((ur.valid_from IS NULL OR ur.valid_from <= now())
+    AND (ur.valid_until IS NULL OR ur.valid_until > now()))::boolean AS active,
+  p.id AS permission_id,
+  p.name AS permission_name
+FROM user_roles ur
+JOIN roles r ON r.id = ur.role_id
+LEFT JOIN role_permissions rp ON rp.role_id = ur.role_id
+LEFT JOIN permissions p ON p.id = rp.permission_id
+WHERE ur.user_id = $1
+ORDER BY r.id, p.id
+`
+
+type ListUserRoleGrantsRow struct {
+	RoleID         int32          `json:"role_id"`
+	RoleName       string         `json:"role_name"`
+	ValidFrom      sql.NullTime   `json:"valid_from"`
+	ValidUntil     sql.NullTime   `json:"valid_until"`
+	Active         bool           `json:"active"`
+	PermissionID   sql.NullInt32  `json:"permission_id"`
+	PermissionName sql.NullString `json:"permission_name"`
+}
+
+func (q *Queries) ListUserRoleGrants(ctx context.Context, userID int32) ([]ListUserRoleGrantsRow, error) {
+	rows, err := q.db.QueryContext(ctx, listUserRoleGrants, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListUserRoleGrantsRow{}
+	for rows.Next() {
+		var i ListUserRoleGrantsRow
+		if err := rows.Scan(
+			&i.RoleID,
+			&i.RoleName,
+			&i.ValidFrom,
+			&i.ValidUntil,
+			&i.Active,
+			&i.PermissionID,
+			&i.PermissionName,
 		); err != nil {
 			return nil, err
 		}
@@ -82,7 +288,7 @@ func (q *Queries) GetRolesForUser(ctx context.Context, userID int32) ([]Role, er
 }
 
 const listUserRoles = `-- name: ListUserRoles :many
-SELECT user_id, role_id, created_at, updated_at FROM user_roles
+SELECT user_id, role_id, created_at, updated_at, valid_from, valid_until, expiry_notified_at FROM user_roles
 ORDER BY user_id, role_id
 LIMIT $1
 OFFSET $2
@@ -107,6 +313,9 @@ func (q *Queries) ListUserRoles(ctx context.Context, arg ListUserRolesParams) ([
 			&i.RoleID,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.ValidFrom,
+			&i.ValidUntil,
+			&i.ExpiryNotifiedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -121,6 +330,93 @@ func (q *Queries) ListUserRoles(ctx context.Context, arg ListUserRolesParams) ([
 	return items, nil
 }
 
+const listUsersForRole = `-- name: ListUsersForRole :many
+SELECT
+  u.id,
+  u.username,
+  u.full_name,
+  u.email,
+  u.phone,
+  ur.valid_from,
+  ur.valid_until,
+  ((ur.valid_from IS NULL OR ur.valid_from <= now())
+    AND (ur.valid_until IS NULL OR ur.valid_until > now()))::boolean AS active,
+  ur.created_at AS assigned_at
+FROM user_roles ur
+JOIN users u ON u.id = ur.user_id
+WHERE ur.role_id = $1
+ORDER BY u.id
+LIMIT $2
+OFFSET $3
+`
+
+type ListUsersForRoleParams struct {
+	RoleID int32 `json:"role_id"`
+	Limit  int32 `json:"limit"`
+	Offset int32 `json:"offset"`
+}
+
+type ListUsersForRoleRow struct {
+	ID         int32          `json:"id"`
+	Username   string         `json:"username"`
+	FullName   string         `json:"full_name"`
+	Email      string         `json:"email"`
+	Phone      sql.NullString `json:"phone"`
+	ValidFrom  sql.NullTime   `json:"valid_from"`
+	ValidUntil sql.NullTime   `json:"valid_until"`
+	Active     bool           `json:"active"`
+	AssignedAt time.Time      `json:"assigned_at"`
+}
+
+func (q *Queries) ListUsersForRole(ctx context.Context, arg ListUsersForRoleParams) ([]ListUsersForRoleRow, error) {
+	rows, err := q.db.QueryContext(ctx, listUsersForRole, arg.RoleID, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListUsersForRoleRow{}
+	for rows.Next() {
+		var i ListUsersForRoleRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Username,
+			&i.FullName,
+			&i.Email,
+			&i.Phone,
+			&i.ValidFrom,
+			&i.ValidUntil,
+			&i.Active,
+			&i.AssignedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const markUserRoleExpiryNotified = `-- name: MarkUserRoleExpiryNotified :exec
+UPDATE user_roles
+SET expiry_notified_at = now()
+WHERE user_id = $1 AND role_id = $2
+`
+
+type MarkUserRoleExpiryNotifiedParams struct {
+	UserID int32 `json:"user_id"`
+	RoleID int32 `json:"role_id"`
+}
+
+func (q *Queries) MarkUserRoleExpiryNotified(ctx context.Context, arg MarkUserRoleExpiryNotifiedParams) error {
+	_, err := q.db.ExecContext(ctx, markUserRoleExpiryNotified, arg.UserID, arg.RoleID)
+	return err
+}
+
 const removeRoleForUser = `-- name: RemoveRoleForUser :exec
 DELETE FROM user_roles
 WHERE user_id = $1 AND role_id = $2
@@ -135,3 +431,41 @@ func (q *Queries) RemoveRoleForUser(ctx context.Context, arg RemoveRoleForUserPa
 	_, err := q.db.ExecContext(ctx, removeRoleForUser, arg.UserID, arg.RoleID)
 	return err
 }
+
+const updateUserRoleValidity = `-- name: UpdateUserRoleValidity :one
+UPDATE user_roles
+SET
+  valid_from = $3,
+  valid_until = $4,
+  expiry_notified_at = NULL,
+  updated_at = now()
+WHERE user_id = $1 AND role_id = $2
+RETURNING user_id, role_id, created_at, updated_at, valid_from, valid_until, expiry_notified_at
+`
+
+type UpdateUserRoleValidityParams struct {
+	UserID     int32        `json:"user_id"`
+	RoleID     int32        `json:"role_id"`
+	ValidFrom  sql.NullTime `json:"valid_from"`
+	ValidUntil sql.NullTime `json:"valid_until"`
+}
+
+func (q *Queries) UpdateUserRoleValidity(ctx context.Context, arg UpdateUserRoleValidityParams) (UserRole, error) {
+	row := q.db.QueryRowContext(ctx, updateUserRoleValidity,
+		arg.UserID,
+		arg.RoleID,
+		arg.ValidFrom,
+		arg.ValidUntil,
+	)
+	var i UserRole
+	err := row.Scan(
+		&i.UserID,
+		&i.RoleID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.ValidFrom,
+		&i.ValidUntil,
+		&i.ExpiryNotifiedAt,
+	)
+	return i, err
+}
@@ -2,6 +2,7 @@ package db
 
 import (
 	"context"
+	"database/sql"
 	"testing"
 	"time"
 
@@ -52,3 +53,49 @@ func TestGetUser(t *testing.T) {
 	require.WithinDuration(t, user1.PasswordChangedAt, user2.PasswordChangedAt, time.Second)
 	require.WithinDuration(t, user1.CreatedAt, user2.CreatedAt, time.Second)
 }
+
+func TestCountSystemAdminsIgnoresTimeLimitedAssignments(t *testing.T) {
+	ctx := context.Background()
+
+	roles, err := testQueries.ListAllRoles(ctx)
+	require.NoError(t, err)
+
+	var admin Role
+	for _, role := range roles {
+		if role.Name == "admin" {
+			admin = role
+		}
+	}
+	require.NotZero(t, admin.ID)
+
+	before, err := testQueries.CountSystemAdmins(ctx)
+	require.NoError(t, err)
+
+	temporary := createRandomUser(t)
+	_, err = testQueries.AddRoleForUser(ctx, AddRoleForUserParams{
+		UserID:     temporary.ID,
+		RoleID:     admin.ID,
+		ValidUntil: sql.NullTime{Time: time.Now().Add(time.Hour), Valid: true},
+	})
+	require.NoError(t, err)
+
+	count, err := testQueries.CountSystemAdmins(ctx)
+	require.NoError(t, err)
+	require.Equal(t, before, count)
+
+	permanent := createRandomUser(t)
+	_, err = testQueries.AddRoleForUser(ctx, AddRoleForUserParams{
+		UserID: permanent.ID,
+		RoleID: admin.ID,
+	})
+	require.NoError(t, err)
+
+	count, err = testQueries.CountSystemAdmins(ctx)
+	require.NoError(t, err)
+	require.Equal(t, before+1, count)
+
+	for _, user := range []User{temporary, permanent} {
+		err = testQueries.RemoveRoleForUser(ctx, RemoveRoleForUserParams{UserID: user.ID, RoleID: admin.ID})
+		require.NoError(t, err)
+	}
+}
@@ -1,13 +1,16 @@
 package main
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"log"
 
 	"github.com/toannguyen3105/nht-bsihuyen.com-api/api"
 	db "github.com/toannguyen3105/nht-bsihuyen.com-api/db/sqlc"
+	"github.com/toannguyen3105/nht-bsihuyen.com-api/notify"
 	"github.com/toannguyen3105/nht-bsihuyen.com-api/utils"
+	"github.com/toannguyen3105/nht-bsihuyen.com-api/worker"
 
 	_ "github.com/lib/pq"
 )
@@ -28,7 +31,18 @@ func main() {
 	}
 
 	store := db.NewStore(conn)
-	server, err := api.NewServer(config, store)
+	notifier := notify.NewLogNotifier(nil)
+	if config.NotifyWebhookURL != "" {
+		notifier = notify.NewWebhookNotifier(config.NotifyWebhookURL, nil)
+	}
+
+	scheduler := worker.NewScheduler()
+	scheduler.Every("user role expiry", config.RoleExpiryInterval, worker.UserRoleExpiryJob(store, notifier, config.RoleExpiryNotice))
+	scheduler.Every("stock alerts", config.StockAlertInterval, worker.StockAlertJob(store, notifier, config.ExpiryAlertNotice))
+	scheduler.Every("medicine prices", config.PriceChangeInterval, worker.MedicinePriceJob(store))
+	scheduler.Start(context.Background())
+
+	server, err := api.NewServer(config, store, notifier)
 	if err != nil {
 		log.Fatal("cannot create server:", err)
 	}
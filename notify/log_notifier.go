@@ -0,0 +1,33 @@
+package notify
+
+import (
+	"context"
+	"log"
+	"strings"
+)
+
+// LogNotifier writes notifications to the server log. It is the default
+// until a mail or chat integration is configured.
+type LogNotifier struct {
+	logger *log.Logger
+}
+
+func NewLogNotifier(logger *log.Logger) Notifier {
+	if logger == nil {
+		logger = log.Default()
+	}
+
+	return &LogNotifier{
+		logger: logger,
+	}
+}
+
+func (notifier *LogNotifier) Notify(ctx context.Context, notification Notification) error {
+	notifier.logger.Printf(
+		"notification to [%s]: %s: %s",
+		strings.Join(notification.Recipients, ", "),
+		notification.Subject,
+		notification.Body,
+	)
+	return nil
+}
@@ -0,0 +1,15 @@
+package notify
+
+import (
+	"context"
+)
+
+type Notification struct {
+	Recipients []string `json:"recipients"`
+	Subject    string   `json:"subject"`
+	Body       string   `json:"body"`
+}
+
+type Notifier interface {
+	Notify(ctx context.Context, notification Notification) error
+}
@@ -0,0 +1,25 @@
+package utils
+
+// IsValidEAN13 reports whether code is 13 digits whose last digit is the
+// EAN-13 check digit of the other twelve.
+func IsValidEAN13(code string) bool {
+	if len(code) != 13 {
+		return false
+	}
+
+	sum := 0
+	for i := 0; i < 12; i++ {
+		digit := int(code[i] - '0')
+		if digit > 9 {
+			return false
+		}
+		// digits in even positions, counting from 1, weigh 3
+		if i%2 == 1 {
+			digit *= 3
+		}
+		sum += digit
+	}
+
+	check := int(code[12] - '0')
+	return check <= 9 && (sum+check)%10 == 0
+}
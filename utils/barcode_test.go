@@ -0,0 +1,18 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsValidEAN13(t *testing.T) {
+	require.True(t, IsValidEAN13("8934588012228"))
+	require.True(t, IsValidEAN13("4006381333931"))
+
+	require.False(t, IsValidEAN13("8934588012229"))
+	require.False(t, IsValidEAN13("893458801222"))
+	require.False(t, IsValidEAN13("89345880122280"))
+	require.False(t, IsValidEAN13("893458801222a"))
+	require.False(t, IsValidEAN13(""))
+}
@@ -13,6 +13,13 @@ type Config struct {
 	TokenSymmetricKey    string        `mapstructure:"TOKEN_SYMMETRIC_KEY"`
 	AccessTokenDuration  time.Duration `mapstructure:"ACCESS_TOKEN_DURATION"`
 	RefreshTokenDuration time.Duration `mapstructure:"REFRESH_TOKEN_DURATION"`
+	RoleExpiryInterval   time.Duration `mapstructure:"ROLE_EXPIRY_INTERVAL"`
+	RoleExpiryNotice     time.Duration `mapstructure:"ROLE_EXPIRY_NOTICE"`
+	BreakGlassDuration   time.Duration `mapstructure:"BREAK_GLASS_DURATION"`
+	StockAlertInterval   time.Duration `mapstructure:"STOCK_ALERT_INTERVAL"`
+	ExpiryAlertNotice    time.Duration `mapstructure:"EXPIRY_ALERT_NOTICE"`
+	NotifyWebhookURL     string        `mapstructure:"NOTIFY_WEBHOOK_URL"`
+	PriceChangeInterval  time.Duration `mapstructure:"PRICE_CHANGE_INTERVAL"`
 }
 
 func LoadConfig(path string) (config Config, err error) {
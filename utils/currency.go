@@ -4,6 +4,7 @@ const (
 	USD = "USD"
 	EUR = "EUR"
 	CAD = "CAD"
+	VND = "VND"
 )
 
 func IsSupportedCurrency(currency string) bool {
@@ -13,3 +14,18 @@ func IsSupportedCurrency(currency string) bool {
 	}
 	return false
 }
+
+// IsSupportedPriceCurrency reports whether medicine prices and purchase costs
+// may be in currency. Unlike accounts, these are usually in đồng.
+func IsSupportedPriceCurrency(currency string) bool {
+	return currency == VND || IsSupportedCurrency(currency)
+}
+
+// CurrencyDecimals is the number of decimal places of the currency's minor
+// unit. The đồng has none in use.
+func CurrencyDecimals(currency string) int {
+	if currency == VND {
+		return 0
+	}
+	return 2
+}
@@ -0,0 +1,296 @@
+package utils
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// DecimalPlaces is the scale of Decimal, matching the NUMERIC(15,2) columns
+// money is stored in.
+const DecimalPlaces = 2
+
+const decimalFactor = 100
+
+var (
+	ErrInvalidDecimal  = errors.New("invalid decimal")
+	ErrDecimalOverflow = errors.New("decimal out of range")
+)
+
+// Decimal is an exact amount with two decimal places, held as a count of
+// hundredths. It is read from and written to JSON as a string ("2500.00") and
+// accepts JSON numbers too, without going through float64.
+type Decimal int64
+
+// ParseDecimal parses "12", "12.5", "-12.50" and the like. More than two
+// decimal places is an error rather than being rounded away.
+func ParseDecimal(s string) (Decimal, error) {
+	text := strings.TrimSpace(s)
+
+	negative := false
+	switch {
+	case strings.HasPrefix(text, "-"):
+		negative = true
+		text = text[1:]
+	case strings.HasPrefix(text, "+"):
+		text = text[1:]
+	}
+
+	whole, fraction, hasPoint := strings.Cut(text, ".")
+	if whole == "" && fraction == "" || hasPoint && fraction == "" {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidDecimal, s)
+	}
+	if len(fraction) > DecimalPlaces {
+		return 0, fmt.Errorf("%w: %q has more than %d decimal places", ErrInvalidDecimal, s, DecimalPlaces)
+	}
+
+	var units int64
+	for _, digits := range []string{whole, fraction + strings.Repeat("0", DecimalPlaces-len(fraction))} {
+		for _, c := range digits {
+			if c < '0' || c > '9' {
+				return 0, fmt.Errorf("%w: %q", ErrInvalidDecimal, s)
+			}
+			if units > (math.MaxInt64-int64(c-'0'))/10 {
+				return 0, fmt.Errorf("%w: %q", ErrDecimalOverflow, s)
+			}
+			units = units*10 + int64(c-'0')
+		}
+	}
+
+	if negative {
+		units = -units
+	}
+	return Decimal(units), nil
+}
+
+// MustParseDecimal is ParseDecimal for constants known to be valid.
+func MustParseDecimal(s string) Decimal {
+	d, err := ParseDecimal(s)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+func (d Decimal) String() string {
+	units := int64(d)
+	sign := ""
+	if units < 0 {
+		sign = "-"
+	}
+
+	whole := units / decimalFactor
+	fraction := units % decimalFactor
+	if units < 0 {
+		whole, fraction = -whole, -fraction
+	}
+
+	return fmt.Sprintf("%s%d.%02d", sign, whole, fraction)
+}
+
+// Hundredths returns d as a count of hundredths.
+func (d Decimal) Hundredths() int64 {
+	return int64(d)
+}
+
+func (d Decimal) IsZero() bool {
+	return d == 0
+}
+
+func (d Decimal) IsNegative() bool {
+	return d < 0
+}
+
+// Cmp returns -1, 0 or +1 as d is less than, equal to or greater than other.
+func (d Decimal) Cmp(other Decimal) int {
+	switch {
+	case d < other:
+		return -1
+	case d > other:
+		return 1
+	}
+	return 0
+}
+
+func (d Decimal) Add(other Decimal) Decimal {
+	return d + other
+}
+
+func (d Decimal) Sub(other Decimal) Decimal {
+	return d - other
+}
+
+// Mul multiplies by a whole number, such as a unit price by a quantity. A
+// product outside the range of Decimal is ErrDecimalOverflow.
+func (d Decimal) Mul(n int64) (Decimal, error) {
+	product, ok := mulInt64(int64(d), n)
+	if !ok {
+		return 0, fmt.Errorf("%w: %s × %d", ErrDecimalOverflow, d, n)
+	}
+	return Decimal(product), nil
+}
+
+// MulRate multiplies by rate (itself a decimal, such as 1.05 for +5%) and
+// rounds half away from zero to two decimal places.
+func (d Decimal) MulRate(rate Decimal) (Decimal, error) {
+	product, ok := mulInt64(int64(d), int64(rate))
+	if !ok {
+		return 0, fmt.Errorf("%w: %s × %s", ErrDecimalOverflow, d, rate)
+	}
+	return Decimal(roundDiv(product, decimalFactor)), nil
+}
+
+// Percent returns percent per cent of d, rounded half away from zero to two
+// decimal places. Percent(5) of 200.00 is 10.00.
+func (d Decimal) Percent(percent Decimal) (Decimal, error) {
+	product, ok := mulInt64(int64(d), int64(percent))
+	if !ok {
+		return 0, fmt.Errorf("%w: %s%% of %s", ErrDecimalOverflow, percent, d)
+	}
+	return Decimal(roundDiv(product, 100*decimalFactor)), nil
+}
+
+// Round rounds half away from zero to the given number of decimal places
+// (at most two).
+func (d Decimal) Round(places int) Decimal {
+	if places >= DecimalPlaces {
+		return d
+	}
+
+	step := int64(math.Pow10(DecimalPlaces - places))
+	return Decimal(roundDiv(int64(d), step) * step)
+}
+
+func roundDiv(a, b int64) int64 {
+	q, r := a/b, a%b
+	if 2*abs(r) >= b {
+		if a < 0 {
+			q--
+		} else {
+			q++
+		}
+	}
+	return q
+}
+
+// mulInt64 is a*b, or false when the product does not fit in an int64.
+func mulInt64(a, b int64) (int64, bool) {
+	if a == 0 || b == 0 {
+		return 0, true
+	}
+	product := a * b
+	if product/b != a || a == -1 && b == math.MinInt64 || b == -1 && a == math.MinInt64 {
+		return 0, false
+	}
+	return product, true
+}
+
+func abs(n int64) int64 {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.Quote(d.String())), nil
+}
+
+func (d *Decimal) UnmarshalJSON(data []byte) error {
+	text := string(data)
+	if text == "null" {
+		return nil
+	}
+	if unquoted, err := strconv.Unquote(text); err == nil {
+		text = unquoted
+	}
+
+	parsed, err := ParseDecimal(text)
+	if err != nil {
+		return err
+	}
+
+	*d = parsed
+	return nil
+}
+
+// UnmarshalParam lets gin bind a Decimal from a query or form parameter.
+func (d *Decimal) UnmarshalParam(param string) error {
+	parsed, err := ParseDecimal(param)
+	if err != nil {
+		return err
+	}
+
+	*d = parsed
+	return nil
+}
+
+// Scan reads a NUMERIC column, which lib/pq returns as text.
+func (d *Decimal) Scan(src interface{}) error {
+	var text string
+	switch value := src.(type) {
+	case []byte:
+		text = string(value)
+	case string:
+		text = value
+	case int64:
+		text = strconv.FormatInt(value, 10)
+	default:
+		return fmt.Errorf("%w: cannot scan %T", ErrInvalidDecimal, src)
+	}
+
+	parsed, err := ParseDecimal(text)
+	if err != nil {
+		return err
+	}
+
+	*d = parsed
+	return nil
+}
+
+func (d Decimal) Value() (driver.Value, error) {
+	return d.String(), nil
+}
+
+// NullDecimal is a Decimal that may be NULL, in the manner of sql.NullString.
+type NullDecimal struct {
+	Decimal Decimal
+	Valid   bool
+}
+
+func (n *NullDecimal) Scan(src interface{}) error {
+	if src == nil {
+		n.Decimal, n.Valid = 0, false
+		return nil
+	}
+
+	n.Valid = true
+	return n.Decimal.Scan(src)
+}
+
+func (n NullDecimal) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	return n.Decimal.Value()
+}
+
+func (n NullDecimal) MarshalJSON() ([]byte, error) {
+	if !n.Valid {
+		return []byte("null"), nil
+	}
+	return n.Decimal.MarshalJSON()
+}
+
+func (n *NullDecimal) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		n.Decimal, n.Valid = 0, false
+		return nil
+	}
+
+	n.Valid = true
+	return n.Decimal.UnmarshalJSON(data)
+}
@@ -0,0 +1,173 @@
+package utils
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDecimal(t *testing.T) {
+	testCases := []struct {
+		input string
+		want  string
+		err   error
+	}{
+		{input: "2500", want: "2500.00"},
+		{input: "99.5", want: "99.50"},
+		{input: "-0.05", want: "-0.05"},
+		{input: ".5", want: "0.50"},
+		{input: " 12.34 ", want: "12.34"},
+		{input: "0.001", err: ErrInvalidDecimal},
+		{input: "1e3", err: ErrInvalidDecimal},
+		{input: "12.", err: ErrInvalidDecimal},
+		{input: "", err: ErrInvalidDecimal},
+		{input: "99999999999999999999", err: ErrDecimalOverflow},
+	}
+
+	for _, tc := range testCases {
+		d, err := ParseDecimal(tc.input)
+		if tc.err != nil {
+			require.ErrorIs(t, err, tc.err, tc.input)
+			continue
+		}
+		require.NoError(t, err, tc.input)
+		require.Equal(t, tc.want, d.String())
+	}
+}
+
+func TestDecimalJSON(t *testing.T) {
+	var value struct {
+		Price Decimal     `json:"price"`
+		Cost  NullDecimal `json:"cost"`
+		Fee   NullDecimal `json:"fee"`
+	}
+
+	// numbers are read from their text, so 0.1 + 0.2 is exact
+	err := json.Unmarshal([]byte(`{"price": 0.1, "cost": "0.20", "fee": null}`), &value)
+	require.NoError(t, err)
+	require.Equal(t, MustParseDecimal("0.30"), value.Price.Add(value.Cost.Decimal))
+	require.False(t, value.Fee.Valid)
+
+	data, err := json.Marshal(value)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"price": "0.10", "cost": "0.20", "fee": null}`, string(data))
+
+	err = json.Unmarshal([]byte(`{"price": 0.125}`), &value)
+	require.ErrorIs(t, err, ErrInvalidDecimal)
+}
+
+func TestDecimalSQL(t *testing.T) {
+	var d Decimal
+	require.NoError(t, d.Scan([]byte("2500.50")))
+	require.Equal(t, Decimal(250050), d)
+
+	value, err := d.Value()
+	require.NoError(t, err)
+	require.Equal(t, "2500.50", value)
+
+	var n NullDecimal
+	require.NoError(t, n.Scan(nil))
+	require.False(t, n.Valid)
+
+	value, err = n.Value()
+	require.NoError(t, err)
+	require.Nil(t, value)
+}
+
+func TestDecimalArithmetic(t *testing.T) {
+	price := MustParseDecimal("12.35")
+
+	total, err := price.Mul(3)
+	require.NoError(t, err)
+	require.Equal(t, "37.05", total.String())
+
+	marked, err := price.MulRate(MustParseDecimal("1.05"))
+	require.NoError(t, err)
+	require.Equal(t, "12.97", marked.String())
+
+	refund, err := price.Mul(-1)
+	require.NoError(t, err)
+	refund, err = refund.MulRate(MustParseDecimal("1.05"))
+	require.NoError(t, err)
+	require.Equal(t, "-12.97", refund.String())
+
+	require.Equal(t, "12.00", price.Round(0).String())
+	require.Equal(t, "13.00", MustParseDecimal("12.50").Round(0).String())
+	require.Equal(t, -1, price.Cmp(MustParseDecimal("12.36")))
+
+	share, err := price.Percent(MustParseDecimal("2.5"))
+	require.NoError(t, err)
+	require.Equal(t, "0.31", share.String())
+
+	share, err = price.Percent(MustParseDecimal("-10"))
+	require.NoError(t, err)
+	require.Equal(t, "-1.24", share.String())
+}
+
+func TestDecimalOverflow(t *testing.T) {
+	large := MustParseDecimal("90000000000000000")
+
+	_, err := large.Mul(2)
+	require.ErrorIs(t, err, ErrDecimalOverflow)
+
+	_, err = Decimal(math.MinInt64).Mul(-1)
+	require.ErrorIs(t, err, ErrDecimalOverflow)
+
+	_, err = large.MulRate(MustParseDecimal("1.05"))
+	require.ErrorIs(t, err, ErrDecimalOverflow)
+
+	_, err = large.Percent(MustParseDecimal("5"))
+	require.ErrorIs(t, err, ErrDecimalOverflow)
+
+	_, err = NewMoney(large, VND).AddPercent(MustParseDecimal("5"))
+	require.ErrorIs(t, err, ErrDecimalOverflow)
+
+	total, err := Decimal(math.MaxInt64).Mul(1)
+	require.NoError(t, err)
+	require.Equal(t, Decimal(math.MaxInt64), total)
+}
+
+func TestMoney(t *testing.T) {
+	price := NewMoney(MustParseDecimal("25000"), VND)
+	require.NoError(t, price.Validate())
+
+	total, err := price.Mul(3)
+	require.NoError(t, err)
+	total, err = total.Add(NewMoney(MustParseDecimal("500"), VND))
+	require.NoError(t, err)
+	require.Equal(t, "75500.00 VND", total.String())
+
+	// a 7% markup on 25000 VND is rounded to whole đồng
+	marked, err := price.MulRate(MustParseDecimal("1.07"))
+	require.NoError(t, err)
+	require.Equal(t, "26750.00", marked.Amount.String())
+
+	marked, err = NewMoney(MustParseDecimal("1"), USD).MulRate(MustParseDecimal("1.07"))
+	require.NoError(t, err)
+	require.Equal(t, "1.07", marked.Amount.String())
+
+	for percent, want := range map[string]Money{
+		"5":   NewMoney(MustParseDecimal("26250"), VND),
+		"-10": NewMoney(MustParseDecimal("22500"), VND),
+	} {
+		adjusted, err := price.AddPercent(MustParseDecimal(percent))
+		require.NoError(t, err)
+		require.Equal(t, want, adjusted)
+	}
+
+	adjusted, err := NewMoney(MustParseDecimal("12.35"), EUR).AddPercent(MustParseDecimal("5"))
+	require.NoError(t, err)
+	require.Equal(t, "12.97", adjusted.Amount.String())
+
+	_, err = price.Add(NewMoney(MustParseDecimal("1"), USD))
+	require.ErrorIs(t, err, ErrCurrencyMismatch)
+
+	require.ErrorIs(t, NewMoney(MustParseDecimal("2500.50"), VND).Validate(), ErrInvalidMoney)
+	require.ErrorIs(t, NewMoney(MustParseDecimal("1"), "JPY").Validate(), ErrInvalidMoney)
+
+	// đồng is for prices only; accounts stay on the original currencies
+	require.True(t, IsSupportedPriceCurrency(VND))
+	require.False(t, IsSupportedCurrency(VND))
+}
@@ -0,0 +1,84 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrCurrencyMismatch = errors.New("currencies do not match")
+	ErrInvalidMoney     = errors.New("invalid amount of money")
+)
+
+// Money is a Decimal amount in a currency. Arithmetic between two amounts
+// fails unless they are in the same currency.
+type Money struct {
+	Amount   Decimal `json:"amount"`
+	Currency string  `json:"currency"`
+}
+
+func NewMoney(amount Decimal, currency string) Money {
+	return Money{
+		Amount:   amount,
+		Currency: currency,
+	}
+}
+
+// Validate checks that the currency is supported and that the amount has no
+// more decimal places than the currency has minor units, so VND amounts are
+// whole.
+func (m Money) Validate() error {
+	if !IsSupportedPriceCurrency(m.Currency) {
+		return fmt.Errorf("%w: unsupported currency %q", ErrInvalidMoney, m.Currency)
+	}
+	if m.Amount.Round(CurrencyDecimals(m.Currency)) != m.Amount {
+		return fmt.Errorf("%w: %s has more than %d decimal places", ErrInvalidMoney, m, CurrencyDecimals(m.Currency))
+	}
+	return nil
+}
+
+func (m Money) String() string {
+	return fmt.Sprintf("%s %s", m.Amount, m.Currency)
+}
+
+func (m Money) Add(other Money) (Money, error) {
+	if m.Currency != other.Currency {
+		return Money{}, fmt.Errorf("%w: %s and %s", ErrCurrencyMismatch, m.Currency, other.Currency)
+	}
+	return NewMoney(m.Amount.Add(other.Amount), m.Currency), nil
+}
+
+func (m Money) Sub(other Money) (Money, error) {
+	if m.Currency != other.Currency {
+		return Money{}, fmt.Errorf("%w: %s and %s", ErrCurrencyMismatch, m.Currency, other.Currency)
+	}
+	return NewMoney(m.Amount.Sub(other.Amount), m.Currency), nil
+}
+
+// Mul multiplies by a whole number, such as a unit price by a quantity.
+func (m Money) Mul(n int64) (Money, error) {
+	amount, err := m.Amount.Mul(n)
+	if err != nil {
+		return Money{}, err
+	}
+	return NewMoney(amount, m.Currency), nil
+}
+
+// MulRate multiplies by rate and rounds to the minor unit of the currency.
+func (m Money) MulRate(rate Decimal) (Money, error) {
+	amount, err := m.Amount.MulRate(rate)
+	if err != nil {
+		return Money{}, err
+	}
+	return NewMoney(amount.Round(CurrencyDecimals(m.Currency)), m.Currency), nil
+}
+
+// AddPercent raises the amount by percent (lowers it when negative), rounded to
+// the minor unit of the currency.
+func (m Money) AddPercent(percent Decimal) (Money, error) {
+	change, err := m.Amount.Percent(percent)
+	if err != nil {
+		return Money{}, err
+	}
+	return NewMoney(m.Amount.Add(change).Round(CurrencyDecimals(m.Currency)), m.Currency), nil
+}
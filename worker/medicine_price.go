@@ -0,0 +1,24 @@
+package worker
+
+import (
+	"context"
+	"log"
+
+	db "github.com/toannguyen3105/nht-bsihuyen.com-api/db/sqlc"
+)
+
+// MedicinePriceJob makes scheduled price changes take effect once their time
+// has come.
+func MedicinePriceJob(store db.Store) Job {
+	return func(ctx context.Context) error {
+		applied, err := store.ApplyDueMedicinePrices(ctx)
+		if err != nil {
+			return err
+		}
+
+		if applied > 0 {
+			log.Printf("applied scheduled prices to %d medicines", applied)
+		}
+		return nil
+	}
+}
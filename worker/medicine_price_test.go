@@ -0,0 +1,32 @@
+package worker
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	mockdb "github.com/toannguyen3105/nht-bsihuyen.com-api/db/mock"
+)
+
+func TestMedicinePriceJob(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockdb.NewMockStore(ctrl)
+	gomock.InOrder(
+		store.EXPECT().
+			ApplyDueMedicinePrices(gomock.Any()).
+			Times(1).
+			Return(int64(2), nil),
+		store.EXPECT().
+			ApplyDueMedicinePrices(gomock.Any()).
+			Times(1).
+			Return(int64(0), sql.ErrConnDone),
+	)
+
+	job := MedicinePriceJob(store)
+	require.NoError(t, job(context.Background()))
+	require.ErrorIs(t, job(context.Background()), sql.ErrConnDone)
+}
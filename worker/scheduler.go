@@ -0,0 +1,63 @@
+package worker
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+type Job func(ctx context.Context) error
+
+type scheduledJob struct {
+	name     string
+	interval time.Duration
+	run      Job
+}
+
+// Scheduler runs background jobs at fixed intervals inside the server process.
+type Scheduler struct {
+	jobs []scheduledJob
+}
+
+func NewScheduler() *Scheduler {
+	return &Scheduler{}
+}
+
+// Every registers a job. Jobs with a non-positive interval are disabled.
+func (scheduler *Scheduler) Every(name string, interval time.Duration, job Job) {
+	scheduler.jobs = append(scheduler.jobs, scheduledJob{
+		name:     name,
+		interval: interval,
+		run:      job,
+	})
+}
+
+// Start launches every enabled job until ctx is cancelled. Each job runs once
+// right away and then on every tick.
+func (scheduler *Scheduler) Start(ctx context.Context) {
+	for _, job := range scheduler.jobs {
+		if job.interval <= 0 {
+			log.Printf("job %q is disabled", job.name)
+			continue
+		}
+
+		go scheduler.loop(ctx, job)
+	}
+}
+
+func (scheduler *Scheduler) loop(ctx context.Context, job scheduledJob) {
+	ticker := time.NewTicker(job.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := job.run(ctx); err != nil {
+			log.Printf("job %q failed: %v", job.name, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,129 @@
+package worker
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	db "github.com/toannguyen3105/nht-bsihuyen.com-api/db/sqlc"
+	"github.com/toannguyen3105/nht-bsihuyen.com-api/notify"
+)
+
+const (
+	lowStockAlert   = "low_stock"
+	nearExpiryAlert = "near_expiry"
+
+	// stockAlertPermission marks the users who are notified about stock alerts.
+	stockAlertPermission = "MANAGE_STOCK_ALERTS"
+)
+
+// StockAlertJob raises an alert for every medicine at or below its reorder
+// level and every batch expiring within notice, and notifies the users holding
+// MANAGE_STOCK_ALERTS about new ones. Alerts whose condition cleared are
+// resolved first, so the same condition can alert again later.
+func StockAlertJob(store db.Store, notifier notify.Notifier, notice time.Duration) Job {
+	return func(ctx context.Context) error {
+		_, err := store.ResolveLowStockAlerts(ctx)
+		if err != nil {
+			return err
+		}
+
+		_, err = store.ResolveNearExpiryAlerts(ctx)
+		if err != nil {
+			return err
+		}
+
+		var raised []notify.Notification
+
+		lowStock, err := store.ListLowStockMedicines(ctx)
+		if err != nil {
+			return err
+		}
+
+		for _, medicine := range lowStock {
+			created, err := raiseStockAlert(ctx, store, db.CreateStockAlertParams{
+				AlertType:  lowStockAlert,
+				MedicineID: medicine.ID,
+				Message:    fmt.Sprintf("%s is running low: %d left, reorder level %d.", medicine.Name, medicine.Stock, medicine.ReorderLevel),
+			})
+			if err != nil {
+				return err
+			}
+			if created != nil {
+				raised = append(raised, notify.Notification{
+					Subject: fmt.Sprintf("Low stock: %s", medicine.Name),
+					Body:    created.Message,
+				})
+			}
+		}
+
+		expiring, err := store.ListExpiringMedicineBatches(ctx, sql.NullTime{Time: time.Now().Add(notice), Valid: true})
+		if err != nil {
+			return err
+		}
+
+		for _, batch := range expiring {
+			created, err := raiseStockAlert(ctx, store, db.CreateStockAlertParams{
+				AlertType:  nearExpiryAlert,
+				MedicineID: batch.MedicineID,
+				BatchID:    sql.NullInt64{Int64: batch.ID, Valid: true},
+				Message: fmt.Sprintf(
+					"Lot %s of %s expires on %s with %d left.",
+					batch.LotNumber,
+					batch.MedicineName,
+					batch.ExpiryDate.Time.Format(time.DateOnly),
+					batch.RemainingQuantity,
+				),
+			})
+			if err != nil {
+				return err
+			}
+			if created != nil {
+				raised = append(raised, notify.Notification{
+					Subject: fmt.Sprintf("Expiring soon: %s lot %s", batch.MedicineName, batch.LotNumber),
+					Body:    created.Message,
+				})
+			}
+		}
+
+		if len(raised) == 0 {
+			return nil
+		}
+
+		users, err := store.ListUsersWithPermission(ctx, stockAlertPermission)
+		if err != nil {
+			return err
+		}
+
+		recipients := make([]string, len(users))
+		for i, user := range users {
+			recipients[i] = user.Email
+		}
+
+		// The alerts are stored already; a failed notification must not keep
+		// the others from going out.
+		var errs []error
+		for _, notification := range raised {
+			notification.Recipients = recipients
+			errs = append(errs, notifier.Notify(ctx, notification))
+		}
+
+		return errors.Join(errs...)
+	}
+}
+
+// raiseStockAlert returns the new alert, or nil when the same condition
+// already has an open alert.
+func raiseStockAlert(ctx context.Context, store db.Store, arg db.CreateStockAlertParams) (*db.StockAlert, error) {
+	alert, err := store.CreateStockAlert(ctx, arg)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &alert, nil
+}
@@ -0,0 +1,150 @@
+package worker
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	mockdb "github.com/toannguyen3105/nht-bsihuyen.com-api/db/mock"
+	db "github.com/toannguyen3105/nht-bsihuyen.com-api/db/sqlc"
+	"github.com/toannguyen3105/nht-bsihuyen.com-api/utils"
+)
+
+func TestStockAlertJob(t *testing.T) {
+	pharmacist := db.User{
+		ID:       2,
+		Username: utils.RandomOwner(),
+		Email:    utils.RandomEmail(),
+	}
+	medicine := db.Medicine{
+		ID:           int32(utils.RandomInt(1, 1000)),
+		Name:         "Paracetamol",
+		Stock:        3,
+		ReorderLevel: 10,
+	}
+	batch := db.ListExpiringMedicineBatchesRow{
+		ID:                int64(utils.RandomInt(1, 1000)),
+		MedicineID:        medicine.ID,
+		LotNumber:         "LOT-2026A",
+		ExpiryDate:        sql.NullTime{Time: time.Now().AddDate(0, 0, 10), Valid: true},
+		RemainingQuantity: 3,
+		MedicineName:      medicine.Name,
+	}
+
+	expectResolve := func(store *mockdb.MockStore) {
+		store.EXPECT().
+			ResolveLowStockAlerts(gomock.Any()).
+			Times(1).
+			Return(int64(0), nil)
+		store.EXPECT().
+			ResolveNearExpiryAlerts(gomock.Any()).
+			Times(1).
+			Return(int64(0), nil)
+	}
+
+	testCases := []struct {
+		name        string
+		buildStubs  func(store *mockdb.MockStore)
+		checkResult func(t *testing.T, err error, notifier *recordingNotifier)
+	}{
+		{
+			name: "RaiseAndNotify",
+			buildStubs: func(store *mockdb.MockStore) {
+				expectResolve(store)
+				store.EXPECT().
+					ListLowStockMedicines(gomock.Any()).
+					Times(1).
+					Return([]db.Medicine{medicine}, nil)
+				store.EXPECT().
+					ListExpiringMedicineBatches(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return([]db.ListExpiringMedicineBatchesRow{batch}, nil)
+				store.EXPECT().
+					CreateStockAlert(gomock.Any(), gomock.Any()).
+					Times(2).
+					DoAndReturn(func(_ context.Context, arg db.CreateStockAlertParams) (db.StockAlert, error) {
+						require.Equal(t, medicine.ID, arg.MedicineID)
+						if arg.AlertType == nearExpiryAlert {
+							require.Equal(t, batch.ID, arg.BatchID.Int64)
+						} else {
+							require.Equal(t, lowStockAlert, arg.AlertType)
+							require.False(t, arg.BatchID.Valid)
+						}
+						return db.StockAlert{ID: 1, AlertType: arg.AlertType, Message: arg.Message}, nil
+					})
+				store.EXPECT().
+					ListUsersWithPermission(gomock.Any(), gomock.Eq(stockAlertPermission)).
+					Times(1).
+					Return([]db.User{pharmacist}, nil)
+			},
+			checkResult: func(t *testing.T, err error, notifier *recordingNotifier) {
+				require.NoError(t, err)
+				require.Len(t, notifier.notifications, 2)
+				require.Equal(t, []string{pharmacist.Email}, notifier.notifications[0].Recipients)
+				require.Contains(t, notifier.notifications[0].Subject, medicine.Name)
+				require.Contains(t, notifier.notifications[1].Body, batch.LotNumber)
+			},
+		},
+		{
+			name: "AlreadyOpen",
+			buildStubs: func(store *mockdb.MockStore) {
+				expectResolve(store)
+				store.EXPECT().
+					ListLowStockMedicines(gomock.Any()).
+					Times(1).
+					Return([]db.Medicine{medicine}, nil)
+				store.EXPECT().
+					ListExpiringMedicineBatches(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return([]db.ListExpiringMedicineBatchesRow{}, nil)
+				store.EXPECT().
+					CreateStockAlert(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.StockAlert{}, sql.ErrNoRows)
+				store.EXPECT().
+					ListUsersWithPermission(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResult: func(t *testing.T, err error, notifier *recordingNotifier) {
+				require.NoError(t, err)
+				require.Empty(t, notifier.notifications)
+			},
+		},
+		{
+			name: "InternalError",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ResolveLowStockAlerts(gomock.Any()).
+					Times(1).
+					Return(int64(0), sql.ErrConnDone)
+				store.EXPECT().
+					ListLowStockMedicines(gomock.Any()).
+					Times(0)
+			},
+			checkResult: func(t *testing.T, err error, notifier *recordingNotifier) {
+				require.ErrorIs(t, err, sql.ErrConnDone)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			notifier := &recordingNotifier{}
+			job := StockAlertJob(store, notifier, 30*24*time.Hour)
+
+			err := job(context.Background())
+			tc.checkResult(t, err, notifier)
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	db "github.com/toannguyen3105/nht-bsihuyen.com-api/db/sqlc"
+	"github.com/toannguyen3105/nht-bsihuyen.com-api/notify"
+)
+
+// UserRoleExpiryJob warns the administrators about role assignments that lapse
+// within notice and removes the assignments that already expired. A failed
+// notification is logged and retried on the next run.
+func UserRoleExpiryJob(store db.Store, notifier notify.Notifier, notice time.Duration) Job {
+	return func(ctx context.Context) error {
+		expiring, err := store.ListExpiringUserRoles(ctx, time.Now().Add(notice))
+		if err != nil {
+			return err
+		}
+
+		if len(expiring) > 0 {
+			admins, err := store.ListSystemAdmins(ctx)
+			if err != nil {
+				return err
+			}
+
+			recipients := make([]string, len(admins))
+			for i, admin := range admins {
+				recipients[i] = admin.Email
+			}
+
+			for _, userRole := range expiring {
+				err = notifier.Notify(ctx, notify.Notification{
+					Recipients: recipients,
+					Subject:    fmt.Sprintf("Role %s of %s is about to expire", userRole.RoleName, userRole.Username),
+					Body: fmt.Sprintf(
+						"The role %s assigned to %s (%s) expires at %s.",
+						userRole.RoleName,
+						userRole.FullName,
+						userRole.Username,
+						userRole.ValidUntil.Time.Format(time.RFC3339),
+					),
+				})
+				if err != nil {
+					// Try again on the next run, but still remove what expired.
+					log.Printf("notify expiry of role %s of %s: %v", userRole.RoleName, userRole.Username, err)
+					continue
+				}
+
+				err = store.MarkUserRoleExpiryNotified(ctx, db.MarkUserRoleExpiryNotifiedParams{
+					UserID: userRole.UserID,
+					RoleID: userRole.RoleID,
+				})
+				if err != nil {
+					return err
+				}
+			}
+		}
+
+		_, err = store.DeleteExpiredUserRoles(ctx)
+		return err
+	}
+}
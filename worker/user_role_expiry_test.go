@@ -0,0 +1,161 @@
+package worker
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	mockdb "github.com/toannguyen3105/nht-bsihuyen.com-api/db/mock"
+	db "github.com/toannguyen3105/nht-bsihuyen.com-api/db/sqlc"
+	"github.com/toannguyen3105/nht-bsihuyen.com-api/notify"
+	"github.com/toannguyen3105/nht-bsihuyen.com-api/utils"
+)
+
+type recordingNotifier struct {
+	notifications []notify.Notification
+	err           error
+}
+
+func (notifier *recordingNotifier) Notify(ctx context.Context, notification notify.Notification) error {
+	if notifier.err != nil {
+		return notifier.err
+	}
+	notifier.notifications = append(notifier.notifications, notification)
+	return nil
+}
+
+func TestUserRoleExpiryJob(t *testing.T) {
+	admin := db.User{
+		ID:       1,
+		Username: utils.RandomOwner(),
+		Email:    utils.RandomEmail(),
+	}
+	expiring := db.ListExpiringUserRolesRow{
+		UserID:     int32(utils.RandomInt(2, 1000)),
+		RoleID:     int32(utils.RandomInt(1, 1000)),
+		ValidUntil: sql.NullTime{Time: time.Now().Add(time.Hour), Valid: true},
+		Username:   utils.RandomOwner(),
+		FullName:   utils.RandomString(10),
+		RoleName:   "doctor",
+	}
+
+	testCases := []struct {
+		name        string
+		notifyErr   error
+		buildStubs  func(store *mockdb.MockStore)
+		checkResult func(t *testing.T, err error, notifier *recordingNotifier)
+	}{
+		{
+			name: "NotifyAndCleanUp",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ListExpiringUserRoles(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return([]db.ListExpiringUserRolesRow{expiring}, nil)
+				store.EXPECT().
+					ListSystemAdmins(gomock.Any()).
+					Times(1).
+					Return([]db.User{admin}, nil)
+				store.EXPECT().
+					MarkUserRoleExpiryNotified(gomock.Any(), gomock.Eq(db.MarkUserRoleExpiryNotifiedParams{
+						UserID: expiring.UserID,
+						RoleID: expiring.RoleID,
+					})).
+					Times(1).
+					Return(nil)
+				store.EXPECT().
+					DeleteExpiredUserRoles(gomock.Any()).
+					Times(1).
+					Return(int64(1), nil)
+			},
+			checkResult: func(t *testing.T, err error, notifier *recordingNotifier) {
+				require.NoError(t, err)
+				require.Len(t, notifier.notifications, 1)
+				require.Equal(t, []string{admin.Email}, notifier.notifications[0].Recipients)
+				require.Contains(t, notifier.notifications[0].Subject, expiring.Username)
+			},
+		},
+		{
+			name:      "NotifierFails",
+			notifyErr: errors.New("smtp unavailable"),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ListExpiringUserRoles(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return([]db.ListExpiringUserRolesRow{expiring}, nil)
+				store.EXPECT().
+					ListSystemAdmins(gomock.Any()).
+					Times(1).
+					Return([]db.User{admin}, nil)
+				store.EXPECT().
+					MarkUserRoleExpiryNotified(gomock.Any(), gomock.Any()).
+					Times(0)
+				store.EXPECT().
+					DeleteExpiredUserRoles(gomock.Any()).
+					Times(1).
+					Return(int64(1), nil)
+			},
+			checkResult: func(t *testing.T, err error, notifier *recordingNotifier) {
+				require.NoError(t, err)
+				require.Empty(t, notifier.notifications)
+			},
+		},
+		{
+			name: "NothingExpiring",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ListExpiringUserRoles(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return([]db.ListExpiringUserRolesRow{}, nil)
+				store.EXPECT().
+					ListSystemAdmins(gomock.Any()).
+					Times(0)
+				store.EXPECT().
+					DeleteExpiredUserRoles(gomock.Any()).
+					Times(1).
+					Return(int64(0), nil)
+			},
+			checkResult: func(t *testing.T, err error, notifier *recordingNotifier) {
+				require.NoError(t, err)
+				require.Empty(t, notifier.notifications)
+			},
+		},
+		{
+			name: "InternalError",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ListExpiringUserRoles(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(nil, sql.ErrConnDone)
+				store.EXPECT().
+					DeleteExpiredUserRoles(gomock.Any()).
+					Times(0)
+			},
+			checkResult: func(t *testing.T, err error, notifier *recordingNotifier) {
+				require.ErrorIs(t, err, sql.ErrConnDone)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			notifier := &recordingNotifier{err: tc.notifyErr}
+			job := UserRoleExpiryJob(store, notifier, 72*time.Hour)
+
+			err := job(context.Background())
+			tc.checkResult(t, err, notifier)
+		})
+	}
+}